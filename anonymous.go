@@ -0,0 +1,42 @@
+package openapi
+
+import "github.com/talav/openapi/internal/build"
+
+// AnonymousStructs is a strategy documenting anonymous struct types, selected
+// with WithAnonymousStructs.
+type AnonymousStructs string
+
+// Anonymous struct strategies.
+const (
+	// AnonymousStructsInline inlines the schemas of anonymous structs wherever
+	// they are used, without component schemas.
+	AnonymousStructsInline AnonymousStructs = "inline"
+	// AnonymousStructsPath names the component schemas of anonymous types after
+	// their property path from the enclosing schema, e.g.
+	// CreateUserRequestBodyAddressGeo, with Item and Value for the elements of
+	// slices and maps.
+	AnonymousStructsPath AnonymousStructs = "path"
+)
+
+// anonymousStructs configure the schema generator for the anonymous struct strategies.
+var anonymousStructs = map[AnonymousStructs]func(*build.SchemaGenerator){
+	"":                     nil, // unset
+	AnonymousStructsInline: (*build.SchemaGenerator).InlineAnonymousStructs,
+	AnonymousStructsPath:   (*build.SchemaGenerator).NameAnonymousStructsByPath,
+}
+
+// WithAnonymousStructs selects how anonymous struct types are documented. By
+// default they get component schemas named after their parent type and field,
+// such as CreateUserRequestBody or AddressStruct, which lose the path of deeply
+// nested structs. Generate fails for unknown strategies.
+//
+// Default: component schemas named after the parent type and field
+//
+// Example:
+//
+//	openapi.WithAnonymousStructs(openapi.AnonymousStructsInline)
+func WithAnonymousStructs(strategy AnonymousStructs) Option {
+	return func(a *API) {
+		a.AnonymousStructs = strategy
+	}
+}
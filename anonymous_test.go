@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// anonymousCreateUser nests anonymous structs in its body.
+type anonymousCreateUser struct {
+	Body struct {
+		Name    string `json:"name"`
+		Address struct {
+			City string `json:"city"`
+			Geo  struct {
+				Lat float64 `json:"lat"`
+			} `json:"geo"`
+		} `json:"address"`
+		Phones []struct {
+			Number string `json:"number"`
+		} `json:"phones"`
+	} `body:"structured"`
+}
+
+func TestWithAnonymousStructs(t *testing.T) {
+	tests := []struct {
+		strategy AnonymousStructs
+		want     []string
+	}{
+		{strategy: "", want: []string{"AnonymousCreateUserBody", "AddressStruct", "GeoStruct", "Item"}},
+		{strategy: AnonymousStructsPath, want: []string{
+			"AnonymousCreateUserBody",
+			"AnonymousCreateUserBodyAddress",
+			"AnonymousCreateUserBodyAddressGeo",
+			"AnonymousCreateUserBodyPhonesItem",
+		}},
+		{strategy: AnonymousStructsInline, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"), WithAnonymousStructs(tt.strategy))
+
+			result, err := api.Generate(context.Background(),
+				POST("/users", WithRequest(anonymousCreateUser{})))
+			require.NoError(t, err)
+
+			var spec struct {
+				Paths map[string]map[string]struct {
+					RequestBody struct {
+						Content map[string]struct {
+							Schema map[string]any `json:"schema"`
+						} `json:"content"`
+					} `json:"requestBody"`
+				} `json:"paths"`
+				Components struct {
+					Schemas map[string]any `json:"schemas"`
+				} `json:"components"`
+			}
+			require.NoError(t, json.Unmarshal(result.JSON, &spec))
+			assert.ElementsMatch(t, tt.want, keys(spec.Components.Schemas))
+
+			if tt.strategy == AnonymousStructsInline {
+				body := spec.Paths["/users"]["post"].RequestBody.Content["application/json"].Schema
+				address, _ := body["properties"].(map[string]any)["address"].(map[string]any)
+				assert.Equal(t, "object", address["type"])
+				assert.Contains(t, address["properties"], "geo")
+			}
+		})
+	}
+}
+
+func TestWithAnonymousStructs_Unknown(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithAnonymousStructs("flatten"))
+
+	_, err := api.Generate(context.Background(), POST("/users", WithRequest(anonymousCreateUser{})))
+	require.ErrorContains(t, err, `unknown anonymous structs strategy "flatten" (valid: inline, path)`)
+}
@@ -1,21 +1,32 @@
 package openapi
 
 import (
+	"bytes"
 	"context"
+	"errors"
 	"fmt"
+	"io"
 	"maps"
 	"net/http"
+	"reflect"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"unicode"
 
 	"github.com/talav/openapi/config"
+	"github.com/talav/openapi/debug"
 	"github.com/talav/openapi/example"
+	"github.com/talav/openapi/hook"
 	"github.com/talav/openapi/internal/build"
 	"github.com/talav/openapi/internal/export"
+	"github.com/talav/openapi/internal/export/util"
 	v304 "github.com/talav/openapi/internal/export/v304"
 	v312 "github.com/talav/openapi/internal/export/v312"
 	"github.com/talav/openapi/internal/model"
+	"github.com/talav/openapi/internal/overlay"
 )
 
 // API holds OpenAPI configuration and defines an API specification.
@@ -30,6 +41,11 @@ type API struct {
 	// Servers lists available server URLs for the API.
 	Servers []model.Server
 
+	// DynamicServers computes the servers at generation time, replacing Servers
+	// when it returns any.
+	// Default: nil
+	DynamicServers func(ctx context.Context) []Server
+
 	// Tags provides additional metadata for operations.
 	Tags []model.Tag
 
@@ -61,6 +77,11 @@ type API struct {
 	// Default: false
 	StrictDownlevel bool
 
+	// StrictEnums causes Generate to error (instead of warn) when a default or
+	// example is outside the enum of its schema, or const and enum are both set.
+	// Default: false
+	StrictEnums bool
+
 	// ValidateSpec enables JSON Schema validation of generated specs.
 	// When enabled, Generate validates the output against the official
 	// OpenAPI meta-schema (3.0.x or 3.1.x based on target version).
@@ -68,6 +89,130 @@ type API struct {
 	// Default: false
 	ValidateSpec bool
 
+	// ValidationScope restricts what is validated when ValidateSpec is set.
+	// Zero validates the whole document against the meta-schema.
+	ValidationScope ValidationScope
+
+	// MetaSchema replaces the embedded meta-schema of the target version that
+	// specs are validated against when ValidateSpec is set.
+	// Default: nil (embedded meta-schema)
+	MetaSchema []byte
+
+	// Validators check generated specs, in addition to ValidateSpec.
+	// Default: none
+	Validators []Validator
+
+	// CheckExamples reports examples that do not conform to their schemas
+	// as warnings in Result.Warnings.
+	// Default: false
+	CheckExamples bool
+
+	// PruneUnused removes components that are never referenced from the spec,
+	// instead of reporting them as warnings.
+	// Default: false
+	PruneUnused bool
+
+	// AutoHead documents a HEAD operation for every GET operation.
+	// Default: false
+	AutoHead bool
+
+	// AutoOptions documents a CORS preflight OPTIONS operation for every path.
+	// Default: false
+	AutoOptions bool
+
+	// PathNormalization controls how operation paths are normalized.
+	// Default: no normalization
+	PathNormalization PathNormalization
+
+	// RedactionProfile names the redaction profile applied to generated specs.
+	// Default: no redaction
+	RedactionProfile string
+
+	// RedactionProfiles holds custom redaction profiles by name.
+	RedactionProfiles map[string]RedactionProfile
+
+	// ResponseEnvelope wraps the JSON bodies of successful responses.
+	// Default: no envelope
+	ResponseEnvelope EnvelopeStyle
+
+	// PropertyNaming names the properties of struct fields without json tags.
+	// Default: NamingAsIs
+	PropertyNaming PropertyNaming
+
+	// SchemaNamespacing qualifies component schema names with the Go package of their types.
+	// Default: no namespacing
+	SchemaNamespacing SchemaNamespacing
+
+	// AnonymousStructs selects how anonymous struct types are documented.
+	// Default: component schemas named after the parent type and field
+	AnonymousStructs AnonymousStructs
+
+	// InlineRequestBodies inlines request body schemas in the operations.
+	// Default: false
+	InlineRequestBodies bool
+
+	// SchemaReuseThreshold is the number of references from which schemas are kept
+	// as components; schemas referenced less are inlined.
+	// Default: 0 (structs are always referenced)
+	SchemaReuseThreshold int
+
+	// FieldTransformers transform the schemas of struct fields after their tags.
+	// Default: none
+	FieldTransformers []hook.FieldTransformer
+
+	// OperationDefaults are applied to every operation, before its own options.
+	// Default: none
+	OperationDefaults []OperationDocOption
+
+	// CustomFormats are the schema formats allowed besides the standard ones.
+	// Default: none
+	CustomFormats []string
+
+	// Descriptions is the description catalog of the descriptionKey tag option.
+	// Default: none
+	Descriptions map[string]string
+
+	// AuthHeaderAsSecurity converts Authorization header parameters to security requirements.
+	// Default: false
+	AuthHeaderAsSecurity bool
+
+	// SchemaTemplates derive the titles and descriptions of component schemas.
+	// Default: no templates
+	SchemaTemplates SchemaTemplates
+
+	// DebugTrace records what produced the generated specification in Result.Trace.
+	// Default: false
+	DebugTrace bool
+
+	// JSONSchemaDialect is the default JSON Schema dialect of the schemas (3.1).
+	// Default: "" (JSON Schema 2020-12)
+	JSONSchemaDialect string
+
+	// SchemaIDs emits the $id of each component schema (3.1).
+	// Default: false
+	SchemaIDs bool
+
+	// SchemaBaseURI is the base URI of the $id of the component schemas.
+	// Default: "" (relative to the specification)
+	SchemaBaseURI string
+
+	// CanonicalJSON emits the specification in the JSON Canonicalization Scheme (RFC 8785).
+	// Default: false
+	CanonicalJSON bool
+
+	// Overlays are applied in order to the generated specification before validation.
+	// Default: none
+	Overlays []*Overlay
+
+	// ExampleRedaction is how fields of examples built from Go values marked
+	// writeOnly or classified secret are redacted.
+	// Default: ExampleRedactionOmit
+	ExampleRedaction ExampleRedaction
+
+	// Environment selects the options registered with ForEnv for it.
+	// Default: "" (no environment-specific options)
+	Environment string
+
 	// SchemaPrefix is the prefix for the OpenAPI schema.
 	SchemaPrefix string
 
@@ -75,6 +220,68 @@ type API struct {
 	// If not set, uses default tag names (schema, body, openapi, validate, default, requires).
 	TagConfig config.TagConfig
 
+	// mu serializes Generate calls: the schema generator memoizes generated
+	// schemas across calls and is not safe for concurrent use.
+	mu sync.Mutex
+
+	// schemaExtensions holds the extensions attached with ExtendSchema.
+	schemaExtensions []schemaExtension
+
+	// scopes is the scope catalog of DefineScopes.
+	scopes map[string]string
+
+	// operationTraces holds the traces of the operations of the current
+	// generation by method and path, when tracing.
+	operationTraces map[string]debug.OperationTrace
+
+	// unions holds the interface types registered with WithUnion, and unionErrs
+	// the invalid registrations, reported by Generate.
+	unions    []union
+	unionErrs []error
+
+	// sourceEnums holds the enum types found with WithSourceEnums, and
+	// sourceEnumErrs the types not found, reported by Generate.
+	sourceEnums    map[reflect.Type][]build.EnumValue
+	sourceEnumErrs []error
+
+	// globalParams holds the parameters registered with WithGlobalParameter, and
+	// globalParamErrs the invalid registrations, reported by Generate.
+	globalParams    []globalParameter
+	globalParamErrs []error
+
+	// errorMappings holds the error types mapped with MapError, and errorMapErrs
+	// the invalid mappings, reported by Generate.
+	errorMappings []errorMapping
+	errorMapErrs  []error
+
+	// examples and headers hold the components registered with RegisterExample
+	// and RegisterHeader, by name.
+	examples map[string]example.Example
+	headers  map[string]Header
+
+	// descriptionErrs holds the description files that failed to load,
+	// reported by Generate.
+	descriptionErrs []error
+
+	// augmentErrs holds the invalid overrides of AugmentType, reported by Generate.
+	augmentErrs []error
+
+	// rawSchemas holds the JSON Schemas added with AddRawSchema, by name.
+	rawSchemas map[string][]byte
+
+	// fileExamples holds the examples of the current generation loaded from
+	// files, with their file.
+	fileExamples map[*model.Example]string
+
+	// envOptions holds the options registered with ForEnv, applied by NewAPI
+	// when their environment is selected.
+	envOptions []envOptions
+
+	// patches holds the patches of WithSpecPatch, and patchErrs the invalid
+	// ones, reported by Generate.
+	patches   []*overlay.Patch
+	patchErrs []error
+
 	generator       *build.SchemaGenerator
 	requestBuilder  build.RequestBuilder
 	responseBuilder build.ResponseBuilder
@@ -106,12 +313,30 @@ func NewAPI(opts ...Option) *API {
 	for _, opt := range opts {
 		opt(api)
 	}
+	api.applyEnvOptions()
 
 	// Create metadata with tag configuration
 	metadata := build.NewMetadata(api.TagConfig)
 
 	// Create schema generator
 	api.generator = build.NewSchemaGenerator(api.SchemaPrefix, metadata, api.TagConfig)
+	for _, u := range api.unions {
+		api.generator.RegisterUnion(u.iface, u.variants)
+	}
+	for t, values := range api.sourceEnums {
+		api.generator.RegisterEnum(t, values)
+	}
+	for _, fn := range api.FieldTransformers {
+		api.generator.AddFieldTransformer(fn)
+	}
+	api.generator.SetPropertyNaming(propertyNamings[api.PropertyNaming])
+	api.generator.SetDescriptions(api.Descriptions)
+	if qualify := schemaNamespacings[api.SchemaNamespacing]; qualify != nil {
+		api.generator.SetNamespacing(qualify)
+	}
+	if configure := anonymousStructs[api.AnonymousStructs]; configure != nil {
+		configure(api.generator)
+	}
 
 	// Create request and response builders
 	api.requestBuilder = build.NewRequestBuilder(api.generator, metadata, api.TagConfig)
@@ -259,9 +484,32 @@ func WithLicenseIdentifier(name, identifier string) Option {
 	}
 }
 
+// Server is a server of the specification, as computed by WithDynamicServer.
+type Server = model.Server
+
+// ServerVariable is a variable of the URL template of a Server.
+type ServerVariable = model.ServerVariable
+
 // ServerOption configures a Server using the functional options pattern.
 type ServerOption func(*model.Server)
 
+// NewServer returns a server with the given URL, configured with the options of
+// WithServer, for the functions of WithDynamicServer.
+//
+// Example:
+//
+//	openapi.NewServer("https://acme.example.com",
+//	    openapi.WithServerDescription("Tenant acme"),
+//	)
+func NewServer(url string, opts ...ServerOption) Server {
+	server := Server{URL: url}
+	for _, opt := range opts {
+		opt(&server)
+	}
+
+	return server
+}
+
 // WithServer adds a server URL to the specification.
 //
 // Multiple servers can be added by calling this option multiple times.
@@ -279,11 +527,7 @@ type ServerOption func(*model.Server)
 //	),
 func WithServer(url string, opts ...ServerOption) Option {
 	return func(a *API) {
-		server := &model.Server{URL: url}
-		for _, opt := range opts {
-			opt(server)
-		}
-		a.Servers = append(a.Servers, *server)
+		a.Servers = append(a.Servers, NewServer(url, opts...))
 	}
 }
 
@@ -428,6 +672,16 @@ func WithAPIKey(name, paramName string, in ParameterLocation, desc string) Optio
 	}
 }
 
+// WithCookieAuth adds session cookie authentication, as an API key scheme in a cookie.
+// Document the CSRF protection of session-authenticated operations with CSRFToken.
+//
+// Example:
+//
+//	openapi.WithCookieAuth("sessionAuth", "session_id", "Session cookie set at login")
+func WithCookieAuth(name, cookieName, desc string) Option {
+	return WithAPIKey(name, cookieName, InCookie, desc)
+}
+
 // OAuthFlowType represents the type of OAuth2 flow.
 type OAuthFlowType string
 
@@ -440,8 +694,34 @@ const (
 	FlowClientCredentials OAuthFlowType = "clientCredentials"
 	// FlowAuthorizationCode represents the OAuth2 authorization code flow.
 	FlowAuthorizationCode OAuthFlowType = "authorizationCode"
+	// FlowDeviceAuthorization represents the OAuth2 device authorization flow (RFC 8628),
+	// documented in the x-flows extension of the security scheme.
+	FlowDeviceAuthorization OAuthFlowType = "deviceAuthorization"
+	// FlowTokenExchange represents the OAuth2 token exchange flow (RFC 8693),
+	// documented in the x-flows extension of the security scheme.
+	FlowTokenExchange OAuthFlowType = "tokenExchange"
 )
 
+// extensionFlows is the extension documenting the OAuth2 flows without an OAuth
+// Flows Object field.
+const extensionFlows = "x-flows"
+
+// extensionFlowGrantTypes are the grant types of the flows documented in x-flows.
+var extensionFlowGrantTypes = map[OAuthFlowType]string{
+	FlowDeviceAuthorization: "urn:ietf:params:oauth:grant-type:device_code",
+	FlowTokenExchange:       "urn:ietf:params:oauth:grant-type:token-exchange",
+}
+
+// extensionFlow documents an OAuth2 flow in x-flows, like an OAuth Flow Object
+// with the device authorization URL and the grant type of the token request.
+type extensionFlow struct {
+	DeviceAuthorizationURL string            `json:"deviceAuthorizationUrl,omitempty"`
+	TokenURL               string            `json:"tokenUrl"`
+	RefreshURL             string            `json:"refreshUrl,omitempty"`
+	GrantType              string            `json:"grantType"`
+	Scopes                 map[string]string `json:"scopes"`
+}
+
 // OAuth2Flow configures a single OAuth2 flow with explicit type.
 type OAuth2Flow struct {
 	// Type specifies the OAuth2 flow type (implicit, password, clientCredentials, authorizationCode).
@@ -450,7 +730,10 @@ type OAuth2Flow struct {
 	// AuthorizationURL is required for implicit and authorizationCode flows.
 	AuthorizationURL string
 
-	// TokenURL is required for password, clientCredentials, and authorizationCode flows.
+	// DeviceAuthorizationURL is required for deviceAuthorization flows.
+	DeviceAuthorizationURL string
+
+	// TokenURL is required for all flows but implicit.
 	TokenURL string
 
 	// RefreshURL is optional for all flows.
@@ -465,6 +748,10 @@ type OAuth2Flow struct {
 // At least one flow must be configured. Use OAuth2Flow to configure each flow type.
 // Multiple flows can be provided to support different OAuth2 flow types.
 //
+// The OAuth Flows Object has no field for the device authorization and token
+// exchange flows: they are documented in the x-flows extension of the security
+// scheme, like OAuth Flow Objects with the grant type of their token requests.
+//
 // Example:
 //
 //	openapi.WithOAuth2("oauth2", "OAuth2 authentication",
@@ -479,9 +766,15 @@ type OAuth2Flow struct {
 //		},
 //		openapi.OAuth2Flow{
 //			Type:     openapi.FlowClientCredentials,
-//			TokenUrl: "https://example.com/oauth/token",
+//			TokenURL: "https://example.com/oauth/token",
 //			Scopes:   map[string]string{"read": "Read access"},
 //		},
+//		openapi.OAuth2Flow{
+//			Type:                   openapi.FlowDeviceAuthorization,
+//			DeviceAuthorizationURL: "https://example.com/oauth/device",
+//			TokenURL:               "https://example.com/oauth/token",
+//			Scopes:                 map[string]string{"read": "Read access"},
+//		},
 //	)
 func WithOAuth2(name, desc string, flows ...OAuth2Flow) Option {
 	return func(a *API) {
@@ -489,7 +782,23 @@ func WithOAuth2(name, desc string, flows ...OAuth2Flow) Option {
 			a.SecuritySchemes = make(map[string]*model.SecurityScheme)
 		}
 		oauthFlows := &model.OAuthFlows{}
+		extFlows := make(map[OAuthFlowType]extensionFlow)
 		for _, flow := range flows {
+			if grantType, ok := extensionFlowGrantTypes[flow.Type]; ok {
+				scopes := flow.Scopes
+				if scopes == nil {
+					scopes = map[string]string{}
+				}
+				extFlows[flow.Type] = extensionFlow{
+					DeviceAuthorizationURL: flow.DeviceAuthorizationURL,
+					TokenURL:               flow.TokenURL,
+					RefreshURL:             flow.RefreshURL,
+					GrantType:              grantType,
+					Scopes:                 scopes,
+				}
+
+				continue
+			}
 			flowConfig := &model.OAuthFlow{
 				AuthorizationURL: flow.AuthorizationURL,
 				TokenURL:         flow.TokenURL,
@@ -507,11 +816,15 @@ func WithOAuth2(name, desc string, flows ...OAuth2Flow) Option {
 				oauthFlows.AuthorizationCode = flowConfig
 			}
 		}
-		a.SecuritySchemes[name] = &model.SecurityScheme{
+		scheme := &model.SecurityScheme{
 			Type:        "oauth2",
 			Description: desc,
 			Flows:       oauthFlows,
 		}
+		if len(extFlows) > 0 {
+			scheme.Extensions = map[string]any{extensionFlows: extFlows}
+		}
+		a.SecuritySchemes[name] = scheme
 	}
 }
 
@@ -601,17 +914,109 @@ func WithStrictDownlevel(strict bool) Option {
 // for backward compatibility. Enable for development and testing to catch
 // errors early.
 //
+// Scopes restrict and extend what is validated; see [ValidationScope].
+// Without scopes, the whole document is validated against the meta-schema.
+//
 // Default: false
 //
 // Example:
 //
 //	openapi.WithValidation(false) // Disable for performance
-func WithValidation(enabled bool) Option {
+//	openapi.WithValidation(true, openapi.ValidateSchemasOnly, openapi.ValidateExamplesAgainstSchemas)
+func WithValidation(enabled bool, scopes ...ValidationScope) Option {
 	return func(a *API) {
 		a.ValidateSpec = enabled
+		a.ValidationScope = 0
+		for _, scope := range scopes {
+			a.ValidationScope |= scope
+		}
 	}
 }
 
+// WithMetaSchema sets the meta-schema that generated specs are validated
+// against with WithValidation, instead of the official one of the target version
+// embedded in the library, e.g. for organizations pinning a patched meta-schema.
+//
+// Validation is offline: the meta-schema must be self-contained, its references
+// being resolved within it or to the JSON Schema meta-schemas of the standard
+// drafts, never fetched.
+//
+// Default: nil (embedded meta-schema)
+//
+// Example:
+//
+//	//go:embed openapi-3.1-patched.json
+//	var metaSchema []byte
+//
+//	openapi.WithValidation(true)
+//	openapi.WithMetaSchema(metaSchema)
+func WithMetaSchema(schema []byte) Option {
+	return func(a *API) {
+		a.MetaSchema = schema
+	}
+}
+
+// WithExampleCheck enables the example conformance check: every example of
+// schemas, parameters, headers and media types is validated against its schema,
+// and mismatches (wrong types, missing required properties, ...) are reported as
+// debug.WarnExampleSchemaMismatch warnings.
+//
+// Unlike WithValidation with ValidateExamplesAgainstSchemas, mismatches do not
+// fail generation.
+//
+// Default: false
+//
+// Example:
+//
+//	api := openapi.NewAPI(openapi.WithExampleCheck(true))
+//	result, err := api.Generate(ctx, ops...)
+//	for _, w := range result.Warnings {
+//	    if w.Code() == debug.WarnExampleSchemaMismatch {
+//	        log.Printf("%s: %s", w.Path(), w.Message())
+//	    }
+//	}
+func WithExampleCheck(enabled bool) Option {
+	return func(a *API) {
+		a.CheckExamples = enabled
+	}
+}
+
+// WithPruneUnused removes component schemas, responses, parameters, request bodies,
+// headers and examples that are never referenced from any operation.
+//
+// Components become unused when they are registered but the operations using them
+// change. Schemas generated for the operations of previous calls are never part of
+// the spec. Without pruning, each unused component is reported as a
+// debug.WarnUnusedComponent warning.
+//
+// Default: false
+//
+// Example:
+//
+//	openapi.WithPruneUnused(true)
+func WithPruneUnused(enabled bool) Option {
+	return func(a *API) {
+		a.PruneUnused = enabled
+	}
+}
+
+// ValidationScope selects the checks performed by spec validation.
+// Scopes combine: the union of the selected checks is performed.
+type ValidationScope uint8
+
+const (
+	// ValidateSchemasOnly reports meta-schema violations in component schemas only.
+	ValidateSchemasOnly = ValidationScope(export.ScopeSchemas)
+
+	// ValidatePathsOnly reports meta-schema violations in paths and operations only.
+	ValidatePathsOnly = ValidationScope(export.ScopePaths)
+
+	// ValidateExamplesAgainstSchemas checks that every declared example (of schemas,
+	// parameters, headers and media types) conforms to its schema, which the
+	// meta-schema check cannot do. Alone, it performs no meta-schema check.
+	ValidateExamplesAgainstSchemas = ValidationScope(export.ScopeExamples)
+)
+
 // WithExtension adds a specification extension to the root OpenAPI specification.
 //
 // Extension keys MUST start with "x-". In OpenAPI 3.1.x, keys starting with
@@ -686,9 +1091,13 @@ func WithSchemaPrefix(prefix string) Option {
 
 // Generate produces an OpenAPI specification from operations.
 //
-// This is a pure function with no side effects. It takes configuration and operations
-// as input and produces JSON/YAML bytes as output. Caching and state management are
-// the caller's responsibility.
+// It takes configuration and operations as input and produces JSON bytes as output.
+// Struct metadata and generated schemas are memoized and reused across calls, and
+// struct metadata is shared between API instances using the same tag configuration.
+// Generate is safe for concurrent use; concurrent calls on the same API are serialized.
+//
+// Generation stops when ctx is done, checked before each operation, each component
+// schema check and validation. The error wraps ctx.Err() and tells how far it got.
 //
 // Example:
 //
@@ -713,37 +1122,377 @@ func WithSchemaPrefix(prefix string) Option {
 //	}
 //	fmt.Println(string(result.JSON))
 func (a *API) Generate(ctx context.Context, ops ...Operation) (*Result, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	spec, warnings, err := a.buildSpec(ctx, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := a.exporter.Export(ctx, spec, a.exportConfig(spec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export OpenAPI spec: %w", err)
+	}
+
+	specJSON := result.Result
+	if a.CanonicalJSON {
+		if specJSON, err = canonicalJSON(specJSON); err != nil {
+			return nil, err
+		}
+	}
+
+	fingerprint, schemaFingerprints, err := fingerprints(specJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	// The model is detached from the schemas cached by the generator
+	m := &Model{spec: spec.Clone(), schemaPrefix: a.SchemaPrefix, scopes: a.scopes}
+
+	res := &Result{
+		JSON:               specJSON,
+		Warnings:           append(warnings, result.Warnings...),
+		Model:              m,
+		Fingerprint:        fingerprint,
+		SchemaFingerprints: schemaFingerprints,
+		Stats:              m.stats(),
+		Deprecations:       m.deprecations(),
+		Trace:              a.trace(spec),
+	}
+	res.Report = a.report(res)
+
+	return res, nil
+}
+
+// GenerateTo produces an OpenAPI specification from operations and writes it to w,
+// instead of returning it: the serialized document is not kept on the Result, nor
+// fingerprinted. Use it to serve or save large specifications.
+//
+// The written JSON is identical to Result.JSON of Generate, followed by a newline.
+// The returned Result carries Warnings, Model, Stats, Deprecations, Trace and Report only: JSON and fingerprints are empty.
+// The document is streamed: its members, down to path items and component
+// entries, are encoded and written one at a time. When validation or the example
+// check is enabled, it is buffered and checked before anything is written instead,
+// so nothing is written for an invalid spec.
+// Canonical JSON (see WithCanonicalJSON), overlaid and patched specs are buffered too.
+//
+// Example:
+//
+//	f, err := os.Create("openapi.json")
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	defer f.Close()
+//
+//	if _, err := api.GenerateTo(ctx, f, ops...); err != nil {
+//	    log.Fatal(err)
+//	}
+func (a *API) GenerateTo(ctx context.Context, w io.Writer, ops ...Operation) (*Result, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	spec, warnings, err := a.buildSpec(ctx, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	out := w
+	var buf bytes.Buffer
+	if a.CanonicalJSON {
+		out = &buf
+	}
+	exportWarnings, err := a.exporter.ExportTo(ctx, out, spec, a.exportConfig(spec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export OpenAPI spec: %w", err)
+	}
+	if a.CanonicalJSON {
+		canonical, err := canonicalJSON(buf.Bytes())
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(append(canonical, '\n')); err != nil {
+			return nil, fmt.Errorf("failed to write spec: %w", err)
+		}
+	}
+
+	return a.unserializedResult(spec, append(warnings, exportWarnings...)), nil
+}
+
+// Check produces an OpenAPI specification from operations like Generate, without
+// serializing it, for callers that only need its errors and warnings, e.g. in
+// tests and CI. The spec is only serialized when it must be to be checked: with
+// validation, the example check, validators, overlays or patches.
+//
+// The returned Result carries Warnings, Model, Stats, Deprecations, Trace and Report only: JSON and fingerprints are empty.
+// Values failing to serialize, such as examples with unsupported types, are only
+// reported when the spec is serialized.
+//
+// Example:
+//
+//	if _, err := api.Check(ctx, ops...); err != nil {
+//	    log.Fatal(err)
+//	}
+func (a *API) Check(ctx context.Context, ops ...Operation) (*Result, error) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	spec, warnings, err := a.buildSpec(ctx, ops)
+	if err != nil {
+		return nil, err
+	}
+
+	exportWarnings, err := a.exporter.Check(ctx, spec, a.exportConfig(spec))
+	if err != nil {
+		return nil, fmt.Errorf("failed to export OpenAPI spec: %w", err)
+	}
+
+	return a.unserializedResult(spec, append(warnings, exportWarnings...)), nil
+}
+
+// unserializedResult returns the Result of a spec without its serialized document.
+func (a *API) unserializedResult(spec *model.Spec, warnings debug.Warnings) *Result {
+	// The model is detached from the schemas cached by the generator
+	m := &Model{spec: spec.Clone(), schemaPrefix: a.SchemaPrefix, scopes: a.scopes}
+
+	res := &Result{
+		Warnings:     warnings,
+		Model:        m,
+		Stats:        m.stats(),
+		Deprecations: m.deprecations(),
+		Trace:        a.trace(spec),
+	}
+	res.Report = a.report(res)
+
+	return res
+}
+
+// exportConfig returns the configuration of the export of a spec.
+func (a *API) exportConfig(spec *model.Spec) export.ExporterConfig {
+	cfg := export.ExporterConfig{
+		Version:         a.Version,
+		ShouldValidate:  a.ValidateSpec,
+		MetaSchema:      a.MetaSchema,
+		StrictDownlevel: a.StrictDownlevel,
+		Scope:           export.ValidationScope(a.ValidationScope),
+		CheckExamples:   a.CheckExamples,
+		SourceOf:        a.violationSource,
+	}
+	if len(a.Overlays) > 0 || len(a.patches) > 0 {
+		cfg.Transform = a.transformSpec
+	}
+	if len(a.Validators) > 0 {
+		cfg.Validate = a.runValidators
+	}
+	if files := a.fileExamplePointers(spec); len(files) > 0 {
+		for ptr := range files {
+			cfg.StrictExamples = append(cfg.StrictExamples, ptr)
+		}
+		cfg.SourceOf = func(pointer string) string {
+			for ptr, file := range files {
+				if strings.HasPrefix(pointer, ptr+"/") {
+					return file + " for " + a.violationSource(pointer)
+				}
+			}
+
+			return a.violationSource(pointer)
+		}
+	}
+
+	return cfg
+}
+
+// violationSource resolves the origin of a JSON pointer in the generated spec,
+// to annotate meta-schema violations:
+//   - /components/schemas/{name}/properties/{prop}/... -> Go type and field
+//   - /paths/{path}/{method}/... -> operation
+//   - /paths/{path}/x-query/... and /paths/{path}/x-additionalOperations/{method}/... -> operation
+func (a *API) violationSource(pointer string) string {
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+	}
+
+	switch {
+	case len(tokens) >= 3 && tokens[0] == "components" && tokens[1] == "schemas":
+		t, ok := a.generator.TypeOf(tokens[2])
+		if !ok {
+			return ""
+		}
+		if len(tokens) >= 5 && tokens[3] == "properties" {
+			if prop, ok := a.propertyField(t, tokens[4]); ok {
+				return t.String() + "." + prop.Path
+			}
+		}
+
+		return t.String()
+	case len(tokens) >= 3 && tokens[0] == "paths":
+		method := strings.ToUpper(tokens[2])
+		switch method {
+		case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+			http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace:
+			return method + " " + tokens[1]
+		}
+		switch tokens[2] {
+		case util.ExtensionQuery:
+			return MethodQuery + " " + tokens[1]
+		case util.ExtensionAdditionalOperations:
+			if len(tokens) >= 4 {
+				return tokens[3] + " " + tokens[1]
+			}
+		}
+	}
+
+	return ""
+}
+
+// propertyField returns the struct field documented as the property name of the
+// object schema of t, which may be promoted from an embedded struct.
+func (a *API) propertyField(t reflect.Type, name string) (build.Property, bool) {
+	for _, prop := range a.generator.Properties(t) {
+		if prop.Name == name {
+			return prop, true
+		}
+	}
+
+	return build.Property{}, false
+}
+
+// buildSpec builds the version-agnostic spec model from operations. The context
+// is checked before each operation and each component schema check.
+func (a *API) buildSpec(ctx context.Context, ops []Operation) (*model.Spec, debug.Warnings, error) {
+	if err := errors.Join(a.unionErrs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid unions: %w", err)
+	}
+	if err := errors.Join(a.sourceEnumErrs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid source enums: %w", err)
+	}
+	if err := errors.Join(a.globalParamErrs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid global parameters: %w", err)
+	}
+	if err := errors.Join(a.errorMapErrs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid error mappings: %w", err)
+	}
+	if err := errors.Join(a.patchErrs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid spec patches: %w", err)
+	}
+	if err := errors.Join(a.descriptionErrs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid description catalog: %w", err)
+	}
+	if err := errors.Join(a.augmentErrs...); err != nil {
+		return nil, nil, fmt.Errorf("invalid type augmentations: %w", err)
+	}
+	if _, ok := schemaNamespacings[a.SchemaNamespacing]; !ok {
+		return nil, nil, fmt.Errorf("unknown schema namespacing %q (valid: %s, %s)", a.SchemaNamespacing, NamespacePackagePrefix, NamespacePackageSuffix)
+	}
+	if _, ok := anonymousStructs[a.AnonymousStructs]; !ok {
+		return nil, nil, fmt.Errorf("unknown anonymous structs strategy %q (valid: %s, %s)", a.AnonymousStructs, AnonymousStructsInline, AnonymousStructsPath)
+	}
+	if _, ok := propertyNamings[a.PropertyNaming]; !ok {
+		return nil, nil, fmt.Errorf("unknown property naming %q (valid: %s, %s, %s)", a.PropertyNaming, NamingAsIs, NamingCamelCase, NamingSnakeCase)
+	}
+	if !exampleRedactions[a.ExampleRedaction] {
+		return nil, nil, fmt.Errorf("unknown example redaction %q (valid: %s, %s, %s)", a.ExampleRedaction, ExampleRedactionOmit, ExampleRedactionMask, ExampleRedactionOff)
+	}
+	if err := a.checkSchemaIdentifiers(); err != nil {
+		return nil, nil, err
+	}
+
+	a.generator.ResetUsage()
 	spec := a.generateSpec()
+	if a.DynamicServers != nil {
+		if servers := a.DynamicServers(ctx); len(servers) > 0 {
+			spec.Servers = servers
+		}
+	}
+
+	ops = a.applyOperationDefaults(ops)
+	warnings, err := a.checkPaths(ops)
+	if err != nil {
+		return nil, nil, fmt.Errorf("conflicting paths: %w", err)
+	}
 
 	// Process operations and add them to the spec
-	if err := a.processOperations(spec, ops); err != nil {
-		return nil, fmt.Errorf("failed to process operations: %w", err)
+	if err := a.processOperations(ctx, spec, ops); err != nil {
+		return nil, nil, fmt.Errorf("failed to process operations: %w", err)
+	}
+	warnings = append(warnings, stripEmptyResponseBodies(spec)...)
+	a.applyGlobalParameters(spec)
+	if err := a.addRegisteredComponents(spec); err != nil {
+		return nil, nil, fmt.Errorf("invalid components: %w", err)
 	}
 
-	// Update schemas after operations are processed (they're populated during operation building)
-	spec.Components.Schemas = a.generator.Schemas()
+	if err := a.applyResponseEnvelope(spec); err != nil {
+		return nil, nil, fmt.Errorf("failed to envelope responses: %w", err)
+	}
 
-	sortSpec(spec)
+	warnings = append(warnings, a.applyAuthHeaders(spec)...)
+	if err := a.checkParameters(spec); err != nil {
+		return nil, nil, fmt.Errorf("duplicate parameters: %w", err)
+	}
+	if err := a.addAutoMethods(spec); err != nil {
+		return nil, nil, fmt.Errorf("invalid generated operations: %w", err)
+	}
 
-	if !a.exporter.IsSupportedVersion(a.Version) {
-		return nil, fmt.Errorf("unsupported OpenAPI version: %s", a.Version)
+	if err := a.generator.Err(); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate schemas: %w", err)
 	}
 
-	// Export spec
-	exportCfg := export.ExporterConfig{
-		Version:        a.Version,
-		ShouldValidate: a.ValidateSpec,
+	// Update schemas after operations are processed (they're populated during operation building)
+	spec.Components.Schemas = a.generator.Schemas()
+	a.dropStaleSchemas(spec.Components.Schemas)
+	if err := a.addRawSchemas(spec.Components.Schemas); err != nil {
+		return nil, nil, fmt.Errorf("invalid raw schemas: %w", err)
 	}
+	warnings = append(warnings, a.checkSchemaRefs(spec)...)
+	if err := a.applySchemaExtensions(spec.Components.Schemas); err != nil {
+		return nil, nil, fmt.Errorf("failed to extend schemas: %w", err)
+	}
+	if err := a.applySchemaTemplates(spec.Components.Schemas); err != nil {
+		return nil, nil, err
+	}
+	// Schemas are checked while they are all components, before some are inlined
+	if err := a.checkSchemas(ctx, spec); err != nil {
+		if ctx.Err() != nil {
+			return nil, nil, err
+		}
 
-	result, err := a.exporter.Export(ctx, spec, exportCfg)
+		return nil, nil, fmt.Errorf("invalid schemas: %w", err)
+	}
+	a.inlineRequestBodies(spec)
+	a.applySchemaReuseThreshold(spec)
+	if err := a.redactSpec(spec); err != nil {
+		return nil, nil, fmt.Errorf("failed to redact spec: %w", err)
+	}
+	if err := a.checkScopes(spec); err != nil {
+		return nil, nil, fmt.Errorf("undefined scopes: %w", err)
+	}
+	enumWarnings, err := a.checkEnums(spec)
 	if err != nil {
-		return nil, fmt.Errorf("failed to export OpenAPI spec: %w", err)
+		return nil, nil, fmt.Errorf("inconsistent enums: %w", err)
+	}
+	warnings = append(warnings, enumWarnings...)
+	warnings = append(warnings, a.checkFormats(spec)...)
+
+	// Components not used by the operations are pruned or reported; generated
+	// schemas of previous calls are already dropped
+	if a.PruneUnused {
+		pruneUnused(spec, a.SchemaPrefix)
+	} else {
+		warnings = append(warnings, unusedWarnings(unusedComponents(spec, a.SchemaPrefix))...)
+	}
+	warnings = append(warnings, a.namingWarnings(spec)...)
+	warnings = append(warnings, a.shadowWarnings(spec)...)
+	warnings = append(warnings, a.marshalerWarnings(spec)...)
+	a.applySchemaIDs(spec)
+
+	sortSpec(spec)
+
+	if !a.exporter.IsSupportedVersion(a.Version) {
+		return nil, nil, fmt.Errorf("unsupported OpenAPI version: %s", a.Version)
 	}
 
-	return &Result{
-		JSON:     result.Result,
-		Warnings: result.Warnings,
-	}, nil
+	return spec, warnings, nil
 }
 
 // convertOperationToModel converts a public Operation to model.Operation.
@@ -752,6 +1501,10 @@ func (a *API) Generate(ctx context.Context, ops ...Operation) (*Result, error) {
 func (a *API) convertOperationToModel(op Operation) (*model.Operation, error) {
 	doc := op.doc
 
+	if len(doc.errs) > 0 {
+		return nil, errors.Join(doc.errs...)
+	}
+
 	// Convert security requirements
 	security := make([]model.SecurityRequirement, 0, len(doc.Security))
 	for _, s := range doc.Security {
@@ -761,15 +1514,16 @@ func (a *API) convertOperationToModel(op Operation) (*model.Operation, error) {
 	}
 
 	modelOp := &model.Operation{
-		Summary:     doc.Summary,
-		Description: doc.Description,
-		OperationID: doc.OperationID,
-		Tags:        doc.Tags,
-		Deprecated:  doc.Deprecated,
-		Security:    security,
-		Extensions:  copyExtensions(doc.Extensions),
-		Responses:   map[string]*model.Response{},
-		Parameters:  []model.Parameter{},
+		Summary:      doc.Summary,
+		Description:  joinDescription(doc.Description, doc.DeprecationNote),
+		OperationID:  doc.OperationID,
+		Tags:         doc.Tags,
+		ExternalDocs: doc.ExternalDocs,
+		Deprecated:   doc.Deprecated,
+		Security:     security,
+		Extensions:   copyExtensions(doc.Extensions),
+		Responses:    map[string]*model.Response{},
+		Parameters:   []model.Parameter{},
 	}
 
 	// Build request using RequestBuilder
@@ -780,89 +1534,294 @@ func (a *API) convertOperationToModel(op Operation) (*model.Operation, error) {
 
 		// Add examples to request body if present
 		if modelOp.RequestBody != nil && len(doc.RequestNamedExamples) > 0 {
-			a.addRequestExamples(modelOp.RequestBody, doc.RequestNamedExamples)
+			if err := a.addRequestExamples(modelOp.RequestBody, doc.RequestNamedExamples); err != nil {
+				return nil, fmt.Errorf("request: %w", err)
+			}
 		}
 	}
 
+	addParameters(modelOp, doc.Parameters)
+
 	// Build responses using ResponseBuilder
-	if len(doc.ResponseTypes) > 0 {
-		if err := a.responseBuilder.BuildOperationResponses(modelOp, doc.ResponseTypes); err != nil {
+	responseTypes, err := a.withErrorResponses(doc.ResponseTypes, doc.ErrorTypes)
+	if err != nil {
+		return nil, err
+	}
+	if doc.PartialContent {
+		responseTypes = withPartialContent(responseTypes)
+	}
+	if len(responseTypes) > 0 {
+		if err := a.responseBuilder.BuildOperationResponses(modelOp, responseTypes); err != nil {
 			return nil, fmt.Errorf("failed to build responses: %w", err)
 		}
 
 		// Add examples to responses if present
 		if len(doc.ResponseNamedExamples) > 0 {
-			a.addResponseExamples(modelOp.Responses, doc.ResponseNamedExamples)
+			if err := a.addResponseExamples(modelOp.Responses, doc.ResponseNamedExamples); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	// Build additional representations after examples so that examples of the
+	// primary representation are not copied into them
+	for status, contents := range doc.ResponseAlternatives {
+		for _, c := range contents {
+			var err error
+			if c.csvRows {
+				err = a.responseBuilder.BuildCSVContent(modelOp, status, c.bodyType)
+			} else {
+				err = a.responseBuilder.BuildResponseContent(modelOp, status, c.contentType, c.bodyType)
+			}
+			if err != nil {
+				return nil, fmt.Errorf("failed to build %s content for response %s: %w", c.contentType, build.StatusKey(status), err)
+			}
+			if len(c.examples) > 0 {
+				content := modelOp.Responses[build.StatusKey(status)].Content[c.contentType]
+				content.Example = nil
+				if err := a.addMediaTypeExamples(content, c.examples, false); err != nil {
+					return nil, fmt.Errorf("%s content for response %s: %w", c.contentType, build.StatusKey(status), err)
+				}
+			}
 		}
 	}
 
+	if err := a.addMediaTypeTargetedExamples(modelOp, doc.MediaTypeExamples); err != nil {
+		return nil, err
+	}
+
+	addResponseHeaders(modelOp, doc.ResponseHeaders)
+	a.addResponseLinks(modelOp, doc.ResponseLinks)
+
 	// Ensure at least one response exists
 	if len(modelOp.Responses) == 0 {
 		modelOp.Responses[strconv.Itoa(http.StatusOK)] = &model.Response{Description: "OK"}
 	}
 
+	addCommonResponseHeaders(modelOp, doc.CommonResponseHeaders)
+	a.addWebSocketMessages(modelOp, doc.WebSocket)
+
 	return modelOp, nil
 }
 
-// addRequestExamples adds named examples to request body media types.
-func (a *API) addRequestExamples(reqBody *model.RequestBody, examples []example.Example) {
-	for _, content := range reqBody.Content {
-		if content.Examples == nil {
-			content.Examples = make(map[string]*model.Example)
+// joinDescription appends a note to a description as a separate paragraph.
+func joinDescription(description, note string) string {
+	if note == "" {
+		return description
+	}
+	if description == "" {
+		return note
+	}
+
+	return description + "\n\n" + note
+}
+
+// addCommonResponseHeaders adds headers declared with options to every response.
+// Headers already documented on a response, whatever their case, are kept as-is.
+func addCommonResponseHeaders(op *model.Operation, headers map[string]*model.Header) {
+	if len(headers) == 0 {
+		return
+	}
+	for _, resp := range op.Responses {
+		if resp.Headers == nil {
+			resp.Headers = make(map[string]*model.Header)
 		}
-		for _, ex := range examples {
-			m := &model.Example{Summary: ex.Summary(), Description: ex.Description()}
-			if ex.IsExternal() {
-				m.ExternalValue = ex.ExternalValue()
-			} else {
-				m.Value = ex.Value()
+		for name, h := range headers {
+			if hasHeader(resp.Headers, name) {
+				continue
 			}
-			content.Examples[ex.Name()] = m
+			header := *h
+			resp.Headers[name] = &header
 		}
 	}
 }
 
+// withPartialContent returns the response types with a 206 response documenting
+// the 200 response body, unless a 206 response is declared explicitly.
+func withPartialContent(responses map[int]reflect.Type) map[int]reflect.Type {
+	full, ok := responses[http.StatusOK]
+	if !ok || full == nil {
+		return responses
+	}
+	if _, ok := responses[http.StatusPartialContent]; ok {
+		return responses
+	}
+
+	result := maps.Clone(responses)
+	result[http.StatusPartialContent] = full
+
+	return result
+}
+
+// addParameters adds parameters declared with options.
+// Parameters already generated from the request struct are kept as-is.
+func addParameters(op *model.Operation, params []model.Parameter) {
+	for _, param := range params {
+		exists := false
+		for _, p := range op.Parameters {
+			if parameterKey(&p) == parameterKey(&param) {
+				exists = true
+
+				break
+			}
+		}
+		if exists {
+			continue
+		}
+
+		op.Parameters = append(op.Parameters, param)
+	}
+}
+
+// addResponseHeaders adds response headers declared with options, creating
+// responses without body for status codes that have no declared response.
+// Headers already generated from the response struct, whatever their case, are
+// kept as-is.
+func addResponseHeaders(op *model.Operation, headers map[int]map[string]*model.Header) {
+	for status, hs := range headers {
+		statusStr := build.StatusKey(status)
+		resp, ok := op.Responses[statusStr]
+		if !ok {
+			resp = &model.Response{Description: build.StatusText(status)}
+			op.Responses[statusStr] = resp
+		}
+		if resp.Headers == nil {
+			resp.Headers = make(map[string]*model.Header)
+		}
+		for name, h := range hs {
+			if hasHeader(resp.Headers, name) {
+				continue
+			}
+			resp.Headers[name] = h
+		}
+	}
+}
+
+// hasHeader reports whether a response documents a header. Header names are
+// case-insensitive.
+func hasHeader(headers map[string]*model.Header, name string) bool {
+	for existing := range headers {
+		if strings.EqualFold(existing, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// addRequestExamples adds named examples to request body media types.
+func (a *API) addRequestExamples(reqBody *model.RequestBody, examples []example.Example) error {
+	for _, content := range reqBody.Content {
+		if err := a.addMediaTypeExamples(content, examples, true); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
 // addResponseExamples adds named examples to response media types.
-func (a *API) addResponseExamples(responses map[string]*model.Response, examples map[int][]example.Example) {
+func (a *API) addResponseExamples(responses map[string]*model.Response, examples map[int][]example.Example) error {
 	for status, exList := range examples {
-		statusStr := strconv.Itoa(status)
+		statusStr := build.StatusKey(status)
 		if resp, ok := responses[statusStr]; ok && resp.Content != nil {
 			for _, content := range resp.Content {
-				if content.Examples == nil {
-					content.Examples = make(map[string]*model.Example)
-				}
-				for _, ex := range exList {
-					m := &model.Example{Summary: ex.Summary(), Description: ex.Description()}
-					if ex.IsExternal() {
-						m.ExternalValue = ex.ExternalValue()
-					} else {
-						m.Value = ex.Value()
-					}
-					content.Examples[ex.Name()] = m
+				if err := a.addMediaTypeExamples(content, exList, false); err != nil {
+					return fmt.Errorf("response %s: %w", statusStr, err)
 				}
 			}
 		}
 	}
+
+	return nil
+}
+
+// addMediaTypeExamples adds named examples to a single media type of the request
+// body or of a response. The values of file examples are loaded, and the examples
+// recorded to be checked.
+func (a *API) addMediaTypeExamples(content *model.MediaType, examples []example.Example, request bool) error {
+	if content.Examples == nil {
+		content.Examples = make(map[string]*model.Example)
+	}
+	for _, ex := range examples {
+		if ex.IsRef() {
+			if _, ok := a.examples[ex.Name()]; !ok {
+				return fmt.Errorf("example %q is not registered; register it with RegisterExample", ex.Name())
+			}
+			content.Examples[ex.Name()] = &model.Example{Ref: componentsPrefix + "examples/" + ex.Name()}
+
+			continue
+		}
+		m, err := a.modelExample(ex, request)
+		if err != nil {
+			return err
+		}
+		if ex.IsFile() {
+			a.fileExamples[m] = ex.File()
+		}
+		content.Examples[ex.Name()] = m
+	}
+
+	return nil
+}
+
+// modelExample converts an example of the request body or of a response, loading
+// the value of file examples.
+func (a *API) modelExample(ex example.Example, request bool) (*model.Example, error) {
+	m := &model.Example{Summary: ex.Summary(), Description: ex.Description()}
+	switch {
+	case ex.IsExternal():
+		m.ExternalValue = ex.ExternalValue()
+	case ex.IsFile():
+		value, err := ex.Load()
+		if err != nil {
+			return nil, fmt.Errorf("example %q: %w", ex.Name(), err)
+		}
+		m.Value = value
+	default:
+		m.Value = a.redactExample(ex.Value(), request)
+	}
+
+	return m, nil
 }
 
-// processOperations processes operations and adds them to the spec.
-func (a *API) processOperations(spec *model.Spec, ops []Operation) error {
+// processOperations processes operations and adds them to the spec. It stops
+// when the context is done, reporting the number of processed operations.
+func (a *API) processOperations(ctx context.Context, spec *model.Spec, ops []Operation) error {
+	a.operationTraces = make(map[string]debug.OperationTrace)
+	a.fileExamples = make(map[*model.Example]string)
+
 	// Group operations by path
 	byPath := make(map[string][]Operation)
 	for _, op := range ops {
-		path := convertPathToOpenAPI(op.Path)
+		path := a.openAPIPath(op.Path)
 		byPath[path] = append(byPath[path], op)
 	}
 
 	// Process each path
+	processed := 0
 	for path, pathOps := range byPath {
 		pathItem := &model.PathItem{}
 
 		for _, op := range pathOps {
+			if err := ctx.Err(); err != nil {
+				return fmt.Errorf("canceled after %d of %d operations: %w", processed, len(ops), err)
+			}
+			processed++
+
+			_, patterns, wildcards := parsePathTemplate(a.PathNormalization.normalize(op.Path))
+			if err := a.checkPathParameters(path, wildcards, &op.doc); err != nil {
+				return fmt.Errorf("operation %s %s: %w", op.Method, op.Path, err)
+			}
+
 			modelOp, err := a.convertOperationToModel(op)
 			if err != nil {
 				return fmt.Errorf("failed to convert operation %s %s: %w", op.Method, op.Path, err)
 			}
+			applyWildcards(modelOp, wildcards)
+			applyTemplateParameters(modelOp, path)
+			applyPathPatterns(modelOp, patterns)
+			a.traceOperation(path, op, modelOp)
 
 			// Add operation to path item based on HTTP method
 			if err := assignOperationToPathItem(pathItem, op.Method, modelOp); err != nil {
@@ -896,23 +1855,154 @@ func assignOperationToPathItem(pathItem *model.PathItem, method string, op *mode
 	case http.MethodTrace:
 		pathItem.Trace = op
 	default:
-		return fmt.Errorf("unsupported HTTP method: %s", method)
+		if !isMethodToken(method) {
+			return fmt.Errorf("unsupported HTTP method: %q", method)
+		}
+		if pathItem.AdditionalOperations == nil {
+			pathItem.AdditionalOperations = make(map[string]*model.Operation)
+		}
+		pathItem.AdditionalOperations[strings.ToUpper(method)] = op
 	}
 
 	return nil
 }
 
-// convertPathToOpenAPI converts router path format (/users/:id) to OpenAPI format (/users/{id}).
+// isMethodToken reports whether method is a valid HTTP method name (an RFC 9110 token).
+func isMethodToken(method string) bool {
+	if method == "" {
+		return false
+	}
+	for _, r := range method {
+		if r > unicode.MaxASCII || !(unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("!#$%&'*+-.^_`|~", r)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// convertPathToOpenAPI converts a router path to OpenAPI format.
+// See parsePathTemplate for the supported syntaxes.
 func convertPathToOpenAPI(path string) string {
-	// Convert :param to {param}
+	converted, _, _ := parsePathTemplate(path)
+
+	return converted
+}
+
+// wildcardParam names the parameter of anonymous wildcard segments (echo, chi "*").
+const wildcardParam = "wildcard"
+
+// parsePathTemplate converts a router path to OpenAPI format. It returns the
+// regular expressions constraining its parameters, by parameter name, and the
+// names of its catch-all parameters.
+// Supported syntaxes:
+//   - :param (gin, echo, httprouter) -> {param}
+//   - {param} (gorilla, chi, OpenAPI) -> {param}
+//   - {param:regex} (gorilla, chi) -> {param}, with pattern ^regex$
+//   - *param (gin, httprouter catch-all) -> {param}
+//   - * (echo, chi catch-all) -> {wildcard}
+func parsePathTemplate(path string) (string, map[string]string, []string) {
+	var patterns map[string]string
+	var wildcards []string
+
 	parts := strings.Split(path, "/")
 	for i, part := range parts {
 		if param, ok := strings.CutPrefix(part, ":"); ok {
 			parts[i] = "{" + param + "}"
+
+			continue
 		}
+		if param, ok := strings.CutPrefix(part, "*"); ok {
+			if param == "" {
+				param = wildcardParam
+			}
+			parts[i] = "{" + param + "}"
+			wildcards = append(wildcards, param)
+
+			continue
+		}
+		if !strings.Contains(part, "{") {
+			continue
+		}
+
+		// Braces may nest within regular expressions, as in {id:[0-9]{3}}
+		var sb strings.Builder
+		depth, start := 0, 0
+		for j, r := range part {
+			switch {
+			case r == '{':
+				if depth == 0 {
+					start = j + 1
+				}
+				depth++
+			case r == '}' && depth > 0:
+				depth--
+				if depth > 0 {
+					continue
+				}
+				name, pattern, ok := strings.Cut(part[start:j], ":")
+				sb.WriteString("{" + name + "}")
+				if ok && pattern != "" {
+					if patterns == nil {
+						patterns = make(map[string]string)
+					}
+					patterns[name] = "^" + pattern + "$"
+				}
+			case depth == 0:
+				sb.WriteRune(r)
+			}
+		}
+		parts[i] = sb.String()
 	}
 
-	return strings.Join(parts, "/")
+	return strings.Join(parts, "/"), patterns, wildcards
+}
+
+// extensionWildcard marks catch-all path parameters, whose values may contain slashes.
+const extensionWildcard = "x-wildcard"
+
+// applyWildcards documents catch-all path parameters: they are declared as string
+// path parameters when the request does not declare them, and marked with the
+// x-wildcard extension, as OpenAPI has no representation for values spanning segments.
+func applyWildcards(op *model.Operation, wildcards []string) {
+	for _, name := range wildcards {
+		i := slices.IndexFunc(op.Parameters, func(p model.Parameter) bool {
+			return p.In == "path" && p.Name == name
+		})
+		if i < 0 {
+			op.Parameters = append(op.Parameters, model.Parameter{
+				Name:        name,
+				In:          "path",
+				Description: "Remainder of the path; may contain slashes",
+				Required:    true,
+				Schema:      &model.Schema{Type: "string"},
+			})
+			i = len(op.Parameters) - 1
+		}
+
+		p := &op.Parameters[i]
+		p.Extensions = copyExtensions(p.Extensions)
+		if p.Extensions == nil {
+			p.Extensions = make(map[string]any, 1)
+		}
+		p.Extensions[extensionWildcard] = true
+	}
+}
+
+// applyPathPatterns sets the regular expressions of the path template on the
+// string schemas of the matching path parameters, unless they already define a pattern.
+func applyPathPatterns(op *model.Operation, patterns map[string]string) {
+	for i := range op.Parameters {
+		p := &op.Parameters[i]
+		pattern, ok := patterns[p.Name]
+		if !ok || p.In != "path" || p.Schema == nil || p.Schema.Type != "string" || p.Schema.Pattern != "" {
+			continue
+		}
+		// Schemas may be shared with other parameters
+		s := *p.Schema
+		s.Pattern = pattern
+		p.Schema = &s
+	}
 }
 
 // copyExtensions creates a deep copy of extensions map.
@@ -928,12 +2018,14 @@ func copyExtensions(ext map[string]any) map[string]any {
 
 func (a *API) generateSpec() *model.Spec {
 	spec := &model.Spec{
-		Info:         a.Info,
-		Servers:      a.Servers,
-		Tags:         a.Tags,
-		Paths:        make(map[string]*model.PathItem),
-		Security:     a.DefaultSecurity,
-		ExternalDocs: a.ExternalDocs,
+		Info:              a.Info,
+		JSONSchemaDialect: a.JSONSchemaDialect,
+		Servers:           a.Servers,
+		Tags:              a.Tags,
+		Paths:             make(map[string]*model.PathItem),
+		Security:          a.DefaultSecurity,
+		ExternalDocs:      a.ExternalDocs,
+		Extensions:        maps.Clone(a.Extensions),
 		Components: &model.Components{
 			Schemas:         a.generator.Schemas(),
 			SecuritySchemes: a.SecuritySchemes,
@@ -1,13 +1,20 @@
 package openapi
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"maps"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/talav/openapi/config"
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/example"
 )
 
 // normalizeJSON normalizes JSON by unmarshaling and remarshaling to ensure consistent formatting.
@@ -25,6 +32,16 @@ func normalizeJSON(jsonBytes []byte) (string, error) {
 	return string(normalized), nil
 }
 
+// forEachVersion runs test as a subtest for each supported OpenAPI version.
+func forEachVersion(t *testing.T, test func(t *testing.T, version string)) {
+	t.Helper()
+	for _, version := range []string{"3.0.4", "3.1.2"} {
+		t.Run(version, func(t *testing.T) {
+			test(t, version)
+		})
+	}
+}
+
 func TestGenerate_SimpleGET(t *testing.T) {
 	type User struct {
 		ID   int    `json:"id"`
@@ -654,6 +671,225 @@ func TestGenerate_WithQueryParameters(t *testing.T) {
 	assert.Equal(t, expected, normalized)
 }
 
+func TestGenerate_QueryParameterConstraints(t *testing.T) {
+	type ListOrdersRequest struct {
+		Limit  int      `schema:"limit,location=query" validate:"min=1,max=100" default:"20"`
+		Status string   `schema:"status,location=query" validate:"oneof=open closed"`
+		Code   string   `schema:"code,location=query" validate:"required,pattern=^[A-Z]{3}$"`
+		Tags   []string `schema:"tags,location=query" validate:"max=5"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/orders", WithRequest(ListOrdersRequest{})),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name     string          `json:"name"`
+				Required bool            `json:"required"`
+				Schema   json.RawMessage `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	params := spec.Paths["/orders"]["get"].Parameters
+	require.Len(t, params, 4)
+
+	assert.False(t, params[0].Required)
+	assert.JSONEq(t, `{"type": "integer", "format": "int64", "minimum": 1, "maximum": 100, "default": 20}`, string(params[0].Schema))
+	assert.JSONEq(t, `{"type": "string", "enum": ["open", "closed"]}`, string(params[1].Schema))
+	assert.True(t, params[2].Required)
+	assert.JSONEq(t, `{"type": "string", "pattern": "^[A-Z]{3}$"}`, string(params[2].Schema))
+	assert.JSONEq(t, `{"type": "array", "items": {"type": "string"}, "maxItems": 5}`, string(params[3].Schema))
+}
+
+func TestGenerate_ParameterMetadata(t *testing.T) {
+	type SearchRequest struct {
+		Query  string `schema:"q,location=query" openapi:"description=Search terms,examples=shoes,allowEmptyValue"`
+		Sort   string `schema:"sort,location=query" openapi:"deprecated,examples=name|date"`
+		Since  string `schema:"since,location=query" openapi:"format=date"`
+		Tenant string `schema:"X-Tenant,location=header" openapi:"allowEmptyValue,x-internal=true"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		result, err := NewAPI(WithVersion(version)).Generate(context.Background(),
+			GET("/search", WithRequest(SearchRequest{})),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Parameters []struct {
+					Name            string `json:"name"`
+					Description     string `json:"description"`
+					Deprecated      bool   `json:"deprecated"`
+					AllowEmptyValue bool   `json:"allowEmptyValue"`
+					Example         any    `json:"example"`
+					Examples        map[string]struct {
+						Value any `json:"value"`
+					} `json:"examples"`
+					Schema struct {
+						Format string `json:"format"`
+					} `json:"schema"`
+					Internal string `json:"x-internal"`
+				} `json:"parameters"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		params := spec.Paths["/search"]["get"].Parameters
+		require.Len(t, params, 4)
+
+		q := params[0]
+		assert.Equal(t, "Search terms", q.Description)
+		assert.Equal(t, "shoes", q.Example)
+		assert.True(t, q.AllowEmptyValue)
+		assert.False(t, q.Deprecated)
+
+		sort := params[1]
+		assert.True(t, sort.Deprecated)
+		assert.Nil(t, sort.Example)
+		assert.Equal(t, "name", sort.Examples["name"].Value)
+		assert.Equal(t, "date", sort.Examples["date"].Value)
+
+		assert.Equal(t, "date", params[2].Schema.Format)
+
+		// allowEmptyValue only applies to query parameters
+		assert.False(t, params[3].AllowEmptyValue)
+		assert.Equal(t, "true", params[3].Internal)
+	})
+}
+
+type queryFilter struct {
+	Status string `json:"status"`
+	Min    int    `json:"min"`
+}
+
+func TestGenerate_ArrayAndObjectQueryParameters(t *testing.T) {
+	type SearchRequest struct {
+		Tags   []string          `schema:"tags,location=query"`
+		IDs    []int             `schema:"ids,location=query,explode=false"`
+		Codes  []string          `schema:"codes,location=query,style=pipeDelimited"`
+		Filter queryFilter       `schema:"filter,location=query,style=deepObject"`
+		Labels map[string]string `schema:"labels,location=query"`
+		Page   int               `schema:"page,location=query,explode=false"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		result, err := NewAPI(WithVersion(version)).Generate(context.Background(),
+			GET("/search", WithRequest(SearchRequest{})),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Parameters []map[string]any `json:"parameters"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		params := spec.Paths["/search"]["get"].Parameters
+		require.Len(t, params, 6)
+
+		styles := make(map[string][]any, len(params))
+		for _, p := range params {
+			styles[p["name"].(string)] = []any{p["style"], p["explode"]}
+		}
+		assert.Equal(t, map[string][]any{
+			"tags":   {"form", true},
+			"ids":    {"form", false},
+			"codes":  {"pipeDelimited", nil},
+			"filter": {"deepObject", true},
+			"labels": {"form", true},
+			"page":   {"form", nil},
+		}, styles)
+		assert.Equal(t, map[string]any{"$ref": "#/components/schemas/QueryFilter"}, params[3]["schema"])
+		assert.Equal(t, "object", params[4]["schema"].(map[string]any)["type"])
+	})
+}
+
+func TestGenerate_QueryParameterStyleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     any
+		wantErr string
+	}{
+		{
+			name: "deepObject scalar",
+			req: struct {
+				Sort string `schema:"sort,location=query,style=deepObject"`
+			}{},
+			wantErr: "parameter sort: style deepObject requires an object, got string",
+		},
+		{
+			name: "pipeDelimited scalar",
+			req: struct {
+				Page int `schema:"page,location=query,style=pipeDelimited"`
+			}{},
+			wantErr: "parameter page: style pipeDelimited requires an array or an object, got integer",
+		},
+		{
+			name: "array of objects",
+			req: struct {
+				Filters []queryFilter `schema:"filters,location=query"`
+			}{},
+			wantErr: "parameter filters: arrays of arrays or objects cannot be serialized in the query",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(), GET("/search", WithRequest(tt.req)))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestGenerate_RequiredParameters(t *testing.T) {
+	type ListRequest struct {
+		Tenant  string `schema:"X-Tenant,location=header,required"`
+		Cursor  string `schema:"cursor,location=query,required=true"`
+		Query   string `schema:"q,location=query" validate:"required"`
+		Page    int    `schema:"page,location=query,required=false" validate:"required"`
+		Session string `schema:"session,location=cookie"`
+	}
+
+	result, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		GET("/items", WithRequest(ListRequest{})),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name     string `json:"name"`
+				Required bool   `json:"required"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	required := make(map[string]bool)
+	for _, p := range spec.Paths["/items"]["get"].Parameters {
+		required[p.Name] = p.Required
+	}
+	assert.Equal(t, map[string]bool{"X-Tenant": true, "cursor": true, "q": true, "page": false, "session": false}, required)
+}
+
+func TestGenerate_RequiredParameterErrors(t *testing.T) {
+	_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(), GET("/items/:id", WithRequest(struct {
+		ID string `schema:"id,location=path,required=false"`
+	}{})))
+	require.ErrorContains(t, err, "parameter id: path parameters are always required")
+
+	_, err = NewAPI(WithVersion("3.1.2")).Generate(context.Background(), GET("/items", WithRequest(struct {
+		Page int `schema:"page,location=query,required=maybe"`
+	}{})))
+	require.ErrorContains(t, err, `parameter page: invalid required value "maybe"`)
+}
+
 func TestGenerate_NestedStructs(t *testing.T) {
 	type Address struct {
 		Street string `json:"street"`
@@ -724,6 +960,16 @@ func TestGenerate_NestedStructs(t *testing.T) {
   "paths": {
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "content": {
@@ -1145,6 +1391,16 @@ func TestGenerate_MultipleResponseCodes(t *testing.T) {
   "paths": {
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "content": {
@@ -1256,6 +1512,16 @@ func TestGenerate_PlainStructResponse(t *testing.T) {
   "paths": {
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "content": {
@@ -1358,6 +1624,16 @@ func TestGenerate_PlainStructWithContentTypeProvider(t *testing.T) {
   "paths": {
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "422": {
             "content": {
@@ -1473,6 +1749,95 @@ func TestGenerate_WithHeaderParameters(t *testing.T) {
 	assert.Equal(t, expected, normalized)
 }
 
+func TestGenerate_HeaderTag(t *testing.T) {
+	type ListUsersRequest struct {
+		Tenant string `header:"X-Tenant-ID" validate:"required"`
+	}
+
+	type User struct {
+		ID int `json:"id"`
+	}
+
+	type ListUsersResponse struct {
+		Total   int       `header:"X-Total-Count" validate:"required" openapi:"description=Number of users"`
+		Expires time.Time `header:"Expires"`
+		Legacy  string    `header:"" openapi:"deprecated=true"`
+		Body    []User    `body:"structured"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+		result, err := api.Generate(context.Background(),
+			GET("/users",
+				WithRequest(ListUsersRequest{}),
+				WithResponse(200, ListUsersResponse{}),
+			),
+		)
+		require.NoError(t, err)
+
+		type header struct {
+			Description string `json:"description"`
+			Required    bool   `json:"required"`
+			Deprecated  bool   `json:"deprecated"`
+			Schema      struct {
+				Type   any    `json:"type"`
+				Format string `json:"format"`
+			} `json:"schema"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Parameters []struct {
+					Name     string `json:"name"`
+					In       string `json:"in"`
+					Required bool   `json:"required"`
+				} `json:"parameters"`
+				Responses map[string]struct {
+					Headers map[string]header `json:"headers"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		op := spec.Paths["/users"]["get"]
+		require.Len(t, op.Parameters, 1)
+		assert.Equal(t, "X-Tenant-ID", op.Parameters[0].Name)
+		assert.Equal(t, "header", op.Parameters[0].In)
+		assert.True(t, op.Parameters[0].Required)
+
+		headers := op.Responses["200"].Headers
+		require.Len(t, headers, 3)
+		assert.Equal(t, "integer", headers["X-Total-Count"].Schema.Type)
+		assert.Equal(t, "Number of users", headers["X-Total-Count"].Description)
+		assert.True(t, headers["X-Total-Count"].Required)
+		assert.Equal(t, "string", headers["Expires"].Schema.Type)
+		assert.False(t, headers["Expires"].Required)
+		assert.True(t, headers["Legacy"].Deprecated)
+	})
+}
+
+func TestGenerate_CustomHeaderTag(t *testing.T) {
+	type CountResponse struct {
+		Total int   `hdr:"X-Total-Count"`
+		Body  []int `body:"structured"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithTagConfig(config.TagConfig{Header: "hdr"}))
+	result, err := api.Generate(context.Background(),
+		GET("/counts", WithResponse(200, CountResponse{})),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Headers map[string]any `json:"headers"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.Contains(t, spec.Paths["/counts"]["get"].Responses["200"].Headers, "X-Total-Count")
+}
+
 func TestGenerate_WithCookieParameters(t *testing.T) {
 	type GetUsersRequest struct {
 		SessionID string `schema:"session_id,location=cookie"`
@@ -1792,6 +2157,16 @@ func TestGenerate_Version312_FullFeatures(t *testing.T) {
   "paths": {
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "content": {
@@ -1864,6 +2239,16 @@ func TestGenerate_Version304_WithValidation(t *testing.T) {
   "paths": {
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "content": {
@@ -1969,6 +2354,16 @@ func TestGenerate_CompareVersions_SameAPI(t *testing.T) {
     },
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "content": {
@@ -2027,6 +2422,16 @@ func TestGenerate_CompareVersions_SameAPI(t *testing.T) {
     },
     "/users/{id}": {
       "get": {
+        "parameters": [
+          {
+            "in": "path",
+            "name": "id",
+            "required": true,
+            "schema": {
+              "type": "string"
+            }
+          }
+        ],
         "responses": {
           "200": {
             "content": {
@@ -2612,6 +3017,109 @@ func TestGenerate_OAuth2Security(t *testing.T) {
 	assert.Equal(t, expected, normalized)
 }
 
+func TestGenerate_OAuth2ExtensionFlows(t *testing.T) {
+	type Response struct {
+		Body struct{} `body:"structured"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithVersion(version),
+			WithOAuth2("oauth2", "OAuth 2.0",
+				OAuth2Flow{
+					Type:     FlowClientCredentials,
+					TokenURL: "https://auth.example.com/token",
+					Scopes:   map[string]string{"read": "Read access"},
+				},
+				OAuth2Flow{
+					Type:                   FlowDeviceAuthorization,
+					DeviceAuthorizationURL: "https://auth.example.com/device",
+					TokenURL:               "https://auth.example.com/token",
+					Scopes:                 map[string]string{"read": "Read access"},
+				},
+				OAuth2Flow{
+					Type:     FlowTokenExchange,
+					TokenURL: "https://auth.example.com/token",
+				},
+			),
+		)
+
+		result, err := api.Generate(context.Background(),
+			GET("/test", WithSecurity("oauth2", "read"), WithResponse(200, Response{})),
+		)
+		require.NoError(t, err)
+
+		type flow struct {
+			DeviceAuthorizationURL string            `json:"deviceAuthorizationUrl"`
+			TokenURL               string            `json:"tokenUrl"`
+			GrantType              string            `json:"grantType"`
+			Scopes                 map[string]string `json:"scopes"`
+		}
+		var spec struct {
+			Components struct {
+				SecuritySchemes map[string]struct {
+					Flows  map[string]flow `json:"flows"`
+					XFlows map[string]flow `json:"x-flows"`
+				} `json:"securitySchemes"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		scheme := spec.Components.SecuritySchemes["oauth2"]
+
+		assert.Equal(t, []string{"clientCredentials"}, slices.Collect(maps.Keys(scheme.Flows)))
+		assert.Equal(t, flow{
+			DeviceAuthorizationURL: "https://auth.example.com/device",
+			TokenURL:               "https://auth.example.com/token",
+			GrantType:              "urn:ietf:params:oauth:grant-type:device_code",
+			Scopes:                 map[string]string{"read": "Read access"},
+		}, scheme.XFlows["deviceAuthorization"])
+		assert.Equal(t, flow{
+			TokenURL:  "https://auth.example.com/token",
+			GrantType: "urn:ietf:params:oauth:grant-type:token-exchange",
+			Scopes:    map[string]string{},
+		}, scheme.XFlows["tokenExchange"])
+	})
+}
+
+func TestGenerate_RequestStructSecurity(t *testing.T) {
+	type UpdateUserRequest struct {
+		_  struct{} `openapi:"security=oauth2|bearerAuth,scopes=users:write"`
+		ID string   `schema:"id,location=path"`
+	}
+	type Response struct {
+		Body struct{} `body:"structured"`
+	}
+
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithBearerAuth("bearerAuth", "JWT"),
+		WithOAuth2("oauth2", "OAuth 2.0", OAuth2Flow{
+			Type:     FlowClientCredentials,
+			TokenURL: "https://auth.example.com/token",
+			Scopes:   map[string]string{"users:write": "Write users"},
+		}),
+	)
+
+	result, err := api.Generate(context.Background(),
+		PUT("/users/:id", WithRequest(UpdateUserRequest{}), WithResponse(200, Response{})),
+		PATCH("/users/:id", WithRequest(UpdateUserRequest{}), WithSecurity("bearerAuth"), WithResponse(200, Response{})),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	assert.Equal(t, []map[string][]string{
+		{"oauth2": {"users:write"}},
+		{"bearerAuth": {"users:write"}},
+	}, spec.Paths["/users/{id}"]["put"].Security)
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, spec.Paths["/users/{id}"]["patch"].Security, "operation security takes precedence")
+}
+
 func TestGenerate_OpenIDConnectSecurity(t *testing.T) {
 	type Response struct {
 		Body struct{} `body:"structured"`
@@ -2936,3 +3444,808 @@ func TestGenerate_UnsupportedVersion(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "unsupported OpenAPI version")
 }
+
+func TestGenerate_TextHTMLAndDownloadResponses(t *testing.T) {
+	type TextResponse struct {
+		Body string `body:"text"`
+	}
+	type HTMLResponse struct {
+		Body string `body:"html"`
+	}
+	type DownloadResponse struct {
+		Body []byte `body:"download"`
+	}
+
+	api := NewAPI(
+		WithInfoTitle("Test API"),
+		WithInfoVersion("1.0.0"),
+		WithVersion("3.1.2"),
+		WithValidation(true),
+	)
+
+	result, err := api.Generate(context.Background(),
+		GET("/report.txt", WithResponse(200, TextResponse{})),
+		GET("/report.html", WithResponse(200, HTMLResponse{})),
+		GET("/report.csv", WithResponse(200, DownloadResponse{})),
+	)
+	require.NoError(t, err)
+
+	normalized, err := normalizeJSON(result.JSON)
+	require.NoError(t, err)
+
+	expected := `{
+  "components": {},
+  "info": {
+    "title": "Test API",
+    "version": "1.0.0"
+  },
+  "openapi": "3.1.2",
+  "paths": {
+    "/report.csv": {
+      "get": {
+        "responses": {
+          "200": {
+            "content": {
+              "application/octet-stream": {
+                "schema": {
+                  "format": "binary",
+                  "type": "string"
+                }
+              }
+            },
+            "description": "OK",
+            "headers": {
+              "Content-Disposition": {
+                "description": "Indicates that the content is an attachment, e.g. attachment; filename=\"report.csv\"",
+                "schema": {
+                  "type": "string"
+                }
+              }
+            }
+          }
+        }
+      }
+    },
+    "/report.html": {
+      "get": {
+        "responses": {
+          "200": {
+            "content": {
+              "text/html": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            },
+            "description": "OK"
+          }
+        }
+      }
+    },
+    "/report.txt": {
+      "get": {
+        "responses": {
+          "200": {
+            "content": {
+              "text/plain": {
+                "schema": {
+                  "type": "string"
+                }
+              }
+            },
+            "description": "OK"
+          }
+        }
+      }
+    }
+  }
+}`
+
+	assert.Equal(t, expected, normalized)
+}
+
+func TestGenerate_FileBodiesAs(t *testing.T) {
+	type TextResponse struct {
+		Body string `body:"file,as=text"`
+	}
+	type HTMLResponse struct {
+		Body string `body:"file,as=html"`
+	}
+	type DownloadResponse struct {
+		Body []byte `body:"file,as=download"`
+	}
+
+	generate := func(ops ...Operation) string {
+		result, err := NewAPI(WithVersion("3.1.2"), WithValidation(true)).Generate(context.Background(), ops...)
+		require.NoError(t, err)
+		normalized, err := normalizeJSON(result.JSON)
+		require.NoError(t, err)
+
+		return normalized
+	}
+
+	// The as option of file bodies is an alias of the body types
+	type TextTagged struct {
+		Body string `body:"text"`
+	}
+	type HTMLTagged struct {
+		Body string `body:"html"`
+	}
+	type DownloadTagged struct {
+		Body []byte `body:"download"`
+	}
+	assert.Equal(t,
+		generate(
+			GET("/report.txt", WithResponse(200, TextTagged{})),
+			GET("/report.html", WithResponse(200, HTMLTagged{})),
+			GET("/report.csv", WithResponse(200, DownloadTagged{})),
+		),
+		generate(
+			GET("/report.txt", WithResponse(200, TextResponse{})),
+			GET("/report.html", WithResponse(200, HTMLResponse{})),
+			GET("/report.csv", WithResponse(200, DownloadResponse{})),
+		),
+	)
+}
+
+func TestGenerate_DownloadResponse_DeclaredDisposition(t *testing.T) {
+	type DownloadResponse struct {
+		Disposition string `schema:"content-disposition,location=header" openapi:"description=Always an attachment"`
+		Body        []byte `body:"download"`
+	}
+
+	api := NewAPI(
+		WithInfoTitle("Test API"),
+		WithInfoVersion("1.0.0"),
+		WithVersion("3.1.2"),
+	)
+
+	result, err := api.Generate(context.Background(), GET("/export", WithResponse(200, DownloadResponse{})))
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &doc))
+	resp := doc["paths"].(map[string]any)["/export"].(map[string]any)["get"].(map[string]any)["responses"].(map[string]any)["200"].(map[string]any)
+	headers := resp["headers"].(map[string]any)
+	assert.Equal(t, []string{"content-disposition"}, keys(headers))
+	assert.Equal(t, "Always an attachment", headers["content-disposition"].(map[string]any)["description"])
+}
+
+func TestGenerate_Concurrent(t *testing.T) {
+	type User struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+	other := NewAPI(WithVersion("3.0.4"))
+
+	var wg sync.WaitGroup
+	results := make([]*Result, 8)
+	errs := make([]error, 8)
+	for i := range results {
+		wg.Go(func() {
+			target := api
+			if i%2 == 1 {
+				target = other
+			}
+			results[i], errs[i] = target.Generate(context.Background(),
+				GET("/users/:id", WithResponse(200, User{})),
+			)
+		})
+	}
+	wg.Wait()
+
+	for i := range results {
+		require.NoError(t, errs[i])
+		assert.Equal(t, results[i%2].Fingerprint, results[i].Fingerprint)
+	}
+}
+
+func TestGenerateTo(t *testing.T) {
+	type User struct {
+		ID int `json:"id"`
+	}
+	ops := []Operation{GET("/users/:id", WithResponse(200, User{}), WithOperationExtension("x-internal", true))}
+
+	// Validated specs are buffered, others streamed
+	for _, validate := range []bool{true, false} {
+		api := NewAPI(WithVersion("3.1.2"), WithValidation(validate), WithInfoExtension("x-logo", map[string]any{"url": "https://example.com/logo.png"}), WithExtension("x-audience", "public"))
+
+		expected, err := api.Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		result, err := api.GenerateTo(context.Background(), &buf, ops...)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(expected.JSON)+"\n", buf.String())
+		assert.Nil(t, result.JSON)
+		assert.Empty(t, result.Fingerprint)
+		require.NotNil(t, result.Model)
+		_, ok := result.Model.SchemaByName("User")
+		assert.True(t, ok)
+	}
+}
+
+func TestGenerateTo_Error(t *testing.T) {
+	api := NewAPI(WithVersion("2.0"))
+
+	var buf bytes.Buffer
+	_, err := api.GenerateTo(context.Background(), &buf)
+
+	require.ErrorContains(t, err, "unsupported OpenAPI version")
+	assert.Zero(t, buf.Len())
+}
+
+func TestCheck(t *testing.T) {
+	type User struct {
+		ID int `json:"id"`
+	}
+	// Examples failing to serialize show whether the spec is serialized
+	ops := []Operation{GET("/users/:id", WithResponse(200, User{}, example.New("user", func() {})))}
+
+	api := NewAPI(WithVersion("3.1.2"))
+	_, err := api.Generate(context.Background(), ops...)
+	require.ErrorContains(t, err, "failed to marshal spec to JSON")
+
+	result, err := api.Check(context.Background(), ops...)
+	require.NoError(t, err, "the spec is not serialized")
+	assert.Nil(t, result.JSON)
+	assert.Empty(t, result.Fingerprint)
+	assert.Equal(t, 1, result.Stats.Operations)
+	_, ok := result.Model.SchemaByName("User")
+	assert.True(t, ok)
+
+	api = NewAPI(WithVersion("3.1.2"), WithValidation(true))
+	_, err = api.Check(context.Background(), ops...)
+	require.ErrorContains(t, err, "failed to marshal spec to JSON", "the spec is serialized to be validated")
+}
+
+func TestGenerate_ValidationErrors(t *testing.T) {
+	type BadName struct {
+		Name string `json:"name" validate:"max=-1"`
+	}
+
+	api := NewAPI(WithVersion("3.0.4"), WithValidation(true))
+
+	_, err := api.Generate(context.Background(),
+		GET("/users/:id",
+			WithResponse(200, BadName{}),
+			WithResponse(99, BadName{}),
+		),
+	)
+	require.Error(t, err)
+
+	var violations debug.ValidationErrors
+	require.ErrorAs(t, err, &violations)
+	require.Len(t, violations, 2)
+
+	assert.Equal(t, "/components/schemas/BadName/properties/name/maxLength", violations[0].Pointer)
+	assert.InDelta(t, -1, violations[0].Fragment, 0)
+	assert.Equal(t, "openapi.BadName.Name", violations[0].Source)
+	assert.Contains(t, violations[0].Rule, "/minimum")
+
+	assert.Equal(t, "/paths/~1users~1{id}/get/responses", violations[1].Pointer)
+	assert.Equal(t, "GET /users/{id}", violations[1].Source)
+	assert.Contains(t, violations[1].Message, "'99'")
+
+	assert.Contains(t, err.Error(), "(from openapi.BadName.Name)")
+	assert.Contains(t, err.Error(), "(from GET /users/{id})")
+}
+
+func TestWithMetaSchema(t *testing.T) {
+	// A pinned meta-schema requiring a description of the API
+	metaSchema := []byte(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"type": "object",
+		"required": ["openapi", "info"],
+		"properties": {"info": {"type": "object", "required": ["description"]}}
+	}`)
+
+	api := NewAPI(WithVersion("3.1.2"), WithValidation(true), WithMetaSchema(metaSchema))
+	_, err := api.Generate(context.Background(), GET("/health"))
+	var violations debug.ValidationErrors
+	require.ErrorAs(t, err, &violations)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "/info", violations[0].Pointer)
+	assert.Contains(t, violations[0].Message, "description")
+
+	api = NewAPI(WithVersion("3.1.2"), WithValidation(true), WithMetaSchema(metaSchema), WithInfoDescription("Health checks"))
+	_, err = api.Generate(context.Background(), GET("/health"))
+	require.NoError(t, err)
+
+	// References outside the meta-schema are never fetched
+	remote := []byte(`{"$ref": "https://example.com/openapi-3.1.json"}`)
+	_, err = NewAPI(WithVersion("3.1.2"), WithValidation(true), WithMetaSchema(remote)).Generate(context.Background(), GET("/health"))
+	require.ErrorContains(t, err, "https://example.com/openapi-3.1.json is not loaded: validation is offline")
+}
+
+func TestGenerate_ValidationScopes(t *testing.T) {
+	type BadName struct {
+		Name string `json:"name" validate:"max=-1"`
+	}
+
+	ops := []Operation{
+		GET("/users/:id",
+			WithResponse(200, BadName{}),
+			WithResponse(99, BadName{}),
+		),
+	}
+
+	tests := []struct {
+		name     string
+		scopes   []ValidationScope
+		pointers []string
+	}{
+		{
+			name:     "schemas only",
+			scopes:   []ValidationScope{ValidateSchemasOnly},
+			pointers: []string{"/components/schemas/BadName/properties/name/maxLength"},
+		},
+		{
+			name:     "paths only",
+			scopes:   []ValidationScope{ValidatePathsOnly},
+			pointers: []string{"/paths/~1users~1{id}/get/responses"},
+		},
+		{
+			name:   "schemas and paths",
+			scopes: []ValidationScope{ValidateSchemasOnly, ValidatePathsOnly},
+			pointers: []string{
+				"/components/schemas/BadName/properties/name/maxLength",
+				"/paths/~1users~1{id}/get/responses",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.0.4"), WithValidation(true, tt.scopes...))
+
+			_, err := api.Generate(context.Background(), ops...)
+			require.Error(t, err)
+
+			var violations debug.ValidationErrors
+			require.ErrorAs(t, err, &violations)
+			pointers := make([]string, 0, len(violations))
+			for _, v := range violations {
+				pointers = append(pointers, v.Pointer)
+			}
+			assert.Equal(t, tt.pointers, pointers)
+		})
+	}
+
+	t.Run("examples only", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.0.4"), WithValidation(true, ValidateExamplesAgainstSchemas))
+
+		_, err := api.Generate(context.Background(), ops...)
+		require.NoError(t, err)
+	})
+}
+
+func TestGenerate_ValidateExamplesAgainstSchemas(t *testing.T) {
+	type Product struct {
+		ID    int     `json:"id" validate:"required"`
+		Name  string  `json:"name" validate:"required"`
+		Price float64 `json:"price" validate:"min=0" openapi:"examples=9.99|-1"`
+		Note  *string `json:"note"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true, ValidateExamplesAgainstSchemas))
+
+		_, err := api.Generate(context.Background(),
+			GET("/products/:id",
+				WithResponse(200, Product{},
+					example.New("valid", Product{ID: 1, Name: "Pen", Price: 1.5}),
+					example.New("invalid", map[string]any{"id": "one", "price": 1}),
+				),
+			),
+		)
+		require.Error(t, err)
+
+		var violations debug.ValidationErrors
+		require.ErrorAs(t, err, &violations)
+
+		pointers := make(map[string]string, len(violations))
+		for _, v := range violations {
+			pointers[v.Pointer] = v.Message
+		}
+
+		mediaType := "/paths/~1products~1{id}/get/responses/200/content/application~1json/examples/invalid/value"
+		assert.Equal(t, "got string, want integer", pointers[mediaType+"/id"])
+		assert.NotContains(t, pointers, "/paths/~1products~1{id}/get/responses/200/content/application~1json/examples/valid/value")
+
+		if version == "3.1.2" {
+			assert.Contains(t, pointers, "/components/schemas/Product/properties/price/examples/1")
+		}
+	})
+}
+
+func TestGenerate_TagExamplesCoercedToFieldTypes(t *testing.T) {
+	type Account struct {
+		Code    string  `json:"code" openapi:"examples=007|42"`
+		Age     int     `json:"age" validate:"min=18" openapi:"examples=25|30"`
+		Active  bool    `json:"active" openapi:"examples=true"`
+		Balance float64 `json:"balance" openapi:"examples=9.99"`
+	}
+	type InvalidAccount struct {
+		Age int `json:"age" openapi:"examples=twenty"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true, ValidateExamplesAgainstSchemas))
+		result, err := api.Generate(context.Background(), GET("/accounts/:id", WithResponse(200, Account{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		props := spec.Components.Schemas["Account"].Properties
+		if version == "3.1.2" {
+			assert.Equal(t, []any{"007", "42"}, props["code"]["examples"])
+			assert.Equal(t, []any{25.0, 30.0}, props["age"]["examples"])
+			assert.Equal(t, []any{true}, props["active"]["examples"])
+			assert.Equal(t, []any{9.99}, props["balance"]["examples"])
+		} else {
+			// OpenAPI 3.0 schemas keep the first example
+			assert.Equal(t, "007", props["code"]["example"])
+			assert.Equal(t, 25.0, props["age"]["example"])
+			assert.Equal(t, true, props["active"]["example"])
+			assert.Equal(t, 9.99, props["balance"]["example"])
+		}
+
+		_, err = api.Generate(context.Background(), GET("/accounts/:id", WithResponse(200, InvalidAccount{})))
+		require.ErrorContains(t, err, `field Age: failed to apply openapi mapping: invalid example "twenty" for type int: expected number`)
+	})
+}
+
+func TestGenerate_ExampleCheck(t *testing.T) {
+	type Product struct {
+		ID   int    `json:"id" validate:"required"`
+		Name string `json:"name" validate:"required"`
+	}
+	type CreateProduct struct {
+		Body Product `body:"structured"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithExampleCheck(true))
+
+	result, err := api.Generate(context.Background(),
+		POST("/products",
+			WithRequest(CreateProduct{}, example.New("invalid", map[string]any{"id": "one"})),
+			WithResponse(201, Product{}, example.New("valid", Product{ID: 1, Name: "Pen"})),
+		),
+	)
+	require.NoError(t, err)
+
+	var mismatches []debug.Warning
+	for _, w := range result.Warnings {
+		if w.Code() == debug.WarnExampleSchemaMismatch {
+			mismatches = append(mismatches, w)
+		}
+	}
+	require.Len(t, mismatches, 2)
+
+	pointer := "#/paths/~1products/post/requestBody/content/application~1json/examples/invalid/value"
+	assert.Equal(t, pointer+"/id", mismatches[0].Path())
+	assert.Contains(t, mismatches[0].Message(), "want integer")
+	assert.Equal(t, pointer, mismatches[1].Path())
+	assert.Contains(t, mismatches[1].Message(), "name")
+}
+
+func TestGenerate_StrictDownlevel(t *testing.T) {
+	type Product struct {
+		Name string `json:"name" openapi:"examples=Pen|Pencil"`
+	}
+	op := GET("/products", WithResponse(200, Product{}))
+
+	result, err := NewAPI(WithVersion("3.0.4")).Generate(context.Background(), op)
+	require.NoError(t, err)
+	assert.True(t, result.Warnings.Has(debug.WarnDegradationMultipleExamples))
+
+	_, err = NewAPI(WithVersion("3.0.4"), WithStrictDownlevel(true)).Generate(context.Background(), op)
+	require.ErrorContains(t, err, "strict downlevel to 3.0.4: #/components/schemas/Product/properties/name/examples: multiple examples collapsed to first example only")
+
+	_, err = NewAPI(WithVersion("3.1.2"), WithStrictDownlevel(true)).Generate(context.Background(), op)
+	require.NoError(t, err)
+}
+
+func TestGenerate_ConditionalSchema(t *testing.T) {
+	type Payment struct {
+		Method     string `json:"method" validate:"required" requires:"card=card_number|expiry"`
+		CardNumber string `json:"card_number"`
+		Expiry     string `json:"expiry"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+		result, err := api.Generate(context.Background(), GET("/payments", WithResponse(200, Payment{})))
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		payment := spec["components"].(map[string]any)["schemas"].(map[string]any)["Payment"].(map[string]any)
+
+		if version == "3.1.2" {
+			assert.Equal(t, map[string]any{"required": []any{"card_number", "expiry"}}, payment["then"])
+			assert.Empty(t, result.Warnings)
+		} else {
+			assert.NotContains(t, payment, "if")
+			assert.Len(t, payment["allOf"], 1)
+			assert.True(t, result.Warnings.Has(debug.WarnDegradationConditional))
+		}
+	})
+}
+
+func TestParsePathTemplate(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		want     string
+		patterns map[string]string
+	}{
+		{name: "gin colon", path: "/users/:id/posts/:postID", want: "/users/{id}/posts/{postID}"},
+		{name: "openapi braces", path: "/users/{id}", want: "/users/{id}"},
+		{name: "gorilla regex", path: "/articles/{category}/{id:[0-9]+}", want: "/articles/{category}/{id}",
+			patterns: map[string]string{"id": "^[0-9]+$"}},
+		{name: "chi regex with quantifier", path: "/dates/{date:\\d{4}-\\d{2}-\\d{2}}", want: "/dates/{date}",
+			patterns: map[string]string{"date": "^\\d{4}-\\d{2}-\\d{2}$"}},
+		{name: "several parameters per segment", path: "/files/{name}.{ext:[a-z]+}", want: "/files/{name}.{ext}",
+			patterns: map[string]string{"ext": "^[a-z]+$"}},
+		{name: "httprouter catch-all", path: "/static/*filepath", want: "/static/{filepath}"},
+		{name: "echo wildcard", path: "/static/*", want: "/static/{wildcard}"},
+		{name: "static", path: "/health", want: "/health"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, patterns, _ := parsePathTemplate(tt.path)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.patterns, patterns)
+		})
+	}
+}
+
+func TestGenerate_PathPatterns(t *testing.T) {
+	type GetArticle struct {
+		ID       string `schema:"id,location=path"`
+		Category string `schema:"category,location=path"`
+		Page     int    `schema:"page,location=path"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/articles/{category:[a-z-]+}/{id:[0-9]+}/{page:[0-9]+}", WithRequest(GetArticle{})),
+	)
+	require.NoError(t, err)
+
+	op := result.Model.Spec().Paths["/articles/{category}/{id}/{page}"].Get
+	require.NotNil(t, op)
+
+	patterns := make(map[string]string)
+	for _, p := range op.Parameters {
+		patterns[p.Name] = p.Schema.Pattern
+	}
+	assert.Equal(t, "^[0-9]+$", patterns["id"])
+	assert.Equal(t, "^[a-z-]+$", patterns["category"])
+	assert.Empty(t, patterns["page"], "patterns only apply to string parameters")
+}
+
+func TestGenerate_WildcardPath(t *testing.T) {
+	type GetFile struct {
+		Bucket string `schema:"bucket,location=path"`
+		Path   string `schema:"path,location=path" openapi:"description=Object key"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/buckets/:bucket/*path", WithRequest(GetFile{})),
+		GET("/proxy/*"),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	paths := spec["paths"].(map[string]any)
+
+	params := paths["/buckets/{bucket}/{path}"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)
+	require.Len(t, params, 2)
+	assert.Equal(t, map[string]any{
+		"name":        "path",
+		"in":          "path",
+		"description": "Object key",
+		"required":    true,
+		"schema":      map[string]any{"type": "string"},
+		"style":       "simple",
+		"x-wildcard":  true,
+	}, params[1])
+	assert.NotContains(t, params[0], "x-wildcard")
+
+	params = paths["/proxy/{wildcard}"].(map[string]any)["get"].(map[string]any)["parameters"].([]any)
+	assert.Equal(t, []any{map[string]any{
+		"name":        "wildcard",
+		"in":          "path",
+		"description": "Remainder of the path; may contain slashes",
+		"required":    true,
+		"schema":      map[string]any{"type": "string"},
+		"x-wildcard":  true,
+	}}, params)
+}
+
+func TestGenerate_AdditionalMethods(t *testing.T) {
+	type SearchUsers struct {
+		Body struct {
+			Query string `json:"query"`
+		} `body:"structured"`
+	}
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+
+		result, err := api.Generate(context.Background(),
+			GET("/users", WithOperationID("listUsers")),
+			QUERY("/users", WithOperationID("searchUsers"), WithRequest(SearchUsers{}), WithResponse(200, []User{})),
+			Method("propfind", "/users", WithOperationID("propfindUsers")),
+		)
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		item := spec["paths"].(map[string]any)["/users"].(map[string]any)
+
+		assert.Equal(t, "listUsers", item["get"].(map[string]any)["operationId"])
+
+		query := item["x-query"].(map[string]any)
+		assert.Equal(t, "searchUsers", query["operationId"])
+		assert.Contains(t, query, "requestBody")
+		found := query["responses"].(map[string]any)["200"].(map[string]any)["content"].(map[string]any)["application/json"].(map[string]any)
+		assert.Equal(t, "array", found["schema"].(map[string]any)["type"])
+
+		additional := item["x-additionalOperations"].(map[string]any)
+		assert.Equal(t, []string{"PROPFIND"}, keys(additional))
+		assert.Equal(t, "propfindUsers", additional["PROPFIND"].(map[string]any)["operationId"])
+
+		var methods []string
+		for _, ref := range result.Model.Operations() {
+			methods = append(methods, ref.Method)
+		}
+		assert.Equal(t, []string{"GET", "PROPFIND", "QUERY"}, methods)
+
+		assert.Equal(t, "GET /users", api.violationSource("/paths/~1users/get/responses"))
+		assert.Equal(t, "QUERY /users", api.violationSource("/paths/~1users/x-query/requestBody"))
+		assert.Equal(t, "PROPFIND /users", api.violationSource("/paths/~1users/x-additionalOperations/PROPFIND/responses"))
+		assert.Empty(t, api.violationSource("/paths/~1users/x-additionalOperations"))
+	})
+}
+
+func TestGenerate_InvalidMethod(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	_, err := api.Generate(context.Background(), Method("GET USERS", "/users"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported HTTP method: "GET USERS"`)
+}
+
+// cancelAfter is a context canceled after n checks of Err.
+type cancelAfter struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfter) Err() error {
+	if c.n == 0 {
+		return context.Canceled
+	}
+	c.n--
+
+	return nil
+}
+
+func TestGenerate_Canceled(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+	type Product struct {
+		Title string `json:"title"`
+	}
+	type Order struct {
+		Total float64 `json:"total"`
+	}
+	ops := []Operation{
+		GET("/users", WithResponse(200, User{})),
+		GET("/products", WithResponse(200, Product{})),
+		GET("/orders", WithResponse(200, Order{})),
+	}
+
+	tests := []struct {
+		name   string
+		checks int
+		errMsg string
+	}{
+		{name: "before operations", checks: 0, errMsg: "canceled after 0 of 3 operations"},
+		{name: "during operations", checks: 2, errMsg: "canceled after 2 of 3 operations"},
+		{name: "during schema checks", checks: 4, errMsg: "canceled after checking 1 of 3 component schemas"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"))
+
+			_, err := api.Generate(&cancelAfter{Context: context.Background(), n: tt.checks}, ops...)
+			require.ErrorIs(t, err, context.Canceled)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestGenerate_InvalidFieldMetadata(t *testing.T) {
+	type Login struct {
+		_    struct{} `openapi:"required=nope"`
+		Name string   `json:"name"`
+	}
+	type NotMap struct {
+		Extra int `json:"extra" openapi:"inlineMap"`
+	}
+	type TwoInlineMaps struct {
+		Extra  map[string]any    `json:"extra" openapi:"inlineMap"`
+		Labels map[string]string `json:"labels" openapi:"inlineMap"`
+	}
+	type ClosedInlineMap struct {
+		_     struct{}       `openapi:"additionalProperties=false"`
+		Extra map[string]any `json:"extra" openapi:"inlineMap"`
+	}
+	type BadKeyPattern struct {
+		Labels map[string]string `json:"labels" openapi:"keyPattern=[a-"`
+	}
+	type NotUnion struct {
+		Shape any `json:"shape" openapi:"discriminator=kind"`
+	}
+	type BadExample struct {
+		Age int `json:"age" openapi:"examples=abc"`
+	}
+	type BadExamples struct {
+		Items []BadExample `json:"items"`
+	}
+	type UnterminatedQuote struct {
+		Items []struct {
+			Greeting string `json:"greeting" openapi:"description='Hello, world"`
+		} `json:"items"`
+	}
+
+	tests := []struct {
+		name   string
+		body   any
+		errMsg string
+	}{
+		{name: "unknown required property of the _ field", body: Login{}, errMsg: "required property 'nope' of the _ field does not exist"},
+		{name: "inline map on a non-map field", body: NotMap{}, errMsg: "field 'Extra': inlineMap requires a map, int is not one"},
+		{name: "two inline maps", body: TwoInlineMaps{}, errMsg: "field 'Labels': inlineMap is already set on field Extra"},
+		{name: "inline map with additionalProperties", body: ClosedInlineMap{}, errMsg: "additionalProperties of the _ field conflicts with the inline map Extra"},
+		{name: "invalid key pattern", body: BadKeyPattern{}, errMsg: `field 'labels': invalid keyPattern "[a-"`},
+		{name: "discriminator on a field that is not a union", body: NotUnion{}, errMsg: "field 'shape': discriminator requires a registered union, interface {} is not one"},
+		{name: "example not of the field type", body: BadExamples{}, errMsg: `field Age: failed to apply openapi mapping: invalid example "abc" for type int: expected number`},
+		{name: "unterminated quote", body: UnterminatedQuote{}, errMsg: "field Greeting: failed to parse openapi tag: unterminated quote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"))
+
+			_, err := api.Generate(context.Background(), POST("/items", WithResponse(200, tt.body)))
+			require.ErrorContains(t, err, tt.errMsg)
+
+			// Nothing is cached from the failed generation
+			_, err = api.Generate(context.Background(), POST("/items", WithResponse(200, tt.body)))
+			require.ErrorContains(t, err, tt.errMsg)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/talav/openapi/metadata"
+)
+
+// FieldOverride is metadata attached to a struct field with AugmentType, as the
+// openapi tag of the field would.
+type FieldOverride struct {
+	// Name is the Go name of the field, e.g. "CreatedAt".
+	Name string
+
+	// ReadOnly, WriteOnly, Deprecated, Hidden and Required set the flags of the
+	// same name. False flags keep those of the tag.
+	ReadOnly   bool
+	WriteOnly  bool
+	Deprecated bool
+	Hidden     bool
+	Required   bool
+
+	// Title, Description and Format replace those of the tag, if not empty.
+	Title       string
+	Description string
+	Format      string
+
+	// DescriptionKey takes the description from the description catalog (see
+	// WithDescriptions), if not empty. It excludes Description.
+	DescriptionKey string
+
+	// Examples replace the examples of the tag, if not nil.
+	Examples []any
+
+	// Extensions are added to those of the tag. Keys must start with "x-".
+	Extensions map[string]any
+}
+
+// AugmentType attaches metadata to the fields of the struct type T, for types from
+// external modules whose struct tags cannot be edited. Overrides are merged over
+// the openapi tags of the fields, and apply to the properties of the schema of T.
+// Generate fails for fields T does not have.
+//
+// Call it before the first Generate using T, as generated schemas are kept
+// across calls.
+//
+// Example:
+//
+//	openapi.AugmentType[vendor.Invoice](api,
+//	    openapi.FieldOverride{Name: "CreatedAt", ReadOnly: true, Description: "Creation time"},
+//	    openapi.FieldOverride{Name: "Secret", Hidden: true},
+//	)
+func AugmentType[T any](a *API, overrides ...FieldOverride) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	t := reflect.TypeFor[T]()
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		a.augmentErrs = append(a.augmentErrs, fmt.Errorf("type %s: want a struct type", t))
+
+		return
+	}
+
+	for _, o := range overrides {
+		if err := checkFieldOverride(t, o); err != nil {
+			a.augmentErrs = append(a.augmentErrs, fmt.Errorf("type %s: %w", t, err))
+
+			continue
+		}
+		a.generator.AugmentField(t, o.Name, o.metadata())
+	}
+}
+
+// checkFieldOverride checks that a field override names an exported field of the
+// struct type t and has valid metadata.
+func checkFieldOverride(t reflect.Type, o FieldOverride) error {
+	f, ok := t.FieldByName(o.Name)
+	if !ok || len(f.Index) != 1 || !f.IsExported() {
+		return fmt.Errorf("no exported field %q", o.Name)
+	}
+	if o.Description != "" && o.DescriptionKey != "" {
+		return fmt.Errorf("field %s: Description and DescriptionKey are mutually exclusive", o.Name)
+	}
+	for key := range o.Extensions {
+		if !strings.HasPrefix(key, "x-") || len(key) < 4 {
+			return fmt.Errorf("field %s: extension %q must start with x-", o.Name, key)
+		}
+	}
+
+	return nil
+}
+
+// metadata returns the openapi tag metadata of a field override.
+func (o FieldOverride) metadata() *metadata.OpenAPIMetadata {
+	flag := func(set bool) *bool {
+		if !set {
+			return nil
+		}
+
+		return &set
+	}
+
+	return &metadata.OpenAPIMetadata{
+		ReadOnly:       flag(o.ReadOnly),
+		WriteOnly:      flag(o.WriteOnly),
+		Deprecated:     flag(o.Deprecated),
+		Hidden:         flag(o.Hidden),
+		Required:       flag(o.Required),
+		Title:          o.Title,
+		Description:    o.Description,
+		DescriptionKey: o.DescriptionKey,
+		Format:         o.Format,
+		Examples:       o.Examples,
+		Extensions:     o.Extensions,
+	}
+}
@@ -0,0 +1,100 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// vendorInvoice stands for a type of an external module, whose tags cannot be edited.
+type vendorInvoice struct {
+	ID        string    `json:"id" openapi:"description=Invoice identifier,x-vendor=acme"`
+	CreatedAt time.Time `json:"created_at"`
+	Total     float64   `json:"total"`
+	Secret    string    `json:"secret"`
+}
+
+func TestAugmentType(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithDescriptions(map[string]string{"invoice.total": "Total amount, taxes included"}))
+		AugmentType[vendorInvoice](api,
+			FieldOverride{Name: "ID", ReadOnly: true, Description: "Invoice number", Extensions: map[string]any{"x-owner": "billing"}},
+			FieldOverride{Name: "CreatedAt", ReadOnly: true, Required: true, Description: "Creation time"},
+			FieldOverride{Name: "Total", DescriptionKey: "invoice.total", Examples: []any{99.5}},
+			FieldOverride{Name: "Secret", Hidden: true},
+		)
+
+		result, err := api.Generate(context.Background(), GET("/invoices/:id", WithResponse(200, vendorInvoice{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Components struct {
+				Schemas map[string]struct {
+					Required   []string                  `json:"required"`
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		invoice := spec.Components.Schemas["VendorInvoice"]
+		assert.Equal(t, []string{"created_at"}, invoice.Required)
+		assert.NotContains(t, invoice.Properties, "secret")
+
+		id := invoice.Properties["id"]
+		assert.Equal(t, "Invoice number", id["description"])
+		assert.Equal(t, true, id["readOnly"])
+		// Extensions of the tag are kept
+		assert.Equal(t, "acme", id["x-vendor"])
+		assert.Equal(t, "billing", id["x-owner"])
+
+		assert.Equal(t, "Creation time", invoice.Properties["created_at"]["description"])
+		assert.Equal(t, "Total amount, taxes included", invoice.Properties["total"]["description"])
+	})
+}
+
+func TestAugmentType_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		augment func(*API)
+		want    string
+	}{
+		{
+			name:    "unknown field",
+			augment: func(a *API) { AugmentType[vendorInvoice](a, FieldOverride{Name: "Amount"}) },
+			want:    `type openapi.vendorInvoice: no exported field "Amount"`,
+		},
+		{
+			name:    "not a struct",
+			augment: func(a *API) { AugmentType[[]vendorInvoice](a, FieldOverride{Name: "ID"}) },
+			want:    "type []openapi.vendorInvoice: want a struct type",
+		},
+		{
+			name: "description and key",
+			augment: func(a *API) {
+				AugmentType[*vendorInvoice](a, FieldOverride{Name: "ID", Description: "ID", DescriptionKey: "invoice.id"})
+			},
+			want: "type openapi.vendorInvoice: field ID: Description and DescriptionKey are mutually exclusive",
+		},
+		{
+			name: "invalid extension",
+			augment: func(a *API) {
+				AugmentType[vendorInvoice](a, FieldOverride{Name: "ID", Extensions: map[string]any{"owner": "billing"}})
+			},
+			want: `type openapi.vendorInvoice: field ID: extension "owner" must start with x-`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI()
+			tt.augment(api)
+			_, err := api.Generate(context.Background(), GET("/invoices/:id", WithResponse(200, vendorInvoice{})))
+			require.ErrorContains(t, err, "invalid type augmentations: "+tt.want)
+		})
+	}
+}
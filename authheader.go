@@ -0,0 +1,94 @@
+package openapi
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+const headerAuthorization = "Authorization"
+
+// WithAuthHeaderAsSecurity converts the Authorization header parameters of the
+// operations to security requirements. OpenAPI tools ignore Authorization header
+// parameters: credentials are documented with security schemes.
+//
+// Operations with security requirements, or covered by WithDefaultSecurity, only
+// lose the parameter. The others get a requirement for each security scheme
+// carried in the Authorization header (http schemes, and apiKey schemes of that
+// header), any of which is accepted. Parameters of operations without such a
+// scheme are kept and reported with an AUTHORIZATION_HEADER warning.
+//
+// Default: false (Authorization header parameters are kept and reported)
+//
+// Example:
+//
+//	openapi.WithBearerAuth("bearerAuth", "JWT"),
+//	openapi.WithAuthHeaderAsSecurity(true)
+func WithAuthHeaderAsSecurity(enabled bool) Option {
+	return func(a *API) {
+		a.AuthHeaderAsSecurity = enabled
+	}
+}
+
+// applyAuthHeaders reports the Authorization header parameters of the operations,
+// or converts them to security requirements when enabled.
+func (a *API) applyAuthHeaders(spec *model.Spec) debug.Warnings {
+	schemes := a.authHeaderSchemes()
+
+	var warns debug.Warnings
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		op := ref.Operation
+		i := slices.IndexFunc(op.Parameters, isAuthHeader)
+		if i < 0 {
+			continue
+		}
+		if a.AuthHeaderAsSecurity {
+			converted := true
+			switch {
+			case len(op.Security) > 0 || len(a.DefaultSecurity) > 0:
+			case len(schemes) > 0:
+				for _, name := range schemes {
+					op.Security = append(op.Security, model.SecurityRequirement{name: []string{}})
+				}
+			default:
+				converted = false
+			}
+			if converted {
+				op.Parameters = slices.DeleteFunc(op.Parameters, isAuthHeader)
+
+				continue
+			}
+		}
+		warns.Append(debug.NewWarning(
+			debug.WarnAuthorizationHeader,
+			util.Pointer("#/paths", ref.Path, strings.ToLower(ref.Method), "parameters", strconv.Itoa(i)),
+			"Authorization header parameters are ignored by OpenAPI tools; document credentials with a security scheme",
+		))
+	}
+
+	return warns
+}
+
+// authHeaderSchemes returns the sorted names of the security schemes carried in
+// the Authorization header.
+func (a *API) authHeaderSchemes() []string {
+	var names []string
+	for _, name := range slices.Sorted(maps.Keys(a.SecuritySchemes)) {
+		s := a.SecuritySchemes[name]
+		if s.Type == "http" || s.Type == "apiKey" && s.In == string(InHeader) && strings.EqualFold(s.Name, headerAuthorization) {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// isAuthHeader reports whether p is an Authorization header parameter.
+func isAuthHeader(p model.Parameter) bool {
+	return p.In == string(InHeader) && strings.EqualFold(p.Name, headerAuthorization)
+}
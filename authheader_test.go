@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+type authHeaderRequest struct {
+	Authorization string `schema:"Authorization,location=header"`
+	Tenant        string `schema:"X-Tenant,location=header"`
+}
+
+type authHeaderResponse struct {
+	Body struct {
+		OK bool `json:"ok"`
+	} `body:"structured"`
+}
+
+type authHeaderSpec struct {
+	Paths map[string]map[string]struct {
+		Parameters []struct {
+			Name string `json:"name"`
+		} `json:"parameters"`
+		Security []map[string][]string `json:"security"`
+	} `json:"paths"`
+}
+
+func generateAuthHeaderSpec(t *testing.T, api *API, ops ...Operation) (authHeaderSpec, debug.Warnings) {
+	t.Helper()
+	result, err := api.Generate(context.Background(), ops...)
+	require.NoError(t, err)
+	var spec authHeaderSpec
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	return spec, result.Warnings
+}
+
+func TestAuthHeader_Warning(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithBearerAuth("bearerAuth", "JWT"))
+
+	spec, warnings := generateAuthHeaderSpec(t, api,
+		GET("/items", WithRequest(authHeaderRequest{}), WithResponse(200, authHeaderResponse{})),
+	)
+
+	require.Len(t, spec.Paths["/items"]["get"].Parameters, 2)
+	require.True(t, warnings.Has(debug.WarnAuthorizationHeader))
+	for _, w := range warnings {
+		if w.Code() == debug.WarnAuthorizationHeader {
+			assert.Equal(t, "#/paths/~1items/get/parameters/0", w.Path())
+		}
+	}
+}
+
+func TestWithAuthHeaderAsSecurity(t *testing.T) {
+	api := NewAPI(
+		WithVersion("3.0.4"),
+		WithBearerAuth("bearerAuth", "JWT"),
+		WithAPIKey("apiKey", "X-API-Key", InHeader, "API key"),
+		WithAuthHeaderAsSecurity(true),
+	)
+
+	spec, warnings := generateAuthHeaderSpec(t, api,
+		GET("/items", WithRequest(authHeaderRequest{}), WithResponse(200, authHeaderResponse{})),
+		POST("/items", WithRequest(authHeaderRequest{}), WithSecurity("apiKey"), WithResponse(200, authHeaderResponse{})),
+	)
+
+	assert.False(t, warnings.Has(debug.WarnAuthorizationHeader))
+
+	get := spec.Paths["/items"]["get"]
+	require.Len(t, get.Parameters, 1)
+	assert.Equal(t, "X-Tenant", get.Parameters[0].Name)
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, get.Security)
+
+	post := spec.Paths["/items"]["post"]
+	require.Len(t, post.Parameters, 1)
+	assert.Equal(t, []map[string][]string{{"apiKey": {}}}, post.Security)
+}
+
+func TestWithAuthHeaderAsSecurity_NoScheme(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithAuthHeaderAsSecurity(true))
+
+	spec, warnings := generateAuthHeaderSpec(t, api,
+		GET("/items", WithRequest(authHeaderRequest{}), WithResponse(200, authHeaderResponse{})),
+	)
+
+	assert.Len(t, spec.Paths["/items"]["get"].Parameters, 2)
+	assert.Empty(t, spec.Paths["/items"]["get"].Security)
+	assert.True(t, warnings.Has(debug.WarnAuthorizationHeader))
+}
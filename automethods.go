@@ -0,0 +1,189 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// CORS headers documented on generated OPTIONS operations.
+const (
+	headerOrigin                     = "Origin"
+	headerAccessControlRequestMethod = "Access-Control-Request-Method"
+	headerAccessControlRequestHeader = "Access-Control-Request-Headers"
+	headerAccessControlAllowOrigin   = "Access-Control-Allow-Origin"
+	headerAccessControlAllowMethods  = "Access-Control-Allow-Methods"
+	headerAccessControlAllowHeaders  = "Access-Control-Allow-Headers"
+	headerAccessControlMaxAge        = "Access-Control-Max-Age"
+	headerAllow                      = "Allow"
+)
+
+// WithAutoHead documents a HEAD operation for every GET operation without one,
+// as most Go routers serve HEAD for GET routes. The HEAD operation mirrors the GET
+// operation, without response bodies. Its operation ID is the GET operation ID
+// suffixed with "Head": Generate fails if another operation already uses it.
+//
+// Default: false
+//
+// Example:
+//
+//	openapi.WithAutoHead(true)
+func WithAutoHead(enabled bool) Option {
+	return func(a *API) {
+		a.AutoHead = enabled
+	}
+}
+
+// WithAutoOptions documents a CORS preflight OPTIONS operation for every path
+// without one, with the standard Access-Control-* request and response headers
+// and the path parameters of the other operations of the path.
+//
+// Default: false
+//
+// Example:
+//
+//	openapi.WithAutoOptions(true)
+func WithAutoOptions(enabled bool) Option {
+	return func(a *API) {
+		a.AutoOptions = enabled
+	}
+}
+
+// addAutoMethods adds the HEAD and OPTIONS operations enabled by WithAutoHead
+// and WithAutoOptions to the paths of the spec. Generated HEAD operations must not
+// take the operation ID of another operation.
+func (a *API) addAutoMethods(spec *model.Spec) error {
+	if !a.AutoHead && !a.AutoOptions {
+		return nil
+	}
+
+	operationIDs := make(map[string]OperationRef)
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		if id := ref.Operation.OperationID; id != "" {
+			operationIDs[id] = ref
+		}
+	}
+
+	var errs []error
+	for _, path := range slices.Sorted(maps.Keys(spec.Paths)) {
+		item := spec.Paths[path]
+		if a.AutoHead && item.Get != nil && item.Head == nil {
+			head := headOperation(item.Get)
+			if other, ok := operationIDs[head.OperationID]; ok && head.OperationID != "" {
+				errs = append(errs, fmt.Errorf("operation %s %s: generated operation ID %q is already used by %s %s",
+					http.MethodHead, path, head.OperationID, other.Method, other.Path))
+			}
+			item.Head = head
+		}
+		if a.AutoOptions && item.Options == nil {
+			item.Options = preflightOperation(spec, item)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// headOperation derives a HEAD operation from a GET operation: same parameters
+// and response headers, without response bodies.
+func headOperation(get *model.Operation) *model.Operation {
+	head := *get
+	if get.OperationID != "" {
+		head.OperationID = get.OperationID + "Head"
+	}
+	head.Parameters = slices.Clone(get.Parameters)
+	head.Extensions = copyExtensions(get.Extensions)
+	head.RequestBody = nil
+	head.Responses = make(map[string]*model.Response, len(get.Responses))
+	for status, resp := range get.Responses {
+		if resp == nil {
+			continue
+		}
+		bodiless := *resp
+		bodiless.Content = nil
+		head.Responses[status] = &bodiless
+	}
+
+	return &head
+}
+
+// preflightOperation builds the CORS preflight OPTIONS operation of a path item.
+// It declares the path parameters of the other operations of the path, which
+// are not declared on the path item itself.
+func preflightOperation(spec *model.Spec, item *model.PathItem) *model.Operation {
+	var methods, tags []string
+	var pathParams []model.Parameter
+	declared := make(map[string]bool)
+	for _, p := range item.Parameters {
+		if p := resolveParameter(spec, p); p.In == "path" {
+			declared[p.Name] = true
+		}
+	}
+
+	type entry struct {
+		method string
+		op     *model.Operation
+	}
+	entries := []entry{
+		{http.MethodGet, item.Get},
+		{http.MethodHead, item.Head},
+		{http.MethodPost, item.Post},
+		{http.MethodPut, item.Put},
+		{http.MethodPatch, item.Patch},
+		{http.MethodDelete, item.Delete},
+		{http.MethodTrace, item.Trace},
+	}
+	for _, method := range slices.Sorted(maps.Keys(item.AdditionalOperations)) {
+		entries = append(entries, entry{method, item.AdditionalOperations[method]})
+	}
+	for _, entry := range entries {
+		if entry.op == nil {
+			continue
+		}
+		methods = append(methods, entry.method)
+		for _, tag := range entry.op.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+		for _, p := range entry.op.Parameters {
+			if resolved := resolveParameter(spec, p); resolved.In == "path" && !declared[resolved.Name] {
+				declared[resolved.Name] = true
+				pathParams = append(pathParams, p)
+			}
+		}
+	}
+	methods = append(methods, http.MethodOptions)
+	allowed := strings.Join(methods, ", ")
+
+	stringSchema := func() *model.Schema { return &model.Schema{Type: "string"} }
+
+	return &model.Operation{
+		Summary: "CORS preflight",
+		Tags:    tags,
+		Parameters: append(pathParams,
+			model.Parameter{Name: headerOrigin, In: string(InHeader), Required: true, Description: "Origin of the cross-origin request", Schema: stringSchema()},
+			model.Parameter{Name: headerAccessControlRequestMethod, In: string(InHeader), Required: true, Description: "Method of the actual request", Schema: stringSchema()},
+			model.Parameter{Name: headerAccessControlRequestHeader, In: string(InHeader), Description: "Headers of the actual request", Schema: stringSchema()},
+		),
+		Responses: map[string]*model.Response{
+			"204": {
+				Description: "Preflight request accepted",
+				Headers: map[string]*model.Header{
+					headerAccessControlAllowOrigin:  {Description: "Origin allowed to make the request", Schema: stringSchema()},
+					headerAccessControlAllowMethods: {Description: "Methods allowed: " + allowed, Schema: stringSchema()},
+					headerAccessControlAllowHeaders: {Description: "Request headers allowed", Schema: stringSchema()},
+					headerAccessControlMaxAge: {
+						Description: "Number of seconds the preflight response can be cached",
+						Schema:      &model.Schema{Type: "integer", Minimum: &model.Bound{Value: 0}},
+					},
+					headerAllow: {Description: "Methods supported by the resource: " + allowed, Schema: stringSchema()},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,122 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_AutoHead(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+	type UserResponse struct {
+		ETag string `schema:"ETag,location=header"`
+		Body User   `body:"structured"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithAutoHead(true), WithValidation(true))
+
+	result, err := api.Generate(context.Background(),
+		GET("/users", WithOperationID("getUser"), WithTags("users"), WithResponse(200, UserResponse{})),
+		GET("/health", WithResponse(200, User{})),
+		HEAD("/health", WithSummary("Explicit HEAD")),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	paths := spec["paths"].(map[string]any)
+
+	head := paths["/users"].(map[string]any)["head"].(map[string]any)
+	assert.Equal(t, "getUserHead", head["operationId"])
+	assert.Equal(t, []any{"users"}, head["tags"])
+	resp := head["responses"].(map[string]any)["200"].(map[string]any)
+	assert.NotContains(t, resp, "content")
+	assert.Contains(t, resp["headers"], "ETag")
+
+	get := paths["/users"].(map[string]any)["get"].(map[string]any)
+	assert.Contains(t, get["responses"].(map[string]any)["200"], "content", "GET keeps its body")
+
+	assert.Equal(t, "Explicit HEAD", paths["/health"].(map[string]any)["head"].(map[string]any)["summary"])
+}
+
+func TestGenerate_AutoOptions(t *testing.T) {
+	api := NewAPI(WithVersion("3.0.4"), WithAutoOptions(true), WithAutoHead(true), WithValidation(true))
+
+	result, err := api.Generate(context.Background(),
+		GET("/users", WithTags("users")),
+		POST("/users", WithTags("users", "admin")),
+		OPTIONS("/health", WithSummary("Explicit OPTIONS")),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	paths := spec["paths"].(map[string]any)
+
+	options := paths["/users"].(map[string]any)["options"].(map[string]any)
+	assert.Equal(t, "CORS preflight", options["summary"])
+	assert.Equal(t, []any{"users", "admin"}, options["tags"])
+
+	var params []string
+	for _, p := range options["parameters"].([]any) {
+		params = append(params, p.(map[string]any)["name"].(string))
+	}
+	assert.Equal(t, []string{"Origin", "Access-Control-Request-Method", "Access-Control-Request-Headers"}, params)
+
+	headers := options["responses"].(map[string]any)["204"].(map[string]any)["headers"].(map[string]any)
+	assert.ElementsMatch(t, []string{
+		"Access-Control-Allow-Origin",
+		"Access-Control-Allow-Methods",
+		"Access-Control-Allow-Headers",
+		"Access-Control-Max-Age",
+		"Allow",
+	}, keys(headers))
+	assert.Equal(t, "Methods supported by the resource: GET, HEAD, POST, OPTIONS",
+		headers["Allow"].(map[string]any)["description"])
+
+	assert.Equal(t, "Explicit OPTIONS", paths["/health"].(map[string]any)["options"].(map[string]any)["summary"])
+}
+
+func TestGenerate_AutoOptions_TemplatedPath(t *testing.T) {
+	type GetUser struct {
+		ID string `schema:"id,location=path"`
+	}
+	type SearchUsers struct {
+		ID string `schema:"id,location=path"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithAutoOptions(true), WithValidation(true))
+
+	result, err := api.Generate(context.Background(),
+		GET("/users/:id", WithRequest(GetUser{})),
+		QUERY("/users/:id", WithRequest(SearchUsers{})),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	options := spec["paths"].(map[string]any)["/users/{id}"].(map[string]any)["options"].(map[string]any)
+
+	params := options["parameters"].([]any)
+	require.Len(t, params, 4)
+	assert.Equal(t, map[string]any{"name": "id", "in": "path", "required": true, "style": "simple", "schema": map[string]any{"type": "string"}}, params[0])
+
+	headers := options["responses"].(map[string]any)["204"].(map[string]any)["headers"].(map[string]any)
+	assert.Equal(t, "Methods supported by the resource: GET, QUERY, OPTIONS",
+		headers["Allow"].(map[string]any)["description"])
+}
+
+func TestGenerate_AutoHead_OperationIDCollision(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithAutoHead(true))
+
+	_, err := api.Generate(context.Background(),
+		GET("/users", WithOperationID("listUsers")),
+		GET("/accounts", WithOperationID("listUsersHead")),
+	)
+	require.ErrorContains(t, err, `operation HEAD /users: generated operation ID "listUsersHead" is already used by GET /accounts`)
+}
@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+)
+
+type benchAddress struct {
+	Street  string `json:"street" validate:"required"`
+	City    string `json:"city" validate:"required"`
+	Country string `json:"country" validate:"len=2"`
+}
+
+type benchUser struct {
+	ID        int            `json:"id" openapi:"readOnly"`
+	Name      string         `json:"name" validate:"required,min=1,max=100"`
+	Email     string         `json:"email" validate:"required,email"`
+	Addresses []benchAddress `json:"addresses"`
+	Tags      []string       `json:"tags" validate:"max=10"`
+	CreatedAt time.Time      `json:"createdAt"`
+}
+
+type benchGetUser struct {
+	ID     int    `schema:"id,location=path"`
+	Fields string `schema:"fields,location=query"`
+}
+
+type benchCreateUser struct {
+	Body benchUser `body:"structured"`
+}
+
+// benchOperations returns n CRUD resources, i.e. 4*n operations.
+func benchOperations(n int) []Operation {
+	ops := make([]Operation, 0, 4*n)
+	for i := range n {
+		path := "/resources" + strconv.Itoa(i)
+		ops = append(ops,
+			GET(path+"/:id", WithOperationExtension("x-owner", "team"),
+				WithRequest(benchGetUser{}), WithResponse(200, benchUser{}), WithProblemResponses(404)),
+			POST(path, WithRequest(benchCreateUser{}), WithResponse(201, benchUser{})),
+			PUT(path+"/:id", WithRequest(benchCreateUser{}), WithResponse(200, benchUser{})),
+			GET(path, WithResponse(200, Paginated[benchUser]{}), WithPagination(PaginationOffset)),
+		)
+	}
+
+	return ops
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	for _, n := range []int{10, 100} {
+		ops := benchOperations(n)
+
+		b.Run("resources="+strconv.Itoa(n), func(b *testing.B) {
+			api := NewAPI(WithVersion("3.1.2"))
+			b.ReportAllocs()
+			for b.Loop() {
+				if _, err := api.Generate(context.Background(), ops...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+
+		b.Run("resources="+strconv.Itoa(n)+"/new-api", func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				api := NewAPI(WithVersion("3.1.2"))
+				if _, err := api.Generate(context.Background(), ops...); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkGenerate_Validated(b *testing.B) {
+	ops := benchOperations(10)
+	api := NewAPI(WithVersion("3.1.2"), WithValidation(true))
+
+	b.ReportAllocs()
+	for b.Loop() {
+		if _, err := api.Generate(context.Background(), ops...); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
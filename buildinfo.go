@@ -0,0 +1,76 @@
+package openapi
+
+import (
+	"fmt"
+	rtdebug "runtime/debug"
+	"strings"
+)
+
+// extensionBuildInfo is the Info extension describing the build of the API.
+const extensionBuildInfo = "x-build-info"
+
+// readBuildInfo reads the build information of the binary; a variable for tests.
+var readBuildInfo = rtdebug.ReadBuildInfo
+
+// WithInfoFromBuildInfo fills the Info object from the build information of the
+// binary (see runtime/debug.ReadBuildInfo), so that published specs are traceable
+// to the build that produced them:
+//   - the version is the version of the main module without its "v" prefix,
+//     unless it is unknown (a "(devel)" build): the configured version is kept;
+//   - the description ends with the VCS revision and commit time;
+//   - the x-build-info extension holds the module path, version, revision,
+//     commit time, whether the working tree was modified, and the Go version.
+//
+// Give it after WithInfoVersion and WithInfoDescription, which it builds upon.
+// It does nothing when the binary has no build information.
+//
+// Example:
+//
+//	openapi.NewAPI(
+//	    openapi.WithInfoDescription("User management."),
+//	    openapi.WithInfoFromBuildInfo(),
+//	)
+func WithInfoFromBuildInfo() Option {
+	return func(a *API) {
+		info, ok := readBuildInfo()
+		if !ok {
+			return
+		}
+
+		build := map[string]any{
+			"module":    info.Main.Path,
+			"goVersion": info.GoVersion,
+		}
+		if v := info.Main.Version; v != "" && v != "(devel)" {
+			a.Info.Version = strings.TrimPrefix(v, "v")
+			build["version"] = v
+		}
+
+		var revision, commitTime string
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+				build["revision"] = s.Value
+			case "vcs.time":
+				commitTime = s.Value
+				build["time"] = s.Value
+			case "vcs.modified":
+				build["modified"] = s.Value == "true"
+			}
+		}
+
+		if revision != "" {
+			line := "Build: revision " + revision
+			if commitTime != "" {
+				line += fmt.Sprintf(" (%s)", commitTime)
+			}
+			if a.Info.Description != "" {
+				line = a.Info.Description + "\n\n" + line
+			}
+			a.Info.Description = line
+		}
+
+		WithInfoExtension(extensionBuildInfo, build)(a)
+	}
+}
@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	rtdebug "runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithInfoFromBuildInfo(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	buildInfo := func(version string, settings ...rtdebug.BuildSetting) func() (*rtdebug.BuildInfo, bool) {
+		return func() (*rtdebug.BuildInfo, bool) {
+			return &rtdebug.BuildInfo{
+				GoVersion: "go1.25.0",
+				Main:      rtdebug.Module{Path: "example.com/users", Version: version},
+				Settings:  settings,
+			}, true
+		}
+	}
+
+	type info struct {
+		Version     string         `json:"version"`
+		Description string         `json:"description"`
+		BuildInfo   map[string]any `json:"x-build-info"`
+	}
+	generate := func(t *testing.T) info {
+		t.Helper()
+		api := NewAPI(WithVersion("3.1.2"), WithInfoVersion("0.9.0"), WithInfoDescription("User management."), WithInfoFromBuildInfo())
+		result, err := api.Generate(context.Background(), GET("/users", WithResponse(200, user{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Info info `json:"info"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		return spec.Info
+	}
+
+	t.Run("release", func(t *testing.T) {
+		t.Cleanup(func() { readBuildInfo = rtdebug.ReadBuildInfo })
+		readBuildInfo = buildInfo("v1.4.2",
+			rtdebug.BuildSetting{Key: "vcs.revision", Value: "3f2a9c1e"},
+			rtdebug.BuildSetting{Key: "vcs.time", Value: "2026-10-01T12:00:00Z"},
+			rtdebug.BuildSetting{Key: "vcs.modified", Value: "false"},
+		)
+
+		got := generate(t)
+		assert.Equal(t, "1.4.2", got.Version)
+		assert.Equal(t, "User management.\n\nBuild: revision 3f2a9c1e (2026-10-01T12:00:00Z)", got.Description)
+		assert.Equal(t, map[string]any{
+			"module":    "example.com/users",
+			"version":   "v1.4.2",
+			"goVersion": "go1.25.0",
+			"revision":  "3f2a9c1e",
+			"time":      "2026-10-01T12:00:00Z",
+			"modified":  false,
+		}, got.BuildInfo)
+	})
+
+	t.Run("devel", func(t *testing.T) {
+		t.Cleanup(func() { readBuildInfo = rtdebug.ReadBuildInfo })
+		readBuildInfo = buildInfo("(devel)")
+
+		got := generate(t)
+		assert.Equal(t, "0.9.0", got.Version)
+		assert.Equal(t, "User management.", got.Description)
+		assert.Equal(t, map[string]any{"module": "example.com/users", "goVersion": "go1.25.0"}, got.BuildInfo)
+	})
+
+	t.Run("no build info", func(t *testing.T) {
+		t.Cleanup(func() { readBuildInfo = rtdebug.ReadBuildInfo })
+		readBuildInfo = func() (*rtdebug.BuildInfo, bool) { return nil, false }
+
+		got := generate(t)
+		assert.Equal(t, "0.9.0", got.Version)
+		assert.Nil(t, got.BuildInfo)
+	})
+}
@@ -0,0 +1,145 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strconv"
+	"unicode/utf16"
+)
+
+// WithCanonicalJSON emits the specification in the JSON Canonicalization Scheme
+// (JCS, RFC 8785): object members sorted, no insignificant whitespace, minimal
+// string escaping and ECMAScript number formatting. Canonical output has stable
+// bytes for the same content, for workflows hashing or signing the specification
+// as a supply-chain artifact. Result.Fingerprint is then the SHA-256 of Result.JSON.
+//
+// Default: false (indented JSON)
+//
+// Example:
+//
+//	openapi.WithCanonicalJSON(true)
+func WithCanonicalJSON(enabled bool) Option {
+	return func(a *API) {
+		a.CanonicalJSON = enabled
+	}
+}
+
+// canonicalJSON returns the JCS (RFC 8785) encoding of a JSON document.
+func canonicalJSON(data []byte) ([]byte, error) {
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec for canonicalization: %w", err)
+	}
+
+	return appendCanonical(nil, doc)
+}
+
+// appendCanonical appends the JCS encoding of a decoded JSON value to b.
+func appendCanonical(b []byte, v any) ([]byte, error) {
+	var err error
+	switch v := v.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case bool:
+		return strconv.AppendBool(b, v), nil
+	case float64:
+		return appendCanonicalNumber(b, v), nil
+	case string:
+		return appendCanonicalString(b, v), nil
+	case []any:
+		b = append(b, '[')
+		for i, elem := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			if b, err = appendCanonical(b, elem); err != nil {
+				return nil, err
+			}
+		}
+
+		return append(b, ']'), nil
+	case map[string]any:
+		// Members are sorted by the UTF-16 code units of their names.
+		keys := slices.SortedFunc(maps.Keys(v), func(x, y string) int {
+			return slices.Compare(utf16.Encode([]rune(x)), utf16.Encode([]rune(y)))
+		})
+		b = append(b, '{')
+		for i, key := range keys {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = append(appendCanonicalString(b, key), ':')
+			if b, err = appendCanonical(b, v[key]); err != nil {
+				return nil, err
+			}
+		}
+
+		return append(b, '}'), nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value of type %T", v)
+	}
+}
+
+// appendCanonicalNumber appends a number formatted like ECMAScript Number.prototype.toString:
+// the shortest representation, in exponent notation below 1e-6 and from 1e21.
+func appendCanonicalNumber(b []byte, f float64) []byte {
+	if f == 0 {
+		// Negative zero is serialized as 0.
+		return append(b, '0')
+	}
+
+	abs := math.Abs(f)
+	format := byte('f')
+	if abs < 1e-6 || abs >= 1e21 {
+		format = 'e'
+	}
+	start := len(b)
+	b = strconv.AppendFloat(b, f, format, -1, 64)
+	if format == 'e' {
+		// Trim the leading zero of one-digit exponents: 1e-07 becomes 1e-7.
+		n := len(b)
+		if n-start >= 4 && b[n-4] == 'e' && b[n-3] == '-' && b[n-2] == '0' {
+			b[n-2] = b[n-1]
+			b = b[:n-1]
+		}
+	}
+
+	return b
+}
+
+// appendCanonicalString appends a string escaping only quotation marks, reverse
+// solidi and control characters, with the short escapes where they exist.
+func appendCanonicalString(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+
+	b = append(b, '"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			b = append(b, '\\', '"')
+		case '\\':
+			b = append(b, '\\', '\\')
+		case '\b':
+			b = append(b, '\\', 'b')
+		case '\f':
+			b = append(b, '\\', 'f')
+		case '\n':
+			b = append(b, '\\', 'n')
+		case '\r':
+			b = append(b, '\\', 'r')
+		case '\t':
+			b = append(b, '\\', 't')
+		default:
+			if r < 0x20 {
+				b = append(b, '\\', 'u', '0', '0', hex[r>>4], hex[r&0xf])
+			} else {
+				b = append(b, string(r)...)
+			}
+		}
+	}
+
+	return append(b, '"')
+}
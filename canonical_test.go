@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "whitespace", input: "{ \"a\" : [ 1 , true , null ] }", want: `{"a":[1,true,null]}`},
+		{name: "zero", input: `[0, -0, 0.0]`, want: `[0,0,0]`},
+		{name: "min value", input: `5e-324`, want: `5e-324`},
+		{name: "max value", input: `1.7976931348623157e308`, want: `1.7976931348623157e+308`},
+		{name: "max safe integer", input: `9007199254740992`, want: `9007199254740992`},
+		{name: "large integer", input: `295147905179352830000`, want: `295147905179352830000`},
+		{name: "exponent from 1e21", input: `1e21`, want: `1e+21`},
+		{name: "exponent below 1e-6", input: `[0.000001, 0.0000001]`, want: `[0.000001,1e-7]`},
+		{name: "fraction", input: `333333333.33333329`, want: `333333333.3333333`},
+		{name: "escapes", input: `"\u20ac$\u000F\u000aA'\u0042\u0022\u005c\\\"\/<>&"`, want: "\"€$\\u000f\\nA'B\\\"\\\\\\\\\\\"/<>&\""},
+		{
+			name:  "member order by UTF-16 code units",
+			input: `{"\u20ac":"Euro","\r":"CR","\ufb33":"Hebrew","1":"One","\ud83d\ude00":"Emoji","\u0080":"Control","\u00f6":"Umlaut"}`,
+			want:  "{\"\\r\":\"CR\",\"1\":\"One\",\"\u0080\":\"Control\",\"ö\":\"Umlaut\",\"€\":\"Euro\",\"😀\":\"Emoji\",\"\ufb33\":\"Hebrew\"}",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := canonicalJSON([]byte(tt.input))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestCanonicalJSON_Invalid(t *testing.T) {
+	_, err := canonicalJSON([]byte(`{`))
+	require.Error(t, err)
+}
+
+func TestWithCanonicalJSON(t *testing.T) {
+	type Item struct {
+		Name string `json:"name" openapi:"description=Name <short> & unique"`
+	}
+	ops := []Operation{GET("/items", WithSummary("List items"), WithResponse(200, Item{}))}
+
+	api := NewAPI(WithVersion("3.1.2"), WithCanonicalJSON(true))
+	result, err := api.Generate(context.Background(), ops...)
+	require.NoError(t, err)
+
+	assert.NotContains(t, string(result.JSON), "\n")
+	assert.Contains(t, string(result.JSON), `"description":"Name <short> & unique"`)
+	again, err := canonicalJSON(result.JSON)
+	require.NoError(t, err)
+	assert.Equal(t, result.JSON, again, "canonical output must be a fixed point")
+
+	sum := sha256.Sum256(result.JSON)
+	assert.Equal(t, hex.EncodeToString(sum[:]), result.Fingerprint)
+
+	indented, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(), ops...)
+	require.NoError(t, err)
+	assert.Equal(t, indented.Fingerprint, result.Fingerprint, "fingerprints do not depend on the output format")
+
+	var buf bytes.Buffer
+	_, err = api.GenerateTo(context.Background(), &buf, ops...)
+	require.NoError(t, err)
+	assert.Equal(t, string(result.JSON)+"\n", buf.String())
+}
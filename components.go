@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/talav/openapi/example"
+	"github.com/talav/openapi/internal/model"
+)
+
+// Header is a reusable response header registered with RegisterHeader.
+type Header struct {
+	// Description of the header.
+	Description string
+
+	// Required marks the header as always sent.
+	Required bool
+
+	// Deprecated marks the header as deprecated.
+	Deprecated bool
+
+	// Type is a value of the Go type of the header, whose schema is generated
+	// like the schemas of struct fields, e.g. 0 for an integer header.
+	// Default: nil (string)
+	Type any
+}
+
+// RegisterExample registers a reusable example, emitted in components.examples
+// under its name. Operations reference it with example.Ref wherever examples are
+// accepted. Registering a name again replaces the example.
+//
+// Example:
+//
+//	api.RegisterExample(example.New("alice", User{ID: 1, Name: "Alice"},
+//	    example.WithSummary("A regular user"),
+//	))
+//
+//	openapi.GET("/users/:id",
+//	    openapi.WithResponse(200, User{}, example.Ref("alice")),
+//	)
+func (a *API) RegisterExample(ex example.Example) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// The registry is shared with the models of previous Generate calls: copy on write
+	examples := maps.Clone(a.examples)
+	if examples == nil {
+		examples = make(map[string]example.Example)
+	}
+	examples[ex.Name()] = ex
+	a.examples = examples
+}
+
+// RegisterHeader registers a reusable response header, emitted in
+// components.headers under its name, which is the name of the header.
+// Operations reference it with WithResponseHeaderRef. Registering a name again
+// replaces the header.
+//
+// Example:
+//
+//	api.RegisterHeader("X-RateLimit-Remaining", openapi.Header{
+//	    Description: "Requests left in the current window",
+//	    Required:    true,
+//	    Type:        0,
+//	})
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, []User{}),
+//	    openapi.WithResponseHeaderRef(200, "X-RateLimit-Remaining"),
+//	)
+func (a *API) RegisterHeader(name string, header Header) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	headers := maps.Clone(a.headers)
+	if headers == nil {
+		headers = make(map[string]Header)
+	}
+	headers[name] = header
+	a.headers = headers
+}
+
+// WithResponseHeaderRef documents a header registered with RegisterHeader for the
+// response of a status code. If no response is declared for the status code, a
+// response without body is documented. Headers declared on the response struct
+// take precedence.
+func WithResponseHeaderRef(status int, name string) OperationDocOption {
+	return func(d *operationDoc) {
+		d.addResponseHeader(status, name, &model.Header{Ref: componentsPrefix + "headers/" + name})
+	}
+}
+
+// addRegisteredComponents adds the registered examples and headers to the
+// components, and checks that the headers referenced by operations are registered.
+func (a *API) addRegisteredComponents(spec *model.Spec) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(a.examples)) {
+		ex := a.examples[name]
+		if ex.IsRef() {
+			errs = append(errs, fmt.Errorf("example %q: a registered example cannot be a reference", name))
+
+			continue
+		}
+		// Registered examples may be referenced by request bodies
+		m, err := a.modelExample(ex, true)
+		if err != nil {
+			errs = append(errs, err)
+
+			continue
+		}
+		if spec.Components.Examples == nil {
+			spec.Components.Examples = make(map[string]*model.Example, len(a.examples))
+		}
+		spec.Components.Examples[name] = m
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(a.headers)) {
+		h := a.headers[name]
+		schema := &model.Schema{Type: "string"}
+		if h.Type != nil {
+			schema = a.generator.Schema(reflect.TypeOf(h.Type))
+		}
+		if spec.Components.Headers == nil {
+			spec.Components.Headers = make(map[string]*model.Header, len(a.headers))
+		}
+		spec.Components.Headers[name] = &model.Header{
+			Description: h.Description,
+			Required:    h.Required,
+			Deprecated:  h.Deprecated,
+			Schema:      schema,
+		}
+	}
+
+	prefix := componentsPrefix + "headers/"
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		for _, status := range slices.Sorted(maps.Keys(ref.Operation.Responses)) {
+			for _, h := range ref.Operation.Responses[status].Headers {
+				name, ok := strings.CutPrefix(h.Ref, prefix)
+				if _, registered := a.headers[name]; ok && !registered {
+					errs = append(errs, fmt.Errorf("operation %s %s: response %s: header %s is not registered; register it with RegisterHeader",
+						ref.Method, ref.Path, status, name))
+				}
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
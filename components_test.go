@@ -0,0 +1,132 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/example"
+)
+
+type componentUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type componentCreateUser struct {
+	Body componentUser `body:"structured"`
+}
+
+func TestRegisterComponents(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+		api.RegisterExample(example.New("alice", componentUser{ID: 1, Name: "Alice"}, example.WithSummary("A regular user")))
+		api.RegisterHeader("X-RateLimit-Remaining", Header{
+			Description: "Requests left in the current window",
+			Required:    true,
+			Type:        0,
+		})
+		api.RegisterHeader("X-Request-ID", Header{Description: "Correlation ID"})
+
+		result, err := api.Generate(context.Background(),
+			GET("/users/:id",
+				WithResponse(200, componentUser{}, example.Ref("alice")),
+				WithResponseHeaderRef(200, "X-RateLimit-Remaining"),
+				WithResponseHeaderRef(404, "X-Request-ID"),
+			),
+			POST("/users",
+				WithRequest(componentCreateUser{}, example.Ref("alice")),
+			),
+			GET("/users",
+				WithResponse(200, []componentUser{}),
+				WithResponseHeaderRef(200, "X-RateLimit-Remaining"),
+			),
+		)
+		require.NoError(t, err)
+
+		type ref struct {
+			Ref string `json:"$ref"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				RequestBody struct {
+					Content map[string]struct {
+						Examples map[string]ref `json:"examples"`
+					} `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Headers map[string]ref `json:"headers"`
+					Content map[string]struct {
+						Examples map[string]ref `json:"examples"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+			Components struct {
+				Examples map[string]struct {
+					Summary string         `json:"summary"`
+					Value   map[string]any `json:"value"`
+				} `json:"examples"`
+				Headers map[string]struct {
+					Description string `json:"description"`
+					Required    bool   `json:"required"`
+					Schema      struct {
+						Type string `json:"type"`
+					} `json:"schema"`
+				} `json:"headers"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		get := spec.Paths["/users/{id}"]["get"]
+		assert.Equal(t, ref{Ref: "#/components/examples/alice"}, get.Responses["200"].Content["application/json"].Examples["alice"])
+		assert.Equal(t, ref{Ref: "#/components/headers/X-RateLimit-Remaining"}, get.Responses["200"].Headers["X-RateLimit-Remaining"])
+		assert.Equal(t, ref{Ref: "#/components/headers/X-Request-ID"}, get.Responses["404"].Headers["X-Request-ID"])
+		list := spec.Paths["/users"]["get"]
+		assert.Equal(t, ref{Ref: "#/components/headers/X-RateLimit-Remaining"}, list.Responses["200"].Headers["X-RateLimit-Remaining"])
+		assert.Contains(t, list.Responses["200"].Content, "application/json")
+		post := spec.Paths["/users"]["post"]
+		assert.Equal(t, ref{Ref: "#/components/examples/alice"}, post.RequestBody.Content["application/json"].Examples["alice"])
+
+		alice := spec.Components.Examples["alice"]
+		assert.Equal(t, "A regular user", alice.Summary)
+		assert.Equal(t, map[string]any{"id": float64(1), "name": "Alice"}, alice.Value)
+		remaining := spec.Components.Headers["X-RateLimit-Remaining"]
+		assert.Equal(t, "integer", remaining.Schema.Type)
+		assert.True(t, remaining.Required)
+		assert.Equal(t, "string", spec.Components.Headers["X-Request-ID"].Schema.Type)
+	})
+}
+
+func TestRegisterComponents_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		op   Operation
+		want string
+	}{
+		{
+			name: "unregistered example",
+			op:   GET("/users", WithResponse(200, componentUser{}, example.Ref("bob"))),
+			want: `example "bob" is not registered; register it with RegisterExample`,
+		},
+		{
+			name: "unregistered header",
+			op:   GET("/users", WithResponseHeaderRef(200, "X-Page")),
+			want: "operation GET /users: response 200: header X-Page is not registered; register it with RegisterHeader",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(), tt.op)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+	api.RegisterExample(example.Ref("alice"))
+	_, err := api.Generate(context.Background(), GET("/users"))
+	require.ErrorContains(t, err, `invalid components: example "alice": a registered example cannot be a reference`)
+}
@@ -19,6 +19,9 @@ type TagConfig struct {
 
 	// Requires is the tag name for dependent required fields (e.g., "requires").
 	Requires string
+
+	// Header is the tag name for header fields (e.g., "header").
+	Header string
 }
 
 // DefaultTagConfig returns the default tag configuration with standard tag names.
@@ -30,6 +33,7 @@ func DefaultTagConfig() TagConfig {
 		Validate: "validate",
 		Default:  "default",
 		Requires: "requires",
+		Header:   "header",
 	}
 }
 
@@ -57,12 +61,15 @@ func MergeTagConfig(current, cfg TagConfig) TagConfig {
 	if cfg.Requires != "" {
 		result.Requires = cfg.Requires
 	}
+	if cfg.Header != "" {
+		result.Header = cfg.Header
+	}
 
 	return result
 }
 
-// NewTagConfig creates a TagConfig with explicit values for all fields.
-// Use this when you want to specify all tag names explicitly.
+// NewTagConfig creates a TagConfig with explicit values for all fields except Header,
+// which keeps its default when the config is merged.
 func NewTagConfig(schema, body, openapi, validate, default_, requires string) TagConfig {
 	return TagConfig{
 		Schema:   schema,
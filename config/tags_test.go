@@ -15,6 +15,7 @@ func TestDefaultTagConfig(t *testing.T) {
 	assert.Equal(t, "validate", cfg.Validate)
 	assert.Equal(t, "default", cfg.Default)
 	assert.Equal(t, "requires", cfg.Requires)
+	assert.Equal(t, "header", cfg.Header)
 }
 
 func TestNewTagConfig(t *testing.T) {
@@ -48,6 +49,7 @@ func TestMergeTagConfig(t *testing.T) {
 				Validate: "validate",
 				Default:  "default",
 				Requires: "requires",
+				Header:   "header",
 			},
 		},
 		{
@@ -60,6 +62,7 @@ func TestMergeTagConfig(t *testing.T) {
 				Validate: "v",
 				Default:  "d",
 				Requires: "r",
+				Header:   "h",
 			},
 			want: TagConfig{
 				Schema:   "s",
@@ -68,6 +71,7 @@ func TestMergeTagConfig(t *testing.T) {
 				Validate: "v",
 				Default:  "d",
 				Requires: "r",
+				Header:   "h",
 			},
 		},
 		{
@@ -84,6 +88,7 @@ func TestMergeTagConfig(t *testing.T) {
 				Validate: "validate",
 				Default:  "default",
 				Requires: "requires",
+				Header:   "header",
 			},
 		},
 	}
@@ -0,0 +1,124 @@
+package openapi
+
+import (
+	"net/http"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// Conventional header names documented by the bundles below.
+const (
+	headerIdempotencyKey     = "Idempotency-Key"
+	headerRequestID          = "X-Request-ID"
+	headerRateLimitLimit     = "X-RateLimit-Limit"
+	headerRateLimitRemaining = "X-RateLimit-Remaining"
+	headerRateLimitReset     = "X-RateLimit-Reset"
+	headerRetryAfter         = "Retry-After"
+	headerCSRFToken          = "X-CSRF-Token"
+)
+
+// IdempotencyKey documents the Idempotency-Key request header, which lets
+// clients safely retry non-idempotent requests such as POST.
+//
+// Example:
+//
+//	openapi.POST("/payments",
+//	    openapi.IdempotencyKey(),
+//	    openapi.WithRequest(CreatePayment{}),
+//	)
+func IdempotencyKey() OperationDocOption {
+	return func(d *operationDoc) {
+		minLength := 1
+		d.Parameters = append(d.Parameters, model.Parameter{
+			Name:        headerIdempotencyKey,
+			In:          string(InHeader),
+			Description: "Unique key identifying the request; retries with the same key are not applied twice",
+			Schema:      &model.Schema{Type: "string", MinLength: &minLength},
+		})
+	}
+}
+
+// RequestID documents the X-Request-ID request header and echoes it on every response.
+//
+// Example:
+//
+//	openapi.GET("/users/:id",
+//	    openapi.RequestID(),
+//	    openapi.WithResponse(200, User{}),
+//	)
+func RequestID() OperationDocOption {
+	return func(d *operationDoc) {
+		d.Parameters = append(d.Parameters, model.Parameter{
+			Name:        headerRequestID,
+			In:          string(InHeader),
+			Description: "Correlation ID of the request; generated by the server when omitted",
+			Schema:      &model.Schema{Type: "string"},
+		})
+		d.addCommonResponseHeader(headerRequestID, &model.Header{
+			Description: "Correlation ID of the request",
+			Schema:      &model.Schema{Type: "string"},
+		})
+	}
+}
+
+// RateLimitHeaders documents the X-RateLimit-* headers on every response and
+// a 429 Too Many Requests response with a Retry-After header.
+//
+// Example:
+//
+//	openapi.GET("/users",
+//	    openapi.RateLimitHeaders(),
+//	    openapi.WithResponse(200, []User{}),
+//	)
+func RateLimitHeaders() OperationDocOption {
+	return func(d *operationDoc) {
+		d.addCommonResponseHeader(headerRateLimitLimit, &model.Header{
+			Description: "Maximum number of requests allowed in the current window",
+			Schema:      &model.Schema{Type: "integer", Minimum: &model.Bound{Value: 0}},
+		})
+		d.addCommonResponseHeader(headerRateLimitRemaining, &model.Header{
+			Description: "Number of requests remaining in the current window",
+			Schema:      &model.Schema{Type: "integer", Minimum: &model.Bound{Value: 0}},
+		})
+		d.addCommonResponseHeader(headerRateLimitReset, &model.Header{
+			Description: "Number of seconds until the current window resets",
+			Schema:      &model.Schema{Type: "integer", Minimum: &model.Bound{Value: 0}},
+		})
+		d.addResponseHeader(http.StatusTooManyRequests, headerRetryAfter, &model.Header{
+			Description: "Number of seconds to wait before retrying",
+			Schema:      &model.Schema{Type: "integer", Minimum: &model.Bound{Value: 0}},
+		})
+	}
+}
+
+// CSRFToken documents double-submit CSRF protection: the cookie holding the CSRF
+// token, and the X-CSRF-Token request header repeating it. Use it on the
+// state-changing operations of session-authenticated APIs (see WithCookieAuth).
+//
+// Example:
+//
+//	openapi.POST("/orders",
+//	    openapi.WithSecurity("sessionAuth"),
+//	    openapi.CSRFToken("csrf_token"),
+//	    openapi.WithRequest(CreateOrder{}),
+//	)
+func CSRFToken(cookieName string) OperationDocOption {
+	return func(d *operationDoc) {
+		d.Parameters = append(d.Parameters,
+			model.Parameter{
+				Name:        cookieName,
+				In:          string(InCookie),
+				Description: "CSRF token set by the server",
+				Required:    true,
+				Schema:      &model.Schema{Type: "string"},
+			},
+			model.Parameter{
+				Name:        headerCSRFToken,
+				In:          string(InHeader),
+				Description: "CSRF token, repeating the value of the " + cookieName + " cookie",
+				Required:    true,
+				Schema:      &model.Schema{Type: "string"},
+			},
+		)
+	}
+}
@@ -0,0 +1,127 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_ConventionBundles(t *testing.T) {
+	type Payment struct {
+		Amount int `json:"amount"`
+	}
+	type CreatePayment struct {
+		Body Payment `body:"structured"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithInfoTitle("Test"),
+			WithInfoVersion("1.0.0"),
+			WithVersion(version),
+			WithValidation(true),
+		)
+
+		result, err := api.Generate(context.Background(),
+			POST("/test",
+				IdempotencyKey(),
+				RequestID(),
+				RateLimitHeaders(),
+				WithRequest(CreatePayment{}),
+				WithResponse(201, Payment{}),
+				WithProblemResponses(400),
+			),
+			GET("/test", RateLimitHeaders(), WithResponse(200, []Payment{})),
+		)
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		op := getOperation(t, spec, "post")
+
+		params, ok := op["parameters"].([]any)
+		require.True(t, ok, "parameters must be an array")
+		require.Len(t, params, 2)
+		idempotency := params[0].(map[string]any)
+		assert.Equal(t, "Idempotency-Key", idempotency["name"])
+		assert.Equal(t, "header", idempotency["in"])
+		assert.Equal(t, "X-Request-ID", params[1].(map[string]any)["name"])
+
+		responses, ok := op["responses"].(map[string]any)
+		require.True(t, ok, "responses must be a map")
+		require.Len(t, responses, 3)
+		for status, r := range responses {
+			headers, ok := r.(map[string]any)["headers"].(map[string]any)
+			require.True(t, ok, "%s response must have headers", status)
+			assert.Contains(t, headers, "X-Request-ID", status)
+			assert.Contains(t, headers, "X-RateLimit-Limit", status)
+			assert.Contains(t, headers, "X-RateLimit-Remaining", status)
+			assert.Contains(t, headers, "X-RateLimit-Reset", status)
+		}
+
+		tooMany, ok := responses["429"].(map[string]any)
+		require.True(t, ok, "429 response must be a map")
+		assert.Equal(t, "Too Many Requests", tooMany["description"])
+		assert.Contains(t, tooMany["headers"], "Retry-After")
+
+		list := getOperation(t, spec, "get")["responses"].(map[string]any)["200"].(map[string]any)
+		assert.Contains(t, list["headers"], "X-RateLimit-Limit")
+		assert.Contains(t, list["content"], "application/json")
+	})
+}
+
+func TestGenerate_CSRFToken(t *testing.T) {
+	type Order struct {
+		ID string `json:"id"`
+	}
+
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithCookieAuth("sessionAuth", "session_id", "Session cookie"),
+	)
+
+	result, err := api.Generate(context.Background(),
+		POST("/orders",
+			WithSecurity("sessionAuth"),
+			CSRFToken("csrf_token"),
+			WithResponse(201, Order{}),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				Name     string `json:"name"`
+				In       string `json:"in"`
+				Required bool   `json:"required"`
+			} `json:"parameters"`
+		} `json:"paths"`
+		Components struct {
+			SecuritySchemes map[string]struct {
+				Type string `json:"type"`
+				Name string `json:"name"`
+				In   string `json:"in"`
+			} `json:"securitySchemes"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	scheme := spec.Components.SecuritySchemes["sessionAuth"]
+	assert.Equal(t, "apiKey", scheme.Type)
+	assert.Equal(t, "session_id", scheme.Name)
+	assert.Equal(t, "cookie", scheme.In)
+
+	params := spec.Paths["/orders"]["post"].Parameters
+	require.Len(t, params, 2)
+	assert.Equal(t, "csrf_token", params[0].Name)
+	assert.Equal(t, "cookie", params[0].In)
+	assert.True(t, params[0].Required)
+	assert.Equal(t, "X-CSRF-Token", params[1].Name)
+	assert.Equal(t, "header", params[1].In)
+	assert.True(t, params[1].Required)
+}
@@ -0,0 +1,51 @@
+package debug
+
+// Trace records what produced the operations and component schemas of a
+// generated specification (see WithDebugTrace). It marshals to JSON, to be saved
+// as a sidecar of the specification.
+type Trace struct {
+	// Operations lists the operations, sorted by path, then by method.
+	Operations []OperationTrace `json:"operations"`
+
+	// Schemas lists the component schemas generated from Go types, sorted by name.
+	Schemas []SchemaTrace `json:"schemas"`
+}
+
+// OperationTrace records the declaration of an operation.
+type OperationTrace struct {
+	Method      string `json:"method"`
+	Path        string `json:"path"`
+	OperationID string `json:"operationId,omitempty"`
+
+	// Source is the file:line of the declaration of the operation.
+	// Example: "/src/api/routes.go:42"
+	Source string `json:"source,omitempty"`
+
+	// Request is the Go type of the request struct, if any.
+	Request string `json:"request,omitempty"`
+
+	// Responses maps status codes to the Go types of the response bodies.
+	Responses map[string]string `json:"responses,omitempty"`
+}
+
+// SchemaTrace records the Go type a component schema was generated from.
+type SchemaTrace struct {
+	Name string `json:"name"`
+
+	// Type is the Go type, qualified with the import path of its package.
+	// Example: "github.com/acme/api/models.User"
+	Type string `json:"type"`
+
+	// Properties lists the properties generated from struct fields, sorted by name.
+	Properties []PropertyTrace `json:"properties,omitempty"`
+}
+
+// PropertyTrace records the struct field a property was generated from.
+type PropertyTrace struct {
+	Name  string `json:"name"`
+	Field string `json:"field"`
+
+	// Tags maps the names of the struct tags read by the generator to their values,
+	// for the tags set on the field.
+	Tags map[string]string `json:"tags,omitempty"`
+}
@@ -0,0 +1,57 @@
+package debug
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationError describes a violation of the OpenAPI meta-schema found
+// when validating a generated specification (see WithValidation), or a finding
+// of a custom validator (see WithValidator).
+type ValidationError struct {
+	// Pointer is the JSON pointer to the failing value in the generated spec.
+	// Example: "/paths/~1users/get/responses/200/description"
+	Pointer string
+
+	// Fragment is the failing value, as decoded from the generated spec.
+	Fragment any
+
+	// Rule is the location of the violated rule in the meta-schema, or the rule
+	// of a custom validator.
+	Rule string
+
+	// Message is a human-readable description of the violation.
+	Message string
+
+	// Source identifies what produced the failing value, when known:
+	// a Go type or field for component schemas, an operation for paths.
+	// Example: "models.User.Email", "GET /users/{id}"
+	Source string
+}
+
+// Error returns a formatted representation.
+func (e ValidationError) Error() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "#%s: %s", e.Pointer, e.Message)
+	if e.Source != "" {
+		fmt.Fprintf(&sb, " (from %s)", e.Source)
+	}
+
+	return sb.String()
+}
+
+// ValidationErrors is the list of violations returned when a generated
+// specification does not conform to the OpenAPI meta-schema.
+// Retrieve it from a Generate error with errors.As.
+type ValidationErrors []ValidationError
+
+// Error returns all violations, one per line.
+func (e ValidationErrors) Error() string {
+	lines := make([]string, 0, len(e)+1)
+	lines = append(lines, fmt.Sprintf("%d meta-schema violation(s)", len(e)))
+	for _, ve := range e {
+		lines = append(lines, "  - "+ve.Error())
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,29 @@
+package debug
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidationErrorString(t *testing.T) {
+	err := ValidationError{
+		Pointer: "/components/schemas/User/properties/name/maxLength",
+		Message: "minimum: got -1, want 0",
+		Source:  "models.User.Name",
+	}
+
+	assert.Equal(t, "#/components/schemas/User/properties/name/maxLength: minimum: got -1, want 0 (from models.User.Name)", err.Error())
+
+	err.Source = ""
+	assert.Equal(t, "#/components/schemas/User/properties/name/maxLength: minimum: got -1, want 0", err.Error())
+}
+
+func TestValidationErrorsString(t *testing.T) {
+	errs := ValidationErrors{
+		{Pointer: "/openapi", Message: "first"},
+		{Pointer: "/info", Message: "second"},
+	}
+
+	assert.Equal(t, "2 meta-schema violation(s)\n  - #/openapi: first\n  - #/info: second", errs.Error())
+}
@@ -61,6 +61,12 @@ const (
 	// WarnDegradationPatternProperties indicates patternProperties was dropped.
 	WarnDegradationPatternProperties WarningCode = "DEGRADATION_PATTERN_PROPERTIES"
 
+	// WarnDegradationPrefixItems indicates prefixItems was converted to an anyOf of items.
+	WarnDegradationPrefixItems WarningCode = "DEGRADATION_PREFIX_ITEMS"
+
+	// WarnDegradationPropertyNames indicates propertyNames was dropped.
+	WarnDegradationPropertyNames WarningCode = "DEGRADATION_PROPERTY_NAMES"
+
 	// WarnDegradationUnevaluatedProperties indicates unevaluatedProperties was dropped.
 	WarnDegradationUnevaluatedProperties WarningCode = "DEGRADATION_UNEVALUATED_PROPERTIES"
 
@@ -70,8 +76,20 @@ const (
 	// WarnDegradationContentMediaType indicates contentMediaType was dropped.
 	WarnDegradationContentMediaType WarningCode = "DEGRADATION_CONTENT_MEDIA_TYPE"
 
+	// WarnDegradationDependentRequired indicates dependentRequired was converted to allOf/anyOf.
+	WarnDegradationDependentRequired WarningCode = "DEGRADATION_DEPENDENT_REQUIRED"
+
+	// WarnDegradationConditional indicates if/then/else was converted to anyOf.
+	WarnDegradationConditional WarningCode = "DEGRADATION_CONDITIONAL"
+
 	// WarnDegradationMultipleExamples indicates multiple examples were collapsed to one.
 	WarnDegradationMultipleExamples WarningCode = "DEGRADATION_MULTIPLE_EXAMPLES"
+
+	// WarnDegradationJSONSchemaDialect indicates jsonSchemaDialect was dropped.
+	WarnDegradationJSONSchemaDialect WarningCode = "DEGRADATION_JSON_SCHEMA_DIALECT"
+
+	// WarnDegradationSchemaID indicates the $id and $schema of a schema were dropped.
+	WarnDegradationSchemaID WarningCode = "DEGRADATION_SCHEMA_ID"
 )
 
 // Spec violation warnings (invalid OpenAPI constructs).
@@ -80,6 +98,50 @@ const (
 	WarnInvalidExampleMutualExclusivity WarningCode = "INVALID_EXAMPLE_MUTUAL_EXCLUSIVITY"
 )
 
+// Lint warnings (valid but likely incorrect documentation).
+const (
+	// WarnExampleSchemaMismatch indicates an example does not conform to its schema.
+	WarnExampleSchemaMismatch WarningCode = "EXAMPLE_SCHEMA_MISMATCH"
+
+	// WarnUnusedComponent indicates a component is never referenced.
+	WarnUnusedComponent WarningCode = "UNUSED_COMPONENT"
+
+	// WarnAmbiguousPath indicates two paths can match the same request.
+	WarnAmbiguousPath WarningCode = "AMBIGUOUS_PATH"
+
+	// WarnMarshalerSchema indicates a json.Marshaler type is documented from its fields.
+	WarnMarshalerSchema WarningCode = "MARSHALER_SCHEMA"
+
+	// WarnPropertyNamingConflict indicates a json tag name does not follow the property naming strategy.
+	WarnPropertyNamingConflict WarningCode = "PROPERTY_NAMING_CONFLICT"
+
+	// WarnShadowedField indicates a struct field hidden by another field with the same property name.
+	WarnShadowedField WarningCode = "SHADOWED_FIELD"
+
+	// WarnAmbiguousField indicates a struct field left out with the other fields with the
+	// same property name at the same depth, which encoding/json drops as ambiguous.
+	WarnAmbiguousField WarningCode = "AMBIGUOUS_FIELD"
+
+	// WarnAuthorizationHeader indicates an Authorization header parameter, ignored by OpenAPI tools.
+	WarnAuthorizationHeader WarningCode = "AUTHORIZATION_HEADER"
+
+	// WarnEnumMismatch indicates a default or example outside the enum of its
+	// schema, or a schema with both const and enum.
+	WarnEnumMismatch WarningCode = "ENUM_MISMATCH"
+
+	// WarnEmptyResponseBody indicates the body of a response that must not have
+	// one, such as 204 No Content, was removed.
+	WarnEmptyResponseBody WarningCode = "EMPTY_RESPONSE_BODY"
+
+	// WarnUndefinedReference indicates a reference to a component schema that is
+	// not defined, such as one set with the ref option of the openapi tag.
+	WarnUndefinedReference WarningCode = "UNDEFINED_REFERENCE"
+
+	// WarnUnknownFormat indicates a schema format that is neither standard nor
+	// allowed as a custom format.
+	WarnUnknownFormat WarningCode = "UNKNOWN_FORMAT"
+)
+
 // Warnings is a collection of Warning with helper methods.
 // Warnings are informational and never break execution.
 type Warnings []Warning
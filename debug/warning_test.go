@@ -81,6 +81,8 @@ func TestWarningCodes(t *testing.T) {
 		WarnDegradationConstToEnumConflict,
 		WarnDegradationPathItems,
 		WarnDegradationPatternProperties,
+		WarnDegradationPrefixItems,
+		WarnDegradationPropertyNames,
 		WarnDegradationUnevaluatedProperties,
 		WarnDegradationContentEncoding,
 		WarnDegradationContentMediaType,
@@ -0,0 +1,62 @@
+package openapi
+
+// WithOperationDefaults applies options to every operation, before the options of
+// the operation, for documentation shared by all routes such as a correlation
+// header or a standard 429 response. Options of the operations take precedence:
+// a default response is replaced by a response of the operation with the same
+// status, and the security requirements of an operation replace the default ones.
+//
+// Default: none
+//
+// Example:
+//
+//	openapi.WithOperationDefaults(
+//	    openapi.WithRequestHeader("X-Correlation-ID", "Correlation ID of the request"),
+//	    openapi.WithResponse(429, Problem{}),
+//	)
+func WithOperationDefaults(opts ...OperationDocOption) Option {
+	return func(a *API) {
+		a.OperationDefaults = append(a.OperationDefaults, opts...)
+	}
+}
+
+// Group applies options to operations, before the options of each operation, for
+// documentation shared by a group of routes such as the admin routes. Groups nest,
+// and API-wide defaults of WithOperationDefaults are applied before them. Options
+// of the operations take precedence over those of groups, as over
+// WithOperationDefaults.
+//
+// Example:
+//
+//	admin := openapi.Group(
+//	    openapi.WithTags("admin"),
+//	    openapi.WithSecurity("bearer", "admin"),
+//	)
+//
+//	api.Generate(ctx, admin(
+//	    openapi.GET("/admin/users", openapi.WithResponse(200, UserList{})),
+//	    openapi.DELETE("/admin/users/:id", openapi.WithRequest(DeleteUserRequest{})),
+//	)...)
+func Group(opts ...OperationDocOption) func(ops ...Operation) []Operation {
+	return func(ops ...Operation) []Operation {
+		grouped := make([]Operation, 0, len(ops))
+		for _, op := range ops {
+			grouped = append(grouped, op.withDefaults(opts))
+		}
+
+		return grouped
+	}
+}
+
+// applyOperationDefaults returns the operations with the API-wide default options applied.
+func (a *API) applyOperationDefaults(ops []Operation) []Operation {
+	if len(a.OperationDefaults) == 0 {
+		return ops
+	}
+	decorated := make([]Operation, 0, len(ops))
+	for _, op := range ops {
+		decorated = append(decorated, op.withDefaults(a.OperationDefaults))
+	}
+
+	return decorated
+}
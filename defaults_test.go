@@ -0,0 +1,118 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type defaultsRateLimited struct {
+	RetryAfter int `json:"retryAfter"`
+}
+
+type defaultsUser struct {
+	Name string `json:"name"`
+}
+
+type defaultsSpec struct {
+	Paths map[string]map[string]struct {
+		Tags       []string              `json:"tags"`
+		Summary    string                `json:"summary"`
+		Security   []map[string][]string `json:"security"`
+		Parameters []struct {
+			Name string `json:"name"`
+			In   string `json:"in"`
+		} `json:"parameters"`
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema struct {
+					Ref string `json:"$ref"`
+				} `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+}
+
+func generateDefaultsSpec(t *testing.T, api *API, ops ...Operation) defaultsSpec {
+	t.Helper()
+	result, err := api.Generate(context.Background(), ops...)
+	require.NoError(t, err)
+	var spec defaultsSpec
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	return spec
+}
+
+func TestWithOperationDefaults(t *testing.T) {
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithOperationDefaults(
+			WithRequestHeader("X-Correlation-ID", "Correlation ID of the request"),
+			WithResponse(429, defaultsRateLimited{}),
+			WithSummary("Default summary"),
+		),
+	)
+
+	spec := generateDefaultsSpec(t, api,
+		GET("/users", WithSummary("List users"), WithResponse(200, defaultsUser{})),
+		GET("/limits", WithResponse(200, defaultsUser{}), WithResponse(429, defaultsUser{})),
+	)
+
+	users := spec.Paths["/users"]["get"]
+	assert.Equal(t, "List users", users.Summary)
+	require.Len(t, users.Parameters, 1)
+	assert.Equal(t, "X-Correlation-ID", users.Parameters[0].Name)
+	assert.Equal(t, "header", users.Parameters[0].In)
+	assert.Equal(t, "#/components/schemas/DefaultsRateLimited", users.Responses["429"].Content["application/json"].Schema.Ref)
+
+	limits := spec.Paths["/limits"]["get"]
+	assert.Equal(t, "Default summary", limits.Summary)
+	assert.Equal(t, "#/components/schemas/DefaultsUser", limits.Responses["429"].Content["application/json"].Schema.Ref)
+}
+
+func TestGroup(t *testing.T) {
+	admin := Group(WithTags("admin"))
+	api := NewAPI(
+		WithVersion("3.0.4"),
+		WithOperationDefaults(WithTags("api")),
+	)
+
+	ops := slices.Concat(
+		admin(
+			GET("/admin/users", WithTags("users"), WithResponse(200, defaultsUser{})),
+			DELETE("/admin/cache", WithResponse(200, defaultsUser{})),
+		),
+		[]Operation{GET("/health", WithResponse(200, defaultsUser{}))},
+	)
+	spec := generateDefaultsSpec(t, api, ops...)
+
+	assert.Equal(t, []string{"api", "admin", "users"}, spec.Paths["/admin/users"]["get"].Tags)
+	assert.Equal(t, []string{"api", "admin"}, spec.Paths["/admin/cache"]["delete"].Tags)
+	assert.Equal(t, []string{"api"}, spec.Paths["/health"]["get"].Tags)
+}
+
+func TestGroup_Security(t *testing.T) {
+	admin := Group(WithSecurity("bearer", "admin"))
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithValidation(true),
+		WithBearerAuth("bearer", "Bearer token"),
+		WithAPIKey("key", "X-API-Key", InHeader, "API key"),
+		WithOperationDefaults(WithSecurity("key")),
+	)
+
+	spec := generateDefaultsSpec(t, api, admin(
+		GET("/admin/users", WithResponse(200, defaultsUser{})),
+		GET("/admin/keys", WithSecurity("key"), WithResponse(200, defaultsUser{})),
+		GET("/admin/audit", WithSecurity("bearer", "admin", "audit"), WithSecurity("key"), WithResponse(200, defaultsUser{})),
+	)...)
+
+	// Requirements of the operation replace the defaults instead of adding alternatives
+	assert.Equal(t, []map[string][]string{{"bearer": {"admin"}}}, spec.Paths["/admin/users"]["get"].Security)
+	assert.Equal(t, []map[string][]string{{"key": {}}}, spec.Paths["/admin/keys"]["get"].Security)
+	assert.Equal(t, []map[string][]string{{"bearer": {"admin", "audit"}}, {"key": {}}}, spec.Paths["/admin/audit"]["get"].Security)
+}
@@ -0,0 +1,143 @@
+package openapi
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// DeprecationKind is the kind of a deprecated element.
+type DeprecationKind string
+
+// Kinds of deprecated elements.
+const (
+	DeprecatedOperation DeprecationKind = "operation"
+	DeprecatedParameter DeprecationKind = "parameter"
+	DeprecatedProperty  DeprecationKind = "property"
+)
+
+// Deprecation is a deprecated operation, parameter or schema property.
+type Deprecation struct {
+	// Kind is the kind of the deprecated element.
+	Kind DeprecationKind
+
+	// Pointer is the JSON pointer to the element, e.g. "#/paths/~1users/get".
+	Pointer string
+
+	// Operation is the deprecated operation, or the operation declaring the
+	// parameter or the inline schema of the property. Zero for properties of
+	// component schemas.
+	Operation OperationRef
+
+	// Name is the parameter name, or the property as "Schema.property". Properties
+	// of inline schemas are named after the path to them, e.g. "request.password".
+	// Empty for operations.
+	Name string
+
+	// Sunset is the x-sunset extension of the element (see WithDeprecation),
+	// if any. Parameters and properties of deprecated operations without their
+	// own sunset have the sunset of the operation.
+	Sunset string
+}
+
+// deprecations lists the deprecated operations and their parameters, sorted by
+// path, then by method, followed by the deprecated properties of component schemas,
+// sorted by schema name.
+func (m *Model) deprecations() []Deprecation {
+	var deps []Deprecation
+	for _, ref := range m.Operations() {
+		op := ref.Operation
+		base := util.Pointer("#/paths", ref.Path, strings.ToLower(ref.Method))
+		opSunset := sunsetOf(op.Extensions)
+		if op.Deprecated {
+			deps = append(deps, Deprecation{Kind: DeprecatedOperation, Pointer: base, Operation: ref, Sunset: opSunset})
+		}
+		for i, p := range op.Parameters {
+			if p.Deprecated {
+				deps = append(deps, Deprecation{
+					Kind:      DeprecatedParameter,
+					Pointer:   util.Pointer(base, "parameters", strconv.Itoa(i)),
+					Operation: ref,
+					Name:      p.Name,
+					Sunset:    cmp.Or(sunsetOf(p.Extensions), opSunset),
+				})
+			}
+		}
+
+		var props []Deprecation
+		if op.RequestBody != nil {
+			for _, mediaType := range slices.Sorted(maps.Keys(op.RequestBody.Content)) {
+				if mt := op.RequestBody.Content[mediaType]; mt != nil {
+					props = deprecatedProperties(props, mt.Schema, "request", util.Pointer(base, "requestBody", "content", mediaType, "schema"))
+				}
+			}
+		}
+		for _, status := range slices.Sorted(maps.Keys(op.Responses)) {
+			resp := op.Responses[status]
+			if resp == nil {
+				continue
+			}
+			for _, mediaType := range slices.Sorted(maps.Keys(resp.Content)) {
+				if mt := resp.Content[mediaType]; mt != nil {
+					props = deprecatedProperties(props, mt.Schema, "response"+status, util.Pointer(base, "responses", status, "content", mediaType, "schema"))
+				}
+			}
+		}
+		for _, p := range props {
+			p.Operation = ref
+			p.Sunset = cmp.Or(p.Sunset, opSunset)
+			deps = append(deps, p)
+		}
+	}
+
+	if m.spec.Components != nil {
+		for _, name := range sortedNames(m.spec.Components.Schemas) {
+			deps = deprecatedProperties(deps, m.spec.Components.Schemas[name], name, util.Pointer(componentsPrefix+"schemas", name))
+		}
+	}
+
+	return deps
+}
+
+// deprecatedProperties appends the deprecated properties of an inline schema at
+// ptr, named after owner, and of its subschemas. References are not followed.
+func deprecatedProperties(deps []Deprecation, s *model.Schema, owner, ptr string) []Deprecation {
+	if s == nil || s.Ref != "" {
+		return deps
+	}
+
+	for _, name := range sortedNames(s.Properties) {
+		ps := s.Properties[name]
+		propPtr := util.Pointer(ptr, "properties", name)
+		if ps != nil && ps.Deprecated {
+			deps = append(deps, Deprecation{Kind: DeprecatedProperty, Pointer: propPtr, Name: owner + "." + name, Sunset: sunsetOf(ps.Extensions)})
+		}
+		deps = deprecatedProperties(deps, ps, owner+"."+name, propPtr)
+	}
+	deps = deprecatedProperties(deps, s.Items, owner, util.Pointer(ptr, "items"))
+	if s.Additional != nil {
+		deps = deprecatedProperties(deps, s.Additional.Schema, owner, util.Pointer(ptr, "additionalProperties"))
+	}
+	for _, c := range []struct {
+		keyword string
+		group   []*model.Schema
+	}{{"prefixItems", s.PrefixItems}, {"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}} {
+		for i, sub := range c.group {
+			deps = deprecatedProperties(deps, sub, owner, util.Pointer(ptr, c.keyword, strconv.Itoa(i)))
+		}
+	}
+
+	return deps
+}
+
+// sunsetOf returns the x-sunset extension, or "" if not set.
+func sunsetOf(extensions map[string]any) string {
+	sunset, _ := extensions[extensionSunset].(string)
+
+	return sunset
+}
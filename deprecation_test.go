@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type deprecationQuery struct {
+	Page   int    `schema:"page,location=query"`
+	Offset int    `schema:"offset,location=query" openapi:"deprecated,x-sunset=2026-03-31"`
+	Sort   string `schema:"sort,location=query" openapi:"deprecated"`
+}
+
+type deprecationUser struct {
+	Name     string `json:"name"`
+	Nickname string `json:"nickname" openapi:"deprecated,x-sunset=2026-01-31"`
+	Address  struct {
+		Zip string `json:"zip" openapi:"deprecated"`
+	} `json:"address"`
+}
+
+func TestResult_Deprecations(t *testing.T) {
+	sunset := time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC)
+	ops := []Operation{
+		GET("/v1/users",
+			WithOperationID("listUsersV1"),
+			WithDeprecation("Replaced by the v2 API", sunset, "listUsersV2"),
+			WithRequest(deprecationQuery{}),
+			WithResponse(200, deprecationUser{}),
+		),
+		GET("/v2/users", WithOperationID("listUsersV2"), WithRequest(deprecationQuery{}), WithResponse(200, deprecationUser{})),
+		GET("/v2/users/legacy", WithOperationID("legacyUsers"), WithDeprecated(), WithResponse(200, deprecationUser{})),
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		result, err := NewAPI(WithVersion(version)).Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		var got []string
+		for _, d := range result.Deprecations {
+			var operationID string
+			if d.Operation.Operation != nil {
+				operationID = d.Operation.Operation.OperationID
+			}
+			got = append(got, strings.Join([]string{string(d.Kind), d.Pointer, operationID, d.Name, d.Sunset}, " "))
+		}
+		assert.Equal(t, []string{
+			"operation #/paths/~1v1~1users/get listUsersV1  2026-06-30",
+			"parameter #/paths/~1v1~1users/get/parameters/1 listUsersV1 offset 2026-03-31",
+			"parameter #/paths/~1v1~1users/get/parameters/2 listUsersV1 sort 2026-06-30",
+			"parameter #/paths/~1v2~1users/get/parameters/1 listUsersV2 offset 2026-03-31",
+			"parameter #/paths/~1v2~1users/get/parameters/2 listUsersV2 sort ",
+			"operation #/paths/~1v2~1users~1legacy/get legacyUsers  ",
+			"property #/components/schemas/DeprecationUser/properties/nickname  DeprecationUser.nickname 2026-01-31",
+			"property #/components/schemas/DeprecationUserAddressStruct/properties/zip  DeprecationUserAddressStruct.zip ",
+		}, got)
+
+		// Deprecated parameters are documented as such
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Parameters []struct {
+					Name       string `json:"name"`
+					Deprecated bool   `json:"deprecated"`
+					Sunset     string `json:"x-sunset"`
+				} `json:"parameters"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		params := spec.Paths["/v2/users"]["get"].Parameters
+		require.Len(t, params, 3)
+		assert.False(t, params[0].Deprecated)
+		assert.True(t, params[1].Deprecated)
+		assert.Equal(t, "2026-03-31", params[1].Sunset)
+	})
+}
@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// WithDescriptions adds descriptions to the description catalog, mapping keys to
+// descriptions. Fields tagged openapi:"descriptionKey=key" take the description of
+// their key, so that long descriptions are kept out of struct tags, as reviewable
+// prose. Generate fails for keys missing from the catalog.
+//
+// Example:
+//
+//	openapi.WithDescriptions(map[string]string{
+//	    "user.email": "Primary email address of the user.\n\nUsed for sign-in and notifications.",
+//	})
+func WithDescriptions(catalog map[string]string) Option {
+	return func(a *API) {
+		if a.Descriptions == nil {
+			a.Descriptions = make(map[string]string, len(catalog))
+		}
+		maps.Copy(a.Descriptions, catalog)
+	}
+}
+
+// WithDescriptionsFile adds the descriptions of a YAML or JSON file to the
+// description catalog (see WithDescriptions). Nested mappings are flattened, their
+// keys joined with dots, and YAML block scalars keep multi-line descriptions
+// readable. Generate fails for unreadable or invalid files.
+//
+// Example:
+//
+//	openapi.WithDescriptionsFile("docs/descriptions.yaml")
+//
+// with docs/descriptions.yaml:
+//
+//	user:
+//	  email: |
+//	    Primary email address of the user.
+//
+//	    Used for sign-in and notifications.
+func WithDescriptionsFile(name string) Option {
+	return func(a *API) {
+		data, err := os.ReadFile(name)
+		a.loadDescriptions(name, data, err)
+	}
+}
+
+// WithDescriptionsFS is WithDescriptionsFile reading the file from fsys, such as
+// an embed.FS.
+func WithDescriptionsFS(fsys fs.FS, name string) Option {
+	return func(a *API) {
+		data, err := fs.ReadFile(fsys, name)
+		a.loadDescriptions(name, data, err)
+	}
+}
+
+// loadDescriptions adds the descriptions of a file to the description catalog,
+// recording read and decoding errors.
+func (a *API) loadDescriptions(name string, data []byte, err error) {
+	if err != nil {
+		a.descriptionErrs = append(a.descriptionErrs, fmt.Errorf("failed to read description file: %w", err))
+
+		return
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		a.descriptionErrs = append(a.descriptionErrs, fmt.Errorf("failed to decode description file %s: %w", name, err))
+
+		return
+	}
+
+	catalog := make(map[string]string)
+	if err := flattenDescriptions(catalog, "", doc); err != nil {
+		a.descriptionErrs = append(a.descriptionErrs, fmt.Errorf("description file %s: %w", name, err))
+
+		return
+	}
+	WithDescriptions(catalog)(a)
+}
+
+// flattenDescriptions adds the descriptions of a decoded mapping to the catalog,
+// joining the keys of nested mappings with dots.
+func flattenDescriptions(catalog map[string]string, prefix string, doc map[string]any) error {
+	for _, key := range slices.Sorted(maps.Keys(doc)) {
+		name := prefix + key
+		switch v := doc[key].(type) {
+		case string:
+			catalog[name] = v
+		case map[string]any:
+			if err := flattenDescriptions(catalog, name+".", v); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("key %s: want a description or a mapping, got %T", name, v)
+		}
+	}
+
+	return nil
+}
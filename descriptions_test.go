@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type describedUser struct {
+	Email string `json:"email" openapi:"descriptionKey=user.email"`
+	Name  string `json:"name" openapi:"description=Display name"`
+}
+
+type describedUserRequest struct {
+	Tenant string        `header:"X-Tenant" openapi:"descriptionKey=params.tenant"`
+	Body   describedUser `body:"structured"`
+}
+
+const describedCatalog = `user:
+  email: |
+    Primary email address of the user.
+
+    Used for sign-in and notifications.
+params:
+  tenant: Tenant of the request
+`
+
+func TestWithDescriptions(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		fsys := fstest.MapFS{"descriptions.yaml": {Data: []byte(describedCatalog)}}
+		api := NewAPI(WithVersion(version),
+			WithDescriptionsFS(fsys, "descriptions.yaml"),
+			WithDescriptions(map[string]string{"params.tenant": "Tenant owning the user"}),
+		)
+		result, err := api.Generate(context.Background(),
+			POST("/users", WithRequest(describedUserRequest{}), WithResponse(201, describedUser{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Parameters []struct {
+					Description string `json:"description"`
+				} `json:"parameters"`
+			} `json:"paths"`
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]struct {
+						Description string `json:"description"`
+					} `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		props := spec.Components.Schemas["DescribedUser"].Properties
+		assert.Equal(t, "Primary email address of the user.\n\nUsed for sign-in and notifications.\n", props["email"].Description)
+		assert.Equal(t, "Display name", props["name"].Description)
+		// Later descriptions replace earlier ones
+		params := spec.Paths["/users"]["post"].Parameters
+		require.Len(t, params, 1)
+		assert.Equal(t, "Tenant owning the user", params[0].Description)
+	})
+}
+
+func TestWithDescriptionsFile(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "descriptions.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"user": {"email": "Email address"}}`), 0o600))
+
+	api := NewAPI(WithDescriptionsFile(file))
+	assert.Equal(t, map[string]string{"user.email": "Email address"}, api.Descriptions)
+}
+
+func TestWithDescriptions_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opt  Option
+		want string
+	}{
+		{
+			name: "missing file",
+			opt:  WithDescriptionsFile(filepath.Join(t.TempDir(), "missing.yaml")),
+			want: "invalid description catalog: failed to read description file",
+		},
+		{
+			name: "invalid YAML",
+			opt:  WithDescriptionsFS(fstest.MapFS{"d.yaml": {Data: []byte("user: [")}}, "d.yaml"),
+			want: "invalid description catalog: failed to decode description file d.yaml",
+		},
+		{
+			name: "invalid value",
+			opt:  WithDescriptionsFS(fstest.MapFS{"d.yaml": {Data: []byte("user:\n  age: 42\n")}}, "d.yaml"),
+			want: "invalid description catalog: description file d.yaml: key user.age: want a description or a mapping, got int",
+		},
+		{
+			name: "unknown key",
+			opt:  WithDescriptions(map[string]string{"user.name": "Name"}),
+			want: `failed to generate schemas: field Email: description key "user.email" is not in the description catalog`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(tt.opt).Generate(context.Background(), GET("/users", WithResponse(200, describedUser{})))
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// shadowWarnings reports the fields of the types of component schemas hidden by
+// other fields with the same property name, such as a field of an embedded
+// struct redeclared by the outer struct. Only the field encoding/json marshals is
+// documented; fields it drops as ambiguous are not documented at all.
+func (a *API) shadowWarnings(spec *model.Spec) debug.Warnings {
+	if spec.Components == nil {
+		return nil
+	}
+
+	var warns debug.Warnings
+	for _, name := range sortedNames(spec.Components.Schemas) {
+		t, ok := a.generator.TypeOf(name)
+		if !ok {
+			continue
+		}
+		for _, s := range a.generator.Shadowed(t) {
+			ptr := util.Pointer(componentsPrefix+"schemas", name, "properties", s.Property)
+			if s.By == "" {
+				warns.Append(debug.NewWarning(debug.WarnAmbiguousField, ptr,
+					fmt.Sprintf("%s.%s is left out: fields named %q at the same depth are ambiguous and dropped by encoding/json", t, s.Field, s.Property)))
+
+				continue
+			}
+			warns.Append(debug.NewWarning(debug.WarnShadowedField, ptr,
+				fmt.Sprintf("%s.%s is hidden by %s.%s, both named %q", t, s.Field, t, s.By, s.Property)))
+		}
+	}
+
+	return warns
+}
@@ -0,0 +1,182 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+type EmbeddedAudit struct {
+	CreatedAt time.Time `json:"created_at" validate:"required"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type embeddedOwner struct {
+	OwnerID string `json:"owner_id"`
+}
+
+type EmbeddedTagged struct {
+	Version int `json:"version"`
+}
+
+type embeddedDocument struct {
+	EmbeddedAudit
+	*embeddedOwner
+	EmbeddedTagged `json:"meta"`
+
+	ID        string `json:"id"`
+	UpdatedAt string `json:"updated_at"`
+}
+
+type EmbeddedRevision struct {
+	Number int
+}
+
+type EmbeddedLegacy struct {
+	ID int
+}
+
+type EmbeddedKey struct {
+	Key string `json:"ID"`
+}
+
+type embeddedConflict struct {
+	EmbeddedLegacy
+	EmbeddedKey
+}
+
+type embeddedAmbiguous struct {
+	EmbeddedRevision
+	EmbeddedRecord
+
+	Title string `json:"title"`
+}
+
+type EmbeddedRecord struct {
+	Number string
+}
+
+func TestEmbeddedStructs(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+
+		result, err := api.Generate(context.Background(), GET("/documents/:id", WithResponse(200, embeddedDocument{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Components struct {
+				Schemas map[string]struct {
+					Required   []string                  `json:"required"`
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		document := spec.Components.Schemas["EmbeddedDocument"]
+		assert.ElementsMatch(t, []string{"created_at", "updated_at", "owner_id", "meta", "id"}, slices.Collect(maps.Keys(document.Properties)))
+		assert.Equal(t, []string{"created_at"}, document.Required)
+		// The outer field is documented
+		assert.Equal(t, "string", document.Properties["updated_at"]["type"])
+		assert.NotContains(t, spec.Components.Schemas, "EmbeddedAudit")
+
+		require.Len(t, result.Warnings, 1)
+		assert.Equal(t, debug.WarnShadowedField, result.Warnings[0].Code())
+		assert.Equal(t, "#/components/schemas/EmbeddedDocument/properties/updated_at", result.Warnings[0].Path())
+		assert.Equal(t, `openapi.embeddedDocument.EmbeddedAudit.UpdatedAt is hidden by openapi.embeddedDocument.UpdatedAt, both named "updated_at"`,
+			result.Warnings[0].Message())
+	})
+}
+
+func TestEmbeddedStructs_TaggedFieldWins(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(), GET("/conflicts", WithResponse(200, embeddedConflict{})))
+	require.NoError(t, err)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	conflict := spec.Components.Schemas["EmbeddedConflict"]
+	assert.Equal(t, map[string]any{"type": "string"}, conflict.Properties["ID"])
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0].Message(), "EmbeddedLegacy.ID is hidden by openapi.embeddedConflict.EmbeddedKey.Key")
+}
+
+func TestEmbeddedStructs_Ambiguous(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(), GET("/ambiguous", WithResponse(200, embeddedAmbiguous{})))
+	require.NoError(t, err)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]map[string]any `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	assert.Equal(t, []string{"title"}, slices.Sorted(maps.Keys(spec.Components.Schemas["EmbeddedAmbiguous"].Properties)))
+	require.Len(t, result.Warnings, 2)
+	for i, field := range []string{"EmbeddedRevision.Number", "EmbeddedRecord.Number"} {
+		assert.Equal(t, debug.WarnAmbiguousField, result.Warnings[i].Code())
+		assert.Equal(t, "#/components/schemas/EmbeddedAmbiguous/properties/Number", result.Warnings[i].Path())
+		assert.Equal(t, "openapi.embeddedAmbiguous."+field+` is left out: fields named "Number" at the same depth are ambiguous and dropped by encoding/json`,
+			result.Warnings[i].Message())
+	}
+}
+
+func TestEmbeddedStructs_PromotedFieldSources(t *testing.T) {
+	type embeddedBase struct {
+		ID string `json:"identifier"`
+	}
+	type embeddedRow struct {
+		embeddedBase
+
+		Name string `json:"name"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithPropertyNaming(NamingSnakeCase), WithDebugTrace(true))
+	result, err := api.Generate(context.Background(),
+		GET("/rows", WithResponse(200, embeddedRow{}, WithCSVContent(embeddedRow{}))),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Schema map[string]any `json:"schema"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	csv := spec.Paths["/rows"]["get"].Responses["200"].Content["text/csv"]
+	assert.Equal(t, []any{"identifier", "name"}, csv.Schema["x-columns"], "columns of embedded structs are promoted")
+
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, debug.WarnPropertyNamingConflict, result.Warnings[0].Code())
+	assert.Equal(t, `openapi.embeddedRow.embeddedBase.ID is tagged "identifier", snake_case naming gives "id"`, result.Warnings[0].Message())
+
+	require.NotNil(t, result.Trace)
+	require.Len(t, result.Trace.Schemas, 1)
+	assert.Equal(t, "embeddedBase.ID", result.Trace.Schemas[0].Properties[0].Field)
+	assert.Equal(t, "openapi.embeddedRow.embeddedBase.ID", api.violationSource("/components/schemas/EmbeddedRow/properties/identifier"))
+}
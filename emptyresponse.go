@@ -0,0 +1,49 @@
+package openapi
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// emptyStatuses lists the status codes of responses that must not have a body.
+var emptyStatuses = []int{http.StatusNoContent, http.StatusResetContent}
+
+// NoContent documents a 204 No Content response, without body.
+//
+// Example:
+//
+//	openapi.DELETE("/users/:id",
+//	    openapi.NoContent(),
+//	)
+func NoContent() OperationDocOption {
+	return WithResponse(http.StatusNoContent, nil)
+}
+
+// stripEmptyResponseBodies removes the content of responses that must not have a
+// body, such as 204 No Content, and reports it with an EMPTY_RESPONSE_BODY warning.
+// Headers of the response struct are kept.
+func stripEmptyResponseBodies(spec *model.Spec) debug.Warnings {
+	var warns debug.Warnings
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		for _, status := range emptyStatuses {
+			code := strconv.Itoa(status)
+			resp := ref.Operation.Responses[code]
+			if resp == nil || len(resp.Content) == 0 {
+				continue
+			}
+			resp.Content = nil
+			warns.Append(debug.NewWarning(
+				debug.WarnEmptyResponseBody,
+				util.Pointer("#/paths", ref.Path, strings.ToLower(ref.Method), "responses", code, "content"),
+				"response "+code+" must not have a body; its content was removed",
+			))
+		}
+	}
+
+	return warns
+}
@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+type emptyResetResponse struct {
+	ETag string   `header:"ETag"`
+	Body struct{} `body:"structured"`
+}
+
+type emptyUser struct {
+	ID int `json:"id"`
+}
+
+func TestEmptyResponseBodies(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+		result, err := api.Generate(context.Background(),
+			DELETE("/users/:id", NoContent()),
+			PUT("/users/:id", WithResponse(204, emptyUser{})),
+			POST("/forms", WithResponse(205, emptyResetResponse{})),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Responses map[string]struct {
+					Description string         `json:"description"`
+					Headers     map[string]any `json:"headers"`
+					Content     map[string]any `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		deleted := spec.Paths["/users/{id}"]["delete"].Responses["204"]
+		assert.Equal(t, "No Content", deleted.Description)
+		assert.Nil(t, deleted.Content)
+		assert.Nil(t, spec.Paths["/users/{id}"]["put"].Responses["204"].Content)
+		reset := spec.Paths["/forms"]["post"].Responses["205"]
+		assert.Nil(t, reset.Content)
+		assert.Contains(t, reset.Headers, "ETag")
+
+		var pointers []string
+		for _, w := range result.Warnings {
+			if w.Code() == debug.WarnEmptyResponseBody {
+				pointers = append(pointers, w.Path())
+			}
+		}
+		assert.ElementsMatch(t, []string{
+			"#/paths/~1users~1{id}/put/responses/204/content",
+			"#/paths/~1forms/post/responses/205/content",
+		}, pointers)
+	})
+}
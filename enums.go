@@ -0,0 +1,182 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// WithStrictEnums causes Generate to error (instead of warn) when the values of
+// a schema contradict its enum: a default or an example that is not one of the
+// enum values (or not the const value), or a schema with both const and enum.
+//
+// Default: false (debug.WarnEnumMismatch warnings)
+//
+// Example:
+//
+//	openapi.WithStrictEnums(true)
+func WithStrictEnums(strict bool) Option {
+	return func(a *API) {
+		a.StrictEnums = strict
+	}
+}
+
+// enumIssue is a value of a schema contradicting its enum.
+type enumIssue struct {
+	ptr string
+	msg string
+}
+
+// checkEnums checks the enums of the component schemas and of the inline schemas
+// of parameters, request bodies and responses. Issues are returned as warnings,
+// or as an error with StrictEnums.
+func (a *API) checkEnums(spec *model.Spec) (debug.Warnings, error) {
+	var issues []enumIssue
+	if spec.Components != nil {
+		for _, name := range sortedNames(spec.Components.Schemas) {
+			issues = enumIssues(issues, spec.Components.Schemas[name], util.Pointer(componentsPrefix+"schemas", name))
+		}
+	}
+
+	content := func(base string, content map[string]*model.MediaType) {
+		for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+			if mt := content[mediaType]; mt != nil {
+				issues = enumIssues(issues, mt.Schema, util.Pointer(base, "content", mediaType, "schema"))
+			}
+		}
+	}
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		base := util.Pointer("#/paths", ref.Path, strings.ToLower(ref.Method))
+		op := ref.Operation
+		for i := range op.Parameters {
+			issues = enumIssues(issues, op.Parameters[i].Schema, util.Pointer(base, "parameters", strconv.Itoa(i), "schema"))
+		}
+		if op.RequestBody != nil {
+			content(base+"/requestBody", op.RequestBody.Content)
+		}
+		for _, status := range slices.Sorted(maps.Keys(op.Responses)) {
+			if resp := op.Responses[status]; resp != nil {
+				content(util.Pointer(base, "responses", status), resp.Content)
+			}
+		}
+	}
+
+	if a.StrictEnums {
+		errs := make([]error, 0, len(issues))
+		for _, issue := range issues {
+			errs = append(errs, a.schemaError(issue.ptr, issue.msg))
+		}
+
+		return nil, errors.Join(errs...)
+	}
+
+	var warns debug.Warnings
+	for _, issue := range issues {
+		msg := issue.msg
+		if source := a.violationSource(issue.ptr[1:]); source != "" {
+			msg = source + ": " + msg
+		}
+		warns.Append(debug.NewWarning(debug.WarnEnumMismatch, issue.ptr, msg))
+	}
+
+	return warns, nil
+}
+
+// enumIssues appends the issues of a schema located at ptr and of its inline subschemas.
+func enumIssues(issues []enumIssue, s *model.Schema, ptr string) []enumIssue {
+	if s == nil || s.Ref != "" {
+		return issues
+	}
+
+	if s.Const != nil && len(s.Enum) > 0 {
+		issues = append(issues, enumIssue{ptr, "const and enum are both set; keep one of them"})
+	}
+	if allowed := allowedValues(s); allowed != nil {
+		if s.Default != nil && !containsValue(allowed, s.Default) {
+			issues = append(issues, enumIssue{ptr, fmt.Sprintf("default %v is not one of %v", s.Default, allowed)})
+		}
+		examples := s.Examples
+		if s.Example != nil {
+			examples = append([]any{s.Example}, examples...)
+		}
+		for _, ex := range examples {
+			if !containsValue(allowed, ex) {
+				issues = append(issues, enumIssue{ptr, fmt.Sprintf("example %v is not one of %v", ex, allowed)})
+			}
+		}
+	}
+	// Defaults of arrays hold items of the enum of their items
+	if items, ok := s.Default.([]any); ok && s.Items != nil {
+		if allowed := allowedValues(s.Items); allowed != nil {
+			for _, item := range items {
+				if !containsValue(allowed, item) {
+					issues = append(issues, enumIssue{ptr, fmt.Sprintf("default item %v is not one of %v", item, allowed)})
+				}
+			}
+		}
+	}
+
+	for _, name := range sortedNames(s.Properties) {
+		issues = enumIssues(issues, s.Properties[name], util.Pointer(ptr, "properties", name))
+	}
+	issues = enumIssues(issues, s.Items, util.Pointer(ptr, "items"))
+	if s.Additional != nil {
+		issues = enumIssues(issues, s.Additional.Schema, util.Pointer(ptr, "additionalProperties"))
+	}
+	for _, c := range []struct {
+		keyword string
+		group   []*model.Schema
+	}{{"prefixItems", s.PrefixItems}, {"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}} {
+		for i, sub := range c.group {
+			issues = enumIssues(issues, sub, util.Pointer(ptr, c.keyword, strconv.Itoa(i)))
+		}
+	}
+
+	return issues
+}
+
+// allowedValues returns the values a schema is restricted to by enum or const,
+// or nil if it is not restricted.
+func allowedValues(s *model.Schema) []any {
+	if len(s.Enum) > 0 {
+		return s.Enum
+	}
+	if s.Const != nil {
+		return []any{s.Const}
+	}
+
+	return nil
+}
+
+// containsValue reports whether values contain v. Scalars are compared by their
+// text, as enums parsed from tags hold strings whatever the type of the field.
+func containsValue(values []any, v any) bool {
+	for _, allowed := range values {
+		if reflect.DeepEqual(allowed, v) || isScalar(allowed) && isScalar(v) && fmt.Sprint(allowed) == fmt.Sprint(v) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isScalar reports whether v is a JSON string, number or boolean.
+func isScalar(v any) bool {
+	switch reflect.ValueOf(v).Kind() {
+	case reflect.String, reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,87 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+type enumFilter struct {
+	Sort   string `json:"sort" validate:"oneof=asc desc" default:"up"`
+	Limit  int    `json:"limit" validate:"oneof=10 50 100" default:"50"`
+	Status string `json:"status" validate:"oneof=open closed" openapi:"examples=open|pending"`
+}
+
+type enumSearch struct {
+	Body enumFilter `body:"structured"`
+}
+
+type enumLevel struct {
+	Level string `json:"level"`
+}
+
+// TransformSchema restricts the schema with both const and enum.
+func (enumLevel) TransformSchema(_ hook.SchemaRegistry, s *model.Schema) *model.Schema {
+	s.Const = map[string]any{"level": "high"}
+	s.Enum = []any{map[string]any{"level": "low"}, map[string]any{"level": "high"}}
+
+	return s
+}
+
+func TestGenerate_EnumWarnings(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		result, err := NewAPI(WithVersion(version)).Generate(context.Background(),
+			POST("/search", WithRequest(enumSearch{}), WithResponse(200, enumLevel{})),
+		)
+		require.NoError(t, err)
+
+		var messages []string
+		for _, w := range result.Warnings {
+			if w.Code() == debug.WarnEnumMismatch {
+				messages = append(messages, w.Path()+" "+w.Message())
+			}
+		}
+		assert.ElementsMatch(t, []string{
+			"#/components/schemas/EnumLevel openapi.enumLevel: const and enum are both set; keep one of them",
+			"#/components/schemas/EnumFilter/properties/sort openapi.enumFilter.Sort: default up is not one of [asc desc]",
+			"#/components/schemas/EnumFilter/properties/status openapi.enumFilter.Status: example pending is not one of [open closed]",
+		}, messages)
+	})
+}
+
+func TestGenerate_StrictEnums(t *testing.T) {
+	_, err := NewAPI(WithVersion("3.1.2"), WithStrictEnums(true)).Generate(context.Background(),
+		POST("/search", WithRequest(enumSearch{}), WithResponse(200, enumLevel{})),
+	)
+	require.ErrorContains(t, err, "inconsistent enums: ")
+	assert.ErrorContains(t, err, "openapi.enumFilter.Sort (#/components/schemas/EnumFilter/properties/sort): default up is not one of [asc desc]")
+	assert.ErrorContains(t, err, "const and enum are both set")
+}
+
+func TestGenerate_EnumsConsistent(t *testing.T) {
+	type filter struct {
+		Sort  string `json:"sort" validate:"oneof=asc desc" default:"asc"`
+		Limit int    `json:"limit" validate:"oneof=10 50 100" default:"50" openapi:"examples=10|100"`
+		Kind  string `json:"kind" validate:"oneof=book" default:"book"`
+	}
+	type search struct {
+		Body filter `body:"structured"`
+	}
+	_, err := NewAPI(WithVersion("3.1.2"), WithStrictEnums(true)).Generate(context.Background(),
+		POST("/search", WithRequest(search{}), WithResponse(200, filter{})),
+	)
+	require.NoError(t, err)
+}
+
+func TestContainsValue(t *testing.T) {
+	assert.True(t, containsValue([]any{"10", "50"}, float64(50)))
+	assert.True(t, containsValue([]any{map[string]any{"a": 1}}, map[string]any{"a": 1}))
+	assert.False(t, containsValue([]any{"asc", "desc"}, "up"))
+	assert.False(t, containsValue([]any{"[a]"}, []any{"a"}))
+}
@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/envelope"
+	"github.com/talav/openapi/internal/model"
+)
+
+// EnvelopeStyle is a convention wrapping response bodies, selected with WithResponseEnvelope.
+type EnvelopeStyle string
+
+// Response envelope styles.
+const (
+	// EnvelopeJSONAPI wraps bodies in JSON:API documents: objects become the
+	// attributes of the data resource, arrays the attributes of data resources.
+	EnvelopeJSONAPI EnvelopeStyle = "jsonapi"
+	// EnvelopeHAL adds _links and _embedded members to objects, and embeds arrays
+	// under _embedded.items.
+	EnvelopeHAL EnvelopeStyle = "hal"
+)
+
+// envelopes are the schema wrappers and content types of the envelope styles.
+var envelopes = map[EnvelopeStyle]struct {
+	wrap        func(body, resolved *model.Schema) *model.Schema
+	contentType string
+}{
+	EnvelopeJSONAPI: {envelope.JSONAPI, envelope.JSONAPIContentType},
+	EnvelopeHAL:     {envelope.HAL, envelope.HALContentType},
+}
+
+// WithResponseEnvelope wraps the JSON bodies of successful responses in the
+// structures of a convention, for APIs standardizing on JSON:API or HAL, so that
+// handlers' Go types need not declare the envelope. The bodies are documented
+// under the media type of the convention instead of application/json.
+//
+// Error responses and other media types are left as is, and so are examples:
+// they must show enveloped bodies. Generate fails for unknown styles.
+//
+// Default: no envelope
+//
+// Example:
+//
+//	openapi.WithResponseEnvelope(openapi.EnvelopeJSONAPI)
+func WithResponseEnvelope(style EnvelopeStyle) Option {
+	return func(a *API) {
+		a.ResponseEnvelope = style
+	}
+}
+
+// applyResponseEnvelope wraps the application/json bodies of the 2xx responses of
+// the spec in the envelope style of the API. Bodies referencing component schemas
+// are enveloped by the shape of the referenced schema, so that named slice types
+// are enveloped as arrays.
+func (a *API) applyResponseEnvelope(spec *model.Spec) error {
+	if a.ResponseEnvelope == "" {
+		return nil
+	}
+	style, ok := envelopes[a.ResponseEnvelope]
+	if !ok {
+		return fmt.Errorf("unknown response envelope style %q (valid: %s, %s)", a.ResponseEnvelope, EnvelopeJSONAPI, EnvelopeHAL)
+	}
+
+	schemas := a.generator.Schemas()
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		for status, resp := range ref.Operation.Responses {
+			if resp == nil || !strings.HasPrefix(status, "2") {
+				continue
+			}
+			content, ok := resp.Content["application/json"]
+			if !ok || content == nil || content.Schema == nil {
+				continue
+			}
+			// Media types are built per operation: no copy needed
+			content.Schema = style.wrap(content.Schema, a.resolveSchemaRef(schemas, content.Schema))
+			delete(resp.Content, "application/json")
+			resp.Content[style.contentType] = content
+		}
+	}
+
+	return nil
+}
+
+// resolveSchemaRef follows the references of s to the generated component schemas
+// and the raw schemas, returning the first schema that is not a reference to them.
+func (a *API) resolveSchemaRef(schemas map[string]*model.Schema, s *model.Schema) *model.Schema {
+	seen := make(map[string]bool)
+	for {
+		name, ok := strings.CutPrefix(s.Ref, a.SchemaPrefix)
+		if !ok || seen[name] {
+			return s
+		}
+		seen[name] = true
+		target := schemas[name]
+		if target == nil {
+			raw, err := build.ParseSchema(a.rawSchemas[name])
+			if err != nil || raw == nil {
+				return s
+			}
+			target = raw
+		}
+		s = target
+	}
+}
@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+type envelopeUser struct {
+	ID string `json:"id"`
+}
+
+type envelopeUsers struct {
+	Body []envelopeUser `body:"structured"`
+}
+
+type envelopeUserList []envelopeUser
+
+// envelopeUserPage documents its body with a reference to the component schema of
+// a named slice type.
+type envelopeUserPage struct{}
+
+func (envelopeUserPage) SchemaWithContext(ctx hook.SchemaContext) *model.Schema {
+	return ctx.RefFor(reflect.TypeFor[envelopeUserList](), "")
+}
+
+// envelopeContent returns the schemas of the response contents of an operation by status.
+func envelopeContent(t *testing.T, result *Result, path string) map[string]map[string]json.RawMessage {
+	t.Helper()
+
+	var spec struct {
+		Paths map[string]struct {
+			Get struct {
+				Responses map[string]struct {
+					Content map[string]struct {
+						Schema json.RawMessage `json:"schema"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"get"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	contents := make(map[string]map[string]json.RawMessage)
+	for status, resp := range spec.Paths[path].Get.Responses {
+		contents[status] = make(map[string]json.RawMessage)
+		for contentType, mediaType := range resp.Content {
+			contents[status][contentType] = mediaType.Schema
+		}
+	}
+
+	return contents
+}
+
+func TestWithResponseEnvelope(t *testing.T) {
+	ops := []Operation{
+		GET("/users/:id", WithResponse(200, envelopeUser{}), WithProblemResponses(404)),
+		GET("/users", WithResponse(200, envelopeUsers{})),
+		GET("/user-list", WithResponse(200, envelopeUserPage{})),
+	}
+
+	t.Run("JSON:API", func(t *testing.T) {
+		result, err := NewAPI(WithVersion("3.1.2"), WithValidation(true), WithResponseEnvelope(EnvelopeJSONAPI)).
+			Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		user := envelopeContent(t, result, "/users/{id}")
+		require.Contains(t, user["200"], "application/vnd.api+json")
+		assert.NotContains(t, user["200"], "application/json")
+		assert.Contains(t, user["404"], ProblemContentType)
+
+		var document struct {
+			Required   []string `json:"required"`
+			Properties struct {
+				Data struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				} `json:"data"`
+			} `json:"properties"`
+		}
+		require.NoError(t, json.Unmarshal(user["200"]["application/vnd.api+json"], &document))
+		assert.Equal(t, []string{"data"}, document.Required)
+		assert.JSONEq(t, `{"$ref": "#/components/schemas/EnvelopeUser"}`, string(document.Properties.Data.Properties["attributes"]))
+
+		type collectionSchema struct {
+			Properties struct {
+				Data struct {
+					Type  string `json:"type"`
+					Items struct {
+						Properties map[string]json.RawMessage `json:"properties"`
+					} `json:"items"`
+				} `json:"data"`
+			} `json:"properties"`
+		}
+		var collection collectionSchema
+		users := envelopeContent(t, result, "/users")
+		require.NoError(t, json.Unmarshal(users["200"]["application/vnd.api+json"], &collection))
+		assert.Equal(t, "array", collection.Properties.Data.Type)
+		assert.JSONEq(t, `{"$ref": "#/components/schemas/EnvelopeUser"}`, string(collection.Properties.Data.Items.Properties["attributes"]))
+
+		var list collectionSchema
+		require.NoError(t, json.Unmarshal(envelopeContent(t, result, "/user-list")["200"]["application/vnd.api+json"], &list))
+		assert.Equal(t, "array", list.Properties.Data.Type)
+		assert.JSONEq(t, `{"$ref": "#/components/schemas/EnvelopeUser"}`, string(list.Properties.Data.Items.Properties["attributes"]))
+	})
+
+	t.Run("HAL", func(t *testing.T) {
+		result, err := NewAPI(WithVersion("3.0.4"), WithValidation(true), WithResponseEnvelope(EnvelopeHAL)).
+			Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		var resource struct {
+			AllOf []struct {
+				Ref        string                     `json:"$ref"`
+				Required   []string                   `json:"required"`
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"allOf"`
+		}
+		user := envelopeContent(t, result, "/users/{id}")
+		require.NoError(t, json.Unmarshal(user["200"]["application/hal+json"], &resource))
+		require.Len(t, resource.AllOf, 2)
+		assert.Equal(t, "#/components/schemas/EnvelopeUser", resource.AllOf[0].Ref)
+		assert.Equal(t, []string{"_links"}, resource.AllOf[1].Required)
+		assert.Contains(t, resource.AllOf[1].Properties, "_embedded")
+
+		type collectionSchema struct {
+			Properties struct {
+				Embedded struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				} `json:"_embedded"`
+			} `json:"properties"`
+		}
+		var collection collectionSchema
+		users := envelopeContent(t, result, "/users")
+		require.NoError(t, json.Unmarshal(users["200"]["application/hal+json"], &collection))
+		assert.JSONEq(t, `{"type": "array", "items": {"$ref": "#/components/schemas/EnvelopeUser"}}`,
+			string(collection.Properties.Embedded.Properties["items"]))
+
+		var list collectionSchema
+		require.NoError(t, json.Unmarshal(envelopeContent(t, result, "/user-list")["200"]["application/hal+json"], &list))
+		assert.JSONEq(t, `{"$ref": "#/components/schemas/EnvelopeUserList"}`, string(list.Properties.Embedded.Properties["items"]))
+	})
+
+	t.Run("unknown style", func(t *testing.T) {
+		_, err := NewAPI(WithVersion("3.1.2"), WithResponseEnvelope("odata")).Generate(context.Background(), ops...)
+		require.ErrorContains(t, err, `unknown response envelope style "odata"`)
+	})
+}
@@ -0,0 +1,72 @@
+package openapi
+
+import "slices"
+
+// envOptions are options applied in some environments only.
+type envOptions struct {
+	envs []string
+	opts []Option
+}
+
+// WithEnvironment selects the environment the specification is generated for,
+// which enables the options registered for it with ForEnv.
+//
+// Default: "" (no environment-specific options)
+//
+// Example:
+//
+//	openapi.WithEnvironment(os.Getenv("APP_ENV"))
+func WithEnvironment(env string) Option {
+	return func(a *API) {
+		a.Environment = env
+	}
+}
+
+// ForEnv registers options applied only when the environment selected with
+// WithEnvironment is env. The options are applied after all the other options,
+// in the order of registration, so WithEnvironment may be given anywhere.
+// Use it for what differs between environments, such as servers, security
+// schemes and extensions, to emit the spec of each environment from one definition.
+//
+// Example:
+//
+//	api := openapi.NewAPI(
+//	    openapi.WithEnvironment(env),
+//	    openapi.ForEnv("staging",
+//	        openapi.WithServer("https://staging.example.com"),
+//	        openapi.WithExtension("x-environment", "staging"),
+//	    ),
+//	    openapi.ForEnv("production",
+//	        openapi.WithServer("https://api.example.com"),
+//	        openapi.WithBearerAuth("bearer", "JWT issued by the identity provider"),
+//	        openapi.WithDefaultSecurity("bearer"),
+//	    ),
+//	)
+func ForEnv(env string, opts ...Option) Option {
+	return ForEnvs([]string{env}, opts...)
+}
+
+// ForEnvs registers options applied only when the environment selected with
+// WithEnvironment is one of envs. See ForEnv.
+//
+// Example:
+//
+//	openapi.ForEnvs([]string{"dev", "staging"}, openapi.WithExtension("x-internal-docs", true))
+func ForEnvs(envs []string, opts ...Option) Option {
+	return func(a *API) {
+		a.envOptions = append(a.envOptions, envOptions{envs: envs, opts: opts})
+	}
+}
+
+// applyEnvOptions applies the options registered for the selected environment.
+// Options registered while applying them, by nested ForEnv, are applied too.
+func (a *API) applyEnvOptions() {
+	for i := 0; i < len(a.envOptions); i++ {
+		if !slices.Contains(a.envOptions[i].envs, a.Environment) {
+			continue
+		}
+		for _, opt := range a.envOptions[i].opts {
+			opt(a)
+		}
+	}
+}
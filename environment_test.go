@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestForEnv(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	newAPI := func(env string) *API {
+		return NewAPI(
+			WithInfoTitle("Test"), WithInfoVersion("1.0.0"), WithVersion("3.1.2"),
+			ForEnv("staging",
+				WithServer("https://staging.example.com"),
+				WithExtension("x-environment", "staging"),
+			),
+			ForEnv("production",
+				WithServer("https://api.example.com"),
+				WithBearerAuth("bearer", "JWT"),
+				WithDefaultSecurity("bearer"),
+			),
+			ForEnvs([]string{"dev", "staging"}, WithExtension("x-internal-docs", true)),
+			WithEnvironment(env),
+		)
+	}
+
+	type spec struct {
+		Servers []struct {
+			URL string `json:"url"`
+		} `json:"servers"`
+		Security     []map[string][]string `json:"security"`
+		Environment  string                `json:"x-environment"`
+		InternalDocs bool                  `json:"x-internal-docs"`
+	}
+
+	generate := func(t *testing.T, env string) spec {
+		t.Helper()
+		result, err := newAPI(env).Generate(context.Background(), GET("/users", WithResponse(200, user{})))
+		require.NoError(t, err)
+
+		var s spec
+		require.NoError(t, json.Unmarshal(result.JSON, &s))
+
+		return s
+	}
+
+	staging := generate(t, "staging")
+	require.Len(t, staging.Servers, 1)
+	assert.Equal(t, "https://staging.example.com", staging.Servers[0].URL)
+	assert.Equal(t, "staging", staging.Environment)
+	assert.True(t, staging.InternalDocs)
+	assert.Empty(t, staging.Security)
+
+	production := generate(t, "production")
+	require.Len(t, production.Servers, 1)
+	assert.Equal(t, "https://api.example.com", production.Servers[0].URL)
+	assert.Equal(t, []map[string][]string{{"bearer": {}}}, production.Security)
+	assert.Empty(t, production.Environment)
+	assert.False(t, production.InternalDocs)
+
+	none := generate(t, "")
+	assert.Empty(t, none.Servers)
+	assert.Empty(t, none.Security)
+	assert.False(t, none.InternalDocs)
+}
+
+func TestForEnv_Nested(t *testing.T) {
+	api := NewAPI(
+		WithEnvironment("staging"),
+		ForEnv("staging", WithInfoTitle("Staging"), ForEnv("staging", WithInfoVersion("2.0.0-rc"))),
+	)
+
+	assert.Equal(t, "Staging", api.Info.Title)
+	assert.Equal(t, "2.0.0-rc", api.Info.Version)
+}
@@ -0,0 +1,108 @@
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+)
+
+// errorMapping is an error type mapped to a response with MapError.
+type errorMapping struct {
+	errType reflect.Type
+	status  int
+	body    reflect.Type
+}
+
+// MapError maps the error type E to a response, documented for the operations
+// declaring with WithError that they can return it. body is the response type,
+// as given to WithResponse, or nil for a response without body. E may be an
+// interface: error types implementing it use its response unless mapped themselves,
+// and the first registered interface wins.
+//
+// Mapping an error type twice or an invalid status code fails Generate. The registry
+// is meant to be shared with the layer binding handlers, which knows the errors
+// they return.
+//
+// Example:
+//
+//	api := openapi.NewAPI(
+//	    openapi.MapError[*NotFoundError](404, ErrorBody{}),
+//	    openapi.MapError[*ValidationError](422, openapi.ProblemDetails{}),
+//	)
+//
+//	openapi.GET("/users/:id",
+//	    openapi.WithResponse(200, User{}),
+//	    openapi.WithError[*NotFoundError](),
+//	)
+func MapError[E error, S Status](code S, body any) Option {
+	return func(a *API) {
+		errType := reflect.TypeFor[E]()
+		status, err := parseStatus(code)
+		switch {
+		case err != nil:
+			a.errorMapErrs = append(a.errorMapErrs, fmt.Errorf("error %s: %w", errType, err))
+
+			return
+		case slices.ContainsFunc(a.errorMappings, func(m errorMapping) bool { return m.errType == errType }):
+			a.errorMapErrs = append(a.errorMapErrs, fmt.Errorf("error %s is mapped twice", errType))
+
+			return
+		}
+		a.errorMappings = append(a.errorMappings, errorMapping{errType: errType, status: status, body: reflect.TypeOf(body)})
+	}
+}
+
+// WithError declares that the operation can return errors of type E, documented
+// with the response mapped to E by MapError. Responses declared with WithResponse
+// take precedence, as does the first error declared for a status code.
+//
+// Example:
+//
+//	openapi.DELETE("/users/:id",
+//	    openapi.NoContent(),
+//	    openapi.WithError[*NotFoundError](),
+//	    openapi.WithError[*ConflictError](),
+//	)
+func WithError[E error]() OperationDocOption {
+	return func(d *operationDoc) {
+		d.ErrorTypes = append(d.ErrorTypes, reflect.TypeFor[E]())
+	}
+}
+
+// withErrorResponses returns the response types with the responses mapped to the
+// error types, for status codes without declared response.
+func (a *API) withErrorResponses(responses map[int]reflect.Type, errTypes []reflect.Type) (map[int]reflect.Type, error) {
+	if len(errTypes) == 0 {
+		return responses, nil
+	}
+
+	result := maps.Clone(responses)
+	for _, t := range errTypes {
+		m, ok := a.errorMapping(t)
+		if !ok {
+			return nil, fmt.Errorf("error %s is not mapped to a response; register it with MapError", t)
+		}
+		if _, exists := result[m.status]; exists {
+			continue
+		}
+		result[m.status] = m.body
+	}
+
+	return result, nil
+}
+
+// errorMapping returns the mapping of an error type: its own, or the first of an
+// interface it implements.
+func (a *API) errorMapping(t reflect.Type) (errorMapping, bool) {
+	if i := slices.IndexFunc(a.errorMappings, func(m errorMapping) bool { return m.errType == t }); i >= 0 {
+		return a.errorMappings[i], true
+	}
+	for _, m := range a.errorMappings {
+		if m.errType.Kind() == reflect.Interface && t.Implements(m.errType) {
+			return m, true
+		}
+	}
+
+	return errorMapping{}, false
+}
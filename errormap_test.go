@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapNotFoundError struct{}
+
+func (mapNotFoundError) Error() string { return "not found" }
+
+type mapConflictError struct{}
+
+func (*mapConflictError) Error() string { return "conflict" }
+
+type mapTemporary interface {
+	error
+	Temporary() bool
+}
+
+type mapUnavailableError struct{}
+
+func (mapUnavailableError) Error() string   { return "unavailable" }
+func (mapUnavailableError) Temporary() bool { return true }
+
+type mapErrorBody struct {
+	Message string `json:"message"`
+}
+
+func TestMapError(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithVersion(version),
+			MapError[mapNotFoundError](404, mapErrorBody{}),
+			MapError[*mapConflictError]("409", nil),
+			MapError[mapTemporary]("5XX", mapErrorBody{}),
+		)
+		result, err := api.Generate(context.Background(),
+			GET("/users/:id",
+				WithResponse(200, mapErrorBody{}),
+				WithError[mapNotFoundError](),
+				WithError[mapUnavailableError](),
+			),
+			DELETE("/users/:id",
+				NoContent(),
+				WithResponse(404, nil),
+				WithError[mapNotFoundError](),
+				WithError[*mapConflictError](),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Responses map[string]struct {
+					Description string         `json:"description"`
+					Content     map[string]any `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		get := spec.Paths["/users/{id}"]["get"].Responses
+		require.Len(t, get, 3)
+		assert.Contains(t, get["404"].Content, "application/json")
+		assert.Equal(t, "Server error", get["5XX"].Description)
+		assert.Contains(t, get["5XX"].Content, "application/json")
+
+		del := spec.Paths["/users/{id}"]["delete"].Responses
+		require.Len(t, del, 3)
+		// The declared 404 response takes precedence
+		assert.Empty(t, del["404"].Content)
+		assert.Equal(t, "Conflict", del["409"].Description)
+		assert.Empty(t, del["409"].Content)
+	})
+}
+
+func TestMapError_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts []Option
+		op   Operation
+		want string
+	}{
+		{
+			name: "not mapped",
+			op:   GET("/users", WithError[mapNotFoundError]()),
+			want: "error openapi.mapNotFoundError is not mapped to a response; register it with MapError",
+		},
+		{
+			name: "mapped twice",
+			opts: []Option{MapError[mapNotFoundError](404, nil), MapError[mapNotFoundError](410, nil)},
+			op:   GET("/users"),
+			want: "invalid error mappings: error openapi.mapNotFoundError is mapped twice",
+		},
+		{
+			name: "invalid status",
+			opts: []Option{MapError[mapNotFoundError]("4xy", nil)},
+			op:   GET("/users"),
+			want: `invalid error mappings: error openapi.mapNotFoundError: response "4xy": invalid status code or range`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(append([]Option{WithVersion("3.1.2")}, tt.opts...)...)
+			_, err := api.Generate(context.Background(), tt.op)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
@@ -18,8 +18,27 @@
 //
 //	// Reference an external example file
 //	example.NewExternal("full-dataset", "https://example.com/data/full.json")
+//
+//	// Embed the content of a local file, loaded at generation time
+//	example.FromFile("large-order", "testdata/order.json")
 package example
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/talav/openapi/internal/sealed"
+)
+
 // Example represents an OpenAPI Example Object.
 // https://spec.openapis.org/oas/v3.1.0#example-object
 //
@@ -40,6 +59,13 @@ type Example struct {
 
 	// A URI that points to the literal example. This provides the capability to reference examples that cannot easily be included in JSON or YAML documents. The value field and externalValue field are mutually exclusive.
 	externalValue string
+
+	// File the value is loaded from, if any, in fsys or the OS file system if fsys is nil.
+	file string
+	fsys fs.FS
+
+	// ref marks a reference to the reusable example registered under name.
+	ref bool
 }
 
 // Option configures an Example using the functional options pattern.
@@ -92,6 +118,61 @@ func NewExternal(name, url string, opts ...Option) Example {
 	return example
 }
 
+// FromFile creates an example whose value is the content of a file, loaded when
+// the specification is generated, so that large realistic examples do not bloat
+// Go source. Paths are relative to the working directory.
+//
+// Files with the .json extension are decoded as JSON, those with the .yaml or .yml
+// extension as YAML; the content of other files is used as a string, for media
+// types such as text/csv or application/xml. The value is checked against the
+// schema of the media type, and generation fails when it does not conform.
+//
+// Examples:
+//
+//	example.FromFile("large-order", "testdata/examples/order.json")
+//	example.FromFile("report", "testdata/examples/report.csv", example.WithSummary("Monthly report"))
+func FromFile(name, file string, opts ...Option) Example {
+	example := Example{
+		name: name,
+		file: file,
+	}
+	for _, opt := range opts {
+		opt(&example)
+	}
+
+	return example
+}
+
+// FromFS creates an example whose value is the content of a file of fsys, such
+// as an embed.FS. See FromFile.
+//
+// Example:
+//
+//	//go:embed examples
+//	var examples embed.FS
+//
+//	example.FromFS("large-order", examples, "examples/order.yaml")
+func FromFS(name string, fsys fs.FS, file string, opts ...Option) Example {
+	example := FromFile(name, file, opts...)
+	example.fsys = fsys
+
+	return example
+}
+
+// Ref creates an example referencing the reusable example registered under name
+// with API.RegisterExample. The name also serves as the key in the examples map.
+//
+// Example:
+//
+//	api.RegisterExample(example.New("alice", User{ID: 1, Name: "Alice"}))
+//
+//	openapi.GET("/users/:id",
+//	    openapi.WithResponse(200, User{}, example.Ref("alice")),
+//	)
+func Ref(name string) Example {
+	return Example{name: name, ref: true}
+}
+
 // WithSummary adds a short description to the example.
 // This typically appears as a title in documentation tools like Swagger UI.
 func WithSummary(summary string) Option {
@@ -111,9 +192,55 @@ func WithDescription(description string) Option {
 // Name returns the example's unique identifier.
 func (example Example) Name() string { return example.name }
 
-// Value returns the inline example value, or nil for external examples.
+// Value returns the inline example value, or nil for external and file examples.
 func (example Example) Value() any { return example.value }
 
+// File returns the file the value is loaded from, or empty string for other examples.
+func (example Example) File() string { return example.file }
+
+// IsFile reports whether the value of this example is loaded from a file.
+func (example Example) IsFile() bool { return example.file != "" }
+
+// Load returns the example value: the inline value, or the decoded content of the
+// file of FromFile and FromFS. It returns nil for external examples.
+func (example Example) Load() (any, error) {
+	if example.file == "" {
+		return example.value, nil
+	}
+
+	var data []byte
+	var err error
+	if example.fsys != nil {
+		data, err = fs.ReadFile(example.fsys, example.file)
+	} else {
+		data, err = os.ReadFile(example.file)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read example file: %w", err)
+	}
+
+	var value any
+	switch strings.ToLower(path.Ext(example.file)) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.UseNumber()
+		if err := dec.Decode(&value); err != nil {
+			return nil, fmt.Errorf("failed to decode example file %s: %w", example.file, err)
+		}
+		if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("failed to decode example file %s: unexpected data after the value", example.file)
+		}
+	case ".yaml", ".yml":
+		if err := yaml.Unmarshal(data, &value); err != nil {
+			return nil, fmt.Errorf("failed to decode example file %s: %w", example.file, err)
+		}
+	default:
+		value = string(data)
+	}
+
+	return value, nil
+}
+
 // ExternalValue returns the external URL, or empty string for inline examples.
 func (example Example) ExternalValue() string { return example.externalValue }
 
@@ -125,3 +252,10 @@ func (example Example) Description() string { return example.description }
 
 // IsExternal reports whether this example references an external URL.
 func (example Example) IsExternal() bool { return example.externalValue != "" }
+
+// IsRef reports whether this example references a registered example.
+func (example Example) IsRef() bool { return example.ref }
+
+// ResponseOption makes examples options of openapi.WithResponse. It cannot be
+// called outside the module.
+func (example Example) ResponseOption(sealed.ResponseOption) {}
@@ -1,9 +1,14 @@
 package example
 
 import (
+	"encoding/json"
+	"os"
+	"path/filepath"
 	"testing"
+	"testing/fstest"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNew_InlineExample(t *testing.T) {
@@ -58,6 +63,16 @@ func TestNewExternal_WithOptions(t *testing.T) {
 	assert.True(t, ex.IsExternal())
 }
 
+func TestRef(t *testing.T) {
+	ex := Ref("alice")
+
+	assert.Equal(t, "alice", ex.Name())
+	assert.Nil(t, ex.Value())
+	assert.True(t, ex.IsRef())
+	assert.False(t, ex.IsExternal())
+	assert.False(t, New("inline", 1).IsRef())
+}
+
 func TestIsExternal_WithInlineValue(t *testing.T) {
 	ex := New("inline", map[string]any{"test": "value"})
 	assert.False(t, ex.IsExternal())
@@ -156,3 +171,58 @@ func TestExampleValue_DifferentTypes(t *testing.T) {
 		})
 	}
 }
+
+func TestFromFS_Load(t *testing.T) {
+	fsys := fstest.MapFS{
+		"order.json":  {Data: []byte(`{"id": 12345678901234567, "items": [{"sku": "A-1"}]}`)},
+		"order.yaml":  {Data: []byte("id: 7\nitems:\n  - sku: A-1\n")},
+		"report.csv":  {Data: []byte("id,total\n1,9.99\n")},
+		"broken.json": {Data: []byte(`{"id": `)},
+		"twice.json":  {Data: []byte(`{} {}`)},
+	}
+
+	tests := []struct {
+		file string
+		want any
+	}{
+		{"order.json", map[string]any{"id": json.Number("12345678901234567"), "items": []any{map[string]any{"sku": "A-1"}}}},
+		{"order.yaml", map[string]any{"id": 7, "items": []any{map[string]any{"sku": "A-1"}}}},
+		{"report.csv", "id,total\n1,9.99\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.file, func(t *testing.T) {
+			ex := FromFS("order", fsys, tt.file, WithSummary("Large order"))
+
+			assert.Equal(t, "order", ex.Name())
+			assert.Equal(t, "Large order", ex.Summary())
+			assert.Equal(t, tt.file, ex.File())
+			assert.True(t, ex.IsFile())
+			assert.False(t, ex.IsExternal())
+			assert.Nil(t, ex.Value())
+
+			value, err := ex.Load()
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, value)
+		})
+	}
+
+	for _, file := range []string{"missing.json", "broken.json", "twice.json"} {
+		t.Run(file, func(t *testing.T) {
+			_, err := FromFS("order", fsys, file).Load()
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFromFile_Load(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "user.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"name": "Ada"}`), 0o600))
+
+	value, err := FromFile("user", file).Load()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"name": "Ada"}, value)
+
+	value, err = New("inline", 42).Load()
+	require.NoError(t, err)
+	assert.Equal(t, 42, value)
+}
@@ -0,0 +1,235 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/metadata"
+)
+
+// ExampleRedaction is how the fields of examples built from Go values that must
+// not be published are redacted, selected with WithExampleRedaction.
+type ExampleRedaction string
+
+// Example redaction modes.
+const (
+	// ExampleRedactionOmit removes the fields from examples, except required
+	// fields, which are masked.
+	ExampleRedactionOmit ExampleRedaction = "omit"
+	// ExampleRedactionMask replaces the values of the fields: strings with
+	// maskedValue, other values with the zero value of their JSON type.
+	ExampleRedactionMask ExampleRedaction = "mask"
+	// ExampleRedactionOff emits examples as is.
+	ExampleRedactionOff ExampleRedaction = "off"
+)
+
+// exampleRedactions are the valid example redaction modes.
+var exampleRedactions = map[ExampleRedaction]bool{
+	"":                   true, // unset
+	ExampleRedactionOmit: true,
+	ExampleRedactionMask: true,
+	ExampleRedactionOff:  true,
+}
+
+// maskedValue replaces the strings of masked example fields.
+const maskedValue = "********"
+
+// WithExampleRedaction sets how the request and response examples built from Go
+// values (see example.New) are redacted, so that real-looking tokens and passwords
+// never end up in published documentation: fields classified secret with the
+// openapi tag are omitted or masked, and so are fields marked writeOnly in
+// response examples. Request examples keep their writeOnly fields, such as the
+// password of a login request, and required fields are masked rather than
+// omitted, so that examples stay valid against their schemas. Registered
+// examples (see RegisterExample) are redacted like request examples. Examples
+// given as maps, loaded from files or external are emitted as is. Generate fails
+// for unknown modes.
+//
+// Default: ExampleRedactionOmit
+//
+// Example:
+//
+//	openapi.WithExampleRedaction(openapi.ExampleRedactionMask)
+func WithExampleRedaction(mode ExampleRedaction) Option {
+	return func(a *API) {
+		a.ExampleRedaction = mode
+	}
+}
+
+// redactExample returns an example value without the fields to redact, writeOnly
+// fields included unless it is a request example, and with the members of fields
+// without json tags named by the property naming strategy, like the properties of
+// their schemas. Values without such fields are returned as is.
+func (a *API) redactExample(value any, request bool) any {
+	if value == nil {
+		return value
+	}
+	redacted := a.ExampleRedaction != ExampleRedactionOff && a.hasRedactedFields(reflect.TypeOf(value), request, make(map[reflect.Type]bool))
+	if !redacted && propertyNamings[a.PropertyNaming] == nil {
+		return value
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return value
+	}
+
+	return a.redactValue(reflect.ValueOf(value), decoded, request)
+}
+
+// hasRedactedFields reports whether values of t can hold fields to redact.
+func (a *API) hasRedactedFields(t reflect.Type, request bool, seen map[reflect.Type]bool) bool {
+	if seen[t] {
+		return false
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return a.hasRedactedFields(t.Elem(), request, seen)
+	case reflect.Interface:
+		return true
+	case reflect.Struct:
+		if build.Implements(t, jsonMarshalerType) {
+			return false
+		}
+		for i := range t.NumField() {
+			f := t.Field(i)
+			if a.redactsField(t, f, i, request) || a.hasRedactedFields(f.Type, request, seen) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// redactsField reports whether a field of a struct type is classified secret, or
+// marked writeOnly, by its tag or AugmentType, outside request examples.
+func (a *API) redactsField(t reflect.Type, f reflect.StructField, index int, request bool) bool {
+	if om := a.generator.Augmentation(t, f.Name); !request && om != nil && om.WriteOnly != nil && *om.WriteOnly {
+		return true
+	}
+	tag, ok := f.Tag.Lookup(a.TagConfig.OpenAPI)
+	if !ok {
+		return false
+	}
+	parsed, err := metadata.ParseOpenAPITag(f, index, tag)
+	if err != nil {
+		return false
+	}
+	om, ok := parsed.(*metadata.OpenAPIMetadata)
+
+	return ok && ((!request && om.WriteOnly != nil && *om.WriteOnly) || om.Sensitivity == metadata.SensitivitySecret)
+}
+
+// redactValue redacts the JSON decoding of a Go value, following its type.
+func (a *API) redactValue(v reflect.Value, decoded any, request bool) any {
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return decoded
+		}
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.Struct:
+		t := v.Type()
+		if obj, ok := decoded.(map[string]any); ok && !build.Implements(t, jsonMarshalerType) {
+			a.redactStruct(v, obj, request)
+		}
+	case reflect.Slice, reflect.Array:
+		if items, ok := decoded.([]any); ok && len(items) == v.Len() {
+			for i := range items {
+				items[i] = a.redactValue(v.Index(i), items[i], request)
+			}
+		}
+	case reflect.Map:
+		if obj, ok := decoded.(map[string]any); ok && v.Type().Key().Kind() == reflect.String {
+			iter := v.MapRange()
+			for iter.Next() {
+				key := iter.Key().String()
+				if item, found := obj[key]; found {
+					obj[key] = a.redactValue(iter.Value(), item, request)
+				}
+			}
+		}
+	}
+
+	return decoded
+}
+
+// redactStruct redacts the JSON object of a struct, whose members are named
+// like encoding/json does, and renames the members of fields without json tags
+// with the property naming strategy.
+func (a *API) redactStruct(v reflect.Value, obj map[string]any, request bool) {
+	naming := propertyNamings[a.PropertyNaming]
+	t := v.Type()
+	for i := range t.NumField() {
+		f := t.Field(i)
+		name, _ := build.JSONTag(f)
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			// Fields of embedded structs are members of the object
+			a.redactValue(v.Field(i), obj, request)
+
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+			if naming != nil {
+				if member, ok := obj[name]; ok {
+					delete(obj, name)
+					name = naming(f.Name)
+					obj[name] = member
+				}
+			}
+		}
+
+		member, ok := obj[name]
+		if !ok {
+			continue
+		}
+		if a.ExampleRedaction != ExampleRedactionOff && a.redactsField(t, f, i, request) {
+			if a.ExampleRedaction == ExampleRedactionMask || a.generator.Required(t, f.Name, name) {
+				obj[name] = maskJSON(member)
+			} else {
+				delete(obj, name)
+			}
+
+			continue
+		}
+		obj[name] = a.redactValue(v.Field(i), member, request)
+	}
+}
+
+// maskJSON returns the mask of a JSON value.
+func maskJSON(value any) any {
+	switch value.(type) {
+	case string:
+		return maskedValue
+	case json.Number:
+		return json.Number("0")
+	case bool:
+		return false
+	case map[string]any:
+		return map[string]any{}
+	case []any:
+		return []any{}
+	default:
+		return nil
+	}
+}
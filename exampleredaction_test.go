@@ -0,0 +1,166 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/example"
+)
+
+type exampleCredentials struct {
+	APIKey string `json:"apiKey" openapi:"sensitivity=secret"`
+	Scopes []string
+}
+
+type exampleAccount struct {
+	exampleCredentials
+
+	ID       int                   `json:"id"`
+	Email    string                `json:"email" openapi:"sensitivity=pii"`
+	Password string                `json:"password" openapi:"writeOnly"`
+	Retries  int                   `json:"retries" openapi:"writeOnly"`
+	Recovery string                `json:"recovery,omitzero" openapi:"writeOnly"`
+	Backup   *exampleAccount       `json:"backup,omitempty"`
+	Keys     map[string]exampleKey `json:"keys,omitempty"`
+}
+
+type exampleKey struct {
+	Secret string `json:"secret" openapi:"sensitivity=secret"`
+	Label  string `json:"label"`
+}
+
+func TestWithExampleRedaction(t *testing.T) {
+	account := exampleAccount{
+		exampleCredentials: exampleCredentials{APIKey: "sk_live_51H8", Scopes: []string{"read"}},
+		ID:                 1,
+		Email:              "ada@example.com",
+		Password:           "hunter2",
+		Retries:            3,
+		Recovery:           "r3c0very",
+		Backup:             &exampleAccount{ID: 2, Password: "hunter3"},
+		Keys:               map[string]exampleKey{"primary": {Secret: "s3cr3t", Label: "Primary"}},
+	}
+
+	tests := []struct {
+		name string
+		mode ExampleRedaction
+		want string
+	}{
+		{"default", "", `{
+			"Scopes": ["read"], "id": 1, "email": "ada@example.com",
+			"backup": {"Scopes": null, "id": 2, "email": ""},
+			"keys": {"primary": {"label": "Primary"}}
+		}`},
+		{"mask", ExampleRedactionMask, `{
+			"apiKey": "********", "Scopes": ["read"], "id": 1, "email": "ada@example.com", "password": "********", "retries": 0, "recovery": "********",
+			"backup": {"apiKey": "********", "Scopes": null, "id": 2, "email": "", "password": "********", "retries": 0},
+			"keys": {"primary": {"secret": "********", "label": "Primary"}}
+		}`},
+		{"off", ExampleRedactionOff, `{
+			"apiKey": "sk_live_51H8", "Scopes": ["read"], "id": 1, "email": "ada@example.com", "password": "hunter2", "retries": 3, "recovery": "r3c0very",
+			"backup": {"apiKey": "", "Scopes": null, "id": 2, "email": "", "password": "hunter3", "retries": 0},
+			"keys": {"primary": {"secret": "s3cr3t", "label": "Primary"}}
+		}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"), WithExampleRedaction(tt.mode))
+			result, err := api.Generate(context.Background(),
+				GET("/accounts/{id}", WithResponse(200, exampleAccount{}, example.New("account", account))),
+			)
+			require.NoError(t, err)
+
+			var spec struct {
+				Paths map[string]map[string]struct {
+					Responses map[string]struct {
+						Content map[string]struct {
+							Examples map[string]struct {
+								Value json.RawMessage `json:"value"`
+							} `json:"examples"`
+						} `json:"content"`
+					} `json:"responses"`
+				} `json:"paths"`
+			}
+			require.NoError(t, json.Unmarshal(result.JSON, &spec))
+			value := spec.Paths["/accounts/{id}"]["get"].Responses["200"].Content["application/json"].Examples["account"].Value
+			assert.JSONEq(t, tt.want, string(value))
+		})
+	}
+}
+
+func TestWithExampleRedaction_RequestExamples(t *testing.T) {
+	type Login struct {
+		Username string `json:"username" validate:"required"`
+		Password string `json:"password" validate:"required" openapi:"writeOnly"`
+		Token    string `json:"token" validate:"required" openapi:"sensitivity=secret"`
+		OTP      string `json:"otp,omitempty" openapi:"sensitivity=secret"`
+	}
+	type LoginRequest struct {
+		Body Login `body:"structured"`
+	}
+	type Session struct {
+		Token    string `json:"token" validate:"required" openapi:"sensitivity=secret"`
+		Password string `json:"password,omitempty" openapi:"writeOnly"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithValidation(true, ValidateExamplesAgainstSchemas))
+	result, err := api.Generate(context.Background(),
+		POST("/login",
+			WithRequest(LoginRequest{}, example.New("alice", Login{Username: "alice", Password: "hunter2", Token: "sk_live_51H8", OTP: "123456"})),
+			WithResponse(200, Session{}, example.New("session", Session{Token: "t0k3n", Password: "hunter2"})),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			RequestBody struct {
+				Content map[string]struct {
+					Examples map[string]struct {
+						Value json.RawMessage `json:"value"`
+					} `json:"examples"`
+				} `json:"content"`
+			} `json:"requestBody"`
+			Responses map[string]struct {
+				Content map[string]struct {
+					Examples map[string]struct {
+						Value json.RawMessage `json:"value"`
+					} `json:"examples"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	login := spec.Paths["/login"]["post"]
+	assert.JSONEq(t, `{"username": "alice", "password": "hunter2", "token": "********"}`,
+		string(login.RequestBody.Content["application/json"].Examples["alice"].Value),
+		"request examples keep writeOnly fields, and required secrets are masked")
+	assert.JSONEq(t, `{"token": "********"}`,
+		string(login.Responses["200"].Content["application/json"].Examples["session"].Value),
+		"writeOnly fields of response examples are omitted, and required secrets masked")
+}
+
+func TestWithExampleRedaction_KeepsOtherValues(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	account := map[string]any{"password": "hunter2"}
+	assert.Equal(t, account, api.redactExample(account, false), "maps are emitted as is")
+
+	value := struct {
+		Label string `json:"label"`
+	}{Label: "Primary"}
+	assert.Equal(t, value, api.redactExample(value, false), "values without secrets are emitted as is")
+	assert.Equal(t, "text", api.redactExample("text", false))
+}
+
+func TestWithExampleRedaction_Unknown(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithExampleRedaction("hide"))
+	_, err := api.Generate(context.Background(), GET("/accounts", WithResponse(200, exampleKey{})))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown example redaction "hide"`)
+}
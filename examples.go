@@ -0,0 +1,140 @@
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/talav/openapi/example"
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// requestExamples is the status of mediaTypeExamples targeting the request body.
+const requestExamples = 0
+
+// mediaTypeExamples are named examples of a single media type of the request
+// body or of a response.
+type mediaTypeExamples struct {
+	status    int
+	mediaType string
+	examples  []example.Example
+}
+
+// WithRequestExample adds named examples to one media type of the request body.
+// The examples of WithRequest apply to every media type of the body; use this
+// option when the media types need examples of their own, e.g. a JSON and a form
+// representation. Generate reports an error when the body has no such media type.
+//
+// Example:
+//
+//	openapi.POST("/users",
+//	    openapi.WithRequest(CreateUserRequest{}),
+//	    openapi.WithRequestExample("application/json",
+//	        example.New("minimal", CreateUserRequest{Name: "Ada"}),
+//	    ),
+//	)
+func WithRequestExample(mediaType string, examples ...example.Example) OperationDocOption {
+	return WithResponseExample(requestExamples, mediaType, examples...)
+}
+
+// WithResponseExample adds named examples to one media type of a response. The
+// examples given to WithResponse apply to its primary representation; use this
+// option to target any representation, including those of WithAlsoContent.
+// Generate reports an error when the response has no such media type.
+//
+// Example:
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, []User{}, openapi.WithAlsoContent("text/csv", UsersCSV{})),
+//	    openapi.WithResponseExample(200, "text/csv",
+//	        example.New("two users", "id,name\n1,Ada\n2,Grace\n"),
+//	    ),
+//	)
+func WithResponseExample(status int, mediaType string, examples ...example.Example) OperationDocOption {
+	return func(d *operationDoc) {
+		d.MediaTypeExamples = append(d.MediaTypeExamples, mediaTypeExamples{
+			status:    status,
+			mediaType: mediaType,
+			examples:  examples,
+		})
+	}
+}
+
+// addMediaTypeTargetedExamples adds the examples declared for single media types
+// of the request body and responses of an operation.
+func (a *API) addMediaTypeTargetedExamples(op *model.Operation, targeted []mediaTypeExamples) error {
+	for _, t := range targeted {
+		var content map[string]*model.MediaType
+		where := "request body"
+		if t.status == requestExamples {
+			if op.RequestBody != nil {
+				content = op.RequestBody.Content
+			}
+		} else {
+			where = "response " + build.StatusKey(t.status)
+			if resp := op.Responses[build.StatusKey(t.status)]; resp != nil {
+				content = resp.Content
+			}
+		}
+
+		mt, ok := content[t.mediaType]
+		if !ok {
+			available := "none"
+			if len(content) > 0 {
+				available = strings.Join(slices.Sorted(maps.Keys(content)), ", ")
+			}
+
+			return fmt.Errorf("examples of %s: no media type %q (media types: %s)", where, t.mediaType, available)
+		}
+		if err := a.addMediaTypeExamples(mt, t.examples, t.status == requestExamples); err != nil {
+			return fmt.Errorf("examples of %s: %w", where, err)
+		}
+	}
+
+	return nil
+}
+
+// fileExamplePointers returns the JSON pointers of the media type examples of a
+// spec loaded from files, with their file. Examples of operations whose methods
+// the OpenAPI version has no field for are not included.
+func (a *API) fileExamplePointers(spec *model.Spec) map[string]string {
+	if len(a.fileExamples) == 0 {
+		return nil
+	}
+
+	pointers := make(map[string]string)
+	collect := func(base string, content map[string]*model.MediaType) {
+		for mediaType, mt := range content {
+			for name, ex := range mt.Examples {
+				if file, ok := a.fileExamples[ex]; ok {
+					pointers[util.Pointer(base, "content", mediaType, "examples", name)] = file
+				}
+			}
+		}
+	}
+
+	m := &Model{spec: spec}
+	for _, ref := range m.Operations() {
+		switch ref.Method {
+		case http.MethodGet, http.MethodPut, http.MethodPost, http.MethodDelete,
+			http.MethodOptions, http.MethodHead, http.MethodPatch, http.MethodTrace:
+		default:
+			continue
+		}
+		base := util.Pointer("", "paths", ref.Path, strings.ToLower(ref.Method))
+		if ref.Operation.RequestBody != nil {
+			collect(base+"/requestBody", ref.Operation.RequestBody.Content)
+		}
+		for status, resp := range ref.Operation.Responses {
+			if resp != nil {
+				collect(util.Pointer(base, "responses", status), resp.Content)
+			}
+		}
+	}
+
+	return pointers
+}
@@ -0,0 +1,202 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/example"
+)
+
+func TestWithResponseExample(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type userXML struct {
+		Body user `body:"structured"`
+	}
+	type createUser struct {
+		Body user `body:"structured"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+		result, err := api.Generate(context.Background(),
+			POST("/users",
+				WithRequest(createUser{}),
+				WithRequestExample("application/json", example.New("minimal", user{Name: "Ada"})),
+				WithResponse(200, user{},
+					example.New("primary", user{ID: 1, Name: "Ada"}),
+					WithAlsoContent("application/xml", userXML{}),
+				),
+				WithResponseExample(200, "application/xml", example.New("xml", user{ID: 2, Name: "Grace"})),
+			),
+		)
+		require.NoError(t, err)
+
+		type mediaType struct {
+			Examples map[string]struct {
+				Value user `json:"value"`
+			} `json:"examples"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				RequestBody struct {
+					Content map[string]mediaType `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Content map[string]mediaType `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		op := spec.Paths["/users"]["post"]
+
+		request := op.RequestBody.Content["application/json"].Examples
+		require.Contains(t, request, "minimal")
+		assert.Equal(t, "Ada", request["minimal"].Value.Name)
+
+		content := op.Responses["200"].Content
+		assert.Equal(t, []string{"primary"}, slices.Collect(maps.Keys(content["application/json"].Examples)))
+		assert.Equal(t, []string{"xml"}, slices.Collect(maps.Keys(content["application/xml"].Examples)))
+		assert.Equal(t, "Grace", content["application/xml"].Examples["xml"].Value.Name)
+	})
+}
+
+func TestWithResponseExample_CSVList(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+	type usersCSV struct {
+		Body string `body:"text"`
+	}
+
+	result, err := NewAPI(WithVersion("3.1.2"), WithValidation(true)).Generate(context.Background(),
+		GET("/users",
+			WithResponse(200, []user{}, WithAlsoContent("text/csv", usersCSV{})),
+			WithResponseExample(200, "text/csv", example.New("two users", "id,name\n1,Ada\n2,Grace\n")),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Examples map[string]any `json:"examples"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	content := spec.Paths["/users"]["get"].Responses["200"].Content
+	assert.Contains(t, content, "application/json")
+	assert.Contains(t, content["text/csv"].Examples, "two users")
+}
+
+func TestWithResponseExample_UnknownMediaType(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	tests := []struct {
+		name string
+		op   Operation
+		want string
+	}{
+		{
+			"response",
+			GET("/users", WithResponse(200, user{}), WithResponseExample(200, "text/csv", example.New("csv", "id\n1\n"))),
+			`examples of response 200: no media type "text/csv" (media types: application/json)`,
+		},
+		{
+			"missing response",
+			GET("/users", WithResponse(200, user{}), WithResponseExample(404, "application/json", example.New("missing", user{}))),
+			`examples of response 404: no media type "application/json" (media types: none)`,
+		},
+		{
+			"request",
+			GET("/users", WithResponse(200, user{}), WithRequestExample("application/json", example.New("body", user{}))),
+			`examples of request body: no media type "application/json" (media types: none)`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(), tt.op)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestFileExamples(t *testing.T) {
+	type user struct {
+		ID   int    `json:"id"`
+		Name string `json:"name" validate:"required"`
+	}
+	type createUser struct {
+		Body user `body:"structured"`
+	}
+
+	fsys := fstest.MapFS{
+		"create.yaml": {Data: []byte("name: Ada\n")},
+		"user.json":   {Data: []byte(`{"id": 1, "name": "Ada"}`)},
+		"bad.json":    {Data: []byte(`{"id": "one"}`)},
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+		result, err := api.Generate(context.Background(),
+			POST("/users",
+				WithRequest(createUser{}, example.FromFS("create", fsys, "create.yaml")),
+				WithResponse(201, user{}, example.FromFS("created", fsys, "user.json")),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				RequestBody struct {
+					Content map[string]struct {
+						Examples map[string]struct {
+							Value user `json:"value"`
+						} `json:"examples"`
+					} `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Content map[string]struct {
+						Examples map[string]struct {
+							Value user `json:"value"`
+						} `json:"examples"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		op := spec.Paths["/users"]["post"]
+		assert.Equal(t, user{Name: "Ada"}, op.RequestBody.Content["application/json"].Examples["create"].Value)
+		assert.Equal(t, user{ID: 1, Name: "Ada"}, op.Responses["201"].Content["application/json"].Examples["created"].Value)
+
+		_, err = api.Generate(context.Background(),
+			GET("/users/{id}", WithResponse(200, user{}, example.FromFS("bad", fsys, "bad.json"))),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "example validation failed")
+		assert.Contains(t, err.Error(), "bad.json for GET /users/{id}")
+
+		_, err = api.Generate(context.Background(),
+			GET("/users/{id}", WithResponse(200, user{}, example.FromFS("missing", fsys, "missing.json"))),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `response 200: example "missing": failed to read example file`)
+	})
+}
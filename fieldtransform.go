@@ -0,0 +1,21 @@
+package openapi
+
+import "github.com/talav/openapi/hook"
+
+// WithFieldTransformer transforms the schemas of all struct fields after their
+// struct tags are applied, for changes across types that would otherwise require
+// a SchemaTransformer on every struct. Transformers run in the order added.
+//
+// Example:
+//
+//	openapi.WithFieldTransformer(func(f hook.Field, s *model.Schema) *model.Schema {
+//	    if unit := f.Field.Tag.Get("unit"); unit != "" {
+//	        s.Description = strings.TrimSpace(s.Description + " (" + unit + ")")
+//	    }
+//	    return s
+//	})
+func WithFieldTransformer(fn hook.FieldTransformer) Option {
+	return func(a *API) {
+		a.FieldTransformers = append(a.FieldTransformers, fn)
+	}
+}
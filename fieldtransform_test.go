@@ -0,0 +1,63 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+type transformReading struct {
+	Temperature float64 `json:"temperature" openapi:"description=Air temperature" unit:"celsius"`
+	Humidity    float64 `json:"humidity" unit:"percent"`
+	Station     string  `json:"station"`
+}
+
+func TestWithFieldTransformer(t *testing.T) {
+	var fields []string
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithFieldTransformer(func(f hook.Field, s *model.Schema) *model.Schema {
+			fields = append(fields, f.Struct.Name()+"."+f.Name)
+			if unit := f.Field.Tag.Get("unit"); unit != "" {
+				s.Description = strings.TrimSpace(s.Description + " (" + unit + ")")
+			}
+
+			return s
+		}),
+		WithFieldTransformer(func(f hook.Field, s *model.Schema) *model.Schema {
+			if f.Field.Type.Kind() != reflect.String {
+				return s
+			}
+
+			return &model.Schema{Type: "string", MaxLength: new(int)}
+		}),
+	)
+
+	result, err := api.Generate(context.Background(), GET("/reading", WithResponse(200, transformReading{})))
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"transformReading.temperature", "transformReading.humidity", "transformReading.station"}, fields)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Description string `json:"description"`
+					MaxLength   *int   `json:"maxLength"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	props := spec.Components.Schemas["TransformReading"].Properties
+	assert.Equal(t, "Air temperature (celsius)", props["temperature"].Description)
+	assert.Equal(t, "(percent)", props["humidity"].Description)
+	assert.NotNil(t, props["station"].MaxLength)
+}
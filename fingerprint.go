@@ -0,0 +1,50 @@
+package openapi
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+)
+
+// fingerprints computes the content hash of a serialized specification and of
+// each of its component schemas.
+//
+// Documents are canonicalized with JCS (RFC 8785) before hashing, so hashes only
+// change when the content changes.
+func fingerprints(specJSON []byte) (string, map[string]string, error) {
+	var doc map[string]any
+	if err := json.Unmarshal(specJSON, &doc); err != nil {
+		return "", nil, fmt.Errorf("failed to parse spec for fingerprinting: %w", err)
+	}
+
+	specHash, err := hashCanonical(doc)
+	if err != nil {
+		return "", nil, err
+	}
+
+	schemaHashes := make(map[string]string)
+	components, _ := doc["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	for name, s := range schemas {
+		h, err := hashCanonical(s)
+		if err != nil {
+			return "", nil, fmt.Errorf("schema %s: %w", name, err)
+		}
+		schemaHashes[name] = h
+	}
+
+	return specHash, schemaHashes, nil
+}
+
+// hashCanonical returns the hex-encoded SHA-256 of the JCS encoding of a decoded
+// JSON document.
+func hashCanonical(v any) (string, error) {
+	data, err := appendCanonical(nil, v)
+	if err != nil {
+		return "", fmt.Errorf("failed to canonicalize document: %w", err)
+	}
+	sum := sha256.Sum256(data)
+
+	return hex.EncodeToString(sum[:]), nil
+}
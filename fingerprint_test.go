@@ -0,0 +1,60 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_Fingerprint(t *testing.T) {
+	type User struct {
+		ID int `json:"id"`
+	}
+	type Order struct {
+		ID    int    `json:"id"`
+		Notes string `json:"notes"`
+	}
+
+	generate := func(t *testing.T, summary string, ops ...Operation) *Result {
+		t.Helper()
+		api := NewAPI(WithInfoTitle("Test"), WithInfoVersion("1.0.0"), WithVersion("3.1.2"))
+		ops = append(ops, GET("/users", WithSummary(summary), WithResponse(200, User{})))
+		result, err := api.Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		return result
+	}
+
+	first := generate(t, "List users", GET("/orders", WithResponse(200, Order{})))
+	second := generate(t, "List users", GET("/orders", WithResponse(200, Order{})))
+	changed := generate(t, "List all users", GET("/orders", WithResponse(200, Order{})))
+
+	assert.Len(t, first.Fingerprint, 64)
+	assert.Equal(t, first.Fingerprint, second.Fingerprint, "fingerprint must be stable")
+	assert.NotEqual(t, first.Fingerprint, changed.Fingerprint, "fingerprint must change with content")
+
+	require.Contains(t, first.SchemaFingerprints, "User")
+	require.Contains(t, first.SchemaFingerprints, "Order")
+	assert.NotEqual(t, first.SchemaFingerprints["User"], first.SchemaFingerprints["Order"])
+	assert.Equal(t, first.SchemaFingerprints, changed.SchemaFingerprints, "schema hashes must not depend on operations")
+}
+
+func TestFingerprints_Canonical(t *testing.T) {
+	a, schemasA, err := fingerprints([]byte(`{"openapi":"3.1.2","components":{"schemas":{"A":{"type":"object","title":"A"}}}}`))
+	require.NoError(t, err)
+	b, schemasB, err := fingerprints([]byte(`{
+		"components": {"schemas": {"A": {"title": "A", "type": "object"}}},
+		"openapi": "3.1.2"
+	}`))
+	require.NoError(t, err)
+
+	assert.Equal(t, a, b)
+	assert.Equal(t, schemasA, schemasB)
+}
+
+func TestFingerprints_InvalidJSON(t *testing.T) {
+	_, _, err := fingerprints([]byte(`{`))
+	require.Error(t, err)
+}
@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/formats"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// WithCustomFormats allows formats other than the standard ones of the formats
+// package, such as organization-specific formats. Schemas with other unknown
+// formats, typically typos in openapi:"format=..." tags, are reported with
+// UNKNOWN_FORMAT warnings.
+//
+// Default: none
+//
+// Example:
+//
+//	openapi.WithCustomFormats("iban", "country-code")
+func WithCustomFormats(names ...string) Option {
+	return func(a *API) {
+		a.CustomFormats = append(a.CustomFormats, names...)
+	}
+}
+
+// checkFormats reports the schemas with formats neither standard nor allowed with
+// WithCustomFormats, suggesting the closest standard format.
+func (a *API) checkFormats(spec *model.Spec) debug.Warnings {
+	var warns debug.Warnings
+	var check func(s *model.Schema, ptr string)
+	check = func(s *model.Schema, ptr string) {
+		if s == nil || s.Ref != "" {
+			return
+		}
+		if s.Format != "" && !formats.Known(s.Format) && !slices.Contains(a.CustomFormats, s.Format) {
+			msg := fmt.Sprintf("unknown format %q", s.Format)
+			if suggestion := formats.Suggest(s.Format); suggestion != "" {
+				msg += fmt.Sprintf("; did you mean %q?", suggestion)
+			} else {
+				msg += "; allow it with WithCustomFormats"
+			}
+			if source := a.violationSource(ptr[1:]); source != "" {
+				msg = source + ": " + msg
+			}
+			warns.Append(debug.NewWarning(debug.WarnUnknownFormat, util.Pointer(ptr, "format"), msg))
+		}
+
+		for _, name := range sortedNames(s.Properties) {
+			check(s.Properties[name], util.Pointer(ptr, "properties", name))
+		}
+		check(s.Items, util.Pointer(ptr, "items"))
+		if s.Additional != nil {
+			check(s.Additional.Schema, util.Pointer(ptr, "additionalProperties"))
+		}
+		for _, c := range []struct {
+			keyword string
+			group   []*model.Schema
+		}{{"prefixItems", s.PrefixItems}, {"allOf", s.AllOf}, {"anyOf", s.AnyOf}, {"oneOf", s.OneOf}} {
+			for i, sub := range c.group {
+				check(sub, util.Pointer(ptr, c.keyword, strconv.Itoa(i)))
+			}
+		}
+	}
+
+	if spec.Components != nil {
+		for _, name := range sortedNames(spec.Components.Schemas) {
+			check(spec.Components.Schemas[name], util.Pointer(componentsPrefix+"schemas", name))
+		}
+	}
+	content := func(base string, content map[string]*model.MediaType) {
+		for _, mediaType := range slices.Sorted(maps.Keys(content)) {
+			if mt := content[mediaType]; mt != nil {
+				check(mt.Schema, util.Pointer(base, "content", mediaType, "schema"))
+			}
+		}
+	}
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		base := util.Pointer("#/paths", ref.Path, strings.ToLower(ref.Method))
+		op := ref.Operation
+		for i := range op.Parameters {
+			check(op.Parameters[i].Schema, util.Pointer(base, "parameters", strconv.Itoa(i), "schema"))
+		}
+		if op.RequestBody != nil {
+			content(base+"/requestBody", op.RequestBody.Content)
+		}
+		for _, status := range slices.Sorted(maps.Keys(op.Responses)) {
+			resp := op.Responses[status]
+			if resp == nil {
+				continue
+			}
+			for _, name := range slices.Sorted(maps.Keys(resp.Headers)) {
+				if h := resp.Headers[name]; h != nil {
+					check(h.Schema, util.Pointer(base, "responses", status, "headers", name, "schema"))
+				}
+			}
+			content(util.Pointer(base, "responses", status), resp.Content)
+		}
+	}
+
+	return warns
+}
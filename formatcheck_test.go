@@ -0,0 +1,67 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+type formatAccount struct {
+	ID      string `json:"id" openapi:"format=uuid"`
+	Created string `json:"created" openapi:"format=datetime"`
+	IBAN    string `json:"iban" openapi:"format=iban"`
+}
+
+type formatAccountRequest struct {
+	Since string `schema:"since,location=query" openapi:"format=dat"`
+}
+
+func TestCheckFormats(t *testing.T) {
+	formatWarnings := func(t *testing.T, opts ...Option) []string {
+		t.Helper()
+		api := NewAPI(append([]Option{WithVersion("3.1.2")}, opts...)...)
+		result, err := api.Generate(context.Background(),
+			GET("/accounts", WithRequest(formatAccountRequest{}), WithResponse(200, formatAccount{})),
+		)
+		require.NoError(t, err)
+
+		var warns []string
+		for _, w := range result.Warnings {
+			if w.Code() == debug.WarnUnknownFormat {
+				warns = append(warns, w.Path()+": "+w.Message())
+			}
+		}
+
+		return warns
+	}
+
+	assert.Equal(t, []string{
+		`#/components/schemas/FormatAccount/properties/created/format: openapi.formatAccount.Created: unknown format "datetime"; did you mean "date-time"?`,
+		`#/components/schemas/FormatAccount/properties/iban/format: openapi.formatAccount.IBAN: unknown format "iban"; allow it with WithCustomFormats`,
+		`#/paths/~1accounts/get/parameters/0/schema/format: GET /accounts: unknown format "dat"; did you mean "date"?`,
+	}, formatWarnings(t))
+
+	assert.Equal(t, []string{
+		`#/components/schemas/FormatAccount/properties/created/format: openapi.formatAccount.Created: unknown format "datetime"; did you mean "date-time"?`,
+	}, formatWarnings(t, WithCustomFormats("iban"), WithCustomFormats("dat")))
+}
+
+func TestCheckFormats_Generated(t *testing.T) {
+	type Event struct {
+		ID   int64     `json:"id"`
+		At   time.Time `json:"at"`
+		Host string    `json:"host" openapi:"format=hostname"`
+		Data []byte    `json:"data"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		result, err := NewAPI(WithVersion(version)).Generate(context.Background(), GET("/events", WithResponse(200, Event{})))
+		require.NoError(t, err)
+		assert.False(t, result.Warnings.Has(debug.WarnUnknownFormat))
+	})
+}
@@ -0,0 +1,143 @@
+// Package formats provides the names of the standard formats of OpenAPI schemas:
+// those of JSON Schema 2020-12 and of the OpenAPI Format Registry.
+//
+// Use the constants in openapi tags and hand-written schemas instead of string
+// literals, and Known and Suggest to check format names:
+//
+//	import "github.com/talav/openapi/formats"
+//
+//	formats.Known(formats.FormatDateTime) // true
+//	formats.Suggest("datetime")            // "date-time"
+//
+// Formats unknown to this package, such as organization-specific ones, are
+// reported by openapi.Generate unless allowed with openapi.WithCustomFormats.
+package formats
+
+import "slices"
+
+// Formats of JSON Schema 2020-12.
+const (
+	FormatDateTime            = "date-time"
+	FormatDate                = "date"
+	FormatTime                = "time"
+	FormatDuration            = "duration"
+	FormatEmail               = "email"
+	FormatIDNEmail            = "idn-email"
+	FormatHostname            = "hostname"
+	FormatIDNHostname         = "idn-hostname"
+	FormatIPv4                = "ipv4"
+	FormatIPv6                = "ipv6"
+	FormatURI                 = "uri"
+	FormatURIReference        = "uri-reference"
+	FormatIRI                 = "iri"
+	FormatIRIReference        = "iri-reference"
+	FormatUUID                = "uuid"
+	FormatURITemplate         = "uri-template"
+	FormatJSONPointer         = "json-pointer"
+	FormatRelativeJSONPointer = "relative-json-pointer"
+	FormatRegex               = "regex"
+)
+
+// Formats of the OpenAPI Format Registry.
+const (
+	FormatInt8       = "int8"
+	FormatInt16      = "int16"
+	FormatInt32      = "int32"
+	FormatInt64      = "int64"
+	FormatUint8      = "uint8"
+	FormatUint16     = "uint16"
+	FormatUint32     = "uint32"
+	FormatUint64     = "uint64"
+	FormatFloat      = "float"
+	FormatDouble     = "double"
+	FormatDecimal    = "decimal"
+	FormatDecimal128 = "decimal128"
+	FormatByte       = "byte"
+	FormatBinary     = "binary"
+	FormatBase64URL  = "base64url"
+	FormatPassword   = "password"
+	FormatChar       = "char"
+	FormatCommonMark = "commonmark"
+	FormatHTML       = "html"
+	FormatHTTPDate   = "http-date"
+	FormatMediaRange = "media-range"
+)
+
+// standard lists the formats of the constants above, sorted.
+var standard = func() []string {
+	all := []string{
+		FormatDateTime, FormatDate, FormatTime, FormatDuration, FormatEmail,
+		FormatIDNEmail, FormatHostname, FormatIDNHostname, FormatIPv4, FormatIPv6,
+		FormatURI, FormatURIReference, FormatIRI, FormatIRIReference, FormatUUID,
+		FormatURITemplate, FormatJSONPointer, FormatRelativeJSONPointer, FormatRegex,
+		FormatInt8, FormatInt16, FormatInt32, FormatInt64, FormatUint8, FormatUint16,
+		FormatUint32, FormatUint64, FormatFloat, FormatDouble, FormatDecimal,
+		FormatDecimal128, FormatByte, FormatBinary, FormatBase64URL, FormatPassword,
+		FormatChar, FormatCommonMark, FormatHTML, FormatHTTPDate, FormatMediaRange,
+	}
+	slices.Sort(all)
+
+	return all
+}()
+
+// Standard returns the standard formats, sorted.
+func Standard() []string {
+	return slices.Clone(standard)
+}
+
+// Known reports whether format is a standard format.
+func Known(format string) bool {
+	_, found := slices.BinarySearch(standard, format)
+
+	return found
+}
+
+// Suggest returns the standard format closest to an unknown format, such as
+// "date-time" for "datetime" or "DateTime", or "" if none is close. Formats are
+// close within two edits, ignoring case.
+func Suggest(format string) string {
+	const maxDistance = 2
+
+	best, bestDistance := "", maxDistance+1
+	for _, f := range standard {
+		if d := distance(lower(format), f); d < bestDistance {
+			best, bestDistance = f, d
+		}
+	}
+
+	return best
+}
+
+// lower returns s in ASCII lower case.
+func lower(s string) string {
+	b := []byte(s)
+	for i, c := range b {
+		if 'A' <= c && c <= 'Z' {
+			b[i] = c + 'a' - 'A'
+		}
+	}
+
+	return string(b)
+}
+
+// distance returns the Levenshtein distance of a and b.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	cur := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+		}
+		prev, cur = cur, prev
+	}
+
+	return prev[len(b)]
+}
@@ -0,0 +1,49 @@
+package formats
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKnown(t *testing.T) {
+	for _, f := range []string{FormatDateTime, FormatUUID, FormatEmail, FormatInt64, FormatBinary, FormatHTTPDate} {
+		assert.True(t, Known(f), f)
+	}
+	for _, f := range []string{"", "datetime", "Date-Time", "iban"} {
+		assert.False(t, Known(f), f)
+	}
+}
+
+func TestStandard(t *testing.T) {
+	all := Standard()
+	assert.True(t, slices.IsSorted(all))
+	assert.Contains(t, all, FormatRelativeJSONPointer)
+
+	// Standard returns a copy
+	all[0] = "changed"
+	assert.NotEqual(t, "changed", Standard()[0])
+}
+
+func TestSuggest(t *testing.T) {
+	tests := []struct {
+		format string
+		want   string
+	}{
+		{"datetime", FormatDateTime},
+		{"DateTime", FormatDateTime},
+		{"url", FormatURI},
+		{"uuid4", FormatUUID},
+		{"emial", FormatEmail},
+		{"ipv5", FormatIPv4},
+		{"iban", ""},
+		{"country-code", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			assert.Equal(t, tt.want, Suggest(tt.format))
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// globalParameter is a parameter registered with WithGlobalParameter.
+type globalParameter struct {
+	name  string
+	param model.Parameter
+	match func(method, path string) bool
+}
+
+// WithGlobalParameter adds a parameter, such as a tenant header, to every operation,
+// or to the operations for which match returns true. match receives the method in
+// upper case and the OpenAPI path template, e.g. "/users/{id}". The parameter is
+// emitted once as the component parameter name, which the operations reference.
+// Operations declaring a parameter with the same name (case-insensitive for headers)
+// and location keep their own.
+//
+// Parameters must be in the query, a header or a cookie, and have a schema or
+// content. Generate fails for invalid parameters and duplicate names.
+//
+// Example:
+//
+//	openapi.WithGlobalParameter("TenantID", model.Parameter{
+//	    Name:     "X-Tenant-ID",
+//	    In:       "header",
+//	    Required: true,
+//	    Schema:   &model.Schema{Type: "string", Format: "uuid"},
+//	}, func(method, path string) bool {
+//	    return strings.HasPrefix(path, "/tenants/")
+//	})
+func WithGlobalParameter(name string, param model.Parameter, match func(method, path string) bool) Option {
+	return func(a *API) {
+		var err error
+		switch {
+		case name == "" || slices.ContainsFunc(a.globalParams, func(g globalParameter) bool { return g.name == name }):
+			err = fmt.Errorf("parameter names must be unique and non-empty, got %q", name)
+		case param.Ref != "" || param.Name == "":
+			err = fmt.Errorf("parameter %s: want a named parameter, not a reference", name)
+		case param.In != string(InQuery) && param.In != string(InHeader) && param.In != string(InCookie):
+			err = fmt.Errorf("parameter %s: invalid location %q (valid: %s, %s, %s)", name, param.In, InQuery, InHeader, InCookie)
+		case param.Schema == nil && len(param.Content) == 0:
+			err = fmt.Errorf("parameter %s: a schema or content is required", name)
+		}
+		if err != nil {
+			a.globalParamErrs = append(a.globalParamErrs, err)
+
+			return
+		}
+		a.globalParams = append(a.globalParams, globalParameter{name: name, param: param, match: match})
+	}
+}
+
+// applyGlobalParameters adds the global parameters to the components and to the
+// matching operations.
+func (a *API) applyGlobalParameters(spec *model.Spec) {
+	if len(a.globalParams) == 0 {
+		return
+	}
+	if spec.Components.Parameters == nil {
+		spec.Components.Parameters = make(map[string]*model.Parameter, len(a.globalParams))
+	}
+
+	ops := (&Model{spec: spec}).Operations()
+	for _, g := range a.globalParams {
+		param := g.param
+		spec.Components.Parameters[g.name] = &param
+		ref := model.Parameter{Ref: componentsPrefix + "parameters/" + g.name}
+		for _, op := range ops {
+			if g.match != nil && !g.match(op.Method, op.Path) {
+				continue
+			}
+			declared := slices.ContainsFunc(op.Operation.Parameters, func(p model.Parameter) bool {
+				return parameterKey(&p) == parameterKey(&param)
+			})
+			if !declared {
+				op.Operation.Parameters = append(op.Operation.Parameters, ref)
+			}
+		}
+	}
+}
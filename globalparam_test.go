@@ -0,0 +1,117 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+type globalTenantRequest struct {
+	Tenant string `schema:"X-Tenant-ID,location=header" openapi:"description=Tenant of the operation"`
+}
+
+func TestWithGlobalParameter(t *testing.T) {
+	tenant := model.Parameter{
+		Name:     "X-Tenant-ID",
+		In:       "header",
+		Required: true,
+		Schema:   &model.Schema{Type: "string", Format: "uuid"},
+	}
+	trace := model.Parameter{Name: "trace", In: "query", Schema: &model.Schema{Type: "boolean"}}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithVersion(version),
+			WithGlobalParameter("TenantID", tenant, func(_, path string) bool {
+				return strings.HasPrefix(path, "/tenants/")
+			}),
+			WithGlobalParameter("Trace", trace, nil),
+		)
+		result, err := api.Generate(context.Background(),
+			GET("/health"),
+			GET("/tenants/users"),
+			POST("/tenants/users", WithRequest(globalTenantRequest{})),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Parameters []struct {
+					Ref         string `json:"$ref"`
+					Name        string `json:"name"`
+					Description string `json:"description"`
+				} `json:"parameters"`
+			} `json:"paths"`
+			Components struct {
+				Parameters map[string]struct {
+					Name     string `json:"name"`
+					In       string `json:"in"`
+					Required bool   `json:"required"`
+				} `json:"parameters"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		params := func(path, method string) []string {
+			var refs []string
+			for _, p := range spec.Paths[path][method].Parameters {
+				refs = append(refs, p.Ref+p.Name)
+			}
+
+			return refs
+		}
+		assert.Equal(t, []string{"#/components/parameters/Trace"}, params("/health", "get"))
+		assert.Equal(t, []string{"#/components/parameters/TenantID", "#/components/parameters/Trace"}, params("/tenants/users", "get"))
+		// The operation keeps its own tenant header
+		assert.Equal(t, []string{"X-Tenant-ID", "#/components/parameters/Trace"}, params("/tenants/users", "post"))
+
+		require.Len(t, spec.Components.Parameters, 2)
+		assert.Equal(t, "X-Tenant-ID", spec.Components.Parameters["TenantID"].Name)
+		assert.True(t, spec.Components.Parameters["TenantID"].Required)
+		assert.Equal(t, "query", spec.Components.Parameters["Trace"].In)
+	})
+}
+
+func TestWithGlobalParameter_Errors(t *testing.T) {
+	header := model.Parameter{Name: "X-Tenant-ID", In: "header", Schema: &model.Schema{Type: "string"}}
+	tests := []struct {
+		name    string
+		opts    []Option
+		wantErr string
+	}{
+		{
+			name:    "duplicate name",
+			opts:    []Option{WithGlobalParameter("Tenant", header, nil), WithGlobalParameter("Tenant", header, nil)},
+			wantErr: `parameter names must be unique and non-empty, got "Tenant"`,
+		},
+		{
+			name:    "path parameter",
+			opts:    []Option{WithGlobalParameter("ID", model.Parameter{Name: "id", In: "path", Schema: &model.Schema{Type: "string"}}, nil)},
+			wantErr: `parameter ID: invalid location "path" (valid: query, header, cookie)`,
+		},
+		{
+			name:    "reference",
+			opts:    []Option{WithGlobalParameter("Ref", model.Parameter{Ref: "#/components/parameters/Other"}, nil)},
+			wantErr: "parameter Ref: want a named parameter, not a reference",
+		},
+		{
+			name:    "no schema",
+			opts:    []Option{WithGlobalParameter("Tenant", model.Parameter{Name: "X-Tenant-ID", In: "header"}, nil)},
+			wantErr: "parameter Tenant: a schema or content is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(append([]Option{WithVersion("3.1.2")}, tt.opts...)...).Generate(context.Background(), GET("/health"))
+			require.ErrorContains(t, err, "invalid global parameters: ")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
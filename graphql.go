@@ -0,0 +1,70 @@
+package openapi
+
+// GraphQLResponseContentType is the media type of GraphQL responses defined by
+// the GraphQL over HTTP specification.
+const GraphQLResponseContentType = "application/graphql-response+json"
+
+// GraphQLRequest is the body of a GraphQL over HTTP POST request.
+type GraphQLRequest struct {
+	Query         string         `json:"query" validate:"required" openapi:"description=Source text of the GraphQL document"`
+	OperationName string         `json:"operationName,omitempty" openapi:"description=Name of the operation to execute when the document contains several"`
+	Variables     map[string]any `json:"variables,omitempty" openapi:"description=Values of the variables of the operation"`
+	Extensions    map[string]any `json:"extensions,omitempty" openapi:"description=Implementation-specific request extensions"`
+}
+
+// GraphQLResponse is the GraphQL response of a GraphQL over HTTP request.
+// Data is absent when the request failed before execution. Error paths hold field
+// names and list indices.
+type GraphQLResponse struct {
+	Data       map[string]any `json:"data,omitempty" openapi:"description=Result of the execution of the operation"`
+	Errors     []GraphQLError `json:"errors,omitempty" openapi:"description=Errors raised while validating or executing the operation"`
+	Extensions map[string]any `json:"extensions,omitempty" openapi:"description=Implementation-specific response extensions"`
+}
+
+// ContentType implements ContentTypeProvider.
+func (GraphQLResponse) ContentType(string) string {
+	return GraphQLResponseContentType
+}
+
+// GraphQLError is an error of a GraphQL response.
+type GraphQLError struct {
+	Message    string            `json:"message" validate:"required" openapi:"description=Description of the error"`
+	Locations  []GraphQLLocation `json:"locations,omitempty" openapi:"description=Locations in the document the error relates to"`
+	Path       []any             `json:"path,omitempty" openapi:"description=Path of the response field the error relates to"`
+	Extensions map[string]any    `json:"extensions,omitempty" openapi:"description=Implementation-specific error details"`
+}
+
+// GraphQLLocation is a location in a GraphQL document.
+type GraphQLLocation struct {
+	Line   int `json:"line" validate:"required,min=1"`
+	Column int `json:"column" validate:"required,min=1"`
+}
+
+// graphQLInput is the request struct of GraphQL operations.
+type graphQLInput struct {
+	Body GraphQLRequest `body:"structured"`
+}
+
+// GraphQL documents a GraphQL over HTTP endpoint accepting POST requests, so that
+// services exposing both REST and GraphQL APIs describe them in one spec.
+// Responses are GraphQL responses: 200 for executed operations, errors included,
+// and 400 for requests failing before execution, such as on syntax errors.
+//
+// opts apply after the preset, e.g. to set the summary or the security.
+//
+// Example:
+//
+//	api.Generate(ctx,
+//	    openapi.GET("/users", openapi.WithResponse(200, []User{})),
+//	    openapi.GraphQL("/graphql", openapi.WithSecurity("bearerAuth")),
+//	)
+func GraphQL(path string, opts ...OperationDocOption) Operation {
+	return POST(path, append([]OperationDocOption{
+		WithOperationID("graphql"),
+		WithSummary("Execute a GraphQL operation"),
+		WithTags("GraphQL"),
+		WithRequest(graphQLInput{}),
+		WithResponse(200, GraphQLResponse{}),
+		WithResponse(400, GraphQLResponse{}),
+	}, opts...)...)
+}
@@ -0,0 +1,86 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQL(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+
+		result, err := api.Generate(context.Background(), GraphQL("/graphql", WithSummary("Query the catalog")))
+		require.NoError(t, err)
+
+		type schemaRef struct {
+			Ref string `json:"$ref"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				OperationID string   `json:"operationId"`
+				Summary     string   `json:"summary"`
+				Tags        []string `json:"tags"`
+				RequestBody struct {
+					Content map[string]struct {
+						Schema schemaRef `json:"schema"`
+					} `json:"content"`
+				} `json:"requestBody"`
+				Responses map[string]struct {
+					Content map[string]struct {
+						Schema schemaRef `json:"schema"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+			Components struct {
+				Schemas map[string]struct {
+					Required   []string       `json:"required"`
+					Properties map[string]any `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		op := spec.Paths["/graphql"]["post"]
+		assert.Equal(t, "graphql", op.OperationID)
+		assert.Equal(t, "Query the catalog", op.Summary)
+		assert.Equal(t, []string{"GraphQL"}, op.Tags)
+		assert.Equal(t, "#/components/schemas/GraphQLRequest", op.RequestBody.Content["application/json"].Schema.Ref)
+		for _, status := range []string{"200", "400"} {
+			require.Contains(t, op.Responses, status)
+			assert.Equal(t, "#/components/schemas/GraphQLResponse", op.Responses[status].Content[GraphQLResponseContentType].Schema.Ref)
+		}
+
+		schemas := spec.Components.Schemas
+		assert.Equal(t, []string{"query"}, schemas["GraphQLRequest"].Required)
+		assert.Contains(t, schemas["GraphQLRequest"].Properties, "variables")
+		assert.Contains(t, schemas["GraphQLResponse"].Properties, "errors")
+		assert.Equal(t, []string{"message"}, schemas["GraphQLError"].Required)
+		assert.ElementsMatch(t, []string{"line", "column"}, schemas["GraphQLLocation"].Required)
+	})
+}
+
+func TestGraphQL_WithRoutes(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithValidation(true), WithBearerAuth("bearerAuth", "Bearer token"))
+	result, err := api.Generate(context.Background(),
+		GET("/users", WithResponse(200, []user{})),
+		GraphQL("/graphql", WithSecurity("bearerAuth")),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Security []map[string][]string `json:"security"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.Contains(t, spec.Paths["/users"], "get")
+	assert.Equal(t, []map[string][]string{{"bearerAuth": {}}}, spec.Paths["/graphql"]["post"].Security)
+}
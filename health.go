@@ -0,0 +1,81 @@
+package openapi
+
+import "net/http"
+
+// PrometheusContentType is the media type of the Prometheus text exposition format.
+const PrometheusContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// tagInfrastructure is the tag of the operations documented by the presets below.
+const tagInfrastructure = "Infrastructure"
+
+// HealthStatus is the response of health and readiness checks.
+type HealthStatus struct {
+	Status string            `json:"status" validate:"required,oneof=pass warn fail" openapi:"description=Overall status of the service"`
+	Checks map[string]string `json:"checks,omitempty" openapi:"description=Status of each dependency check by name"`
+}
+
+// prometheusText is the body of metrics responses.
+type prometheusText string
+
+// ContentType implements ContentTypeProvider.
+func (prometheusText) ContentType(string) string {
+	return PrometheusContentType
+}
+
+// metricsOutput is the response of metrics operations.
+type metricsOutput struct {
+	Body prometheusText `body:"text"`
+}
+
+// HealthCheck documents a liveness endpoint, conventionally /healthz, answering
+// 200 with a HealthStatus while the service is alive and 503 otherwise.
+//
+// opts apply after the preset, e.g. to set the summary.
+//
+// Example:
+//
+//	openapi.HealthCheck("/healthz")
+func HealthCheck(path string, opts ...OperationDocOption) Operation {
+	return GET(path, append([]OperationDocOption{
+		WithOperationID("healthCheck"),
+		WithSummary("Check that the service is alive"),
+		WithTags(tagInfrastructure),
+		WithResponse(http.StatusOK, HealthStatus{}),
+		WithResponse(http.StatusServiceUnavailable, HealthStatus{}),
+	}, opts...)...)
+}
+
+// ReadinessCheck documents a readiness endpoint, conventionally /readyz, answering
+// 200 with a HealthStatus when the service is ready to serve traffic and 503 otherwise.
+//
+// opts apply after the preset, e.g. to set the summary.
+//
+// Example:
+//
+//	openapi.ReadinessCheck("/readyz")
+func ReadinessCheck(path string, opts ...OperationDocOption) Operation {
+	return GET(path, append([]OperationDocOption{
+		WithOperationID("readinessCheck"),
+		WithSummary("Check that the service is ready to serve traffic"),
+		WithTags(tagInfrastructure),
+		WithResponse(http.StatusOK, HealthStatus{}),
+		WithResponse(http.StatusServiceUnavailable, HealthStatus{}),
+	}, opts...)...)
+}
+
+// Metrics documents a metrics endpoint, conventionally /metrics, serving the
+// metrics of the service in the Prometheus text exposition format.
+//
+// opts apply after the preset, e.g. to set the summary.
+//
+// Example:
+//
+//	openapi.Metrics("/metrics")
+func Metrics(path string, opts ...OperationDocOption) Operation {
+	return GET(path, append([]OperationDocOption{
+		WithOperationID("metrics"),
+		WithSummary("Get the metrics of the service"),
+		WithTags(tagInfrastructure),
+		WithResponse(http.StatusOK, metricsOutput{}),
+	}, opts...)...)
+}
@@ -0,0 +1,66 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInfrastructurePresets(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+
+		result, err := api.Generate(context.Background(),
+			HealthCheck("/healthz"),
+			ReadinessCheck("/readyz", WithSummary("Readiness")),
+			Metrics("/metrics"),
+		)
+		require.NoError(t, err)
+		assert.Empty(t, result.Warnings)
+
+		type content map[string]struct {
+			Schema struct {
+				Ref  string `json:"$ref"`
+				Type any    `json:"type"`
+			} `json:"schema"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				OperationID string   `json:"operationId"`
+				Summary     string   `json:"summary"`
+				Tags        []string `json:"tags"`
+				Responses   map[string]struct {
+					Content content `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]struct {
+						Enum []string `json:"enum"`
+					} `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		for path, id := range map[string]string{"/healthz": "healthCheck", "/readyz": "readinessCheck"} {
+			op := spec.Paths[path]["get"]
+			assert.Equal(t, id, op.OperationID)
+			assert.Equal(t, []string{"Infrastructure"}, op.Tags)
+			for _, status := range []string{"200", "503"} {
+				require.Contains(t, op.Responses, status)
+				assert.Equal(t, "#/components/schemas/HealthStatus", op.Responses[status].Content["application/json"].Schema.Ref)
+			}
+		}
+		assert.Equal(t, "Readiness", spec.Paths["/readyz"]["get"].Summary)
+		assert.ElementsMatch(t, []string{"pass", "warn", "fail"}, spec.Components.Schemas["HealthStatus"].Properties["status"].Enum)
+
+		metrics := spec.Paths["/metrics"]["get"]
+		assert.Equal(t, "metrics", metrics.OperationID)
+		require.Contains(t, metrics.Responses["200"].Content, PrometheusContentType)
+		assert.Equal(t, "string", metrics.Responses["200"].Content[PrometheusContentType].Schema.Type)
+	})
+}
@@ -3,6 +3,7 @@ package hook
 import (
 	"reflect"
 
+	"github.com/talav/openapi/config"
 	"github.com/talav/openapi/internal/model"
 )
 
@@ -13,6 +14,22 @@ type SchemaProvider interface {
 	Schema(r SchemaRegistry) *model.Schema
 }
 
+// ContextSchemaProvider is an interface that can be implemented by types to provide
+// a custom schema for themselves, like SchemaProvider, with access to the schema
+// generation context. It takes precedence over SchemaProvider.
+type ContextSchemaProvider interface {
+	SchemaWithContext(ctx SchemaContext) *model.Schema
+}
+
+// MarshalSchema is an interface that can be implemented by json.Marshaler types
+// to document the JSON their MarshalJSON method produces, which cannot be
+// inferred from their fields. MarshalSchema returns a value of the type the JSON
+// is generated from, such as "" for types marshaled to strings, or nil for any
+// value. It must not return a value of the implementing type.
+type MarshalSchema interface {
+	MarshalSchema() any
+}
+
 // SchemaTransformer is an interface that can be implemented by types
 // to transform the generated schema as needed.
 // This can be used to leverage the default schema generation for a type,
@@ -21,8 +38,65 @@ type SchemaTransformer interface {
 	TransformSchema(r SchemaRegistry, s *model.Schema) *model.Schema
 }
 
+// FieldTransformer transforms the schemas of struct fields after their struct
+// tags are applied, for changes across all types such as appending units to
+// descriptions. It returns the schema of the field, which may be the one it
+// received. Schemas of fields of a component type are references to it.
+type FieldTransformer func(f Field, s *model.Schema) *model.Schema
+
+// Field is the struct field of a schema transformed by a FieldTransformer.
+type Field struct {
+	// Struct is the struct type declaring the field.
+	Struct reflect.Type
+
+	// Field is the struct field.
+	Field reflect.StructField
+
+	// Name is the property name of the field.
+	Name string
+}
+
+// ConditionalSchema is an interface that can be implemented by struct types
+// to declare requirements depending on the value of a property, such as
+// card_number being required when type is "card". Conditions are emitted as
+// if/then/else (3.1), or approximated with anyOf in 3.0.
+type ConditionalSchema interface {
+	SchemaConditions() []Condition
+}
+
+// Condition is a requirement of an object schema depending on a property value.
+// Properties are named by their JSON names.
+type Condition struct {
+	// If is the property the condition depends on.
+	If string
+	// Equals is the value of the property the condition matches. It must not be nil.
+	Equals any
+	// Then lists the properties required when the property equals the value.
+	Then []string
+	// Else lists the properties required otherwise.
+	Else []string
+}
+
 // SchemaRegistry is a minimal interface for schema generation.
 // It's used by SchemaProvider and SchemaTransformer implementations.
 type SchemaRegistry interface {
 	Schema(t reflect.Type) *model.Schema
 }
+
+// SchemaContext is the schema generation context of ContextSchemaProvider
+// implementations, composing schemas that share component schemas with the rest
+// of the spec.
+type SchemaContext interface {
+	SchemaRegistry
+
+	// RefFor returns a reference to the component schema of t, registering one
+	// under NameOf(t, hint) for types otherwise inlined, such as enum types.
+	RefFor(t reflect.Type, hint string) *model.Schema
+
+	// NameOf returns the component schema name of t. The hint names unnamed
+	// types, and is ignored for named ones.
+	NameOf(t reflect.Type, hint string) string
+
+	// TagConfig returns the struct tag names of the generator.
+	TagConfig() config.TagConfig
+}
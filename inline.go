@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// InlineSchema inlines the schema of T wherever it is used instead of referencing
+// a component schema, for one-off types such as request wrappers that would
+// otherwise clutter the components. Generate fails if T references itself.
+//
+// Call it before the first Generate using T, as generated schemas are kept
+// across calls.
+//
+// Example:
+//
+//	openapi.InlineSchema[CreateUserRequestBody](api)
+func InlineSchema[T any](a *API) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.generator.MarkInline(reflect.TypeFor[T]())
+}
+
+// WithInlineRequestBodies inlines the schemas of request bodies in the operations
+// instead of referencing component schemas. Components used only by request bodies
+// are removed; those also used elsewhere are kept for the other references.
+//
+// Default: false
+//
+// Example:
+//
+//	openapi.WithInlineRequestBodies(true)
+func WithInlineRequestBodies(enabled bool) Option {
+	return func(a *API) {
+		a.InlineRequestBodies = enabled
+	}
+}
+
+// inlineRequestBodies replaces the component schema references of the request
+// bodies of the spec with the component schemas, and removes the components
+// no longer used.
+func (a *API) inlineRequestBodies(spec *model.Spec) {
+	if !a.InlineRequestBodies || spec.Components == nil {
+		return
+	}
+
+	inlined := make(map[string]bool)
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		rb := ref.Operation.RequestBody
+		if rb == nil {
+			continue
+		}
+		for _, content := range rb.Content {
+			if content == nil || content.Schema == nil || !strings.HasPrefix(content.Schema.Ref, a.SchemaPrefix) {
+				continue
+			}
+			name := strings.TrimPrefix(content.Schema.Ref, a.SchemaPrefix)
+			if s, ok := spec.Components.Schemas[name]; ok {
+				// Media types are built per operation: no copy needed
+				content.Schema = s
+				inlined[name] = true
+			}
+		}
+	}
+
+	// Schemas referenced by other components are kept, used or not
+	referenced := componentReferences(spec, a.SchemaPrefix)
+	for _, location := range unusedComponents(spec, a.SchemaPrefix) {
+		kind, name, _ := strings.Cut(location, "/")
+		if kind == "schemas" && inlined[name] && !referenced[location] {
+			delete(spec.Components.Schemas, name)
+		}
+	}
+}
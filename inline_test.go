@@ -0,0 +1,109 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type inlineUser struct {
+	Name string `json:"name"`
+}
+
+type inlineCreateUserBody struct {
+	User   inlineUser `json:"user"`
+	Notify bool       `json:"notify"`
+}
+
+type inlineCreateUserRequest struct {
+	Body inlineCreateUserBody `body:"structured"`
+}
+
+type inlineUpdateUserRequest struct {
+	Body inlineUser `body:"structured"`
+}
+
+type inlineNode struct {
+	Children []inlineNode `json:"children"`
+}
+
+// inlineSpec holds the parts of generated specs checked by the inline tests.
+type inlineSpec struct {
+	Paths map[string]map[string]struct {
+		RequestBody struct {
+			Content map[string]struct {
+				Schema struct {
+					Ref        string         `json:"$ref"`
+					Properties map[string]any `json:"properties"`
+				} `json:"schema"`
+			} `json:"content"`
+		} `json:"requestBody"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]any `json:"schemas"`
+	} `json:"components"`
+}
+
+func TestInlineSchema(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+	InlineSchema[inlineCreateUserBody](api)
+
+	result, err := api.Generate(context.Background(),
+		POST("/users", WithRequest(inlineCreateUserRequest{}), WithResponse(201, inlineUser{})),
+	)
+	require.NoError(t, err)
+
+	var spec inlineSpec
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	body := spec.Paths["/users"]["post"].RequestBody.Content["application/json"].Schema
+	assert.Empty(t, body.Ref)
+	assert.ElementsMatch(t, []string{"user", "notify"}, keys(body.Properties))
+	assert.ElementsMatch(t, []string{"InlineUser"}, keys(spec.Components.Schemas))
+}
+
+func TestInlineSchema_Recursive(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+	InlineSchema[inlineNode](api)
+
+	_, err := api.Generate(context.Background(), GET("/tree", WithResponse(200, inlineNode{})))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "inline schema InlineNode references itself")
+}
+
+func TestWithInlineRequestBodies(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithInlineRequestBodies(true))
+
+	result, err := api.Generate(context.Background(),
+		POST("/users", WithRequest(inlineCreateUserRequest{}), WithResponse(201, inlineUser{})),
+		PUT("/profile", WithRequest(inlineUpdateUserRequest{}), WithResponse(200, inlineUser{})),
+	)
+	require.NoError(t, err)
+
+	var spec inlineSpec
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	for path, method := range map[string]string{"/users": "post", "/profile": "put"} {
+		body := spec.Paths[path][method].RequestBody.Content["application/json"].Schema
+		assert.Empty(t, body.Ref, path)
+		assert.NotEmpty(t, body.Properties, path)
+	}
+	// The create body is used by the request only; the user also by the responses
+	assert.ElementsMatch(t, []string{"InlineUser"}, keys(spec.Components.Schemas))
+	assert.Empty(t, result.Warnings)
+}
+
+func TestWithInlineRequestBodies_ComponentReferences(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithInlineRequestBodies(true))
+	// The header is not used by the operations, but refers to the body schema
+	api.RegisterHeader("X-User", Header{Type: inlineUser{}})
+
+	result, err := api.Generate(context.Background(), PUT("/profile", WithRequest(inlineUpdateUserRequest{})))
+	require.NoError(t, err)
+
+	var spec inlineSpec
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.Empty(t, spec.Paths["/profile"]["put"].RequestBody.Content["application/json"].Schema.Ref)
+	assert.Contains(t, spec.Components.Schemas, "InlineUser")
+}
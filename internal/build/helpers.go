@@ -2,6 +2,7 @@ package build
 
 import (
 	"reflect"
+	"strings"
 
 	"github.com/talav/openapi/config"
 	"github.com/talav/openapi/metadata"
@@ -12,7 +13,11 @@ const (
 	contentTypeMultipart   = "multipart/form-data"
 	contentTypeOctetStream = "application/octet-stream"
 	contentTypeJSON        = "application/json"
+	contentTypeText        = "text/plain"
+	contentTypeHTML        = "text/html"
 	formatBinary           = "binary"
+
+	headerContentDisposition = "Content-Disposition"
 )
 
 // getSchemaHint generates a hint for schema naming from type and field name.
@@ -54,6 +59,22 @@ func deref(t reflect.Type) reflect.Type {
 	return t
 }
 
+// Implements reports whether t or a pointer to t implements the interface type iface.
+func Implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// qualifiedTypeName returns the name of a type with the import path of its package,
+// e.g. github.com/acme/api/v1.User, telling apart types from same-named packages.
+func qualifiedTypeName(t reflect.Type) string {
+	t = deref(t)
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+
+	return t.PkgPath() + "." + t.Name()
+}
+
 // toBool converts a bool or *bool to bool.
 // If the input is a pointer, returns false if nil, otherwise the dereferenced value.
 // If the input is a bool, returns it directly.
@@ -72,6 +93,27 @@ func toBool(b any) bool {
 	}
 }
 
+// JSONTag returns the property name set by the json tag of a struct field, empty
+// if the tag has none, and whether the tag has the omitzero option, leaving the
+// field out of the JSON when it is zero.
+//
+// The omitempty option is deliberately not reported. It leaves nil pointers out
+// of the JSON like omitzero, but pointers tagged omitempty have always been
+// documented nullable, and clients of existing APIs may send null for them;
+// only omitzero, whose support is new, drops nullable from pointers.
+func JSONTag(f reflect.StructField) (name string, omitzero bool) {
+	name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == "omitzero" {
+			omitzero = true
+		}
+	}
+
+	return name, omitzero
+}
+
 // isRequiredFromMetadata returns true if the field is marked required via openapi or validate tags.
 func isRequiredFromMetadata(field *schema.FieldMetadata, tagCfg config.TagConfig) bool {
 	if openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](field, tagCfg.OpenAPI); ok {
@@ -125,6 +125,31 @@ func TestDeref(t *testing.T) {
 	}
 }
 
+func TestJSONTag(t *testing.T) {
+	tests := []struct {
+		tag          string
+		wantName     string
+		wantOmitZero bool
+	}{
+		{tag: ``, wantName: ""},
+		{tag: `json:"id"`, wantName: "id"},
+		{tag: `json:"id,omitempty"`, wantName: "id"},
+		{tag: `json:"id,omitzero"`, wantName: "id", wantOmitZero: true},
+		{tag: `json:",omitzero"`, wantName: "", wantOmitZero: true},
+		{tag: `json:"id,string,omitzero"`, wantName: "id", wantOmitZero: true},
+		{tag: `json:"id,string"`, wantName: "id"},
+		{tag: `json:"-"`, wantName: "-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.tag, func(t *testing.T) {
+			name, omitzero := JSONTag(reflect.StructField{Name: "ID", Tag: reflect.StructTag(tt.tag)})
+			assert.Equal(t, tt.wantName, name)
+			assert.Equal(t, tt.wantOmitZero, omitzero)
+		})
+	}
+}
+
 func TestToBool(t *testing.T) {
 	tests := []struct {
 		name  string
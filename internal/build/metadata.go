@@ -1,24 +1,50 @@
 package build
 
 import (
+	"fmt"
 	"reflect"
+	"strings"
+	"sync"
 
 	"github.com/talav/openapi/config"
 	"github.com/talav/openapi/metadata"
 	"github.com/talav/schema"
 )
 
-// NewMetadata creates a new schema metadata instance with the given tag configuration.
+// sharedMetadata caches metadata instances by merged tag configuration, so that
+// reflection-derived struct metadata is built once per type and shared by all
+// API instances using the same tags. schema.Metadata is safe for concurrent use.
+// Schemas are not shared: they depend on the options of each API, and are cached
+// by its SchemaGenerator across generations instead.
+var sharedMetadata sync.Map // map[config.TagConfig]*schema.Metadata
+
+// NewMetadata returns the schema metadata instance for the given tag configuration.
 // Partial configs are merged with defaults using config.MergeTagConfig().
+// Instances are shared across callers using the same configuration.
 func NewMetadata(cfg config.TagConfig) *schema.Metadata {
 	// Merge with defaults to handle partial configs
 	cfg = config.MergeTagConfig(config.DefaultTagConfig(), cfg)
 
+	if cached, ok := sharedMetadata.Load(cfg); ok {
+		if m, ok := cached.(*schema.Metadata); ok {
+			return m
+		}
+	}
+
+	// Store (or get existing if another goroutine stored it first)
+	actual, _ := sharedMetadata.LoadOrStore(cfg, newMetadata(cfg))
+	m, _ := actual.(*schema.Metadata)
+
+	return m
+}
+
+// newMetadata creates a metadata instance with the parsers for the given tag configuration.
+func newMetadata(cfg config.TagConfig) *schema.Metadata {
 	return schema.NewMetadata(schema.NewTagParserRegistry(
 		schema.WithTagParser(cfg.Schema, schema.ParseSchemaTag, func(field reflect.StructField, index int) any {
 			return conditionalSchemaDefault(field, index, cfg)
 		}),
-		schema.WithTagParser(cfg.Body, schema.ParseBodyTag),
+		schema.WithTagParser(cfg.Body, metadata.ParseBodyTag),
 		schema.WithTagParser(cfg.OpenAPI, metadata.ParseOpenAPITag),
 		schema.WithTagParser(cfg.Validate, metadata.ParseValidateTag),
 		schema.WithTagParser(cfg.Default, metadata.ParseDefaultTag),
@@ -27,12 +53,47 @@ func NewMetadata(cfg config.TagConfig) *schema.Metadata {
 }
 
 // conditionalSchemaDefault applies schema default metadata only if the field doesn't have a body tag.
-// Business rule: fields with body tags should not receive default schema metadata.
+// Business rule: fields with body tags should not receive default schema metadata, and fields
+// with a header tag are header parameters named by the tag.
 func conditionalSchemaDefault(field reflect.StructField, index int, cfg config.TagConfig) any {
 	// Don't apply schema default if field has body tag
 	if _, ok := field.Tag.Lookup(cfg.Body); ok {
 		return nil
 	}
 
+	if tag, ok := field.Tag.Lookup(cfg.Header); ok {
+		name, _, _ := strings.Cut(tag, ",")
+		if name == "" {
+			name = field.Name
+		}
+
+		return &schema.SchemaMetadata{
+			ParamName: name,
+			MapKey:    field.Name,
+			Location:  schema.LocationHeader,
+			Style:     schema.StyleSimple,
+		}
+	}
+
 	return schema.DefaultSchemaMetadata(field, index)
 }
+
+// structLevelMetadata parses the openapi tag of the _ field of a struct type, which
+// struct metadata skips as unexported. It returns nil without such a tag.
+func structLevelMetadata(t reflect.Type, tagCfg config.TagConfig) (*metadata.OpenAPIMetadata, error) {
+	field, ok := t.FieldByName("_")
+	if !ok {
+		return nil, nil //nolint:nilnil // No struct-level metadata
+	}
+	tagValue, ok := field.Tag.Lookup(tagCfg.OpenAPI)
+	if !ok {
+		return nil, nil //nolint:nilnil // No struct-level metadata
+	}
+	parsed, err := metadata.ParseOpenAPITag(field, field.Index[0], tagValue)
+	if err != nil {
+		return nil, fmt.Errorf("type %s: %w", t, err)
+	}
+	om, _ := parsed.(*metadata.OpenAPIMetadata)
+
+	return om, nil
+}
@@ -1,8 +1,11 @@
 package build
 
 import (
+	"path"
 	"reflect"
+	"slices"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -10,21 +13,29 @@ import (
 // when possible, ignoring the package name. If the type is generic, e.g.
 // `MyType[SubType]`, then the brackets are removed like `MyTypeSubType`.
 // If the type is unnamed, then the name hint is used.
-// Note: if you plan to use types with the same name from different packages,
-// you should implement your own namer function to prevent issues. Nested
+// Note: types with the same name from different packages get the same name,
+// which is reported as a collision unless a namespacing qualifier is set. Nested
 // anonymous types can also present naming issues.
 func schemaNamer(t reflect.Type, hint string) string {
+	return qualifiedSchemaName(t, hint, nil)
+}
+
+// qualifiedSchemaName names types like schemaNamer, qualifying the name of each
+// named type, including generic type arguments, with its package path.
+func qualifiedSchemaName(t reflect.Type, hint string, qualify Qualifier) string {
 	name := deref(t).Name()
+	pkg := deref(t).PkgPath()
 
 	if name == "" {
 		name = hint
+		pkg = ""
 	}
 
 	// Better support for lists, so e.g. `[]int` becomes `ListInt`.
 	name = strings.ReplaceAll(name, "[]", "List[")
 
 	result := ""
-	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+	for i, part := range strings.FieldsFunc(name, func(r rune) bool {
 		// Split on special characters. Note that `,` is used when there are
 		// multiple inputs to a generic type.
 		return r == '[' || r == ']' || r == '*' || r == ','
@@ -36,9 +47,97 @@ func schemaNamer(t reflect.Type, hint string) string {
 		// Add to result, and uppercase for better scalar support (`int` -> `Int`).
 		// Use unicode-aware uppercase to support non-ASCII characters.
 		r, size := utf8.DecodeRuneInString(base)
-		result += strings.ToUpper(string(r)) + base[size:]
+		base = strings.ToUpper(string(r)) + base[size:]
+
+		if qualify != nil {
+			// Type names omit their package, unlike the generic type arguments within.
+			partPkg := strings.Join(fqn[:len(fqn)-1], ".")
+			if i == 0 {
+				partPkg = pkg
+			}
+			if partPkg != "" {
+				base = qualify(partPkg, base)
+			}
+		}
+		result += base
 	}
 	name = result
 
 	return name
 }
+
+// Qualifier qualifies the schema name of a type with the import path of its package.
+type Qualifier func(pkg, name string) string
+
+// PackagePrefix qualifies schema names with a package prefix: v1.User becomes V1User.
+func PackagePrefix(pkg, name string) string {
+	return packageQualifier(pkg) + name
+}
+
+// PackageSuffix qualifies schema names with a package suffix: v1.User becomes UserV1.
+func PackageSuffix(pkg, name string) string {
+	return name + packageQualifier(pkg)
+}
+
+// packageQualifier returns the capitalized last element of an import path,
+// e.g. V1 for github.com/acme/api/v1.
+func packageQualifier(pkg string) string {
+	base := path.Base(pkg)
+	r, size := utf8.DecodeRuneInString(base)
+
+	return string(unicode.ToUpper(r)) + base[size:]
+}
+
+// CamelCase converts a Go field name to camelCase, e.g. UserID to userId and
+// HTTPServer to httpServer.
+func CamelCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		w = strings.ToLower(w)
+		if i > 0 {
+			r, size := utf8.DecodeRuneInString(w)
+			w = string(unicode.ToUpper(r)) + w[size:]
+		}
+		words[i] = w
+	}
+
+	return strings.Join(words, "")
+}
+
+// SnakeCase converts a Go field name to snake_case, e.g. UserID to user_id and
+// HTTPServer to http_server.
+func SnakeCase(name string) string {
+	words := splitWords(name)
+	for i, w := range words {
+		words[i] = strings.ToLower(w)
+	}
+
+	return strings.Join(words, "_")
+}
+
+// splitWords splits a Go identifier into words at case changes, keeping
+// initialisms and trailing digits together: HTTPServer2 is HTTP and Server2.
+func splitWords(name string) []string {
+	var words []string
+	runes := []rune(name)
+	start := 0
+	for i := 1; i < len(runes); i++ {
+		prev, cur := runes[i-1], runes[i]
+		switch {
+		case cur == '_':
+			words = append(words, string(runes[start:i]))
+			start = i + 1
+		case unicode.IsUpper(cur) && (unicode.IsLower(prev) || unicode.IsDigit(prev)):
+			// userID: a word starts at I
+			words = append(words, string(runes[start:i]))
+			start = i
+		case unicode.IsUpper(prev) && unicode.IsLower(cur) && i-1 > start:
+			// HTTPServer: a word starts at S
+			words = append(words, string(runes[start:i-1]))
+			start = i - 1
+		}
+	}
+	words = append(words, string(runes[start:]))
+
+	return slices.DeleteFunc(words, func(w string) bool { return w == "" })
+}
@@ -1,8 +1,10 @@
 package build
 
 import (
+	"go/token"
 	"reflect"
 	"testing"
+	"text/scanner"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -253,3 +255,79 @@ func TestSchemaNamer(t *testing.T) {
 		})
 	}
 }
+
+type namerPage[T any] struct {
+	Items []T
+}
+
+func TestQualifiedSchemaName(t *testing.T) {
+	tests := []struct {
+		name   string
+		typ    reflect.Type
+		hint   string
+		prefix string
+		suffix string
+	}{
+		{
+			name:   "named type",
+			typ:    reflect.TypeOf(token.Position{}),
+			prefix: "TokenPosition",
+			suffix: "PositionToken",
+		},
+		{
+			name:   "same name in another package",
+			typ:    reflect.TypeOf(&scanner.Position{}),
+			prefix: "ScannerPosition",
+			suffix: "PositionScanner",
+		},
+		{
+			name:   "generic type arguments",
+			typ:    reflect.TypeOf(namerPage[scanner.Position]{}),
+			prefix: "BuildNamerPageScannerPosition",
+			suffix: "NamerPageBuildPositionScanner",
+		},
+		{
+			name:   "primitive",
+			typ:    reflect.TypeOf(0),
+			prefix: "Int",
+			suffix: "Int",
+		},
+		{
+			name:   "unnamed type",
+			typ:    reflect.TypeOf(struct{}{}),
+			hint:   "Request",
+			prefix: "Request",
+			suffix: "Request",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.prefix, qualifiedSchemaName(tt.typ, tt.hint, PackagePrefix))
+			assert.Equal(t, tt.suffix, qualifiedSchemaName(tt.typ, tt.hint, PackageSuffix))
+		})
+	}
+}
+
+func TestPropertyNaming(t *testing.T) {
+	tests := []struct {
+		name  string
+		camel string
+		snake string
+	}{
+		{name: "Name", camel: "name", snake: "name"},
+		{name: "FirstName", camel: "firstName", snake: "first_name"},
+		{name: "ID", camel: "id", snake: "id"},
+		{name: "UserID", camel: "userId", snake: "user_id"},
+		{name: "HTTPServer", camel: "httpServer", snake: "http_server"},
+		{name: "Address2Line", camel: "address2Line", snake: "address2_line"},
+		{name: "Already_Snake", camel: "alreadySnake", snake: "already_snake"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.camel, CamelCase(tt.name))
+			assert.Equal(t, tt.snake, SnakeCase(tt.name))
+		})
+	}
+}
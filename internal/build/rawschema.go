@@ -0,0 +1,334 @@
+package build
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// ignoredKeywords lists the JSON Schema keywords dropped from hand-written schemas.
+var ignoredKeywords = []string{"$schema", "$id", "$comment"}
+
+// ParseSchema decodes a hand-written JSON Schema, in the JSON Schema 2020-12 or
+// OpenAPI 3.0 dialect. Extensions (x-*) are kept, and the $schema, $id and $comment
+// keywords dropped. Other keywords the model cannot represent, such as $defs, are
+// reported as errors with their JSON pointer.
+func ParseSchema(data []byte) (*model.Schema, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("invalid JSON: %w", err)
+	}
+
+	d := &schemaDecoder{}
+	s := d.schema(raw, "#")
+
+	return s, errors.Join(d.errs...)
+}
+
+// schemaDecoder decodes JSON values into schemas, collecting errors.
+type schemaDecoder struct {
+	errs []error
+}
+
+func (d *schemaDecoder) fail(ptr, format string, args ...any) {
+	d.errs = append(d.errs, fmt.Errorf("%s: %s", ptr, fmt.Sprintf(format, args...)))
+}
+
+// schema decodes a schema object, or a boolean schema.
+func (d *schemaDecoder) schema(v any, ptr string) *model.Schema {
+	switch v := v.(type) {
+	case bool:
+		if v {
+			return &model.Schema{}
+		}
+
+		return &model.Schema{Not: &model.Schema{}}
+	case map[string]any:
+		s := &model.Schema{}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			d.keyword(s, key, v[key], util.Pointer(ptr, key))
+		}
+
+		return s
+	default:
+		d.fail(ptr, "want a schema object or boolean, got %T", v)
+
+		return nil
+	}
+}
+
+// keyword decodes a keyword of a schema object.
+func (d *schemaDecoder) keyword(s *model.Schema, key string, v any, ptr string) {
+	switch key {
+	case "$ref":
+		s.Ref = d.string(v, ptr)
+	case "type":
+		d.types(s, v, ptr)
+	case "nullable":
+		s.Nullable = s.Nullable || d.bool(v, ptr)
+	case "title":
+		s.Title = d.string(v, ptr)
+	case "description":
+		s.Description = d.string(v, ptr)
+	case "format":
+		s.Format = d.string(v, ptr)
+	case "contentEncoding":
+		s.ContentEncoding = d.string(v, ptr)
+	case "contentMediaType":
+		s.ContentMediaType = d.string(v, ptr)
+	case "deprecated":
+		s.Deprecated = d.bool(v, ptr)
+	case "readOnly":
+		s.ReadOnly = d.bool(v, ptr)
+	case "writeOnly":
+		s.WriteOnly = d.bool(v, ptr)
+	case "example":
+		s.Example = v
+	case "examples":
+		s.Examples = d.array(v, ptr)
+	case "pattern":
+		s.Pattern = d.string(v, ptr)
+	case "minLength":
+		s.MinLength = d.int(v, ptr)
+	case "maxLength":
+		s.MaxLength = d.int(v, ptr)
+	case "minItems":
+		s.MinItems = d.int(v, ptr)
+	case "maxItems":
+		s.MaxItems = d.int(v, ptr)
+	case "minProperties":
+		s.MinProperties = d.int(v, ptr)
+	case "maxProperties":
+		s.MaxProperties = d.int(v, ptr)
+	case "minimum", "exclusiveMinimum":
+		s.Minimum = d.bound(s.Minimum, key == "exclusiveMinimum", v, ptr)
+	case "maximum", "exclusiveMaximum":
+		s.Maximum = d.bound(s.Maximum, key == "exclusiveMaximum", v, ptr)
+	case "multipleOf":
+		if f, ok := v.(float64); ok {
+			s.MultipleOf = &f
+		} else {
+			d.fail(ptr, "want a number, got %T", v)
+		}
+	case "uniqueItems":
+		s.UniqueItems = d.bool(v, ptr)
+	case "items":
+		if items, ok := v.([]any); ok {
+			// Draft 4 tuples
+			s.PrefixItems = d.schemas(items, ptr)
+		} else {
+			s.Items = d.schema(v, ptr)
+		}
+	case "prefixItems":
+		s.PrefixItems = d.schemas(d.array(v, ptr), ptr)
+	case "additionalItems":
+		if b, ok := v.(bool); ok && !b {
+			s.NoAdditionalItems = true
+		} else if !ok {
+			d.fail(ptr, "only false is supported")
+		}
+	case "properties", "patternProperties":
+		props := d.schemaMap(v, ptr)
+		if key == "properties" {
+			s.Properties = props
+		} else {
+			s.PatternProps = props
+		}
+	case "required":
+		for i, name := range d.array(v, ptr) {
+			s.Required = append(s.Required, d.string(name, util.Pointer(ptr, fmt.Sprint(i))))
+		}
+	case "dependentRequired":
+		obj, _ := v.(map[string]any)
+		if obj == nil {
+			d.fail(ptr, "want an object, got %T", v)
+		}
+		for _, name := range slices.Sorted(maps.Keys(obj)) {
+			if s.DependentRequired == nil {
+				s.DependentRequired = make(map[string][]string)
+			}
+			for i, dep := range d.array(obj[name], util.Pointer(ptr, name)) {
+				s.DependentRequired[name] = append(s.DependentRequired[name], d.string(dep, util.Pointer(ptr, name, fmt.Sprint(i))))
+			}
+		}
+	case "additionalProperties":
+		if b, ok := v.(bool); ok {
+			s.Additional = &model.Additional{Allow: &b}
+		} else {
+			s.Additional = &model.Additional{Schema: d.schema(v, ptr)}
+		}
+	case "propertyNames":
+		s.PropertyNames = d.schema(v, ptr)
+	case "unevaluatedProperties":
+		s.Unevaluated = d.schema(v, ptr)
+	case "allOf":
+		s.AllOf = d.schemas(d.array(v, ptr), ptr)
+	case "anyOf":
+		s.AnyOf = d.schemas(d.array(v, ptr), ptr)
+	case "oneOf":
+		s.OneOf = d.schemas(d.array(v, ptr), ptr)
+	case "not":
+		s.Not = d.schema(v, ptr)
+	case "if":
+		s.If = d.schema(v, ptr)
+	case "then":
+		s.Then = d.schema(v, ptr)
+	case "else":
+		s.Else = d.schema(v, ptr)
+	case "enum":
+		s.Enum = d.array(v, ptr)
+	case "const":
+		s.Const = v
+	case "default":
+		s.Default = v
+	case "discriminator":
+		s.Discriminator = d.discriminator(v, ptr)
+	default:
+		switch {
+		case strings.HasPrefix(key, "x-"):
+			if s.Extensions == nil {
+				s.Extensions = make(map[string]any)
+			}
+			s.Extensions[key] = v
+		case slices.Contains(ignoredKeywords, key):
+		default:
+			d.fail(ptr, "unsupported keyword")
+		}
+	}
+}
+
+// types decodes the type keyword: a type, or a list of types. A null type makes
+// the schema nullable, and several other types an anyOf of them.
+func (d *schemaDecoder) types(s *model.Schema, v any, ptr string) {
+	var types []string
+	if list, ok := v.([]any); ok {
+		for i, t := range list {
+			types = append(types, d.string(t, util.Pointer(ptr, fmt.Sprint(i))))
+		}
+	} else {
+		types = []string{d.string(v, ptr)}
+	}
+
+	if i := slices.Index(types, "null"); i >= 0 {
+		s.Nullable = true
+		types = slices.Delete(types, i, i+1)
+	}
+	switch len(types) {
+	case 0:
+	case 1:
+		s.Type = types[0]
+	default:
+		for _, t := range types {
+			s.AnyOf = append(s.AnyOf, &model.Schema{Type: t})
+		}
+	}
+}
+
+// bound decodes minimum and maximum, and their exclusive variants: numbers in
+// JSON Schema 2020-12, booleans applying to the inclusive bound in OpenAPI 3.0.
+func (d *schemaDecoder) bound(b *model.Bound, exclusive bool, v any, ptr string) *model.Bound {
+	if b == nil {
+		b = &model.Bound{}
+	}
+	switch v := v.(type) {
+	case float64:
+		b.Value = v
+		b.Exclusive = b.Exclusive || exclusive
+	case bool:
+		if !exclusive {
+			d.fail(ptr, "want a number, got bool")
+		}
+		b.Exclusive = v
+	default:
+		d.fail(ptr, "want a number, got %T", v)
+	}
+
+	return b
+}
+
+func (d *schemaDecoder) discriminator(v any, ptr string) *model.Discriminator {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		d.fail(ptr, "want an object, got %T", v)
+
+		return nil
+	}
+	disc := &model.Discriminator{PropertyName: d.string(obj["propertyName"], util.Pointer(ptr, "propertyName"))}
+	if mapping, ok := obj["mapping"].(map[string]any); ok {
+		disc.Mapping = make(map[string]string, len(mapping))
+		for value, ref := range mapping {
+			disc.Mapping[value] = d.string(ref, util.Pointer(ptr, "mapping", value))
+		}
+	}
+
+	return disc
+}
+
+func (d *schemaDecoder) schemas(list []any, ptr string) []*model.Schema {
+	out := make([]*model.Schema, 0, len(list))
+	for i, v := range list {
+		out = append(out, d.schema(v, util.Pointer(ptr, fmt.Sprint(i))))
+	}
+
+	return out
+}
+
+func (d *schemaDecoder) schemaMap(v any, ptr string) map[string]*model.Schema {
+	obj, ok := v.(map[string]any)
+	if !ok {
+		d.fail(ptr, "want an object, got %T", v)
+
+		return nil
+	}
+	out := make(map[string]*model.Schema, len(obj))
+	for name, sub := range obj {
+		out[name] = d.schema(sub, util.Pointer(ptr, name))
+	}
+
+	return out
+}
+
+func (d *schemaDecoder) array(v any, ptr string) []any {
+	list, ok := v.([]any)
+	if !ok {
+		d.fail(ptr, "want an array, got %T", v)
+	}
+
+	return list
+}
+
+func (d *schemaDecoder) string(v any, ptr string) string {
+	s, ok := v.(string)
+	if !ok {
+		d.fail(ptr, "want a string, got %T", v)
+	}
+
+	return s
+}
+
+func (d *schemaDecoder) bool(v any, ptr string) bool {
+	b, ok := v.(bool)
+	if !ok {
+		d.fail(ptr, "want a boolean, got %T", v)
+	}
+
+	return b
+}
+
+func (d *schemaDecoder) int(v any, ptr string) *int {
+	f, ok := v.(float64)
+	if !ok || f != float64(int(f)) || f < 0 {
+		d.fail(ptr, "want a non-negative integer, got %v", v)
+
+		return nil
+	}
+	n := int(f)
+
+	return &n
+}
@@ -0,0 +1,79 @@
+package build
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+func TestParseSchema(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   *model.Schema
+	}{
+		{
+			name:   "nullable type list",
+			schema: `{"type": ["integer", "null"], "minimum": 1, "exclusiveMaximum": 10}`,
+			want: &model.Schema{
+				Type:     "integer",
+				Nullable: true,
+				Minimum:  &model.Bound{Value: 1},
+				Maximum:  &model.Bound{Value: 10, Exclusive: true},
+			},
+		},
+		{
+			name:   "OpenAPI 3.0 dialect",
+			schema: `{"type": "number", "nullable": true, "minimum": 0, "exclusiveMinimum": true}`,
+			want: &model.Schema{
+				Type:     "number",
+				Nullable: true,
+				Minimum:  &model.Bound{Value: 0, Exclusive: true},
+			},
+		},
+		{
+			name:   "several types",
+			schema: `{"type": ["string", "integer"]}`,
+			want:   &model.Schema{AnyOf: []*model.Schema{{Type: "string"}, {Type: "integer"}}},
+		},
+		{
+			name:   "object",
+			schema: `{"$id": "urn:thing", "type": "object", "required": ["a"], "properties": {"a": {"$ref": "#/components/schemas/A"}}, "additionalProperties": false, "x-owner": "billing"}`,
+			want: &model.Schema{
+				Type:       "object",
+				Required:   []string{"a"},
+				Properties: map[string]*model.Schema{"a": {Ref: "#/components/schemas/A"}},
+				Additional: &model.Additional{Allow: new(bool)},
+				Extensions: map[string]any{"x-owner": "billing"},
+			},
+		},
+		{
+			name:   "tuple",
+			schema: `{"type": "array", "items": [{"type": "string"}, true], "additionalItems": false}`,
+			want: &model.Schema{
+				Type:              "array",
+				PrefixItems:       []*model.Schema{{Type: "string"}, {}},
+				NoAdditionalItems: true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseSchema([]byte(tt.schema))
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseSchema_Errors(t *testing.T) {
+	_, err := ParseSchema([]byte(`{"definitions": {}, "properties": {"a": {"type": 1, "enum": "x"}}}`))
+	require.Error(t, err)
+	assert.Equal(t, "#/definitions: unsupported keyword\n"+
+		"#/properties/a/enum: want an array, got string\n"+
+		"#/properties/a/type: want a string, got float64", err.Error())
+}
@@ -1,17 +1,23 @@
 package build
 
 import (
+	"errors"
 	"fmt"
+	"maps"
 	"reflect"
+	"strconv"
 
 	"github.com/talav/openapi/config"
 	"github.com/talav/openapi/internal/model"
 	"github.com/talav/openapi/metadata"
 	"github.com/talav/schema"
+	"github.com/talav/tagparser"
 )
 
 type RequestBuilder interface {
 	BuildRequest(op *model.Operation, inputType reflect.Type) error
+	// PathFields maps the path parameter names of an input struct type to their field names.
+	PathFields(inputType reflect.Type) (map[string]string, error)
 }
 
 // requestBuilder extracts OpenAPI request schemas from input struct types.
@@ -48,7 +54,9 @@ func (rb *requestBuilder) BuildRequest(op *model.Operation, inputType reflect.Ty
 
 	// Process parameters (fields with "schema" tag, excluding body)
 	// Parameters can be in path, query, header, or cookie locations
-	rb.buildParameters(op, structMeta, inputType)
+	if err := rb.buildParameters(op, structMeta, inputType); err != nil {
+		return err
+	}
 
 	// Process request body (field with "body" tag)
 	// Body is handled separately as it's not a parameter
@@ -56,13 +64,53 @@ func (rb *requestBuilder) BuildRequest(op *model.Operation, inputType reflect.Ty
 		return fmt.Errorf("failed to build request body schema: %w", err)
 	}
 
+	return rb.buildSecurity(op, inputType)
+}
+
+// buildSecurity applies the security requirements declared on the _ field of an
+// input struct type (security and scopes options), unless the operation has its own.
+// Each scheme is an alternative requirement, with the scopes.
+func (rb *requestBuilder) buildSecurity(op *model.Operation, inputType reflect.Type) error {
+	if len(op.Security) > 0 {
+		return nil
+	}
+	openAPIMeta, err := structLevelMetadata(inputType, rb.tagCfg)
+	if err != nil || openAPIMeta == nil {
+		return err
+	}
+
+	for _, scheme := range openAPIMeta.Security {
+		scopes := append([]string{}, openAPIMeta.Scopes...)
+		op.Security = append(op.Security, model.SecurityRequirement{scheme: scopes})
+	}
+
 	return nil
 }
 
+// PathFields maps the names of the path parameters declared by an input struct type
+// (fields with location=path) to the names of their struct fields.
+func (rb *requestBuilder) PathFields(inputType reflect.Type) (map[string]string, error) {
+	structMeta, err := rb.metadata.GetStructMetadata(inputType)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get struct metadata for type %s: %w", inputType, err)
+	}
+
+	fields := make(map[string]string)
+	for i := range structMeta.Fields {
+		field := &structMeta.Fields[i]
+		schemaMeta, ok := schema.GetTagMetadata[*schema.SchemaMetadata](field, rb.tagCfg.Schema)
+		if ok && schemaMeta.Location == schema.LocationPath {
+			fields[schemaMeta.ParamName] = field.StructFieldName
+		}
+	}
+
+	return fields, nil
+}
+
 // buildParameters extracts OpenAPI parameters from struct fields with "schema" tag.
 // Skips fields with "body" tag (handled separately).
 // Only processes valid parameter locations: path, query, header, cookie.
-func (rb *requestBuilder) buildParameters(op *model.Operation, structMeta *schema.StructMetadata, inputType reflect.Type) {
+func (rb *requestBuilder) buildParameters(op *model.Operation, structMeta *schema.StructMetadata, inputType reflect.Type) error {
 	if op.Parameters == nil {
 		op.Parameters = make([]model.Parameter, 0, len(structMeta.Fields))
 	}
@@ -82,35 +130,143 @@ func (rb *requestBuilder) buildParameters(op *model.Operation, structMeta *schem
 		if paramSchema == nil {
 			continue
 		}
+		rb.generator.applyParameterConstraints(paramSchema, *field)
+		if err := checkParameterStyle(schemaMeta, paramSchema); err != nil {
+			return fmt.Errorf("parameter %s: %w", schemaMeta.ParamName, err)
+		}
+		required, err := rb.isParameterRequired(inputType, field, schemaMeta)
+		if err != nil {
+			return fmt.Errorf("parameter %s: %w", schemaMeta.ParamName, err)
+		}
 
 		// Create and add parameter using values from schema parser
-		op.Parameters = append(op.Parameters, model.Parameter{
+		param := model.Parameter{
 			Name:        schemaMeta.ParamName,
 			Description: rb.getDescription(field),
 			In:          string(schemaMeta.Location),
-			Required:    rb.isParameterRequired(field, schemaMeta),
+			Required:    required,
 			Schema:      paramSchema,
 			Style:       string(schemaMeta.Style),
 			Explode:     schemaMeta.Explode,
-		})
+		}
+		rb.applyParameterMetadata(&param, field, schemaMeta)
+		op.Parameters = append(op.Parameters, param)
+	}
+
+	return nil
+}
+
+// checkParameterStyle checks that the style of a parameter can serialize its
+// values: deepObject takes objects, spaceDelimited and pipeDelimited take arrays
+// or objects, and arrays hold scalars, as styles do not define how to serialize
+// nested values. Objects are structs and maps, referenced or not.
+func checkParameterStyle(schemaMeta *schema.SchemaMetadata, s *model.Schema) error {
+	kind := s.Type
+	switch {
+	case s.Ref != "":
+		kind = TypeObject
+	case kind == "":
+		kind = "any value"
+	}
+
+	switch schemaMeta.Style {
+	case schema.StyleDeepObject:
+		if kind != TypeObject {
+			return fmt.Errorf("style %s requires an object, got %s", schemaMeta.Style, kind)
+		}
+	case schema.StyleSpaceDelimited, schema.StylePipeDelimited:
+		if kind != TypeArray && kind != TypeObject {
+			return fmt.Errorf("style %s requires an array or an object, got %s", schemaMeta.Style, kind)
+		}
+	}
+	if kind == TypeArray && s.Items != nil && (s.Items.Ref != "" || s.Items.Type == TypeArray || s.Items.Type == TypeObject) {
+		return fmt.Errorf("arrays of arrays or objects cannot be serialized in the %s", schemaMeta.Location)
+	}
+
+	return nil
+}
+
+// applyParameterMetadata applies the openapi tag of a parameter field: deprecated,
+// allowEmptyValue (query parameters only), examples and extensions. A single
+// example is the parameter example; several are named examples named after their values.
+func (rb *requestBuilder) applyParameterMetadata(param *model.Parameter, field *schema.FieldMetadata, schemaMeta *schema.SchemaMetadata) {
+	openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](field, rb.tagCfg.OpenAPI)
+	if !ok {
+		return
+	}
+
+	param.Deprecated = toBool(openAPIMeta.Deprecated)
+	param.AllowEmptyValue = schemaMeta.Location == schema.LocationQuery && toBool(openAPIMeta.AllowEmptyValue)
+	param.Extensions = maps.Clone(openAPIMeta.Extensions)
+	if openAPIMeta.Format != "" && param.Schema.Ref == "" {
+		param.Schema.Format = openAPIMeta.Format
+	}
+
+	switch len(openAPIMeta.Examples) {
+	case 0:
+	case 1:
+		param.Example = openAPIMeta.Examples[0]
+	default:
+		param.Examples = make(map[string]*model.Example, len(openAPIMeta.Examples))
+		for _, v := range openAPIMeta.Examples {
+			param.Examples[fmt.Sprint(v)] = &model.Example{Value: v}
+		}
 	}
 }
 
 // isParameterRequired determines if a parameter is required.
 // Path parameters are always required per OpenAPI spec.
-// For other locations, required is derived from openapi or validate tags, or defaults to false.
-func (rb *requestBuilder) isParameterRequired(field *schema.FieldMetadata, schemaMeta *schema.SchemaMetadata) bool {
-	if schemaMeta.Location == schema.LocationPath {
-		return true
+// For other locations, the required option of the schema tag (schema:"q,required"
+// or schema:"q,required=false") takes precedence; otherwise required is derived
+// from openapi or validate tags, or defaults to false.
+func (rb *requestBuilder) isParameterRequired(inputType reflect.Type, field *schema.FieldMetadata, schemaMeta *schema.SchemaMetadata) (bool, error) {
+	required, ok, err := schemaTagRequired(inputType, field, rb.tagCfg)
+	switch {
+	case err != nil:
+		return false, err
+	case schemaMeta.Location == schema.LocationPath:
+		if ok && !required {
+			return false, errors.New("path parameters are always required")
+		}
+
+		return true, nil
+	case ok:
+		return required, nil
+	default:
+		return isRequiredFromMetadata(field, rb.tagCfg), nil
 	}
+}
 
-	return isRequiredFromMetadata(field, rb.tagCfg)
+// schemaTagRequired returns the value of the required option of the schema tag of
+// a field, which the schema tag parser ignores, and whether it is set.
+func schemaTagRequired(inputType reflect.Type, field *schema.FieldMetadata, tagCfg config.TagConfig) (required, ok bool, err error) {
+	sf, found := deref(inputType).FieldByName(field.StructFieldName)
+	if !found {
+		return false, false, nil
+	}
+	tag, err := tagparser.ParseWithName(sf.Tag.Get(tagCfg.Schema))
+	if err != nil {
+		return false, false, fmt.Errorf("failed to parse schema tag: %w", err)
+	}
+	value, ok := tag.Options["required"]
+	if !ok {
+		return false, false, nil
+	}
+	if value == "" {
+		return true, true, nil
+	}
+	required, err = strconv.ParseBool(value)
+	if err != nil {
+		return false, false, fmt.Errorf("invalid required value %q", value)
+	}
+
+	return required, true, nil
 }
 
 // getDescription returns the description from openapi metadata for the field, or "" if unset.
 func (rb *requestBuilder) getDescription(field *schema.FieldMetadata) string {
 	if openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](field, rb.tagCfg.OpenAPI); ok {
-		return openAPIMeta.Description
+		return rb.generator.description(openAPIMeta, "field "+field.StructFieldName)
 	}
 
 	return ""
@@ -191,6 +347,14 @@ func isRequestBodyRequired(bodyField *schema.FieldMetadata, tagCfg config.TagCon
 // generateBodySchema generates and transforms the request body schema based on body type.
 // Returns the schema and optional encoding map (for multipart).
 func (rb *requestBuilder) generateBodySchema(bodyField *schema.FieldMetadata, bodyMeta *schema.BodyMetadata, hint string) (*model.Schema, map[string]*model.Encoding) {
+	// Text and download bodies are documented as strings regardless of the Go type
+	switch bodyMeta.BodyType {
+	case metadata.BodyTypeText, metadata.BodyTypeHTML:
+		return &model.Schema{Type: TypeString}, nil
+	case metadata.BodyTypeDownload:
+		return &model.Schema{Type: TypeString, Format: formatBinary}, nil
+	}
+
 	// Multipart schemas must be inline and excluded from components
 	allowRef := bodyMeta.BodyType != schema.BodyTypeMultipart
 	if !allowRef {
@@ -274,8 +438,12 @@ func getRequestContentType(bodyType schema.BodyType) string {
 	switch bodyType {
 	case schema.BodyTypeMultipart:
 		return contentTypeMultipart
-	case schema.BodyTypeFile:
+	case schema.BodyTypeFile, metadata.BodyTypeDownload:
 		return contentTypeOctetStream
+	case metadata.BodyTypeText:
+		return contentTypeText
+	case metadata.BodyTypeHTML:
+		return contentTypeHTML
 	case schema.BodyTypeStructured:
 		fallthrough
 	default:
@@ -1,10 +1,13 @@
 package build
 
 import (
+	"encoding/csv"
+	"errors"
 	"fmt"
 	"net/http"
 	"reflect"
 	"strconv"
+	"strings"
 
 	"github.com/talav/openapi/config"
 	"github.com/talav/openapi/internal/model"
@@ -21,8 +24,18 @@ type BaseRoute struct {
 
 type ResponseBuilder interface {
 	BuildOperationResponses(op *model.Operation, responses map[int]reflect.Type) error
+	BuildResponseContent(op *model.Operation, status int, contentType string, body reflect.Type) error
+	BuildCSVContent(op *model.Operation, status int, row reflect.Type) error
 }
 
+const (
+	// ContentTypeCSV is the content type of CSV representations.
+	ContentTypeCSV = "text/csv"
+
+	// ExtensionColumns lists the columns of a CSV representation.
+	ExtensionColumns = "x-columns"
+)
+
 // ContentTypeProvider allows you to override the content type for responses,
 // allowing you to return a different content type like
 // `application/problem+json` after using the `application/json` marshaller.
@@ -62,6 +75,28 @@ func (rb *responseBuilder) BuildOperationResponses(op *model.Operation, response
 }
 
 func (rb *responseBuilder) buildOperationResponse(op *model.Operation, status int, response reflect.Type) error {
+	// Responses without body, such as 204 or redirects
+	if response == nil {
+		key := StatusKey(status)
+		if op.Responses[key] == nil {
+			op.Responses[key] = &model.Response{Description: StatusText(status)}
+		}
+
+		return nil
+	}
+
+	// Non-struct bodies (slices, maps, strings) are used as-is
+	response = deref(response)
+	if response.Kind() != reflect.Struct {
+		hint := getSchemaHint(response, StatusKey(status)+"Response", op.OperationID)
+		resp := getResponse(op, status)
+		resp.Content[rb.determineContentType(response, schema.BodyTypeStructured)] = &model.MediaType{
+			Schema: rb.generator.schema(response, true, hint),
+		}
+
+		return nil
+	}
+
 	structMeta, err := rb.metadata.GetStructMetadata(response)
 	if err != nil {
 		return fmt.Errorf("failed to get struct metadata for type %s: %w", response, err)
@@ -69,22 +104,42 @@ func (rb *responseBuilder) buildOperationResponse(op *model.Operation, status in
 
 	resp := getResponse(op, status)
 
+	// Extract headers only when using wrapper pattern. They are built first so
+	// that the headers documented for downloads do not replace declared ones.
+	rb.buildResponseHeaders(structMeta, resp)
+
 	// Extract body schema - handles both tagged fields and plain structs
 	if err := rb.extractBodySchema(structMeta, resp, op.OperationID); err != nil {
 		return err
 	}
 
-	// Extract headers only when using wrapper pattern
-	rb.buildResponseHeaders(structMeta, resp)
-
 	return nil
 }
 
 // extractBodySchema extracts the body schema and adds it to the response.
 // Supports both wrapper pattern (bodyField != nil) and plain struct pattern (bodyField == nil).
 func (rb *responseBuilder) extractBodySchema(structMeta *schema.StructMetadata, resp *model.Response, operationID string) error {
+	ct, bodySchema, bodyType, err := rb.resolveBody(structMeta, operationID)
+	if err != nil {
+		return err
+	}
+
+	if bodyType == metadata.BodyTypeDownload {
+		addDownloadHeaders(resp)
+	}
+
+	// Set response content
+	resp.Content[ct] = &model.MediaType{
+		Schema: bodySchema,
+	}
+
+	return nil
+}
+
+// resolveBody determines the content type, schema and body type of a response struct.
+// Supports both wrapper pattern (body-tagged field) and plain struct pattern.
+func (rb *responseBuilder) resolveBody(structMeta *schema.StructMetadata, operationID string) (string, *model.Schema, schema.BodyType, error) {
 	var bodyType reflect.Type
-	var bodyMeta *schema.BodyMetadata
 	var hint string
 	var schemaBodyType schema.BodyType
 
@@ -92,10 +147,9 @@ func (rb *responseBuilder) extractBodySchema(structMeta *schema.StructMetadata,
 	bodyField := findBodyField(structMeta, rb.tagCfg)
 	if bodyField != nil {
 		// Wrapper pattern: extract from tagged field
-		var ok bool
-		bodyMeta, ok = schema.GetTagMetadata[*schema.BodyMetadata](bodyField, rb.tagCfg.Body)
+		bodyMeta, ok := schema.GetTagMetadata[*schema.BodyMetadata](bodyField, rb.tagCfg.Body)
 		if !ok {
-			return fmt.Errorf("body field missing body metadata")
+			return "", nil, "", fmt.Errorf("body field missing body metadata")
 		}
 		bodyType = bodyField.Type
 		schemaBodyType = bodyMeta.BodyType
@@ -111,19 +165,129 @@ func (rb *responseBuilder) extractBodySchema(structMeta *schema.StructMetadata,
 	ct := rb.determineContentType(bodyType, schemaBodyType)
 
 	// Generate schema
-	bodySchema := rb.generator.schema(bodyType, true, hint)
-	if bodyMeta != nil && bodyMeta.BodyType == schema.BodyTypeFile {
-		bodySchema = transformSchemaForFileResponse(bodySchema)
+	var bodySchema *model.Schema
+	switch schemaBodyType {
+	case metadata.BodyTypeText, metadata.BodyTypeHTML:
+		// Text bodies are documented as plain strings regardless of the Go type
+		bodySchema = &model.Schema{Type: TypeString}
+	case metadata.BodyTypeDownload:
+		bodySchema = &model.Schema{Type: TypeString, Format: formatBinary}
+	default:
+		bodySchema = rb.generator.schema(bodyType, true, hint)
+		if schemaBodyType == schema.BodyTypeFile {
+			bodySchema = transformSchemaForFileResponse(bodySchema)
+		}
 	}
 
-	// Set response content
-	resp.Content[ct] = &model.MediaType{
+	return ct, bodySchema, schemaBodyType, nil
+}
+
+// BuildResponseContent adds an additional representation of a response under the given content type.
+// The body type follows the same rules as response types: a plain type or a struct with a
+// body-tagged field. Header fields of wrapper structs are merged into the response headers.
+func (rb *responseBuilder) BuildResponseContent(op *model.Operation, status int, contentType string, body reflect.Type) error {
+	if body == nil {
+		return errors.New("the body is nil; pass a value of the type documenting the representation")
+	}
+	if op.Responses == nil {
+		op.Responses = make(map[string]*model.Response)
+	}
+	resp := getResponse(op, status)
+
+	// Non-struct bodies (strings, slices, maps) are used as-is
+	if body.Kind() != reflect.Struct {
+		hint := getSchemaHint(body, StatusKey(status)+"Response", op.OperationID)
+		resp.Content[contentType] = &model.MediaType{
+			Schema: rb.generator.schema(body, true, hint),
+		}
+
+		return nil
+	}
+
+	structMeta, err := rb.metadata.GetStructMetadata(body)
+	if err != nil {
+		return fmt.Errorf("failed to get struct metadata for type %s: %w", body, err)
+	}
+
+	_, bodySchema, bodyType, err := rb.resolveBody(structMeta, op.OperationID)
+	if err != nil {
+		return err
+	}
+
+	resp.Content[contentType] = &model.MediaType{
 		Schema: bodySchema,
 	}
 
+	rb.buildResponseHeaders(structMeta, resp)
+
+	if bodyType == metadata.BodyTypeDownload {
+		addDownloadHeaders(resp)
+	}
+
+	return nil
+}
+
+// BuildCSVContent adds a text/csv representation of a response whose rows are the given
+// struct type. CSV is plain text: the schema is a string listing the columns in the
+// x-columns extension, and the example is the header row. Columns are named after the
+// fields like JSON properties, in declaration order, with the fields of embedded structs.
+func (rb *responseBuilder) BuildCSVContent(op *model.Operation, status int, row reflect.Type) error {
+	if row != nil {
+		row = deref(row)
+	}
+	if row == nil || row.Kind() != reflect.Struct {
+		return fmt.Errorf("CSV rows must be structs, got %s", row)
+	}
+	if _, err := rb.metadata.GetStructMetadata(row); err != nil {
+		return fmt.Errorf("failed to get struct metadata for type %s: %w", row, err)
+	}
+
+	props := rb.generator.Properties(row)
+	columns := make([]string, len(props))
+	for i, prop := range props {
+		columns[i] = prop.Name
+	}
+
+	var header strings.Builder
+	w := csv.NewWriter(&header)
+	if err := w.Write(columns); err != nil {
+		return fmt.Errorf("failed to write CSV header: %w", err)
+	}
+	w.Flush()
+
+	if op.Responses == nil {
+		op.Responses = make(map[string]*model.Response)
+	}
+	getResponse(op, status).Content[ContentTypeCSV] = &model.MediaType{
+		Schema: &model.Schema{
+			Type:       TypeString,
+			Extensions: map[string]any{ExtensionColumns: columns},
+		},
+		Example: header.String(),
+	}
+
 	return nil
 }
 
+// addDownloadHeaders documents the Content-Disposition header sent with file downloads.
+// Headers declared explicitly on the response struct take precedence, in any casing.
+func addDownloadHeaders(resp *model.Response) {
+	if resp.Headers == nil {
+		resp.Headers = make(map[string]*model.Header)
+	}
+
+	for name := range resp.Headers {
+		if strings.EqualFold(name, headerContentDisposition) {
+			return
+		}
+	}
+
+	resp.Headers[headerContentDisposition] = &model.Header{
+		Description: `Indicates that the content is an attachment, e.g. attachment; filename="report.csv"`,
+		Schema:      &model.Schema{Type: TypeString},
+	}
+}
+
 // determineContentType determines the content type for a response body.
 // Uses bodyMeta if available (wrapper pattern), otherwise defaults to JSON.
 func (rb *responseBuilder) determineContentType(bodyType reflect.Type, bodySchemaType schema.BodyType) string {
@@ -132,8 +296,12 @@ func (rb *responseBuilder) determineContentType(bodyType reflect.Type, bodySchem
 	switch bodySchemaType {
 	case schema.BodyTypeStructured:
 		ct = contentTypeJSON
-	case schema.BodyTypeFile:
+	case schema.BodyTypeFile, metadata.BodyTypeDownload:
 		ct = contentTypeOctetStream
+	case metadata.BodyTypeText:
+		ct = contentTypeText
+	case metadata.BodyTypeHTML:
+		ct = contentTypeHTML
 	case schema.BodyTypeMultipart:
 		// Multipart is not valid for responses, but we'll default to JSON
 		// The validation will be caught elsewhere if needed
@@ -152,8 +320,8 @@ func (rb *responseBuilder) determineContentType(bodyType reflect.Type, bodySchem
 	return ct
 }
 
-// buildResponseHeaders extracts header schemas from fields with "schema" tag and location=header
-// and adds them to the success response.
+// buildResponseHeaders extracts header schemas from fields with "schema" tag and location=header,
+// or with "header" tag, and adds them to the success response.
 func (rb *responseBuilder) buildResponseHeaders(structMeta *schema.StructMetadata, response *model.Response) {
 	if response.Headers == nil {
 		response.Headers = make(map[string]*model.Header)
@@ -188,18 +356,47 @@ func (rb *responseBuilder) buildResponseHeaders(structMeta *schema.StructMetadat
 		hint := getSchemaHint(structMeta.Type, fieldMeta.StructFieldName, headerName)
 		headerSchema := rb.generator.schema(fieldType, true, hint)
 
+		// Create header parameter
+		header := &model.Header{
+			Schema:   headerSchema,
+			Required: isRequiredFromMetadata(&fieldMeta, rb.tagCfg),
+		}
+
 		// Get description from openapi metadata if available
-		description := ""
 		if openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](&fieldMeta, rb.tagCfg.OpenAPI); ok {
-			description = openAPIMeta.Description
+			header.Description = rb.generator.description(openAPIMeta, "field "+fieldMeta.StructFieldName)
+			header.Deprecated = toBool(openAPIMeta.Deprecated)
 		}
+		response.Headers[headerName] = header
+	}
+}
 
-		// Create header parameter
-		response.Headers[headerName] = &model.Header{
-			Schema:      headerSchema,
-			Description: description,
-		}
+// statusRanges holds the descriptions of the status code ranges, by class.
+var statusRanges = [...]string{
+	1: "Informational",
+	2: "Success",
+	3: "Redirection",
+	4: "Client error",
+	5: "Server error",
+}
+
+// StatusKey returns the key of a status code in the responses map. Status codes
+// 1 to 5 stand for the ranges of their class, such as "3XX".
+func StatusKey(status int) string {
+	if status > 0 && status < len(statusRanges) {
+		return strconv.Itoa(status) + "XX"
 	}
+
+	return strconv.Itoa(status)
+}
+
+// StatusText returns the default description of the response of a status code.
+func StatusText(status int) string {
+	if status > 0 && status < len(statusRanges) {
+		return statusRanges[status]
+	}
+
+	return http.StatusText(status)
 }
 
 // getResponse ensures a response exists for the given status code.
@@ -207,10 +404,10 @@ func (rb *responseBuilder) buildResponseHeaders(structMeta *schema.StructMetadat
 // If description is empty, it uses the HTTP status text.
 // Returns the response (existing or newly created).
 func getResponse(op *model.Operation, statusCode int) *model.Response {
-	statusStr := strconv.Itoa(statusCode)
+	statusStr := StatusKey(statusCode)
 	if op.Responses[statusStr] == nil {
 		op.Responses[statusStr] = &model.Response{
-			Description: http.StatusText(statusCode),
+			Description: StatusText(statusCode),
 		}
 	}
 
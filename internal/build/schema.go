@@ -2,12 +2,17 @@ package build
 
 import (
 	"encoding"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"math"
 	"math/bits"
 	"net"
 	"net/url"
 	"reflect"
+	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -36,7 +41,12 @@ var (
 	// Interface types for efficient implementation checks without allocation.
 	schemaTransformerType = reflect.TypeOf((*hook.SchemaTransformer)(nil)).Elem()
 	schemaProviderType    = reflect.TypeOf((*hook.SchemaProvider)(nil)).Elem()
+	contextProviderType   = reflect.TypeOf((*hook.ContextSchemaProvider)(nil)).Elem()
+	conditionalSchemaType = reflect.TypeOf((*hook.ConditionalSchema)(nil)).Elem()
+	marshalSchemaType     = reflect.TypeOf((*hook.MarshalSchema)(nil)).Elem()
 	textUnmarshalerType   = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType     = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+	jsonMarshalerType     = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
 
 	// Standard library types for schema generation.
 	timeType   = reflect.TypeOf(time.Time{})
@@ -62,8 +72,48 @@ type SchemaGenerator struct {
 	seen    map[reflect.Type]string // type -> name mapping for deduplication
 
 	// Options
-	inlineOnly map[string]bool               // Schemas excluded from components
-	aliases    map[reflect.Type]reflect.Type // Type aliases
+	inlineOnly map[string]bool                 // Schemas excluded from components
+	inline     map[reflect.Type]bool           // Types never referenced
+	generating map[string]bool                 // Schemas being generated, for recursive types
+	aliases    map[reflect.Type]reflect.Type   // Type aliases
+	unions     map[reflect.Type][]UnionVariant // Implementations of interface types
+	enums      map[reflect.Type][]EnumValue    // Values of enum types
+	naming     func(string) string             // Property names of fields without name tags
+	fieldHooks []hook.FieldTransformer         // Transformers of field schemas
+	catalog    map[string]string               // Descriptions of the descriptionKey option
+	inlineAnon bool                            // Anonymous structs are never referenced
+	pathNames  bool                            // Anonymous types are named after their property path
+	parents    []string                        // Names of the types being generated, when naming by path
+
+	// augments holds the openapi tag metadata attached to fields with
+	// AugmentField, by type and field name.
+	augments map[reflect.Type]map[string]*metadata.OpenAPIMetadata
+
+	// shadowed holds the fields hidden by fields with the same property name, by
+	// struct type.
+	shadowed map[reflect.Type][]Shadowing
+
+	// used holds the names of the component schemas generated or referenced
+	// since the last call of ResetUsage.
+	used map[string]bool
+
+	// errs lists the types whose schema name is taken by another type, the inline
+	// types referencing themselves, the description keys missing from the catalog,
+	// the ambiguous fields of structs and the types whose schema failed to
+	// generate, such as those with invalid field metadata.
+	errs []error
+
+	// failures counts the types whose schema failed to generate.
+	failures int
+}
+
+// UnionVariant is a registered implementation of an interface type.
+type UnionVariant struct {
+	// Name is the discriminator value of the implementation.
+	Name string
+
+	// Type is the implementation type.
+	Type reflect.Type
 }
 
 // NewSchemaGenerator creates a new schema generator with the given configuration.
@@ -77,8 +127,121 @@ func NewSchemaGenerator(prefix string, m *schema.Metadata, tagCfg config.TagConf
 		types:      make(map[string]reflect.Type),
 		seen:       make(map[reflect.Type]string),
 		inlineOnly: make(map[string]bool),
+		inline:     make(map[reflect.Type]bool),
+		generating: make(map[string]bool),
 		aliases:    make(map[reflect.Type]reflect.Type),
+		unions:     make(map[reflect.Type][]UnionVariant),
+		enums:      make(map[reflect.Type][]EnumValue),
+		augments:   make(map[reflect.Type]map[string]*metadata.OpenAPIMetadata),
+		shadowed:   make(map[reflect.Type][]Shadowing),
+		used:       make(map[string]bool),
+	}
+}
+
+// SetPropertyNaming sets the function naming the properties of struct fields without
+// json or schema tag names after the field name. Nil keeps field names as is.
+func (g *SchemaGenerator) SetPropertyNaming(naming func(string) string) {
+	g.naming = naming
+}
+
+// SetNamespacing qualifies the schema names of named types with their package, so
+// that types with the same name from different packages get distinct schemas.
+// It must be set before generating schemas.
+func (g *SchemaGenerator) SetNamespacing(qualify Qualifier) {
+	g.namer = func(t reflect.Type, hint string) string {
+		return qualifiedSchemaName(t, hint, qualify)
+	}
+}
+
+// Err returns the schema name collisions, recursive inline types, unknown
+// description keys, ambiguous promoted fields and schema generation failures
+// found since the last call, and clears them.
+func (g *SchemaGenerator) Err() error {
+	err := errors.Join(g.errs...)
+	g.errs = nil
+
+	return err
+}
+
+// InlineAnonymousStructs inlines the schemas of anonymous struct types wherever
+// they are used, instead of referencing component schemas with synthesized names.
+// It must be set before generating schemas.
+func (g *SchemaGenerator) InlineAnonymousStructs() {
+	g.inlineAnon = true
+}
+
+// NameAnonymousStructsByPath names the schemas of anonymous types after their
+// property path from the enclosing schema: the name of the enclosing schema
+// followed by the field names, and Item or Value for the elements of slices and
+// maps, e.g. CreateUserRequestBodyAddressGeo. It must be set before generating
+// schemas.
+func (g *SchemaGenerator) NameAnonymousStructsByPath() {
+	g.pathNames = true
+}
+
+// childHint returns the naming hint of a type within the type being generated,
+// used if the type is unnamed: its property path when naming by path, the given
+// hint otherwise.
+func (g *SchemaGenerator) childHint(segment, hint string) string {
+	if !g.pathNames || len(g.parents) == 0 {
+		return hint
+	}
+
+	return g.parents[len(g.parents)-1] + segment
+}
+
+// SetDescriptions sets the description catalog, mapping the keys of the
+// descriptionKey option of openapi tags to descriptions. It must be set before
+// generating schemas.
+func (g *SchemaGenerator) SetDescriptions(catalog map[string]string) {
+	g.catalog = catalog
+}
+
+// description returns the description of an openapi tag: its description, or the
+// description of its descriptionKey in the catalog. Keys missing from the catalog
+// are recorded as errors, prefixed with the tagged field or type.
+func (g *SchemaGenerator) description(openAPIMeta *metadata.OpenAPIMetadata, owner string) string {
+	if openAPIMeta.DescriptionKey == "" {
+		return openAPIMeta.Description
 	}
+	description, ok := g.catalog[openAPIMeta.DescriptionKey]
+	if !ok {
+		g.errs = append(g.errs, fmt.Errorf("%s: description key %q is not in the description catalog", owner, openAPIMeta.DescriptionKey))
+	}
+
+	return description
+}
+
+// AddFieldTransformer adds a transformer of the schemas of struct fields, applied
+// after their struct tags in the order added. It must be added before generating
+// schemas.
+func (g *SchemaGenerator) AddFieldTransformer(fn hook.FieldTransformer) {
+	g.fieldHooks = append(g.fieldHooks, fn)
+}
+
+// RegisterUnion registers the implementations of an interface type. Fields of the
+// interface type get a oneOf of the implementation schemas instead of any value.
+func (g *SchemaGenerator) RegisterUnion(iface reflect.Type, variants []UnionVariant) {
+	g.unions[iface] = variants
+}
+
+// EnumValue is a value of an enum type.
+type EnumValue struct {
+	// Name is the name of the Go constant.
+	Name string
+
+	// Value is the value of the constant.
+	Value any
+
+	// Description documents the value, if not empty.
+	Description string
+}
+
+// RegisterEnum registers the values of an enum type. Schemas of the type get an
+// enum of the values, with the constant names and value descriptions in the
+// x-enum-varnames and x-enum-descriptions extensions.
+func (g *SchemaGenerator) RegisterEnum(t reflect.Type, values []EnumValue) {
+	g.enums[t] = values
 }
 
 // Schema generates a schema for the given type. It handles caching, references,
@@ -100,6 +263,184 @@ func (g *SchemaGenerator) Schemas() map[string]*model.Schema {
 	return result
 }
 
+// ResetUsage forgets the component schemas generated or referenced so far, so
+// that Used reports those of the next generation only. Schemas are still cached.
+func (g *SchemaGenerator) ResetUsage() {
+	clear(g.used)
+}
+
+// Used reports whether the named component schema was generated or referenced
+// since the last call of ResetUsage. The schemas it references may not be.
+func (g *SchemaGenerator) Used(name string) bool {
+	return g.used[name]
+}
+
+// TypeOf returns the Go type a named component schema was generated from.
+func (g *SchemaGenerator) TypeOf(name string) (reflect.Type, bool) {
+	t, ok := g.types[name]
+
+	return t, ok
+}
+
+// RefFor implements hook.SchemaContext. Types not getting component schemas on
+// their own, such as enums, are registered under their name when first requested.
+func (g *SchemaGenerator) RefFor(t reflect.Type, hint string) *model.Schema {
+	if g.shouldGetRef(deref(t)) {
+		return g.schema(t, true, hint)
+	}
+
+	name := g.namer(t, hint)
+	g.used[name] = true
+	if _, ok := g.schemas[name]; ok {
+		if g.types[name] != deref(t) {
+			g.reportCollision(name, t)
+		}
+
+		return &model.Schema{Ref: g.prefix + name}
+	}
+	s := g.schema(t, false, hint)
+	g.schemas[name] = s
+	g.types[name] = deref(t)
+	g.seen[deref(t)] = name
+
+	return &model.Schema{Ref: g.prefix + name}
+}
+
+// reportCollision reports the schema name of another type taken by t, once.
+func (g *SchemaGenerator) reportCollision(name string, t reflect.Type) {
+	g.reportOnce(fmt.Errorf("duplicate schema name %s: %s and %s", name, qualifiedTypeName(g.types[name]), qualifiedTypeName(t)))
+}
+
+// reportOnce records err unless an error with the same message is recorded.
+func (g *SchemaGenerator) reportOnce(err error) {
+	if !slices.ContainsFunc(g.errs, func(e error) bool { return e.Error() == err.Error() }) {
+		g.errs = append(g.errs, err)
+	}
+}
+
+// NameOf implements hook.SchemaContext.
+func (g *SchemaGenerator) NameOf(t reflect.Type, hint string) string {
+	return g.namer(t, hint)
+}
+
+// TagConfig implements hook.SchemaContext.
+func (g *SchemaGenerator) TagConfig() config.TagConfig {
+	return g.tagCfg
+}
+
+// AugmentField attaches openapi tag metadata to a field of a struct type, for types
+// whose tags cannot be edited. The metadata is merged over the tag of the field:
+// set flags, non-empty strings and examples replace those of the tag, and
+// extensions are added. It must be called before generating schemas of the type.
+func (g *SchemaGenerator) AugmentField(t reflect.Type, field string, om *metadata.OpenAPIMetadata) {
+	t = deref(t)
+	if g.augments[t] == nil {
+		g.augments[t] = make(map[string]*metadata.OpenAPIMetadata)
+	}
+	g.augments[t][field] = mergeOpenAPIMetadata(g.augments[t][field], om)
+}
+
+// Augmentation returns the openapi tag metadata attached to a field of a struct
+// type with AugmentField, or nil.
+func (g *SchemaGenerator) Augmentation(t reflect.Type, field string) *metadata.OpenAPIMetadata {
+	return g.augments[deref(t)][field]
+}
+
+// Required reports whether a field of a struct type, documented as the given
+// property, is required: by the generated schema of the type, or else by the
+// field tags and AugmentField.
+func (g *SchemaGenerator) Required(t reflect.Type, field, property string) bool {
+	t = deref(t)
+	if name, ok := g.seen[t]; ok {
+		if s := g.schemas[name]; s != nil && slices.Contains(s.Required, property) {
+			return true
+		}
+	}
+	structMeta, err := g.metadata.GetStructMetadata(t)
+	if err != nil {
+		return false
+	}
+	fieldMeta, ok := structMeta.Field(field)
+	if !ok {
+		return false
+	}
+	augmented := g.augmentField(t, *fieldMeta)
+
+	return isRequiredFromMetadata(&augmented, g.tagCfg)
+}
+
+// augmentField returns the metadata of a field with the openapi tag metadata
+// attached by AugmentField merged in. The metadata is shared: the tag metadata
+// map is copied.
+func (g *SchemaGenerator) augmentField(t reflect.Type, fieldMeta schema.FieldMetadata) schema.FieldMetadata {
+	om, ok := g.augments[t][fieldMeta.StructFieldName]
+	if !ok {
+		return fieldMeta
+	}
+
+	tagged, _ := schema.GetTagMetadata[*metadata.OpenAPIMetadata](&fieldMeta, g.tagCfg.OpenAPI)
+	fieldMeta.TagMetadata = maps.Clone(fieldMeta.TagMetadata)
+	if fieldMeta.TagMetadata == nil {
+		fieldMeta.TagMetadata = make(map[string]any, 1)
+	}
+	fieldMeta.TagMetadata[g.tagCfg.OpenAPI] = mergeOpenAPIMetadata(tagged, om)
+
+	return fieldMeta
+}
+
+// mergeOpenAPIMetadata returns a copy of base, which may be nil, with the set
+// flags, non-empty strings and examples of overlay replacing its own, and the
+// extensions of overlay added.
+func mergeOpenAPIMetadata(base, overlay *metadata.OpenAPIMetadata) *metadata.OpenAPIMetadata {
+	merged := &metadata.OpenAPIMetadata{}
+	if base != nil {
+		*merged = *base
+	}
+
+	for _, f := range []struct{ dst, src **bool }{
+		{&merged.ReadOnly, &overlay.ReadOnly},
+		{&merged.WriteOnly, &overlay.WriteOnly},
+		{&merged.Deprecated, &overlay.Deprecated},
+		{&merged.Hidden, &overlay.Hidden},
+		{&merged.Required, &overlay.Required},
+	} {
+		if *f.src != nil {
+			*f.dst = *f.src
+		}
+	}
+	for _, f := range []struct{ dst, src *string }{
+		{&merged.Title, &overlay.Title},
+		{&merged.Format, &overlay.Format},
+	} {
+		if *f.src != "" {
+			*f.dst = *f.src
+		}
+	}
+	// A description replaces a description key, and conversely
+	if overlay.Description != "" || overlay.DescriptionKey != "" {
+		merged.Description, merged.DescriptionKey = overlay.Description, overlay.DescriptionKey
+	}
+	if overlay.Examples != nil {
+		merged.Examples = overlay.Examples
+	}
+	if len(overlay.Extensions) > 0 {
+		merged.Extensions = maps.Clone(merged.Extensions)
+		if merged.Extensions == nil {
+			merged.Extensions = make(map[string]any, len(overlay.Extensions))
+		}
+		maps.Copy(merged.Extensions, overlay.Extensions)
+	}
+
+	return merged
+}
+
+// MarkInline marks a type to be inlined wherever it is used instead of referenced,
+// and excluded from the Schemas() map. Inline types must not reference themselves.
+// It must be called before generating schemas referencing the type.
+func (g *SchemaGenerator) MarkInline(t reflect.Type) {
+	g.inline[deref(t)] = true
+}
+
 // markInlineOnly marks a type to be excluded from the Schemas() map.
 // The schema will still be generated and can be referenced, but won't appear
 // in components/schemas. Useful for types that are only used inline.
@@ -132,15 +473,25 @@ func (g *SchemaGenerator) schema(t reflect.Type, allowRef bool, hint string) *mo
 	// Determine if this type should get a reference
 	getsRef := g.shouldGetRef(t)
 	name := g.namer(origType, hint)
+	if g.inline[t] {
+		allowRef = false
+		g.inlineOnly[name] = true
+	}
 
 	// Check cache if it gets a ref
 	//nolint:nestif // Complex nested logic for reference handling - acceptable complexity
 	if getsRef {
+		g.used[name] = true
 		if s, ok := g.schemas[name]; ok {
 			// Verify type consistency
 			if seenName, exists := g.seen[t]; !exists || seenName != name {
-				// Name matches but type is different, so we have a dupe.
-				panic(fmt.Errorf("duplicate name: %s, new type: %s, existing type: %s", name, t, g.types[name]))
+				// Name matches but type is different, so we have a dupe: keep the
+				// first type and report the collision.
+				g.reportCollision(name, t)
+			}
+			if g.inline[t] && g.generating[name] {
+				// The placeholder of a recursive type cannot be inlined
+				g.errs = append(g.errs, fmt.Errorf("inline schema %s references itself", name))
 			}
 			if allowRef {
 				return &model.Schema{Ref: g.prefix + name}
@@ -155,12 +506,35 @@ func (g *SchemaGenerator) schema(t reflect.Type, allowRef bool, hint string) *mo
 		g.schemas[name] = &model.Schema{}
 		g.types[name] = t
 		g.seen[t] = name
+		g.generating[name] = true
 	}
 
 	// Generate the schema
+	if g.pathNames {
+		g.parents = append(g.parents, name)
+	}
+	failures := g.failures
 	s, err := g.generate(origType)
+	if g.pathNames {
+		g.parents = g.parents[:len(g.parents)-1]
+	}
+	delete(g.generating, name)
 	if err != nil {
-		panic(fmt.Errorf("failed to generate schema for type %s: %w", origType, err))
+		g.failures++
+		g.reportOnce(fmt.Errorf("failed to generate schema for type %s: %w", origType, err))
+		s = &model.Schema{}
+	}
+	if g.failures != failures {
+		// Schemas of failed types, or of types referencing them, are forgotten so
+		// that they are generated, and their errors reported, again by the next
+		// generation.
+		if getsRef {
+			delete(g.schemas, name)
+			delete(g.types, name)
+			delete(g.seen, t)
+		}
+
+		return s
 	}
 
 	// Store if it gets a ref
@@ -178,7 +552,7 @@ func (g *SchemaGenerator) schema(t reflect.Type, allowRef bool, hint string) *mo
 
 // shouldGetRef determines if a type should be stored with a reference.
 func (g *SchemaGenerator) shouldGetRef(t reflect.Type) bool {
-	if t.Kind() != reflect.Struct {
+	if t.Kind() != reflect.Struct || g.inlineAnon && t.Name() == "" {
 		return false
 	}
 
@@ -192,9 +566,21 @@ func (g *SchemaGenerator) shouldGetRef(t reflect.Type) bool {
 	if _, ok := v.(hook.SchemaProvider); ok {
 		return false
 	}
+	if _, ok := v.(hook.ContextSchemaProvider); ok {
+		return false
+	}
+	if _, ok := v.(hook.MarshalSchema); ok {
+		return false
+	}
+	if _, ok := v.(json.Marshaler); ok {
+		return true
+	}
 	if _, ok := v.(encoding.TextUnmarshaler); ok {
 		return false
 	}
+	if _, ok := v.(encoding.TextMarshaler); ok {
+		return false
+	}
 
 	return true
 }
@@ -211,6 +597,10 @@ func (g *SchemaGenerator) generate(t reflect.Type) (*model.Schema, error) {
 
 	// Lookup in maps (type first, then kind)
 	if s := g.schemaForSimpleType(t, isPointer); s != nil {
+		if values, ok := g.enums[t]; ok {
+			applyEnumValues(s, values)
+		}
+
 		return s, nil
 	}
 
@@ -223,6 +613,9 @@ func (g *SchemaGenerator) generate(t reflect.Type) (*model.Schema, error) {
 	case reflect.Struct:
 		return g.generateStruct(t)
 	case reflect.Interface:
+		if variants, ok := g.unions[t]; ok {
+			return g.generateUnion(variants), nil
+		}
 		// Interfaces mean any object.
 		return &model.Schema{}, nil
 	default:
@@ -231,8 +624,19 @@ func (g *SchemaGenerator) generate(t reflect.Type) (*model.Schema, error) {
 	}
 }
 
-// schemaFromInterface checks if the type implements SchemaProvider or TextUnmarshaler.
+// schemaFromInterface checks if the type implements ContextSchemaProvider, SchemaProvider,
+// MarshalSchema, TextUnmarshaler or TextMarshaler.
 func (g *SchemaGenerator) schemaFromInterface(t reflect.Type, isPointer bool) (*model.Schema, error) {
+	if Implements(t, contextProviderType) {
+		// Special case: type provides its own schema. Do not try to generate.
+		cp, ok := reflect.New(t).Interface().(hook.ContextSchemaProvider)
+		if !ok {
+			return nil, fmt.Errorf("type does not implement ContextSchemaProvider")
+		}
+
+		return cp.SchemaWithContext(g), nil
+	}
+
 	// Check SchemaProvider without allocation first
 	if t.Implements(schemaProviderType) || reflect.PointerTo(t).Implements(schemaProviderType) {
 		// Special case: type provides its own schema. Do not try to generate.
@@ -245,10 +649,30 @@ func (g *SchemaGenerator) schemaFromInterface(t reflect.Type, isPointer bool) (*
 		return sp.Schema(g), nil
 	}
 
-	// Check TextUnmarshaler without allocation
-	if t.Implements(textUnmarshalerType) || reflect.PointerTo(t).Implements(textUnmarshalerType) {
-		// Special case: types that implement encoding.TextUnmarshaler are able to
-		// be loaded from plain text, and so should be treated as strings.
+	if Implements(t, marshalSchemaType) {
+		// The type documents the JSON its MarshalJSON method produces.
+		ms, ok := reflect.New(t).Interface().(hook.MarshalSchema)
+		if !ok {
+			return nil, fmt.Errorf("type does not implement MarshalSchema")
+		}
+		v := ms.MarshalSchema()
+		if v == nil {
+			return &model.Schema{}, nil
+		}
+		if deref(reflect.TypeOf(v)) == t {
+			return nil, fmt.Errorf("MarshalSchema of %s returns a value of the same type", t)
+		}
+
+		return g.schema(reflect.TypeOf(v), true, ""), nil
+	}
+
+	// Check TextUnmarshaler and TextMarshaler without allocation. A json.Marshaler
+	// takes precedence over MarshalText in encoding/json, so such types are not
+	// written as plain text.
+	if !Implements(t, jsonMarshalerType) && (Implements(t, textUnmarshalerType) || Implements(t, textMarshalerType)) {
+		// Special case: types that implement encoding.TextUnmarshaler or
+		// encoding.TextMarshaler are loaded from and written as plain text, and so
+		// should be treated as strings.
 		return &model.Schema{Type: TypeString, Nullable: isPointer}, nil
 	}
 
@@ -330,7 +754,7 @@ func (g *SchemaGenerator) generateArray(t reflect.Type, isPointer bool) (*model.
 	} else {
 		s.Type = TypeArray
 		s.Nullable = false
-		s.Items = g.schema(t.Elem(), true, t.Name()+"Item")
+		s.Items = g.schema(t.Elem(), true, g.childHint("Item", t.Name()+"Item"))
 
 		if t.Kind() == reflect.Array {
 			l := t.Len()
@@ -345,12 +769,22 @@ func (g *SchemaGenerator) generateArray(t reflect.Type, isPointer bool) (*model.
 // generateMap generates a schema for map types.
 func (g *SchemaGenerator) generateMap(t reflect.Type) (*model.Schema, error) {
 	s := model.Schema{Type: TypeObject}
-	valueSchema := g.schema(t.Elem(), true, t.Name()+"Value")
+	valueSchema := g.schema(t.Elem(), true, g.childHint("Value", t.Name()+"Value"))
 	s.Additional = &model.Additional{Schema: valueSchema}
 
 	return &s, nil
 }
 
+// generateUnion generates a oneOf schema of the implementations of a registered union.
+func (g *SchemaGenerator) generateUnion(variants []UnionVariant) *model.Schema {
+	s := model.Schema{OneOf: make([]*model.Schema, 0, len(variants))}
+	for _, v := range variants {
+		s.OneOf = append(s.OneOf, g.schema(v.Type, true, ""))
+	}
+
+	return &s
+}
+
 // structFieldsResult contains the results of processing struct fields.
 type structFieldsResult struct {
 	// props maps property names to their OpenAPI schemas.
@@ -365,6 +799,20 @@ type structFieldsResult struct {
 	// when the mapped field is present. This implements JSON Schema 2019-09 / OpenAPI 3.1
 	// dependentRequired feature for conditional required fields.
 	dependentRequired map[string][]string
+
+	// conditions lists requirements depending on field values, from requires
+	// tags with values. They become if/then/else subschemas.
+	conditions []hook.Condition
+
+	// inlineMap is the schema of the map field tagged inlineMap, whose entries
+	// are the additional properties of the object, and inlineMapField its Go
+	// selector.
+	inlineMap      *model.Schema
+	inlineMapField string
+
+	// errs lists invalid field metadata, such as a discriminator on a field
+	// not holding a registered union.
+	errs []error
 }
 
 // generateStruct generates a schema for struct types.
@@ -379,6 +827,12 @@ func (g *SchemaGenerator) generateStruct(t reflect.Type) (*model.Schema, error)
 
 	// Process each field and build properties
 	result := g.processStructFields(t, *structMeta)
+	if err := errors.Join(result.errs...); err != nil {
+		return nil, err
+	}
+	if err := g.applyRequiredProperties(t, &result); err != nil {
+		return nil, err
+	}
 
 	// Validate dependent required fields
 	if err := validateDependentRequired(result.dependentRequired, result.props); err != nil {
@@ -390,8 +844,31 @@ func (g *SchemaGenerator) generateStruct(t reflect.Type) (*model.Schema, error)
 		s.DependentRequired = result.dependentRequired
 	}
 
+	// Collect conditional requirements from tags and the ConditionalSchema hook
+	conditions := result.conditions
+	if t.Implements(conditionalSchemaType) || reflect.PointerTo(t).Implements(conditionalSchemaType) {
+		if cs, ok := reflect.New(t).Interface().(hook.ConditionalSchema); ok {
+			conditions = append(conditions, cs.SchemaConditions()...)
+		}
+	}
+	if err := validateConditions(conditions, result.props); err != nil {
+		return nil, err
+	}
+	applyConditions(&s, conditions)
+
 	// Handle struct-level metadata (_ field)
-	g.applyStructLevelMetadata(&s, structMeta)
+	if err := g.applyStructLevelMetadata(&s, t); err != nil {
+		return nil, err
+	}
+
+	// Document the entries of the inline map as additional properties
+	if result.inlineMap != nil {
+		if s.Additional != nil {
+			return nil, fmt.Errorf("additionalProperties of the _ field conflicts with the inline map %s", result.inlineMapField)
+		}
+		s.Additional = result.inlineMap.Additional
+		s.PropertyNames = result.inlineMap.PropertyNames
+	}
 
 	// Apply SchemaTransformer if implemented
 	if t.Implements(schemaTransformerType) || reflect.PointerTo(t).Implements(schemaTransformerType) {
@@ -414,49 +891,335 @@ func (g *SchemaGenerator) processStructFields(t reflect.Type, structMeta schema.
 		dependentRequired: make(map[string][]string),
 	}
 
-	// Iterate through metadata fields
+	fields := g.resolveStructFields(t, structMeta)
+	result.errs = append(result.errs, fields.errs...)
+	for _, f := range fields.inlineMaps {
+		if err := g.inlineMap(f, &result); err != nil {
+			result.errs = append(result.errs, fmt.Errorf("field '%s': %w", f.field.Name, err))
+		}
+	}
+	for _, f := range fields.props {
+		g.processStructField(f, &result)
+	}
+
+	if len(fields.shadowed) > 0 {
+		g.shadowed[t] = fields.shadowed
+	} else {
+		delete(g.shadowed, t)
+	}
+
+	return result
+}
+
+// processStructField builds the property schema of a field.
+func (g *SchemaGenerator) processStructField(f structField, result *structFieldsResult) {
+	t, reflectField, fieldMeta, name := f.owner, f.field, f.meta, f.name
+
+	var fs *model.Schema
+	if ref := g.fieldRef(fieldMeta); ref != "" {
+		fs = &model.Schema{Ref: ref}
+	} else {
+		fs = g.schema(reflectField.Type, true, g.childHint(fieldMeta.StructFieldName, t.Name()+fieldMeta.StructFieldName+"Struct"))
+	}
+	if fs == nil {
+		return
+	}
+
+	// Determine required status from metadata
+	fieldRequired := isRequiredFromMetadata(&fieldMeta, g.tagCfg)
+
+	// Apply OpenAPI metadata
+	g.applyOpenAPIMetadata(fs, fieldMeta)
+	if err := g.applyDiscriminator(fs, reflectField.Type, fieldMeta); err != nil {
+		result.errs = append(result.errs, fmt.Errorf("field '%s': %w", name, err))
+	}
+	if err := g.applyKeyPattern(fs, reflectField.Type, fieldMeta); err != nil {
+		result.errs = append(result.errs, fmt.Errorf("field '%s': %w", name, err))
+	}
+
+	// Apply validation metadata
+	g.applyValidateMetadata(fs, fieldMeta)
+
+	// If field is required, it cannot be null
+	_, omitzero := JSONTag(reflectField)
+	if fieldRequired {
+		fs.Nullable = false
+	}
+	// Nil pointers of fields omitted when zero are left out, not null (see JSONTag)
+	if omitzero && reflectField.Type.Kind() == reflect.Pointer {
+		fs.Nullable = false
+	}
+
+	// Apply default value from default tag
+	g.applyDefaultValue(fs, fieldMeta)
+
+	// Apply field transformers once the tags are applied
+	for _, fn := range g.fieldHooks {
+		fs = fn(hook.Field{Struct: t, Field: reflectField, Name: name}, fs)
+	}
+
+	// Apply dependent required metadata (on object schema, not field schema)
+	g.applyDependentRequired(result.dependentRequired, fieldMeta, name)
+	result.conditions = g.appendConditions(result.conditions, fieldMeta, name)
+
+	// Add to properties
+	result.props[name] = fs
+
+	if fieldRequired {
+		result.required = append(result.required, name)
+	}
+}
+
+// inlineMap documents a map field tagged inlineMap as the additional properties
+// of the parent object, instead of a property. A struct has at most one.
+func (g *SchemaGenerator) inlineMap(f structField, result *structFieldsResult) error {
+	t := deref(f.field.Type)
+	if t.Kind() != reflect.Map {
+		return fmt.Errorf("inlineMap requires a map, %s is not one", t)
+	}
+	if result.inlineMap != nil {
+		return fmt.Errorf("inlineMap is already set on field %s", result.inlineMapField)
+	}
+
+	ms, err := g.generateMap(t)
+	if err != nil {
+		return err
+	}
+	if err := g.applyKeyPattern(ms, t, f.meta); err != nil {
+		return err
+	}
+	result.inlineMap = ms
+	result.inlineMapField = f.path
+
+	return nil
+}
+
+// structField is a field of a struct, or a field promoted from an embedded struct.
+type structField struct {
+	name   string
+	owner  reflect.Type // Struct declaring the field
+	field  reflect.StructField
+	meta   schema.FieldMetadata
+	path   string // Go selector of the field, e.g. "Base.ID"
+	depth  int    // Number of embedded structs the field is promoted through
+	tagged bool   // The field has a json tag name
+}
+
+// structFields are the fields of a struct type, resolved like encoding/json.
+type structFields struct {
+	// props are the fields documented as properties, in declaration order.
+	props []structField
+
+	// inlineMaps are the map fields tagged inlineMap.
+	inlineMaps []structField
+
+	// shadowed are the fields hidden by fields with the same property name.
+	shadowed []Shadowing
+
+	// errs lists the embedded structs without metadata.
+	errs []error
+}
+
+// resolveStructFields returns the fields of a struct type documented as
+// properties, including the fields promoted from embedded structs. Fields with
+// the same property name are resolved like encoding/json: the least nested field
+// wins, then the only one with a json tag name. The other fields are recorded
+// as shadowed, and fields encoding/json would drop as ambiguous are left out.
+// No schema is generated.
+func (g *SchemaGenerator) resolveStructFields(t reflect.Type, structMeta schema.StructMetadata) structFields {
+	var fields structFields
+	candidates := g.collectFields(t, structMeta, nil, map[reflect.Type]bool{t: true}, &fields)
+
+	byName := make(map[string][]structField)
+	for _, f := range candidates {
+		byName[f.name] = append(byName[f.name], f)
+	}
+
+	winners := make(map[string]string, len(byName))
+	for _, f := range candidates {
+		name := f.name
+		if _, resolved := winners[name]; resolved {
+			continue
+		}
+		winner, ok := dominantField(byName[name])
+		winners[name] = winner.path
+		for _, other := range byName[name] {
+			if !ok || other.path != winner.path {
+				fields.shadowed = append(fields.shadowed, Shadowing{Property: name, Field: other.path, By: winner.path})
+			}
+		}
+	}
+	for _, f := range candidates {
+		if winners[f.name] == f.path {
+			fields.props = append(fields.props, f)
+		}
+	}
+
+	return fields
+}
+
+// collectFields lists the fields of a struct type, including the fields promoted
+// from embedded structs. path holds the names of the embedded fields t is
+// promoted through, and visited their types.
+func (g *SchemaGenerator) collectFields(
+	t reflect.Type, structMeta schema.StructMetadata, path []string, visited map[reflect.Type]bool, fields *structFields,
+) []structField {
+	byIndex := make(map[int]schema.FieldMetadata, len(structMeta.Fields))
 	for _, fieldMeta := range structMeta.Fields {
-		if g.isHidden(fieldMeta) {
+		byIndex[fieldMeta.Index] = fieldMeta
+	}
+
+	var candidates []structField
+	for i := range t.NumField() {
+		reflectField := t.Field(i)
+		fieldMeta, ok := byIndex[i]
+		if ok {
+			fieldMeta = g.augmentField(t, fieldMeta)
+			if g.isHidden(fieldMeta) {
+				continue
+			}
+		}
+
+		// Fields of embedded structs are properties of the object, as with encoding/json
+		if et, promoted := promotedType(reflectField); promoted {
+			if visited[et] {
+				continue
+			}
+			embeddedMeta, err := g.metadata.GetStructMetadata(et)
+			if err != nil {
+				fields.errs = append(fields.errs, fmt.Errorf("failed to get struct metadata for embedded type %s: %w", et, err))
+
+				continue
+			}
+			visited[et] = true
+			candidates = append(candidates, g.collectFields(et, *embeddedMeta, append(path, reflectField.Name), visited, fields)...)
+			delete(visited, et)
+
+			continue
+		}
+		if !ok {
 			continue
 		}
 
-		reflectField := t.Field(fieldMeta.Index)
-		fs := g.schema(reflectField.Type, true, t.Name()+fieldMeta.StructFieldName+"Struct")
-		if fs == nil {
+		jsonName, _ := JSONTag(reflectField)
+		f := structField{
+			name:   g.defineFieldName(reflectField, fieldMeta),
+			owner:  t,
+			field:  reflectField,
+			meta:   fieldMeta,
+			path:   strings.Join(append(slices.Clone(path), reflectField.Name), "."),
+			depth:  len(path),
+			tagged: jsonName != "",
+		}
+		if g.isInlineMap(fieldMeta) {
+			fields.inlineMaps = append(fields.inlineMaps, f)
+
 			continue
 		}
-		// Extract field name from metadata (respects JSON tags)
-		name := g.defineFieldName(reflectField, fieldMeta)
+		candidates = append(candidates, f)
+	}
 
-		// Determine required status from metadata
-		fieldRequired := isRequiredFromMetadata(&fieldMeta, g.tagCfg)
+	return candidates
+}
 
-		// Apply OpenAPI metadata
-		g.applyOpenAPIMetadata(fs, fieldMeta)
+// promotedType returns the struct type whose fields are promoted by an embedded
+// field, following encoding/json: embedded structs and pointers to structs
+// without a json tag name.
+func promotedType(f reflect.StructField) (reflect.Type, bool) {
+	if !f.Anonymous {
+		return nil, false
+	}
+	if name, _ := JSONTag(f); name != "" {
+		return nil, false
+	}
+	t := deref(f.Type)
 
-		// Apply validation metadata
-		g.applyValidateMetadata(fs, fieldMeta)
+	return t, t.Kind() == reflect.Struct
+}
 
-		// If field is required, it cannot be null
-		if fieldRequired {
-			fs.Nullable = false
+// dominantField returns the field documented among fields with the same property
+// name, or false if encoding/json would drop them all as ambiguous.
+func dominantField(fields []structField) (structField, bool) {
+	depth := fields[0].depth
+	for _, f := range fields {
+		depth = min(depth, f.depth)
+	}
+
+	var shallowest, tagged []structField
+	for _, f := range fields {
+		if f.depth != depth {
+			continue
 		}
+		shallowest = append(shallowest, f)
+		if f.tagged {
+			tagged = append(tagged, f)
+		}
+	}
+	switch {
+	case len(shallowest) == 1:
+		return shallowest[0], true
+	case len(tagged) == 1:
+		return tagged[0], true
+	}
 
-		// Apply default value from default tag
-		g.applyDefaultValue(fs, fieldMeta)
+	return structField{}, false
+}
 
-		// Apply dependent required metadata (on object schema, not field schema)
-		g.applyDependentRequired(result.dependentRequired, fieldMeta, name)
+// Shadowing is a struct field hidden by another field with the same property
+// name, such as a field of an embedded struct redeclared by the outer struct.
+type Shadowing struct {
+	// Property is the property name of the fields.
+	Property string
 
-		// Add to properties
-		result.props[name] = fs
+	// Field is the Go selector of the hidden field, e.g. "Base.ID".
+	Field string
 
-		if fieldRequired {
-			result.required = append(result.required, name)
-		}
+	// By is the Go selector of the documented field, or empty when the fields
+	// with the property name are ambiguous: encoding/json drops them all, and
+	// no property is documented.
+	By string
+}
+
+// Shadowed returns the fields of a struct type hidden by other fields with the
+// same property name, once its schema is generated.
+func (g *SchemaGenerator) Shadowed(t reflect.Type) []Shadowing {
+	return g.shadowed[deref(t)]
+}
+
+// Property is a struct field documented as a property of the object schema of
+// its struct.
+type Property struct {
+	// Name is the property name.
+	Name string
+
+	// Path is the Go selector of the field, e.g. "Base.ID" for a field promoted
+	// from an embedded struct.
+	Path string
+
+	// Field is the struct field, declared by the struct or an embedded struct.
+	Field reflect.StructField
+}
+
+// Properties returns the fields of a struct type documented as properties of its
+// object schema, in declaration order, including the fields promoted from
+// embedded structs.
+func (g *SchemaGenerator) Properties(t reflect.Type) []Property {
+	t = deref(t)
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+	structMeta, err := g.metadata.GetStructMetadata(t)
+	if err != nil {
+		return nil
 	}
 
-	return result
+	fields := g.resolveStructFields(t, *structMeta)
+	props := make([]Property, len(fields.props))
+	for i, f := range fields.props {
+		props[i] = Property{Name: f.name, Path: f.path, Field: f.field}
+	}
+
+	return props
 }
 
 // validateDependentRequired validates that all dependent required fields exist.
@@ -476,29 +1239,104 @@ func validateDependentRequired(dependentRequired map[string][]string, props map[
 	return nil
 }
 
+// validateConditions validates that all properties of conditional requirements exist.
+func validateConditions(conditions []hook.Condition, props map[string]*model.Schema) error {
+	var errs []error
+	for _, c := range conditions {
+		if _, ok := props[c.If]; !ok {
+			errs = append(errs, fmt.Errorf("condition field '%s' does not exist", c.If))
+		}
+		if c.Equals == nil {
+			errs = append(errs, fmt.Errorf("condition on field '%s' has no value", c.If))
+		}
+		for _, name := range slices.Concat(c.Then, c.Else) {
+			if _, ok := props[name]; !ok {
+				errs = append(errs, fmt.Errorf("required field '%s' for condition on field '%s' does not exist", name, c.If))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("conditional required validation failed: %w", errors.Join(errs...))
+	}
+
+	return nil
+}
+
+// applyConditions adds conditional requirements to an object schema as if/then/else.
+// A single condition is set on the schema itself, several are combined with allOf.
+func applyConditions(s *model.Schema, conditions []hook.Condition) {
+	if len(conditions) == 1 {
+		s.If, s.Then, s.Else = conditionSchemas(conditions[0])
+
+		return
+	}
+	for _, c := range conditions {
+		sub := &model.Schema{}
+		sub.If, sub.Then, sub.Else = conditionSchemas(c)
+		s.AllOf = append(s.AllOf, sub)
+	}
+}
+
+// conditionSchemas returns the if, then and else subschemas of a condition.
+func conditionSchemas(c hook.Condition) (ifSchema, thenSchema, elseSchema *model.Schema) {
+	ifSchema = &model.Schema{
+		Properties: map[string]*model.Schema{c.If: {Const: c.Equals}},
+		Required:   []string{c.If},
+	}
+	if len(c.Then) > 0 {
+		thenSchema = &model.Schema{Required: slices.Clone(c.Then)}
+	}
+	if len(c.Else) > 0 {
+		elseSchema = &model.Schema{Required: slices.Clone(c.Else)}
+	}
+
+	return ifSchema, thenSchema, elseSchema
+}
+
 // defineFieldName extracts the field name from metadata, respecting JSON tags.
 // Priority: JSON tag > explicit schema tag > struct field name.
 func (g *SchemaGenerator) defineFieldName(field reflect.StructField, fieldMeta schema.FieldMetadata) string {
 	// First, check JSON tag for field name (most common case for OpenAPI schemas)
-	if jsonTag, ok := field.Tag.Lookup("json"); ok {
-		// Parse JSON tag (format: "name,omitempty,string")
-		parts := strings.Split(jsonTag, ",")
-		if len(parts) > 0 && parts[0] != "" && parts[0] != "-" {
-			return parts[0]
-		}
+	if name, _ := JSONTag(field); name != "" && name != "-" {
+		return name
 	}
 
 	// Second, check schema tag for explicit parameter name
 	if schemaMeta, ok := schema.GetTagMetadata[*schema.SchemaMetadata](&fieldMeta, g.tagCfg.Schema); ok {
-		if schemaMeta.ParamName != "" {
+		// Untagged fields get default metadata named after the field
+		_, tagged := field.Tag.Lookup(g.tagCfg.Schema)
+		if schemaMeta.ParamName != "" && (tagged || g.naming == nil) {
 			return schemaMeta.ParamName
 		}
 	}
 
 	// Fall back to struct field name
+	if g.naming != nil {
+		return g.naming(fieldMeta.StructFieldName)
+	}
+
 	return fieldMeta.StructFieldName
 }
 
+// fieldRef returns the reference replacing the schema of a field, set with the
+// ref option of the openapi tag, or an empty string.
+func (g *SchemaGenerator) fieldRef(fieldMeta schema.FieldMetadata) string {
+	if openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](&fieldMeta, g.tagCfg.OpenAPI); ok {
+		return openAPIMeta.Ref
+	}
+
+	return ""
+}
+
+// isInlineMap reports whether a field is tagged inlineMap.
+func (g *SchemaGenerator) isInlineMap(fieldMeta schema.FieldMetadata) bool {
+	if openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](&fieldMeta, g.tagCfg.OpenAPI); ok {
+		return toBool(openAPIMeta.InlineMap)
+	}
+
+	return false
+}
+
 // isHidden determines if a field is hidden based on metadata.
 func (g *SchemaGenerator) isHidden(fieldMeta schema.FieldMetadata) bool {
 	if openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](&fieldMeta, g.tagCfg.OpenAPI); ok {
@@ -516,25 +1354,119 @@ func (g *SchemaGenerator) applyOpenAPIMetadata(fs *model.Schema, fieldMeta schem
 	}
 
 	fs.Title = openAPIMeta.Title
-	fs.Description = openAPIMeta.Description
+	fs.Description = g.description(openAPIMeta, "field "+fieldMeta.StructFieldName)
 	fs.Format = openAPIMeta.Format
 	fs.Examples = openAPIMeta.Examples
 	fs.ReadOnly = toBool(openAPIMeta.ReadOnly)
 	fs.WriteOnly = toBool(openAPIMeta.WriteOnly)
 	fs.Deprecated = toBool(openAPIMeta.Deprecated)
 	fs.Extensions = openAPIMeta.Extensions
+	if openAPIMeta.Sensitivity != "" {
+		// Metadata is shared: extend a copy of its extensions
+		fs.Extensions = maps.Clone(openAPIMeta.Extensions)
+		if fs.Extensions == nil {
+			fs.Extensions = make(map[string]any, 1)
+		}
+		fs.Extensions[ExtensionDataClassification] = openAPIMeta.Sensitivity
+	}
 }
 
-// applyStructLevelMetadata extracts struct-level metadata from the _ field.
-func (g *SchemaGenerator) applyStructLevelMetadata(s *model.Schema, structMeta *schema.StructMetadata) {
-	fieldMeta, ok := structMeta.Field("_")
-	if !ok {
-		return
+// applyDiscriminator adds the discriminator of the openapi tag to the oneOf schema of a
+// field holding a registered union, or a slice of them. The mapping takes the names
+// of the registered implementations.
+func (g *SchemaGenerator) applyDiscriminator(fs *model.Schema, t reflect.Type, fieldMeta schema.FieldMetadata) error {
+	openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](&fieldMeta, g.tagCfg.OpenAPI)
+	if !ok || openAPIMeta.Discriminator == "" {
+		return nil
 	}
 
-	openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](fieldMeta, g.tagCfg.OpenAPI)
+	t = deref(t)
+	if (t.Kind() == reflect.Slice || t.Kind() == reflect.Array) && fs.Items != nil {
+		fs, t = fs.Items, deref(t.Elem())
+	}
+	variants, ok := g.unions[t]
 	if !ok {
-		return
+		return fmt.Errorf("discriminator requires a registered union, %s is not one", t)
+	}
+
+	mapping := make(map[string]string, len(variants))
+	for _, v := range variants {
+		ref := g.schema(v.Type, true, "").Ref
+		if ref == "" {
+			return fmt.Errorf("union variant %s has no component schema to map %q to", v.Type, v.Name)
+		}
+		// Variants being generated (recursive unions) have no properties yet
+		vs := g.schemas[strings.TrimPrefix(ref, g.prefix)]
+		if vs != nil && vs.Type == TypeObject && vs.Properties[openAPIMeta.Discriminator] == nil {
+			return fmt.Errorf("union variant %s has no property %q", v.Type, openAPIMeta.Discriminator)
+		}
+		mapping[v.Name] = ref
+	}
+	fs.Discriminator = &model.Discriminator{
+		PropertyName: openAPIMeta.Discriminator,
+		Mapping:      mapping,
+	}
+
+	return nil
+}
+
+// applyKeyPattern constrains the keys of a map field to the keyPattern of the openapi
+// tag with propertyNames.
+func (g *SchemaGenerator) applyKeyPattern(fs *model.Schema, t reflect.Type, fieldMeta schema.FieldMetadata) error {
+	openAPIMeta, ok := schema.GetTagMetadata[*metadata.OpenAPIMetadata](&fieldMeta, g.tagCfg.OpenAPI)
+	if !ok || openAPIMeta.KeyPattern == "" {
+		return nil
+	}
+
+	if t = deref(t); t.Kind() != reflect.Map {
+		return fmt.Errorf("keyPattern requires a map, %s is not one", t)
+	}
+	if _, err := regexp.Compile(openAPIMeta.KeyPattern); err != nil {
+		return fmt.Errorf("invalid keyPattern %q: %w", openAPIMeta.KeyPattern, err)
+	}
+	fs.PropertyNames = &model.Schema{Pattern: openAPIMeta.KeyPattern}
+
+	return nil
+}
+
+// Extensions of the schemas of enum types registered with RegisterEnum, as
+// understood by code generators.
+const (
+	ExtensionEnumVarNames     = "x-enum-varnames"
+	ExtensionEnumDescriptions = "x-enum-descriptions"
+)
+
+// applyEnumValues sets the enum of a schema to the values of an enum type.
+// Descriptions are only added if a value has one.
+func applyEnumValues(s *model.Schema, values []EnumValue) {
+	names := make([]any, 0, len(values))
+	descriptions := make([]any, 0, len(values))
+	documented := false
+	for _, v := range values {
+		s.Enum = append(s.Enum, v.Value)
+		names = append(names, v.Name)
+		descriptions = append(descriptions, v.Description)
+		documented = documented || v.Description != ""
+	}
+
+	if s.Extensions == nil {
+		s.Extensions = make(map[string]any)
+	}
+	s.Extensions[ExtensionEnumVarNames] = names
+	if documented {
+		s.Extensions[ExtensionEnumDescriptions] = descriptions
+	}
+}
+
+// ExtensionDataClassification holds the data classification of a property
+// declared with the sensitivity option of the openapi tag.
+const ExtensionDataClassification = "x-data-classification"
+
+// applyStructLevelMetadata extracts struct-level metadata from the _ field.
+func (g *SchemaGenerator) applyStructLevelMetadata(s *model.Schema, t reflect.Type) error {
+	openAPIMeta, err := structLevelMetadata(t, g.tagCfg)
+	if err != nil || openAPIMeta == nil {
+		return err
 	}
 
 	// Apply struct-level options from parsed metadata (only valid when used on _ field)
@@ -546,6 +1478,48 @@ func (g *SchemaGenerator) applyStructLevelMetadata(s *model.Schema, structMeta *
 	if openAPIMeta.Nullable != nil {
 		s.Nullable = *openAPIMeta.Nullable
 	}
+	if openAPIMeta.Title != "" {
+		s.Title = openAPIMeta.Title
+	}
+	if description := g.description(openAPIMeta, "type "+t.String()); description != "" {
+		s.Description = description
+	}
+
+	return nil
+}
+
+// applyRequiredProperties merges the required option of the _ field, listing
+// properties of fields that cannot be tagged, with the required fields. Listed
+// properties cannot be null, as required fields.
+func (g *SchemaGenerator) applyRequiredProperties(t reflect.Type, result *structFieldsResult) error {
+	openAPIMeta, err := structLevelMetadata(t, g.tagCfg)
+	if err != nil || openAPIMeta == nil {
+		return err
+	}
+
+	for _, name := range openAPIMeta.RequiredProperties {
+		prop, ok := result.props[name]
+		if !ok {
+			return fmt.Errorf("required property '%s' of the _ field does not exist", name)
+		}
+		prop.Nullable = false
+		if !slices.Contains(result.required, name) {
+			result.required = append(result.required, name)
+		}
+	}
+
+	return nil
+}
+
+// applyParameterConstraints applies the validate and default tags of a parameter
+// field to its schema, as for properties. Referenced schemas are shared and left
+// as is.
+func (g *SchemaGenerator) applyParameterConstraints(ps *model.Schema, fieldMeta schema.FieldMetadata) {
+	if ps.Ref != "" {
+		return
+	}
+	g.applyValidateMetadata(ps, fieldMeta)
+	g.applyDefaultValue(ps, fieldMeta)
 }
 
 // applyDefaultValue reads the default tag from metadata and applies it to the schema.
@@ -567,14 +1541,6 @@ func (g *SchemaGenerator) applyValidateMetadata(fs *model.Schema, fieldMeta sche
 
 	// Handle minimum/maximum based on type
 	applyMinMaxConstraints(fs, validateMeta)
-
-	// Exclusive numeric constraints (convert to Bound format)
-	if validateMeta.ExclusiveMinimum != nil {
-		fs.Minimum = &model.Bound{Value: *validateMeta.ExclusiveMinimum, Exclusive: true}
-	}
-	if validateMeta.ExclusiveMaximum != nil {
-		fs.Maximum = &model.Bound{Value: *validateMeta.ExclusiveMaximum, Exclusive: true}
-	}
 	fs.MultipleOf = validateMeta.MultipleOf
 
 	// String-specific constraints
@@ -588,63 +1554,49 @@ func (g *SchemaGenerator) applyValidateMetadata(fs *model.Schema, fieldMeta sche
 }
 
 // applyMinMaxConstraints applies minimum and maximum constraints based on schema type.
+// Inclusive and exclusive bounds combine into the tighter of the two.
 func applyMinMaxConstraints(fs *model.Schema, validateMeta *metadata.ValidateMetadata) {
 	switch fs.Type {
 	case TypeString:
-		applyStringMinMax(fs, validateMeta)
+		fs.MinLength, fs.MaxLength = countBounds(validateMeta)
 	case TypeInteger, TypeNumber:
 		applyNumericMinMax(fs, validateMeta)
 	case TypeArray:
-		applyArrayMinMax(fs, validateMeta)
+		fs.MinItems, fs.MaxItems = countBounds(validateMeta)
 	case TypeObject:
-		applyObjectMinMax(fs, validateMeta)
-	}
-}
-
-// applyStringMinMax applies min/max length constraints for string types.
-func applyStringMinMax(fs *model.Schema, validateMeta *metadata.ValidateMetadata) {
-	if validateMeta.Minimum != nil {
-		minLen := int(*validateMeta.Minimum)
-		fs.MinLength = &minLen
-	}
-	if validateMeta.Maximum != nil {
-		maxLen := int(*validateMeta.Maximum)
-		fs.MaxLength = &maxLen
+		fs.MinProperties, fs.MaxProperties = countBounds(validateMeta)
 	}
 }
 
 // applyNumericMinMax applies min/max value constraints for numeric types.
 func applyNumericMinMax(fs *model.Schema, validateMeta *metadata.ValidateMetadata) {
-	if validateMeta.Minimum != nil {
-		fs.Minimum = &model.Bound{Value: *validateMeta.Minimum, Exclusive: false}
+	if value, exclusive, ok := validateMeta.LowerBound(); ok {
+		fs.Minimum = &model.Bound{Value: value, Exclusive: exclusive}
 	}
-	if validateMeta.Maximum != nil {
-		fs.Maximum = &model.Bound{Value: *validateMeta.Maximum, Exclusive: false}
+	if value, exclusive, ok := validateMeta.UpperBound(); ok {
+		fs.Maximum = &model.Bound{Value: value, Exclusive: exclusive}
 	}
 }
 
-// applyArrayMinMax applies min/max item count constraints for array types.
-func applyArrayMinMax(fs *model.Schema, validateMeta *metadata.ValidateMetadata) {
-	if validateMeta.Minimum != nil {
-		minItems := int(*validateMeta.Minimum)
-		fs.MinItems = &minItems
+// countBounds converts the bounds of a length, item count or property count into
+// inclusive counts: gt=2 is a minimum of 3, lt=10 a maximum of 9.
+func countBounds(validateMeta *metadata.ValidateMetadata) (minCount, maxCount *int) {
+	if value, exclusive, ok := validateMeta.LowerBound(); ok {
+		n := int(math.Ceil(value))
+		if exclusive && float64(n) == value {
+			n++
+		}
+		minCount = &n
 	}
-	if validateMeta.Maximum != nil {
-		maxItems := int(*validateMeta.Maximum)
-		fs.MaxItems = &maxItems
+	if value, exclusive, ok := validateMeta.UpperBound(); ok {
+		n := int(math.Floor(value))
+		if exclusive && float64(n) == value {
+			n--
+		}
+		maxCount = &n
 	}
-}
 
-// applyObjectMinMax applies min/max property count constraints for object types.
-func applyObjectMinMax(fs *model.Schema, validateMeta *metadata.ValidateMetadata) {
-	if validateMeta.Minimum != nil {
-		minProps := int(*validateMeta.Minimum)
-		fs.MinProperties = &minProps
-	}
-	if validateMeta.Maximum != nil {
-		maxProps := int(*validateMeta.Maximum)
-		fs.MaxProperties = &maxProps
-	}
+	return minCount, maxCount
 }
 
 // applyEnumConstraints applies enum or const constraints to the schema.
@@ -671,6 +1623,20 @@ func (g *SchemaGenerator) applyDependentRequired(dependentRequired map[string][]
 	dependentRequired[fieldName] = reqMeta.Fields
 }
 
+// appendConditions appends the conditional requirements of a requires tag with values.
+func (g *SchemaGenerator) appendConditions(conditions []hook.Condition, fieldMeta schema.FieldMetadata, fieldName string) []hook.Condition {
+	reqMeta, ok := schema.GetTagMetadata[*metadata.RequiresMetadata](&fieldMeta, g.tagCfg.Requires)
+	if !ok {
+		return conditions
+	}
+
+	for _, c := range reqMeta.Conditions {
+		conditions = append(conditions, hook.Condition{If: fieldName, Equals: c.Value, Then: c.Fields})
+	}
+
+	return conditions
+}
+
 // applyNullableForScalar sets nullable for scalar types if isPointer is true.
 func applyNullableForScalar(s *model.Schema, isPointer bool) {
 	if s.Type == TypeBoolean || s.Type == TypeInteger || s.Type == TypeNumber || s.Type == TypeString {
@@ -2,13 +2,17 @@ package build
 
 import (
 	"encoding/json"
+	"maps"
 	"reflect"
+	"slices"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/talav/openapi/config"
+	"github.com/talav/openapi/hook"
 	"github.com/talav/openapi/internal/model"
 )
 
@@ -215,6 +219,96 @@ func TestSchemaGenerator_StructFeatures(t *testing.T) {
 	}
 }
 
+func TestSchemaGenerator_CombinedBounds(t *testing.T) {
+	type Bounded struct {
+		Score    float64  `json:"score" validate:"gte=0,lt=100"`
+		Ratio    float64  `json:"ratio" validate:"gte=0,gt=0,lte=1"`
+		Quantity int      `json:"quantity" validate:"gt=0,gte=10"`
+		Code     string   `json:"code" validate:"gt=2,lt=10"`
+		Tags     []string `json:"tags" validate:"gte=1,lt=5"`
+	}
+
+	metadata := NewMetadata(config.DefaultTagConfig())
+	gen := NewSchemaGenerator("#/components/schemas/", metadata, config.DefaultTagConfig())
+	gen.Schema(reflect.TypeOf(Bounded{}))
+	props := gen.Schemas()["Bounded"].Properties
+
+	assert.Equal(t, &model.Bound{Value: 0}, props["score"].Minimum)
+	assert.Equal(t, &model.Bound{Value: 100, Exclusive: true}, props["score"].Maximum)
+
+	assert.Equal(t, &model.Bound{Value: 0, Exclusive: true}, props["ratio"].Minimum)
+	assert.Equal(t, &model.Bound{Value: 1}, props["ratio"].Maximum)
+
+	assert.Equal(t, &model.Bound{Value: 10}, props["quantity"].Minimum)
+	assert.Nil(t, props["quantity"].Maximum)
+
+	// Lengths and counts are integers: exclusive bounds become inclusive ones
+	assert.Equal(t, 3, *props["code"].MinLength)
+	assert.Equal(t, 9, *props["code"].MaxLength)
+	assert.Nil(t, props["code"].Minimum)
+	assert.Equal(t, 1, *props["tags"].MinItems)
+	assert.Equal(t, 4, *props["tags"].MaxItems)
+}
+
+type conditionalPayment struct {
+	Method     string `json:"method" requires:"card=card_number"`
+	CardNumber string `json:"card_number"`
+	IBAN       string `json:"iban"`
+	Country    string `json:"country"`
+	TaxID      string `json:"tax_id"`
+}
+
+func (conditionalPayment) SchemaConditions() []hook.Condition {
+	return []hook.Condition{{If: "country", Equals: "DE", Then: []string{"tax_id"}, Else: []string{"iban"}}}
+}
+
+func TestSchemaGenerator_Conditions(t *testing.T) {
+	t.Run("single condition", func(t *testing.T) {
+		type Payment struct {
+			Method     string `json:"method" requires:"card=card_number"`
+			CardNumber string `json:"card_number"`
+		}
+
+		metadata := NewMetadata(config.DefaultTagConfig())
+		gen := NewSchemaGenerator("#/components/schemas/", metadata, config.DefaultTagConfig())
+		gen.Schema(reflect.TypeOf(Payment{}))
+		s := gen.Schemas()["Payment"]
+
+		assert.Equal(t, &model.Schema{
+			Properties: map[string]*model.Schema{"method": {Const: "card"}},
+			Required:   []string{"method"},
+		}, s.If)
+		assert.Equal(t, &model.Schema{Required: []string{"card_number"}}, s.Then)
+		assert.Nil(t, s.Else)
+	})
+
+	t.Run("tags and hook combined", func(t *testing.T) {
+		metadata := NewMetadata(config.DefaultTagConfig())
+		gen := NewSchemaGenerator("#/components/schemas/", metadata, config.DefaultTagConfig())
+		gen.Schema(reflect.TypeOf(conditionalPayment{}))
+		s := gen.Schemas()["ConditionalPayment"]
+
+		assert.Nil(t, s.If)
+		require.Len(t, s.AllOf, 2)
+		assert.Equal(t, "card", s.AllOf[0].If.Properties["method"].Const)
+		assert.Equal(t, []string{"card_number"}, s.AllOf[0].Then.Required)
+		assert.Equal(t, "DE", s.AllOf[1].If.Properties["country"].Const)
+		assert.Equal(t, []string{"tax_id"}, s.AllOf[1].Then.Required)
+		assert.Equal(t, []string{"iban"}, s.AllOf[1].Else.Required)
+	})
+
+	t.Run("unknown field", func(t *testing.T) {
+		type Payment struct {
+			Method string `json:"method" requires:"card=card_number"`
+		}
+
+		metadata := NewMetadata(config.DefaultTagConfig())
+		gen := NewSchemaGenerator("#/components/schemas/", metadata, config.DefaultTagConfig())
+		_, err := gen.generateStruct(reflect.TypeOf(Payment{}))
+		require.ErrorContains(t, err, "required field 'card_number' for condition on field 'method' does not exist")
+	})
+}
+
 func TestSchemaGenerator_ComplexStructJSON(t *testing.T) {
 	type Address struct {
 		Street  string `json:"street" validate:"required"`
@@ -264,6 +358,102 @@ func TestSchemaGenerator_EmptyStruct(t *testing.T) {
 	assert.Equal(t, "object", emptySchema.Type)
 }
 
+func TestSchemaGenerator_StructLevelMetadata(t *testing.T) {
+	type Strict struct {
+		_    struct{} `openapi:"additionalProperties=false,nullable=true"`
+		Name string   `json:"name"`
+	}
+
+	metadata := NewMetadata(config.DefaultTagConfig())
+	gen := NewSchemaGenerator("#/components/schemas/", metadata, config.DefaultTagConfig())
+
+	gen.Schema(reflect.TypeOf(Strict{}))
+	strict := gen.Schemas()["Strict"]
+	require.NotNil(t, strict)
+	require.NotNil(t, strict.Additional)
+	require.NotNil(t, strict.Additional.Allow)
+	assert.False(t, *strict.Additional.Allow)
+	assert.True(t, strict.Nullable)
+	assert.Equal(t, []string{"name"}, slices.Collect(maps.Keys(strict.Properties)))
+}
+
+func TestSchemaGenerator_StructLevelTitleAndDescription(t *testing.T) {
+	type Titled struct {
+		_    struct{} `openapi:"title=Titled object,description='A titled, described object'"`
+		Name string   `json:"name"`
+	}
+	type Cataloged struct {
+		_    struct{} `openapi:"descriptionKey=schemas.cataloged"`
+		Name string   `json:"name"`
+	}
+	type Uncataloged struct {
+		_    struct{} `openapi:"descriptionKey=schemas.missing"`
+		Name string   `json:"name"`
+	}
+
+	metadata := NewMetadata(config.DefaultTagConfig())
+	gen := NewSchemaGenerator("#/components/schemas/", metadata, config.DefaultTagConfig())
+	gen.SetDescriptions(map[string]string{"schemas.cataloged": "Described in the catalog"})
+
+	gen.Schema(reflect.TypeOf(Titled{}))
+	gen.Schema(reflect.TypeOf(Cataloged{}))
+	require.NoError(t, gen.Err())
+
+	titled := gen.Schemas()["Titled"]
+	require.NotNil(t, titled)
+	assert.Equal(t, "Titled object", titled.Title)
+	assert.Equal(t, "A titled, described object", titled.Description)
+	assert.Equal(t, "Described in the catalog", gen.Schemas()["Cataloged"].Description)
+
+	gen.Schema(reflect.TypeOf(Uncataloged{}))
+	require.ErrorContains(t, gen.Err(), `type build.Uncataloged: description key "schemas.missing" is not in the description catalog`)
+}
+
+// RequiredVendorThing is not a struct: embedded, it is a property named after
+// its type, as with encoding/json.
+type RequiredVendorThing string
+
+type RequiredVendorCode struct {
+	Code string `json:"code"`
+}
+
+func TestSchemaGenerator_StructLevelRequired(t *testing.T) {
+	type Order struct {
+		_ struct{} `openapi:"required=RequiredVendorThing|note|id"`
+		RequiredVendorThing
+		ID   int      `json:"id" validate:"required"`
+		Note *string  `json:"note"`
+		Tags []string `json:"tags"`
+	}
+	type Invoice struct {
+		_ struct{} `openapi:"required=code"`
+		RequiredVendorCode
+		ID int `json:"id"`
+	}
+	type Unknown struct {
+		_  struct{} `openapi:"required=missing"`
+		ID int      `json:"id"`
+	}
+
+	metadata := NewMetadata(config.DefaultTagConfig())
+	gen := NewSchemaGenerator("#/components/schemas/", metadata, config.DefaultTagConfig())
+
+	gen.Schema(reflect.TypeOf(Order{}))
+	require.NoError(t, gen.Err())
+	order := gen.Schemas()["Order"]
+	require.NotNil(t, order)
+	assert.Equal(t, []string{"id", "RequiredVendorThing", "note"}, order.Required)
+	assert.False(t, order.Properties["note"].Nullable)
+
+	invoice, err := gen.generateStruct(reflect.TypeOf(Invoice{}))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"code"}, invoice.Required, "promoted fields can be required")
+	assert.Contains(t, invoice.Properties, "code")
+
+	_, err = gen.generateStruct(reflect.TypeOf(Unknown{}))
+	require.ErrorContains(t, err, "required property 'missing' of the _ field does not exist")
+}
+
 func TestSchemaGenerator_InterfaceType(t *testing.T) {
 	metadata := NewMetadata(config.DefaultTagConfig())
 	gen := NewSchemaGenerator("", metadata, config.DefaultTagConfig())
@@ -275,6 +465,58 @@ func TestSchemaGenerator_InterfaceType(t *testing.T) {
 	assert.Empty(t, schema.Type)
 }
 
+type unionShape interface{ shape() }
+
+type unionCircle struct {
+	Kind string `json:"kind"`
+}
+
+func (unionCircle) shape() {}
+
+func TestSchemaGenerator_Union(t *testing.T) {
+	shapeType := reflect.TypeOf((*unionShape)(nil)).Elem()
+	newGenerator := func() *SchemaGenerator {
+		gen := NewSchemaGenerator("#/components/schemas/", NewMetadata(config.DefaultTagConfig()), config.DefaultTagConfig())
+		gen.RegisterUnion(shapeType, []UnionVariant{{Name: "circle", Type: reflect.TypeOf(unionCircle{})}})
+
+		return gen
+	}
+
+	t.Run("discriminator", func(t *testing.T) {
+		type Drawing struct {
+			Shape unionShape `json:"shape" openapi:"discriminator=kind"`
+		}
+
+		s, err := newGenerator().generateStruct(reflect.TypeOf(Drawing{}))
+		require.NoError(t, err)
+		assert.Equal(t, &model.Schema{
+			OneOf: []*model.Schema{{Ref: "#/components/schemas/UnionCircle"}},
+			Discriminator: &model.Discriminator{
+				PropertyName: "kind",
+				Mapping:      map[string]string{"circle": "#/components/schemas/UnionCircle"},
+			},
+		}, s.Properties["shape"])
+	})
+
+	t.Run("not a registered union", func(t *testing.T) {
+		type Drawing struct {
+			Shape any `json:"shape" openapi:"discriminator=kind"`
+		}
+
+		_, err := newGenerator().generateStruct(reflect.TypeOf(Drawing{}))
+		require.ErrorContains(t, err, "field 'shape': discriminator requires a registered union, interface {} is not one")
+	})
+
+	t.Run("variant without the property", func(t *testing.T) {
+		type Drawing struct {
+			Shape unionShape `json:"shape" openapi:"discriminator=type"`
+		}
+
+		_, err := newGenerator().generateStruct(reflect.TypeOf(Drawing{}))
+		require.ErrorContains(t, err, `field 'shape': union variant build.unionCircle has no property "type"`)
+	})
+}
+
 func TestSchemaGenerator_Caching(t *testing.T) {
 	type User struct {
 		ID   int    `json:"id"`
@@ -295,3 +537,213 @@ func TestSchemaGenerator_Caching(t *testing.T) {
 	schemas := gen.Schemas()
 	assert.Len(t, schemas, 1)
 }
+
+func TestSchemaGenerator_MarkInline(t *testing.T) {
+	type Address struct {
+		City string `json:"city"`
+	}
+	type Customer struct {
+		Address Address `json:"address"`
+	}
+
+	gen := NewSchemaGenerator("#/components/schemas/", NewMetadata(config.DefaultTagConfig()), config.DefaultTagConfig())
+	gen.MarkInline(reflect.TypeOf(&Address{}))
+
+	s := gen.Schema(reflect.TypeOf(Customer{}))
+	assert.Equal(t, "#/components/schemas/Customer", s.Ref)
+	assert.Contains(t, gen.Schemas()["Customer"].Properties["address"].Properties, "city")
+	assert.NotContains(t, gen.Schemas(), "Address")
+	assert.NoError(t, gen.Err())
+}
+
+func TestSchemaGenerator_KeyPattern(t *testing.T) {
+	gen := NewSchemaGenerator("#/components/schemas/", NewMetadata(config.DefaultTagConfig()), config.DefaultTagConfig())
+
+	t.Run("map", func(t *testing.T) {
+		type Resource struct {
+			Labels      map[string]string  `json:"labels" openapi:"keyPattern=^[a-z]+$"`
+			Annotations *map[string]string `json:"annotations" openapi:"keyPattern='^[a-z]{1,8}$'"`
+		}
+
+		s, err := gen.generateStruct(reflect.TypeOf(Resource{}))
+		require.NoError(t, err)
+		assert.Equal(t, &model.Schema{Pattern: "^[a-z]+$"}, s.Properties["labels"].PropertyNames)
+		assert.Equal(t, &model.Schema{Pattern: "^[a-z]{1,8}$"}, s.Properties["annotations"].PropertyNames)
+	})
+
+	t.Run("not a map", func(t *testing.T) {
+		type Resource struct {
+			Name string `json:"name" openapi:"keyPattern=^[a-z]+$"`
+		}
+
+		_, err := gen.generateStruct(reflect.TypeOf(Resource{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "field 'name': keyPattern requires a map, string is not one")
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		type Resource struct {
+			Labels map[string]string `json:"labels" openapi:"keyPattern=^[a-z+$"`
+		}
+
+		_, err := gen.generateStruct(reflect.TypeOf(Resource{}))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `invalid keyPattern "^[a-z+$"`)
+	})
+}
+
+func TestSchemaGenerator_OmitZero(t *testing.T) {
+	type Event struct {
+		ID        string     `json:"id,omitzero" validate:"required"`
+		Title     string     `json:",omitzero"`
+		StartsAt  *time.Time `json:"starts_at,omitzero"`
+		EndsAt    *time.Time `json:"ends_at,omitempty"`
+		Cancelled *time.Time `json:"cancelled_at"`
+	}
+
+	gen := NewSchemaGenerator("#/components/schemas/", NewMetadata(config.DefaultTagConfig()), config.DefaultTagConfig())
+	s, err := gen.generateStruct(reflect.TypeOf(Event{}))
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"id", "Title", "starts_at", "ends_at", "cancelled_at"}, slices.Collect(maps.Keys(s.Properties)))
+	// Omitted fields are still required when tagged required
+	assert.Equal(t, []string{"id"}, s.Required)
+	// Nil pointers are left out rather than null with omitzero only
+	assert.False(t, s.Properties["starts_at"].Nullable)
+	assert.True(t, s.Properties["ends_at"].Nullable)
+	assert.True(t, s.Properties["cancelled_at"].Nullable)
+}
+
+func TestSchemaGenerator_InlineMap(t *testing.T) {
+	gen := NewSchemaGenerator("#/components/schemas/", NewMetadata(config.DefaultTagConfig()), config.DefaultTagConfig())
+
+	t.Run("map", func(t *testing.T) {
+		type Labels map[string]string
+		type Metadata struct {
+			Labels `openapi:"inlineMap,keyPattern=^[a-z]+$"`
+		}
+		type Resource struct {
+			Name string `json:"name" validate:"required"`
+			Metadata
+		}
+
+		s, err := gen.generateStruct(reflect.TypeOf(Resource{}))
+		require.NoError(t, err)
+		assert.Equal(t, []string{"name"}, slices.Collect(maps.Keys(s.Properties)))
+		assert.Equal(t, &model.Additional{Schema: &model.Schema{Type: TypeString}}, s.Additional)
+		assert.Equal(t, &model.Schema{Pattern: "^[a-z]+$"}, s.PropertyNames)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		type NotMap struct {
+			Extra []string `json:"extra" openapi:"inlineMap"`
+		}
+		type Twice struct {
+			Extra  map[string]any    `json:"extra" openapi:"inlineMap"`
+			Labels map[string]string `json:"labels" openapi:"inlineMap"`
+		}
+		type Closed struct {
+			_     struct{}       `openapi:"additionalProperties=false"`
+			Extra map[string]any `json:"extra" openapi:"inlineMap"`
+		}
+
+		_, err := gen.generateStruct(reflect.TypeOf(NotMap{}))
+		require.ErrorContains(t, err, "field 'Extra': inlineMap requires a map, []string is not one")
+		_, err = gen.generateStruct(reflect.TypeOf(Twice{}))
+		require.ErrorContains(t, err, "field 'Labels': inlineMap is already set on field Extra")
+		_, err = gen.generateStruct(reflect.TypeOf(Closed{}))
+		require.ErrorContains(t, err, "additionalProperties of the _ field conflicts with the inline map Extra")
+	})
+}
+
+type textOnlyID struct{ n int }
+
+func (id textOnlyID) MarshalText() ([]byte, error) { return []byte(strconv.Itoa(id.n)), nil }
+
+// textJSONID is written by MarshalJSON, which encoding/json prefers over MarshalText.
+type textJSONID struct {
+	N int `json:"n"`
+}
+
+func (id textJSONID) MarshalText() ([]byte, error) { return []byte(strconv.Itoa(id.N)), nil }
+func (id textJSONID) MarshalJSON() ([]byte, error) { return json.Marshal(id.N) }
+
+type marshalMoney struct {
+	Cents int64
+}
+
+func (m marshalMoney) MarshalJSON() ([]byte, error) { return json.Marshal(m.Cents) }
+func (marshalMoney) MarshalSchema() any             { return int64(0) }
+
+type marshalTotal struct {
+	Amount marshalMoney
+}
+
+func (t marshalTotal) MarshalJSON() ([]byte, error) { return json.Marshal(t.Amount) }
+func (marshalTotal) MarshalSchema() any             { return marshalMoney{} }
+
+type marshalAny struct{}
+
+func (marshalAny) MarshalSchema() any { return nil }
+
+type marshalSelf struct{}
+
+func (marshalSelf) MarshalSchema() any { return &marshalSelf{} }
+
+func TestSchemaGenerator_Marshalers(t *testing.T) {
+	gen := NewSchemaGenerator("#/components/schemas/", NewMetadata(config.DefaultTagConfig()), config.DefaultTagConfig())
+
+	assert.Equal(t, &model.Schema{Type: TypeString}, gen.Schema(reflect.TypeOf(textOnlyID{})))
+	assert.Equal(t, &model.Schema{Type: TypeString, Nullable: true}, gen.Schema(reflect.TypeOf(&textOnlyID{})))
+	assert.Equal(t, &model.Schema{Ref: "#/components/schemas/TextJSONID"}, gen.Schema(reflect.TypeOf(textJSONID{})))
+	require.Contains(t, gen.Schemas(), "TextJSONID")
+	assert.Equal(t, TypeObject, gen.Schemas()["TextJSONID"].Type)
+	assert.Equal(t, &model.Schema{Type: TypeInteger, Format: formatInt64}, gen.Schema(reflect.TypeOf(marshalMoney{})))
+	assert.Equal(t, &model.Schema{Type: TypeInteger, Format: formatInt64}, gen.Schema(reflect.TypeOf(marshalTotal{})))
+	assert.Equal(t, &model.Schema{}, gen.Schema(reflect.TypeOf(marshalAny{})))
+	assert.Equal(t, []string{"TextJSONID"}, slices.Collect(maps.Keys(gen.Schemas())))
+
+	_, err := gen.generate(reflect.TypeOf(marshalSelf{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "returns a value of the same type")
+}
+
+type contextStatus string
+
+type contextItem struct {
+	ID int `json:"id"`
+}
+
+// contextPage documents its items with the item component schema, and its status
+// with a status component schema.
+type contextPage struct{}
+
+func (contextPage) SchemaWithContext(ctx hook.SchemaContext) *model.Schema {
+	return &model.Schema{
+		Type: TypeObject,
+		Properties: map[string]*model.Schema{
+			"status": ctx.RefFor(reflect.TypeFor[contextStatus](), ""),
+			"items":  {Type: TypeArray, Items: ctx.RefFor(reflect.TypeFor[contextItem](), "")},
+			"next":   ctx.RefFor(reflect.TypeFor[struct{ Cursor string }](), "Cursor"),
+		},
+		Extensions: map[string]any{
+			"x-item-name": ctx.NameOf(reflect.TypeFor[contextItem](), ""),
+			"x-tag":       ctx.TagConfig().OpenAPI,
+		},
+	}
+}
+
+func TestSchemaGenerator_ContextSchemaProvider(t *testing.T) {
+	gen := NewSchemaGenerator("#/components/schemas/", NewMetadata(config.DefaultTagConfig()), config.DefaultTagConfig())
+
+	s := gen.Schema(reflect.TypeOf(contextPage{}))
+	assert.Equal(t, &model.Schema{Ref: "#/components/schemas/ContextStatus"}, s.Properties["status"])
+	assert.Equal(t, &model.Schema{Ref: "#/components/schemas/ContextItem"}, s.Properties["items"].Items)
+	assert.Equal(t, &model.Schema{Ref: "#/components/schemas/Cursor"}, s.Properties["next"])
+	assert.Equal(t, map[string]any{"x-item-name": "ContextItem", "x-tag": "openapi"}, s.Extensions)
+
+	assert.Equal(t, &model.Schema{Type: TypeString}, gen.Schemas()["ContextStatus"])
+	assert.ElementsMatch(t, []string{"ContextStatus", "ContextItem", "Cursor"}, slices.Collect(maps.Keys(gen.Schemas())))
+	assert.Equal(t, &model.Schema{Ref: "#/components/schemas/ContextStatus"}, gen.RefFor(reflect.TypeFor[contextStatus](), ""))
+	assert.NoError(t, gen.Err())
+}
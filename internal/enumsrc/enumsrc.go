@@ -0,0 +1,174 @@
+// Package enumsrc finds the values of Go enum types in their source: the
+// constants declared with the type, in the package directory.
+package enumsrc
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// Value is a constant of an enum type.
+type Value struct {
+	// Name is the name of the constant, e.g. "StatusActive".
+	Name string
+
+	// Value is the value of the constant: a string, int64, float64 or bool.
+	Value any
+
+	// Description is the doc comment of the constant, or its line comment.
+	Description string
+}
+
+// Package is a parsed and type-checked package directory.
+type Package struct {
+	fset     *token.FileSet
+	pkg      *types.Package
+	comments map[token.Pos]string
+}
+
+// Load parses the Go files of a package directory matching the build context,
+// test files excluded, and type-checks them. Imports are not loaded: constants
+// whose values depend on other packages are unknown.
+func Load(dir string) (*Package, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read package: %w", err)
+	}
+
+	p := &Package{fset: token.NewFileSet(), comments: make(map[token.Pos]string)}
+	var files []*ast.File
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		if ok, err := build.Default.MatchFile(dir, name); err != nil || !ok {
+			continue
+		}
+		f, err := parser.ParseFile(p.fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse package: %w", err)
+		}
+		files = append(files, f)
+		p.collectComments(f)
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no Go files in %s", dir)
+	}
+
+	conf := types.Config{
+		Importer: noImporter{},
+		// Errors of declarations depending on imports are expected
+		Error: func(error) {},
+	}
+	p.pkg, _ = conf.Check(files[0].Name.Name, p.fset, files, nil)
+
+	return p, nil
+}
+
+// collectComments records the comments of the constants of a file by position.
+func (p *Package) collectComments(f *ast.File) {
+	for _, decl := range f.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs, ok := spec.(*ast.ValueSpec)
+			if !ok {
+				continue
+			}
+			doc := vs.Doc
+			if doc == nil {
+				doc = vs.Comment
+			}
+			if doc == nil && len(gen.Specs) == 1 {
+				doc = gen.Doc
+			}
+			if text := strings.Join(strings.Fields(doc.Text()), " "); text != "" {
+				for _, name := range vs.Names {
+					p.comments[name.Pos()] = text
+				}
+			}
+		}
+	}
+}
+
+// Name returns the name of the package.
+func (p *Package) Name() string {
+	return p.pkg.Name()
+}
+
+// Values returns the constants of the named type of the package, in source order.
+func (p *Package) Values(typeName string) ([]Value, error) {
+	obj, ok := p.pkg.Scope().Lookup(typeName).(*types.TypeName)
+	if !ok {
+		return nil, fmt.Errorf("type %s not found in package %s", typeName, p.pkg.Name())
+	}
+
+	var consts []*types.Const
+	for _, name := range p.pkg.Scope().Names() {
+		if c, ok := p.pkg.Scope().Lookup(name).(*types.Const); ok && types.Identical(c.Type(), obj.Type()) && c.Name() != "_" {
+			consts = append(consts, c)
+		}
+	}
+	if len(consts) == 0 {
+		return nil, fmt.Errorf("type %s has no constants", typeName)
+	}
+	// Positions of files follow each other in the file set
+	slices.SortFunc(consts, func(a, b *types.Const) int {
+		return cmp.Compare(a.Pos(), b.Pos())
+	})
+
+	values := make([]Value, 0, len(consts))
+	for _, c := range consts {
+		v, err := goValue(c.Val())
+		if err != nil {
+			return nil, fmt.Errorf("constant %s: %w", c.Name(), err)
+		}
+		values = append(values, Value{Name: c.Name(), Value: v, Description: p.comments[c.Pos()]})
+	}
+
+	return values, nil
+}
+
+// goValue converts a constant value to a JSON-compatible Go value.
+func goValue(v constant.Value) (any, error) {
+	switch v.Kind() {
+	case constant.String:
+		return constant.StringVal(v), nil
+	case constant.Bool:
+		return constant.BoolVal(v), nil
+	case constant.Int:
+		if i, exact := constant.Int64Val(v); exact {
+			return i, nil
+		}
+	case constant.Float:
+		f, _ := constant.Float64Val(v)
+
+		return f, nil
+	case constant.Unknown:
+		return nil, errors.New("unknown value")
+	}
+
+	return nil, fmt.Errorf("unsupported value %s", v)
+}
+
+// noImporter fails to import any package.
+type noImporter struct{}
+
+// Import implements types.Importer.
+func (noImporter) Import(path string) (*types.Package, error) {
+	return nil, fmt.Errorf("package %s not loaded", path)
+}
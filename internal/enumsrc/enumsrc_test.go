@@ -0,0 +1,103 @@
+package enumsrc
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writePackage writes the files of a package to a temporary directory.
+func writePackage(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, src := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(src), 0o600))
+	}
+
+	return dir
+}
+
+func TestValues(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"status.go": `package account
+
+import "time"
+
+type Status string
+
+const (
+	// StatusActive accounts can sign in.
+	StatusActive Status = "active"
+	StatusLocked Status = "locked" // Locked after failed attempts.
+	StatusClosed Status = "closed"
+)
+
+// Timeout depends on an import.
+const Timeout = 5 * time.Second
+
+type Level int
+
+const (
+	LevelLow Level = iota + 1
+	LevelHigh
+	_
+	LevelMax
+)
+`,
+		"more.go": `package account
+
+// StatusPending accounts are being reviewed.
+const StatusPending Status = "pending"
+`,
+		"status_test.go": `package account
+
+const statusTest Status = "test"
+`,
+	})
+
+	pkg, err := Load(dir)
+	require.NoError(t, err)
+
+	values, err := pkg.Values("Status")
+	require.NoError(t, err)
+	assert.Equal(t, []Value{
+		{Name: "StatusPending", Value: "pending", Description: "StatusPending accounts are being reviewed."},
+		{Name: "StatusActive", Value: "active", Description: "StatusActive accounts can sign in."},
+		{Name: "StatusLocked", Value: "locked", Description: "Locked after failed attempts."},
+		{Name: "StatusClosed", Value: "closed"},
+	}, values)
+
+	values, err = pkg.Values("Level")
+	require.NoError(t, err)
+	assert.Equal(t, []Value{
+		{Name: "LevelLow", Value: int64(1)},
+		{Name: "LevelHigh", Value: int64(2)},
+		{Name: "LevelMax", Value: int64(4)},
+	}, values)
+}
+
+func TestValues_Errors(t *testing.T) {
+	dir := writePackage(t, map[string]string{
+		"kind.go": `package shapes
+
+type Kind string
+
+type Empty int
+`,
+	})
+	pkg, err := Load(dir)
+	require.NoError(t, err)
+
+	_, err = pkg.Values("Missing")
+	require.EqualError(t, err, "type Missing not found in package shapes")
+	_, err = pkg.Values("Empty")
+	require.EqualError(t, err, "type Empty has no constants")
+
+	_, err = Load(writePackage(t, map[string]string{"broken.go": "package broken\n\nconst ("}))
+	require.ErrorContains(t, err, "failed to parse package")
+	_, err = Load(t.TempDir())
+	require.ErrorContains(t, err, "no Go files")
+}
@@ -0,0 +1,3 @@
+// Package envelope builds the schemas of the JSON:API and HAL payload formats,
+// shared by the presets package and the response envelopes of the openapi package.
+package envelope
@@ -0,0 +1,62 @@
+package envelope
+
+import "github.com/talav/openapi/internal/model"
+
+// HALContentType is the media type of HAL documents.
+const HALContentType = "application/hal+json"
+
+// HAL returns the schema of a HAL resource wrapping body: the body with _links and
+// _embedded members, or a resource embedding the body under "items" when the
+// resolved body schema is an array. resolved is body with its reference followed,
+// or body itself.
+func HAL(body, resolved *model.Schema) *model.Schema {
+	members := &model.Schema{
+		Type: "object",
+		Properties: map[string]*model.Schema{
+			"_links":    Links(),
+			"_embedded": {Type: "object"},
+		},
+		Required: []string{"_links"},
+	}
+	if resolved.Type == "array" {
+		members.Properties["_embedded"].Properties = map[string]*model.Schema{"items": body}
+
+		return members
+	}
+
+	return &model.Schema{AllOf: []*model.Schema{body, members}}
+}
+
+// Links returns the schema of a _links member, mapping relation types to a link or
+// an array of links.
+func Links() *model.Schema {
+	return &model.Schema{
+		Type:       "object",
+		Properties: map[string]*model.Schema{"self": Link()},
+		Additional: &model.Additional{Schema: &model.Schema{
+			OneOf: []*model.Schema{Link(), {Type: "array", Items: Link()}},
+		}},
+	}
+}
+
+// Link returns the schema of a link object.
+func Link() *model.Schema {
+	str := func(description string) *model.Schema {
+		return &model.Schema{Type: "string", Description: description}
+	}
+
+	return &model.Schema{
+		Type: "object",
+		Properties: map[string]*model.Schema{
+			"href":        {Type: "string", Description: "URI or URI Template of the target resource"},
+			"templated":   {Type: "boolean", Description: "Whether href is a URI Template"},
+			"type":        str("Media type expected when dereferencing the target resource"),
+			"deprecation": {Type: "string", Format: "uri", Description: "URL of information about the deprecation of the link"},
+			"name":        str("Secondary key for selecting links of the same relation type"),
+			"profile":     {Type: "string", Format: "uri", Description: "Profile of the target resource"},
+			"title":       str("Human-readable label of the link"),
+			"hreflang":    str("Language of the target resource"),
+		},
+		Required: []string{"href"},
+	}
+}
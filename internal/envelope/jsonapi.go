@@ -0,0 +1,107 @@
+package envelope
+
+import "github.com/talav/openapi/internal/model"
+
+// JSONAPIContentType is the media type of JSON:API documents.
+const JSONAPIContentType = "application/vnd.api+json"
+
+// JSONAPI returns the schema of a JSON:API document wrapping body: the attributes
+// of a single resource, or of a resource per item when the resolved body schema is
+// an array. resolved is body with its reference followed, or body itself.
+func JSONAPI(body, resolved *model.Schema) *model.Schema {
+	if resolved.Type == "array" && resolved.Items != nil {
+		return Document(&model.Schema{Type: "array", Items: Resource(resolved.Items)})
+	}
+
+	return Document(Resource(body))
+}
+
+// Document returns the schema of a top-level document with the given primary data.
+func Document(data *model.Schema) *model.Schema {
+	return &model.Schema{
+		Type: "object",
+		Properties: map[string]*model.Schema{
+			"data":     data,
+			"included": {Type: "array", Items: Resource(&model.Schema{Type: "object"})},
+			"meta":     metaSchema(),
+			"links":    linksSchema(),
+			"jsonapi": {
+				Type:       "object",
+				Properties: map[string]*model.Schema{"version": {Type: "string"}, "meta": metaSchema()},
+			},
+		},
+		Required: []string{"data"},
+	}
+}
+
+// Resource returns the schema of a resource object with the given attributes schema.
+func Resource(attributes *model.Schema) *model.Schema {
+	return &model.Schema{
+		Type: "object",
+		Properties: map[string]*model.Schema{
+			"type":       {Type: "string"},
+			"id":         {Type: "string"},
+			"attributes": attributes,
+			"relationships": {
+				Type:       "object",
+				Additional: &model.Additional{Schema: Relationship()},
+			},
+			"links": linksSchema(),
+			"meta":  metaSchema(),
+		},
+		Required: []string{"type"},
+	}
+}
+
+// Relationship returns the schema of a relationship object.
+func Relationship() *model.Schema {
+	identifier := func() *model.Schema {
+		return &model.Schema{
+			Type: "object",
+			Properties: map[string]*model.Schema{
+				"type": {Type: "string"},
+				"id":   {Type: "string"},
+				"meta": metaSchema(),
+			},
+			Required: []string{"type", "id"},
+		}
+	}
+	one := 1
+
+	return &model.Schema{
+		Type: "object",
+		Properties: map[string]*model.Schema{
+			"data": {
+				OneOf: []*model.Schema{identifier(), {Type: "array", Items: identifier()}},
+			},
+			"links": linksSchema(),
+			"meta":  metaSchema(),
+		},
+		MinProperties: &one,
+	}
+}
+
+// linksSchema returns the schema of a links object: links are URIs or link objects.
+func linksSchema() *model.Schema {
+	return &model.Schema{
+		Type: "object",
+		Additional: &model.Additional{Schema: &model.Schema{
+			OneOf: []*model.Schema{
+				{Type: "string", Format: "uri-reference"},
+				{
+					Type: "object",
+					Properties: map[string]*model.Schema{
+						"href": {Type: "string", Format: "uri-reference"},
+						"meta": metaSchema(),
+					},
+					Required: []string{"href"},
+				},
+			},
+		}},
+	}
+}
+
+// metaSchema returns the schema of a meta object holding non-standard information.
+func metaSchema() *model.Schema {
+	return &model.Schema{Type: "object"}
+}
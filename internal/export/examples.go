@@ -0,0 +1,273 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v6"
+
+	"github.com/talav/openapi/debug"
+)
+
+// specResource is the resource name of the spec document when compiling its schemas.
+const specResource = "openapi-spec.json"
+
+// exampleSite is a declared example value and the schema it must conform to.
+type exampleSite struct {
+	pointer []string // location of the example value
+	schema  []string // location of the schema
+	value   any
+}
+
+// validateExamples checks that every example declared in the spec conforms to its schema:
+// schema examples, and examples of parameters, headers and media types. This is not
+// covered by the meta-schema, which only checks the structure of the document.
+func validateExamples(specJSON []byte, version string) (debug.ValidationErrors, error) {
+	var doc any
+	if err := json.Unmarshal(specJSON, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	sites := collectExamples(doc)
+	if len(sites) == 0 {
+		return nil, nil
+	}
+
+	// Schemas are compiled from a copy, as 3.0 schemas are rewritten to plain JSON Schema
+	var schemaDoc any
+	if err := json.Unmarshal(specJSON, &schemaDoc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(offlineLoader{})
+	if strings.HasPrefix(version, "3.0") {
+		compiler.DefaultDraft(jsonschema.Draft4)
+		normalizeNullable(schemaDoc)
+	} else {
+		compiler.DefaultDraft(jsonschema.Draft2020)
+	}
+	if err := compiler.AddResource(specResource, schemaDoc); err != nil {
+		return nil, fmt.Errorf("failed to add spec resource: %w", err)
+	}
+	// Identified component schemas are resources of their own, referenced by $id.
+	// Their dialect, such as the OpenAPI base dialect, is checked as the default one.
+	components, _ := schemaDoc.(map[string]any)["components"].(map[string]any)
+	schemas, _ := components["schemas"].(map[string]any)
+	for _, name := range sortedKeys(schemas) {
+		schema, _ := schemas[name].(map[string]any)
+		if id, ok := schema["$id"].(string); ok {
+			delete(schema, "$schema")
+			if err := compiler.AddResource(id, schema); err != nil {
+				return nil, fmt.Errorf("failed to add schema resource %s: %w", id, err)
+			}
+		}
+	}
+
+	var result debug.ValidationErrors
+	for _, site := range sites {
+		schema, err := compiler.Compile(specResource + "#" + jsonPointer(site.schema))
+		if err != nil {
+			return nil, fmt.Errorf("failed to compile schema at %s: %w", jsonPointer(site.schema), err)
+		}
+
+		err = schema.Validate(site.value)
+
+		var ve *jsonschema.ValidationError
+		if errors.As(err, &ve) {
+			// Examples rarely involve alternatives: report every mismatch, such as
+			// missing required properties next to mistyped ones
+			for _, v := range collectViolations(ve, site.value, false) {
+				v.Pointer = jsonPointer(site.pointer) + v.Pointer
+				result = append(result, v)
+			}
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to validate example at %s: %w", jsonPointer(site.pointer), err)
+		}
+	}
+
+	return result, nil
+}
+
+// collectExamples finds the declared examples of the spec, in deterministic order.
+func collectExamples(doc any) []exampleSite {
+	var sites []exampleSite
+	root, _ := doc.(map[string]any)
+
+	components, _ := root["components"].(map[string]any)
+	for _, name := range sortedKeys(components["schemas"]) {
+		sites = schemaExamples(sites, components["schemas"].(map[string]any)[name], []string{"components", "schemas", name})
+	}
+	for _, name := range sortedKeys(components["parameters"]) {
+		sites = objectExamples(sites, root, components["parameters"].(map[string]any)[name], []string{"components", "parameters", name})
+	}
+	for _, name := range sortedKeys(components["headers"]) {
+		sites = objectExamples(sites, root, components["headers"].(map[string]any)[name], []string{"components", "headers", name})
+	}
+	for _, name := range sortedKeys(components["requestBodies"]) {
+		sites = bodyExamples(sites, root, components["requestBodies"].(map[string]any)[name], []string{"components", "requestBodies", name})
+	}
+	for _, name := range sortedKeys(components["responses"]) {
+		sites = bodyExamples(sites, root, components["responses"].(map[string]any)[name], []string{"components", "responses", name})
+	}
+
+	paths, _ := root["paths"].(map[string]any)
+	for _, path := range sortedKeys(paths) {
+		item, _ := paths[path].(map[string]any)
+		base := []string{"paths", path}
+		sites = parameterExamples(sites, root, item, base)
+		for _, method := range sortedKeys(item) {
+			op, ok := item[method].(map[string]any)
+			if !ok || method == "parameters" {
+				continue
+			}
+			opBase := appendPath(base, method)
+			sites = parameterExamples(sites, root, op, opBase)
+			sites = bodyExamples(sites, root, op["requestBody"], appendPath(opBase, "requestBody"))
+			responses, _ := op["responses"].(map[string]any)
+			for _, status := range sortedKeys(responses) {
+				sites = bodyExamples(sites, root, responses[status], appendPath(opBase, "responses", status))
+			}
+		}
+	}
+
+	return sites
+}
+
+// parameterExamples collects the examples of the parameters declared by a path item or operation.
+func parameterExamples(sites []exampleSite, root map[string]any, node map[string]any, base []string) []exampleSite {
+	params, _ := node["parameters"].([]any)
+	for i, p := range params {
+		sites = objectExamples(sites, root, p, appendPath(base, "parameters", fmt.Sprint(i)))
+	}
+
+	return sites
+}
+
+// bodyExamples collects the examples of a request body or response: its media types and headers.
+func bodyExamples(sites []exampleSite, root map[string]any, node any, base []string) []exampleSite {
+	body, _ := node.(map[string]any)
+	for _, name := range sortedKeys(body["headers"]) {
+		sites = objectExamples(sites, root, body["headers"].(map[string]any)[name], appendPath(base, "headers", name))
+	}
+	for _, mediaType := range sortedKeys(body["content"]) {
+		sites = objectExamples(sites, root, body["content"].(map[string]any)[mediaType], appendPath(base, "content", mediaType))
+	}
+
+	return sites
+}
+
+// objectExamples collects the examples of a parameter, header or media type,
+// and of its schema.
+func objectExamples(sites []exampleSite, root map[string]any, node any, base []string) []exampleSite {
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return sites
+	}
+	if _, ok := obj["schema"].(map[string]any); !ok {
+		return sites
+	}
+	schema := appendPath(base, "schema")
+	sites = schemaExamples(sites, obj["schema"], schema)
+
+	if value, ok := obj["example"]; ok {
+		sites = append(sites, exampleSite{pointer: appendPath(base, "example"), schema: schema, value: value})
+	}
+	examples, _ := obj["examples"].(map[string]any)
+	for _, name := range sortedKeys(examples) {
+		example, _ := examples[name].(map[string]any)
+		pointer := appendPath(base, "examples", name, "value")
+		if ref, ok := example["$ref"].(string); ok {
+			// Examples defined in components are validated against each schema using them
+			example, _ = fragment(root, strings.Split(strings.TrimPrefix(ref, "#/"), "/")).(map[string]any)
+		}
+		if value, ok := example["value"]; ok {
+			sites = append(sites, exampleSite{pointer: pointer, schema: schema, value: value})
+		}
+	}
+
+	return sites
+}
+
+// schemaExamples collects the examples declared by a schema and its subschemas:
+// "example" in 3.0, and the "examples" array in 3.1.
+func schemaExamples(sites []exampleSite, node any, base []string) []exampleSite {
+	schema, ok := node.(map[string]any)
+	if !ok {
+		return sites
+	}
+	if _, ok := schema["$ref"]; ok {
+		// Referenced schemas are validated where they are defined
+		return sites
+	}
+
+	if value, ok := schema["example"]; ok {
+		sites = append(sites, exampleSite{pointer: appendPath(base, "example"), schema: base, value: value})
+	}
+	if examples, ok := schema["examples"].([]any); ok {
+		for i, value := range examples {
+			sites = append(sites, exampleSite{pointer: appendPath(base, "examples", fmt.Sprint(i)), schema: base, value: value})
+		}
+	}
+
+	for _, name := range sortedKeys(schema["properties"]) {
+		sites = schemaExamples(sites, schema["properties"].(map[string]any)[name], appendPath(base, "properties", name))
+	}
+	for _, key := range []string{"items", "additionalProperties", "not", "if", "then", "else"} {
+		sites = schemaExamples(sites, schema[key], appendPath(base, key))
+	}
+	for _, key := range []string{"allOf", "anyOf", "oneOf", "prefixItems"} {
+		subs, _ := schema[key].([]any)
+		for i, sub := range subs {
+			sites = schemaExamples(sites, sub, appendPath(base, key, fmt.Sprint(i)))
+		}
+	}
+
+	return sites
+}
+
+// normalizeNullable rewrites the OpenAPI 3.0 "nullable" keyword into JSON Schema,
+// so that null examples of nullable schemas are accepted.
+func normalizeNullable(node any) {
+	switch v := node.(type) {
+	case map[string]any:
+		if nullable, _ := v["nullable"].(bool); nullable {
+			if t, ok := v["type"].(string); ok {
+				v["type"] = []any{t, "null"}
+			}
+			if enum, ok := v["enum"].([]any); ok {
+				v["enum"] = append(enum, nil)
+			}
+		}
+		for _, child := range v {
+			normalizeNullable(child)
+		}
+	case []any:
+		for _, child := range v {
+			normalizeNullable(child)
+		}
+	}
+}
+
+// sortedKeys returns the keys of a JSON object in order, or nil if node is not an object.
+func sortedKeys(node any) []string {
+	obj, ok := node.(map[string]any)
+	if !ok {
+		return nil
+	}
+	keys := make([]string, 0, len(obj))
+	for k := range obj {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// appendPath returns a new pointer with tokens appended to base.
+func appendPath(base []string, tokens ...string) []string {
+	return append(append(make([]string, 0, len(base)+len(tokens)), base...), tokens...)
+}
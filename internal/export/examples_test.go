@@ -0,0 +1,83 @@
+package export
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateExamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		version  string
+		spec     string
+		pointers []string
+	}{
+		{
+			name:    "nullable 3.0 schema accepts null",
+			version: "3.0.4",
+			spec: `{"components": {"schemas": {"Note": {
+				"type": "object",
+				"properties": {"text": {"type": "string", "nullable": true, "example": null}}
+			}}}}`,
+		},
+		{
+			name:    "3.0 schema without nullable rejects null",
+			version: "3.0.4",
+			spec: `{"components": {"schemas": {"Note": {
+				"type": "object",
+				"properties": {"text": {"type": "string", "example": null}}
+			}}}}`,
+			pointers: []string{"/components/schemas/Note/properties/text/example"},
+		},
+		{
+			name:    "parameter example",
+			version: "3.1.2",
+			spec: `{"paths": {"/items": {"get": {"parameters": [
+				{"name": "limit", "in": "query", "schema": {"type": "integer"}, "example": "ten"}
+			]}}}}`,
+			pointers: []string{"/paths/~1items/get/parameters/0/example"},
+		},
+		{
+			name:    "referenced example against referenced schema",
+			version: "3.1.2",
+			spec: `{
+				"paths": {"/items": {"get": {"responses": {"200": {"content": {"application/json": {
+					"schema": {"$ref": "#/components/schemas/Item"},
+					"examples": {"bad": {"$ref": "#/components/examples/Bad"}}
+				}}}}}}},
+				"components": {
+					"schemas": {"Item": {"type": "object", "required": ["id"]}},
+					"examples": {"Bad": {"value": {}}}
+				}
+			}`,
+			pointers: []string{"/paths/~1items/get/responses/200/content/application~1json/examples/bad/value"},
+		},
+		{
+			name:    "mistyped and missing required properties",
+			version: "3.1.2",
+			spec: `{"paths": {"/items": {"post": {"requestBody": {"content": {"application/json": {
+				"schema": {"type": "object", "required": ["id", "name"], "properties": {"id": {"type": "integer"}}},
+				"example": {"id": "one"}
+			}}}}}}}`,
+			pointers: []string{
+				"/paths/~1items/post/requestBody/content/application~1json/example/id",
+				"/paths/~1items/post/requestBody/content/application~1json/example",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			violations, err := validateExamples([]byte(tt.spec), tt.version)
+			require.NoError(t, err)
+
+			var pointers []string
+			for _, v := range violations {
+				pointers = append(pointers, v.Pointer)
+			}
+			assert.Equal(t, tt.pointers, pointers)
+		})
+	}
+}
@@ -5,19 +5,63 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
+	"strings"
 
 	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/export/util"
 	"github.com/talav/openapi/internal/model"
 )
 
 type Exporter interface {
 	Export(ctx context.Context, spec *model.Spec, cfg ExporterConfig) (*ExporterResult, error)
+	// ExportTo writes the marshaled spec to w instead of returning it.
+	// The output is identical to Export, followed by a newline. Exports that
+	// are not validated, checked or transformed are streamed: the members of
+	// the document, down to path items and component entries, are encoded one
+	// at a time.
+	ExportTo(ctx context.Context, w io.Writer, spec *model.Spec, cfg ExporterConfig) (debug.Warnings, error)
+	// Check runs the checks of Export without keeping the marshaled spec. The
+	// spec is only marshaled when validated, checked or transformed.
+	Check(ctx context.Context, spec *model.Spec, cfg ExporterConfig) (debug.Warnings, error)
 	IsSupportedVersion(version string) bool
 }
 
 type ExporterConfig struct {
 	Version        string
 	ShouldValidate bool
+
+	// StrictDownlevel fails the export, instead of warning, when features of
+	// the spec cannot be represented in the target version.
+	StrictDownlevel bool
+
+	// MetaSchema optionally replaces the meta-schema of the version the spec is
+	// validated against.
+	MetaSchema []byte
+
+	// Validate optionally checks the marshaled spec, e.g. with custom validators,
+	// independently of ShouldValidate: its violations fail the export.
+	Validate func(specJSON []byte) debug.ValidationErrors
+
+	// Scope restricts what is validated when ShouldValidate is set.
+	// Zero validates the whole document against the meta-schema.
+	Scope ValidationScope
+
+	// CheckExamples reports examples that do not conform to their schemas as
+	// warnings, independently of validation.
+	CheckExamples bool
+
+	// SourceOf optionally resolves what produced the value at a JSON pointer
+	// of the spec (e.g. a Go type or field). Used to annotate validation errors.
+	SourceOf func(pointer string) string
+
+	// StrictExamples lists the JSON pointers of examples that must conform to
+	// their schemas, e.g. those loaded from files: a mismatch fails the export.
+	StrictExamples []string
+
+	// Transform optionally rewrites the marshaled spec before it is validated,
+	// e.g. to apply overlays.
+	Transform func(specJSON []byte) ([]byte, error)
 }
 
 // Result contains the output of spec projection.
@@ -30,6 +74,41 @@ type ExporterResult struct {
 	Warnings debug.Warnings
 }
 
+// ValidationScope is a set of validation checks.
+type ValidationScope uint8
+
+const (
+	// ScopeSchemas checks component schemas against the meta-schema.
+	ScopeSchemas ValidationScope = 1 << iota
+	// ScopePaths checks paths and operations against the meta-schema.
+	ScopePaths
+	// ScopeExamples checks that declared examples conform to their schemas.
+	ScopeExamples
+)
+
+// metaSchema reports whether the scope includes meta-schema validation.
+func (s ValidationScope) metaSchema() bool {
+	return s == 0 || s&(ScopeSchemas|ScopePaths) != 0
+}
+
+// includes reports whether a meta-schema violation at pointer is in scope.
+func (s ValidationScope) includes(pointer string) bool {
+	if s&(ScopeSchemas|ScopePaths) == 0 {
+		return true
+	}
+
+	return s&ScopeSchemas != 0 && underPointer(pointer, "/components/schemas") ||
+		s&ScopePaths != 0 && underPointer(pointer, "/paths")
+}
+
+// underPointer reports whether pointer is base or one of its descendants.
+func underPointer(pointer, base string) bool {
+	return pointer == base || strings.HasPrefix(pointer, base+"/")
+}
+
+// degradationPrefix is the prefix of the codes of degradation warnings.
+const degradationPrefix = "DEGRADATION_"
+
 type ViewAdapter interface {
 	View(spec *model.Spec) (any, debug.Warnings, error)
 	Version() string
@@ -56,38 +135,259 @@ func (e *exporter) IsSupportedVersion(version string) bool {
 }
 
 func (e *exporter) Export(ctx context.Context, spec *model.Spec, cfg ExporterConfig) (*ExporterResult, error) {
+	adapter, out, warns, err := e.view(spec, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec to JSON: %w", err)
+	}
+	if cfg.Transform != nil {
+		if result, err = cfg.Transform(result); err != nil {
+			return nil, err
+		}
+	}
+
+	if cfg.ShouldValidate || cfg.Validate != nil || cfg.CheckExamples || len(cfg.StrictExamples) > 0 {
+		if err := ctx.Err(); err != nil {
+			return nil, fmt.Errorf("canceled before validation: %w", err)
+		}
+	}
+
+	if cfg.ShouldValidate || cfg.Validate != nil {
+		if err := validate(ctx, adapter, result, cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	// Examples validated above need no other check
+	if !cfg.ShouldValidate || cfg.Scope&ScopeExamples == 0 {
+		if err := checkStrictExamples(result, adapter.Version(), cfg); err != nil {
+			return nil, err
+		}
+		if cfg.CheckExamples {
+			mismatches, err := checkExamples(result, adapter.Version(), cfg.SourceOf)
+			if err != nil {
+				return nil, err
+			}
+			warns = append(warns, mismatches...)
+		}
+	}
+
+	return &ExporterResult{
+		Result:   result,
+		Warnings: warns,
+	}, nil
+}
+
+func (e *exporter) ExportTo(ctx context.Context, w io.Writer, spec *model.Spec, cfg ExporterConfig) (debug.Warnings, error) {
+	// An invalid spec must not be written, so exports marshaling the spec for
+	// checks are buffered.
+	if cfg.marshals() {
+		result, err := e.Export(ctx, spec, cfg)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(append(result.Result, '\n')); err != nil {
+			return nil, fmt.Errorf("failed to write spec: %w", err)
+		}
+
+		return result.Warnings, nil
+	}
+
+	_, out, warns, err := e.view(spec, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("canceled before writing: %w", err)
+	}
+
+	if err := util.Stream(w, out); err != nil {
+		return nil, fmt.Errorf("failed to write spec: %w", err)
+	}
+
+	return warns, nil
+}
+
+func (e *exporter) Check(ctx context.Context, spec *model.Spec, cfg ExporterConfig) (debug.Warnings, error) {
+	if cfg.marshals() {
+		result, err := e.Export(ctx, spec, cfg)
+		if err != nil {
+			return nil, err
+		}
+
+		return result.Warnings, nil
+	}
+
+	_, _, warns, err := e.view(spec, cfg)
+
+	return warns, err
+}
+
+// marshals reports whether the export needs the marshaled spec besides its
+// output: a spec must be complete to be validated, checked or transformed.
+func (cfg ExporterConfig) marshals() bool {
+	return cfg.ShouldValidate || cfg.Validate != nil || cfg.CheckExamples || len(cfg.StrictExamples) > 0 || cfg.Transform != nil
+}
+
+// view creates the version-specific view of the spec.
+func (e *exporter) view(spec *model.Spec, cfg ExporterConfig) (ViewAdapter, any, debug.Warnings, error) {
 	if spec == nil {
-		return nil, errors.New("nil spec")
+		return nil, nil, nil, errors.New("nil spec")
 	}
 
 	adapter, ok := e.adapters[cfg.Version]
 	if !ok {
-		return nil, fmt.Errorf("unknown version: %s", cfg.Version)
+		return nil, nil, nil, fmt.Errorf("unknown version: %s", cfg.Version)
 	}
 	out, warns, err := adapter.View(spec)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create a view of the spec: %w", err)
+		return nil, nil, nil, fmt.Errorf("failed to create a view of the spec: %w", err)
+	}
+	if cfg.StrictDownlevel {
+		if err := degradations(warns); err != nil {
+			return nil, nil, nil, fmt.Errorf("strict downlevel to %s: %w", cfg.Version, err)
+		}
 	}
 
-	result, err := json.MarshalIndent(out, "", "  ")
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal spec to JSON: %w", err)
+	return adapter, out, warns, nil
+}
+
+// degradations returns the features lost or converted by a downlevel projection
+// as an error, or nil if there are none.
+func degradations(warns debug.Warnings) error {
+	var errs []error
+	for _, w := range warns {
+		if strings.HasPrefix(string(w.Code()), degradationPrefix) {
+			errs = append(errs, fmt.Errorf("%s: %s", w.Path(), w.Message()))
+		}
 	}
 
-	if cfg.ShouldValidate {
-		schemaJSON := adapter.SchemaJSON()
+	return errors.Join(errs...)
+}
+
+// validate validates the marshaled spec within the configured scope: against the
+// meta-schema of the adapter version, and optionally its examples against their
+// schemas. Violations of cfg.Validate are added.
+func validate(ctx context.Context, adapter ViewAdapter, specJSON []byte, cfg ExporterConfig) error {
+	var violations debug.ValidationErrors
 
-		validator, err := NewValidator(schemaJSON)
+	if cfg.ShouldValidate && cfg.Scope.metaSchema() {
+		metaSchema := adapter.SchemaJSON()
+		if cfg.MetaSchema != nil {
+			metaSchema = cfg.MetaSchema
+		}
+		validator, err := NewValidator(metaSchema)
 		if err != nil {
-			return nil, fmt.Errorf("failed to create validator: %w", err)
+			return fmt.Errorf("failed to create validator: %w", err)
 		}
-		if err := validator.Validate(ctx, result); err != nil {
-			return nil, fmt.Errorf("validation failed: %w", err)
+		if err := validator.Validate(ctx, specJSON); err != nil {
+			var found debug.ValidationErrors
+			if !errors.As(err, &found) {
+				return fmt.Errorf("validation failed: %w", err)
+			}
+			for _, v := range found {
+				if cfg.Scope.includes(v.Pointer) {
+					violations = append(violations, v)
+				}
+			}
 		}
 	}
 
-	return &ExporterResult{
-		Result:   result,
-		Warnings: warns,
-	}, nil
+	if cfg.ShouldValidate && cfg.Scope&ScopeExamples != 0 {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("canceled after meta-schema validation: %w", err)
+		}
+		found, err := validateExamples(specJSON, adapter.Version())
+		if err != nil {
+			return fmt.Errorf("failed to validate examples: %w", err)
+		}
+		violations = append(violations, found...)
+	}
+
+	if cfg.Validate != nil {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("canceled before custom validation: %w", err)
+		}
+		found := cfg.Validate(specJSON)
+		if len(found) > 0 {
+			var doc any
+			if err := json.Unmarshal(specJSON, &doc); err != nil {
+				return fmt.Errorf("failed to unmarshal JSON: %w", err)
+			}
+			for i := range found {
+				if found[i].Fragment == nil {
+					found[i].Fragment = fragment(doc, pointerTokens(found[i].Pointer))
+				}
+			}
+		}
+		violations = append(violations, found...)
+	}
+
+	if len(violations) == 0 {
+		return nil
+	}
+	if cfg.SourceOf != nil {
+		for i := range violations {
+			violations[i].Source = cfg.SourceOf(violations[i].Pointer)
+		}
+	}
+
+	return fmt.Errorf("validation failed: %w", violations)
+}
+
+// checkStrictExamples fails when the examples of cfg.StrictExamples do not
+// conform to their schemas.
+func checkStrictExamples(specJSON []byte, version string, cfg ExporterConfig) error {
+	if len(cfg.StrictExamples) == 0 {
+		return nil
+	}
+	mismatches, err := validateExamples(specJSON, version)
+	if err != nil {
+		return fmt.Errorf("failed to validate examples: %w", err)
+	}
+
+	var violations debug.ValidationErrors
+	for _, m := range mismatches {
+		for _, ptr := range cfg.StrictExamples {
+			if strings.HasPrefix(m.Pointer, ptr+"/") {
+				if cfg.SourceOf != nil {
+					m.Source = cfg.SourceOf(m.Pointer)
+				}
+				violations = append(violations, m)
+
+				break
+			}
+		}
+	}
+	if len(violations) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("example validation failed: %w", violations)
+}
+
+// checkExamples reports the examples of the marshaled spec that do not conform
+// to their schemas as warnings.
+func checkExamples(specJSON []byte, version string, sourceOf func(string) string) (debug.Warnings, error) {
+	mismatches, err := validateExamples(specJSON, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check examples: %w", err)
+	}
+
+	var warns debug.Warnings
+	for _, m := range mismatches {
+		msg := "example does not conform to its schema: " + m.Message
+		if sourceOf != nil {
+			if source := sourceOf(m.Pointer); source != "" {
+				msg += " (from " + source + ")"
+			}
+		}
+		warns.Append(debug.NewWarning(debug.WarnExampleSchemaMismatch, "#"+m.Pointer, msg))
+	}
+
+	return warns, nil
 }
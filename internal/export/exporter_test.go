@@ -1,9 +1,11 @@
 package export
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -134,6 +136,37 @@ func TestExport_ValidatorCreationError(t *testing.T) {
 	assert.Contains(t, err.Error(), "failed to create validator")
 }
 
+func TestNewValidator_Offline(t *testing.T) {
+	// The embedded meta-schemas are self-contained
+	for _, adapter := range []ViewAdapter{&v304.AdapterV304{}, &v312.AdapterV312{}} {
+		t.Run(adapter.Version(), func(t *testing.T) {
+			_, err := NewValidator(adapter.SchemaJSON())
+			require.NoError(t, err)
+		})
+	}
+
+	for _, ref := range []string{"https://example.com/schema.json", "file:///etc/schema.json"} {
+		t.Run(ref, func(t *testing.T) {
+			_, err := NewValidator([]byte(`{"$ref": "` + ref + `"}`))
+			require.ErrorContains(t, err, ref+" is not loaded: validation is offline")
+		})
+	}
+}
+
+func TestExport_MetaSchema(t *testing.T) {
+	exporter := NewExporter([]ViewAdapter{&v312.AdapterV312{}})
+	cfg := ExporterConfig{
+		Version:        "3.1.2",
+		ShouldValidate: true,
+		MetaSchema:     []byte(`{"properties": {"openapi": {"const": "3.1.0"}}}`),
+	}
+
+	_, err := exporter.Export(context.Background(), createMinimalSpec(), cfg)
+	var violations debug.ValidationErrors
+	require.ErrorAs(t, err, &violations)
+	assert.Equal(t, "/openapi", violations[0].Pointer)
+}
+
 func TestExport_ValidationFailure(t *testing.T) {
 	// Use a valid schema but return a view that doesn't match it
 	// We'll use the 3.0.4 schema but return invalid OpenAPI JSON
@@ -161,6 +194,40 @@ func TestExport_ValidationFailure(t *testing.T) {
 	assert.Contains(t, err.Error(), "validation failed")
 }
 
+func TestExport_ValidationFailure_Violations(t *testing.T) {
+	mock := &mockAdapter{
+		version:    "3.0.4",
+		schemaJSON: (&v304.AdapterV304{}).SchemaJSON(),
+		viewFunc: func(*model.Spec) (any, debug.Warnings, error) {
+			return map[string]any{
+				"openapi": "3.0.4",
+				"info":    map[string]any{"title": "Test", "version": 1},
+				"paths":   map[string]any{},
+			}, nil, nil
+		},
+	}
+
+	exporter := NewExporter([]ViewAdapter{mock})
+	cfg := ExporterConfig{
+		Version:        "3.0.4",
+		ShouldValidate: true,
+		SourceOf:       func(pointer string) string { return "source of " + pointer },
+	}
+
+	_, err := exporter.Export(context.Background(), createMinimalSpec(), cfg)
+	require.Error(t, err)
+
+	var violations debug.ValidationErrors
+	require.ErrorAs(t, err, &violations)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "/info/version", violations[0].Pointer)
+	assert.InDelta(t, 1, violations[0].Fragment, 0)
+	assert.Contains(t, violations[0].Rule, "type")
+	assert.NotEmpty(t, violations[0].Message)
+	assert.Equal(t, "source of /info/version", violations[0].Source)
+	assert.Contains(t, err.Error(), "#/info/version")
+}
+
 func TestExport_Success_V304(t *testing.T) {
 	adapter := &v304.AdapterV304{}
 	exporter := NewExporter([]ViewAdapter{adapter})
@@ -298,6 +365,110 @@ func TestExport_Success_WithExtensions(t *testing.T) {
 }
 
 // Helper function to create a minimal spec.
+func TestExportTo_MatchesExport(t *testing.T) {
+	for _, adapter := range []ViewAdapter{&v304.AdapterV304{}, &v312.AdapterV312{}} {
+		t.Run(adapter.Version(), func(t *testing.T) {
+			exporter := NewExporter([]ViewAdapter{adapter})
+			spec := createComprehensiveSpec()
+			ctx := context.Background()
+
+			for _, validate := range []bool{false, true} {
+				cfg := ExporterConfig{Version: adapter.Version(), ShouldValidate: validate}
+
+				result, err := exporter.Export(ctx, spec, cfg)
+				require.NoError(t, err)
+
+				var buf bytes.Buffer
+				warnings, err := exporter.ExportTo(ctx, &buf, spec, cfg)
+				require.NoError(t, err)
+
+				assert.Equal(t, string(result.Result)+"\n", buf.String())
+				assert.Equal(t, result.Warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestCheck_MatchesExport(t *testing.T) {
+	for _, adapter := range []ViewAdapter{&v304.AdapterV304{}, &v312.AdapterV312{}} {
+		t.Run(adapter.Version(), func(t *testing.T) {
+			exporter := NewExporter([]ViewAdapter{adapter})
+			spec := createComprehensiveSpec()
+			ctx := context.Background()
+
+			for _, validate := range []bool{false, true} {
+				cfg := ExporterConfig{Version: adapter.Version(), ShouldValidate: validate}
+
+				result, err := exporter.Export(ctx, spec, cfg)
+				require.NoError(t, err)
+
+				warnings, err := exporter.Check(ctx, spec, cfg)
+				require.NoError(t, err)
+				assert.Equal(t, result.Warnings, warnings)
+			}
+		})
+	}
+}
+
+func TestExportTo_ValidationFailureWritesNothing(t *testing.T) {
+	mock := &mockAdapter{
+		version:    "3.0.4",
+		schemaJSON: (&v304.AdapterV304{}).SchemaJSON(),
+		viewFunc: func(*model.Spec) (any, debug.Warnings, error) {
+			return map[string]any{"openapi": "3.0.4"}, nil, nil
+		},
+	}
+	exporter := NewExporter([]ViewAdapter{mock})
+
+	var buf bytes.Buffer
+	_, err := exporter.ExportTo(context.Background(), &buf, createMinimalSpec(), ExporterConfig{Version: "3.0.4", ShouldValidate: true})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "validation failed")
+	assert.Zero(t, buf.Len())
+}
+
+func TestExport_Canceled(t *testing.T) {
+	exporter := NewExporter([]ViewAdapter{&v304.AdapterV304{}})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	result, err := exporter.Export(ctx, createMinimalSpec(), ExporterConfig{Version: "3.0.4", ShouldValidate: true})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "canceled before validation")
+	assert.Nil(t, result)
+
+	var buf bytes.Buffer
+	_, err = exporter.ExportTo(ctx, &buf, createMinimalSpec(), ExporterConfig{Version: "3.0.4"})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Contains(t, err.Error(), "canceled before writing")
+	assert.Zero(t, buf.Len())
+}
+
+func TestExportTo_Errors(t *testing.T) {
+	exporter := NewExporter([]ViewAdapter{&v304.AdapterV304{}})
+	ctx := context.Background()
+
+	_, err := exporter.ExportTo(ctx, io.Discard, nil, ExporterConfig{Version: "3.0.4"})
+	require.ErrorContains(t, err, "nil spec")
+
+	_, err = exporter.ExportTo(ctx, io.Discard, createMinimalSpec(), ExporterConfig{Version: "2.0.0"})
+	require.ErrorContains(t, err, "unknown version")
+
+	_, err = exporter.ExportTo(ctx, failingWriter{}, createMinimalSpec(), ExporterConfig{Version: "3.0.4"})
+	require.ErrorContains(t, err, "failed to write spec")
+
+	_, err = exporter.ExportTo(ctx, failingWriter{}, createMinimalSpec(), ExporterConfig{Version: "3.0.4", ShouldValidate: true})
+	require.ErrorContains(t, err, "failed to write spec")
+}
+
+// failingWriter is an io.Writer that always fails.
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
 func createMinimalSpec() *model.Spec {
 	return &model.Spec{
 		Info: model.Info{
@@ -0,0 +1,19 @@
+package util
+
+import "github.com/talav/openapi/internal/model"
+
+// Explode returns the explode field of a parameter: set if true (as generated so
+// far), and if false for array and object values with the form style, whose default
+// is true. Nil omits the field, which then has no effect or defaults to false.
+func Explode(p *model.Parameter) *bool {
+	style := p.Style
+	if style == "" && (p.In == "query" || p.In == "cookie") {
+		style = "form"
+	}
+	scalar := p.Schema != nil && p.Schema.Ref == "" && p.Schema.Type != "array" && p.Schema.Type != "object" && p.Schema.Type != ""
+	if !p.Explode && (style != "form" || scalar) {
+		return nil
+	}
+
+	return &p.Explode
+}
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+func TestExplode(t *testing.T) {
+	yes, no := true, false
+	array := &model.Schema{Type: "array", Items: &model.Schema{Type: "string"}}
+	tests := []struct {
+		param model.Parameter
+		want  *bool
+	}{
+		{model.Parameter{In: "query", Style: "form", Explode: true, Schema: array}, &yes},
+		{model.Parameter{In: "query", Style: "form", Schema: array}, &no},
+		{model.Parameter{In: "query", Schema: array}, &no},
+		{model.Parameter{In: "cookie", Schema: &model.Schema{Ref: "#/components/schemas/Session"}}, &no},
+		{model.Parameter{In: "query", Style: "deepObject", Explode: true, Schema: &model.Schema{Type: "object"}}, &yes},
+		{model.Parameter{In: "query", Style: "pipeDelimited", Schema: array}, nil},
+		{model.Parameter{In: "query", Schema: &model.Schema{Type: "integer"}}, nil},
+		{model.Parameter{In: "path", Style: "simple", Schema: array}, nil},
+		{model.Parameter{In: "header", Schema: array}, nil},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Explode(&tt.param), "%+v", tt.param)
+	}
+}
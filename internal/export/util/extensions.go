@@ -1,8 +1,11 @@
 package util
 
 import (
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"maps"
+	"slices"
 )
 
 // marshalWithExtensions marshals a struct with extensions inlined.
@@ -20,6 +23,9 @@ import (
 // on the same type, causing infinite recursion. The type alias creates a
 // new type that doesn't have the MarshalJSON method, allowing standard
 // JSON marshaling to proceed.
+//
+// Extensions named like a member of the marshaled object are rejected, as they
+// would produce duplicate keys.
 func MarshalWithExtensions(v any, extensions map[string]any) ([]byte, error) {
 	// Marshal the base struct
 	data, err := json.Marshal(v)
@@ -31,15 +37,88 @@ func MarshalWithExtensions(v any, extensions map[string]any) ([]byte, error) {
 		return data, nil
 	}
 
-	// Parse the JSON into a map
-	var m map[string]any
-	if unmarshalErr := json.Unmarshal(data, &m); unmarshalErr != nil {
-		return nil, unmarshalErr
+	if len(data) < 2 || data[0] != '{' || data[len(data)-1] != '}' {
+		return nil, fmt.Errorf("cannot inline extensions into non-object JSON %q", data)
+	}
+
+	// Splice the extensions into the object instead of decoding and re-encoding
+	// it, in sorted key order for deterministic output.
+	keys := slices.Sorted(maps.Keys(extensions))
+	if err := checkMembers(data, keys); err != nil {
+		return nil, err
+	}
+
+	// The result is retained by the caller, so it is allocated once, not pooled
+	buf := bytes.NewBuffer(make([]byte, 0, len(data)+32*len(keys)))
+	buf.Write(data[:len(data)-1])
+	needsComma := len(bytes.TrimSpace(data[1:len(data)-1])) > 0
+	for _, key := range keys {
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		val, err := json.Marshal(extensions[key])
+		if err != nil {
+			return nil, fmt.Errorf("extension %s: %w", key, err)
+		}
+		if needsComma {
+			buf.WriteByte(',')
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(val)
+		needsComma = true
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// checkMembers reports an error for the first key naming a member of the JSON
+// object data. The object is only scanned when it holds one of the keys.
+func checkMembers(data []byte, keys []string) error {
+	for _, key := range keys {
+		if !bytes.Contains(data, []byte(key)) {
+			continue
+		}
+		members, err := objectKeys(data)
+		if err != nil {
+			return err
+		}
+		for _, name := range keys {
+			if members[name] {
+				return fmt.Errorf("extension %s: conflicts with the member of the same name", name)
+			}
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// objectKeys returns the member names of the JSON object data, skipping over the
+// member values.
+func objectKeys(data []byte) (map[string]bool, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if _, err := dec.Token(); err != nil {
+		return nil, err
 	}
 
-	// Merge extensions into the map
-	maps.Copy(m, extensions)
+	keys := make(map[string]bool)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		keys[key] = true
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+	}
 
-	// Marshal back to JSON
-	return json.Marshal(m)
+	return keys, nil
 }
@@ -0,0 +1,32 @@
+package util
+
+import (
+	"reflect"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// LeafKey identifies a leaf schema: a reference, or a schema with only a type,
+// a format and nullability, such as {type: string} or {type: integer, format: int64}.
+// Leaf schemas are the most repeated schemas of large specifications.
+type LeafKey struct {
+	Ref      string
+	Type     string
+	Format   string
+	Nullable bool
+}
+
+// Leaf returns the key of a leaf schema, and false for other schemas. Adapters
+// intern the views of leaf schemas, shared by all their occurrences: views are
+// written once and never modified, and leaf schemas are projected without warnings.
+func Leaf(s *model.Schema) (LeafKey, bool) {
+	if s.Ref != "" {
+		// References are projected without their siblings, except the identifiers
+		// of the schema resource.
+		return LeafKey{Ref: s.Ref}, s.ID == "" && s.Dialect == ""
+	}
+
+	leaf := model.Schema{Type: s.Type, Format: s.Format, Nullable: s.Nullable}
+
+	return LeafKey{Type: s.Type, Format: s.Format, Nullable: s.Nullable}, reflect.DeepEqual(*s, leaf)
+}
@@ -0,0 +1,35 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+func TestLeaf(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema *model.Schema
+		key    LeafKey
+		leaf   bool
+	}{
+		{name: "type", schema: &model.Schema{Type: "string"}, key: LeafKey{Type: "string"}, leaf: true},
+		{name: "format", schema: &model.Schema{Type: "integer", Format: "int64"}, key: LeafKey{Type: "integer", Format: "int64"}, leaf: true},
+		{name: "nullable", schema: &model.Schema{Type: "string", Nullable: true}, key: LeafKey{Type: "string", Nullable: true}, leaf: true},
+		{name: "reference", schema: &model.Schema{Ref: "#/components/schemas/User", Description: "ignored"}, key: LeafKey{Ref: "#/components/schemas/User"}, leaf: true},
+		{name: "description", schema: &model.Schema{Type: "string", Description: "Name"}},
+		{name: "enum", schema: &model.Schema{Type: "string", Enum: []any{"a"}}},
+		{name: "object", schema: &model.Schema{Type: "object", Properties: map[string]*model.Schema{}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, leaf := Leaf(tt.schema)
+			assert.Equal(t, tt.leaf, leaf)
+			if tt.leaf {
+				assert.Equal(t, tt.key, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package util
+
+import "maps"
+
+// Extensions documenting operations of HTTP methods without a path item field.
+// They mirror the "query" and "additionalOperations" fields of OpenAPI 3.2.
+const (
+	ExtensionQuery                = "x-query"
+	ExtensionAdditionalOperations = "x-additionalOperations"
+)
+
+// AdditionalOperationExtensions returns a copy of the path item extensions with the
+// operations of other HTTP methods added: QUERY under x-query, and the others under
+// x-additionalOperations, keyed by method name. Operations are projected with transform,
+// which receives the location of the operation relative to the path item as reference tokens.
+func AdditionalOperationExtensions[O any](extensions map[string]any, ops map[string]O, transform func(op O, location ...string) any) map[string]any {
+	out := make(map[string]any, len(extensions)+2)
+	maps.Copy(out, extensions)
+
+	additional := make(map[string]any)
+	for method, op := range ops {
+		if method == "QUERY" {
+			out[ExtensionQuery] = transform(op, ExtensionQuery)
+
+			continue
+		}
+		additional[method] = transform(op, ExtensionAdditionalOperations, method)
+	}
+	if len(additional) > 0 {
+		out[ExtensionAdditionalOperations] = additional
+	}
+
+	return out
+}
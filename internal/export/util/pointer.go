@@ -0,0 +1,22 @@
+package util
+
+import "strings"
+
+// pointerEscaper escapes reference tokens of JSON pointers (RFC 6901).
+var pointerEscaper = strings.NewReplacer("~", "~0", "/", "~1")
+
+// Pointer appends reference tokens to a JSON pointer, escaping them.
+//
+// Example:
+//
+//	util.Pointer("#/paths", "/users/{id}", "get") // "#/paths/~1users~1{id}/get"
+func Pointer(base string, tokens ...string) string {
+	var sb strings.Builder
+	sb.WriteString(base)
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(pointerEscaper.Replace(tok))
+	}
+
+	return sb.String()
+}
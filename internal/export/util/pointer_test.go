@@ -0,0 +1,14 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPointer(t *testing.T) {
+	assert.Equal(t, "#/components/schemas/User", Pointer("#/components/schemas", "User"))
+	assert.Equal(t, "#/paths/~1users~1{id}/get", Pointer("#/paths", "/users/{id}", "get"))
+	assert.Equal(t, "#/a~0b", Pointer("#", "a~b"))
+	assert.Equal(t, "#", Pointer("#"))
+}
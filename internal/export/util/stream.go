@@ -0,0 +1,216 @@
+package util
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// streamDepth is the depth down to which Stream encodes objects member by
+// member: the document, its paths and components, and the maps of components.
+// Deeper values, such as path items and component schemas, are marshaled whole.
+const streamDepth = 3
+
+// Stream writes the JSON encoding of a view to w, indented like
+// json.MarshalIndent(v, "", "  ") followed by a newline. The members of view
+// objects and the entries of maps are encoded one at a time down to streamDepth,
+// so that the encoding of the whole document is never held in memory.
+//
+// View objects are structs whose extensions are held in an Extensions field,
+// inlined after their other members like MarshalWithExtensions does.
+func Stream(w io.Writer, v any) error {
+	bw := bufio.NewWriter(w)
+	if err := streamValue(bw, reflect.ValueOf(v), 0); err != nil {
+		return err
+	}
+	if err := bw.WriteByte('\n'); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// streamValue writes the indented encoding of v at the given depth.
+func streamValue(w *bufio.Writer, v reflect.Value, depth int) error {
+	if !v.IsValid() {
+		return marshalValue(w, nil, depth)
+	}
+
+	elem := v
+	for (elem.Kind() == reflect.Pointer || elem.Kind() == reflect.Interface) && !elem.IsNil() {
+		elem = elem.Elem()
+	}
+	if depth < streamDepth {
+		switch {
+		case isViewObject(elem):
+			return streamObject(w, elem, depth)
+		case elem.Kind() == reflect.Map && elem.Type().Key().Kind() == reflect.String && !elem.IsNil():
+			return streamMap(w, elem, depth)
+		}
+	}
+
+	// Values are marshaled as held: view objects implement json.Marshaler on
+	// their pointers, and MarshalWithExtensions marshals copies of the objects
+	return marshalValue(w, v.Interface(), depth)
+}
+
+// marshalValue writes the indented encoding of a value at the given depth whole.
+func marshalValue(w *bufio.Writer, value any, depth int) error {
+	data, err := json.MarshalIndent(value, indent(depth), "  ")
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+
+	return err
+}
+
+// isViewObject reports whether v is a struct with an Extensions field and no
+// embedded fields.
+func isViewObject(v reflect.Value) bool {
+	if v.Kind() != reflect.Struct {
+		return false
+	}
+	ext, ok := v.Type().FieldByName("Extensions")
+	if !ok || ext.Type != reflect.TypeFor[map[string]any]() || ext.Tag.Get("json") != "-" {
+		return false
+	}
+
+	return !slices.ContainsFunc(reflect.VisibleFields(v.Type()), func(f reflect.StructField) bool { return f.Anonymous })
+}
+
+// streamObject writes the members of a view object, then its extensions.
+func streamObject(w *bufio.Writer, v reflect.Value, depth int) error {
+	t := v.Type()
+	o := objectWriter{w: w, depth: depth}
+	members := make(map[string]bool)
+	var extensions map[string]any
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if f.Name == "Extensions" {
+			extensions, _ = v.Field(i).Interface().(map[string]any)
+
+			continue
+		}
+		name, opts, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fv := v.Field(i)
+		if hasOption(opts, "omitempty") && isEmptyValue(fv) || hasOption(opts, "omitzero") && fv.IsZero() {
+			continue
+		}
+		members[name] = true
+		if err := o.member(name, func() error { return streamValue(w, fv, depth+1) }); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(extensions)) {
+		if members[key] {
+			return fmt.Errorf("extension %s: conflicts with the member of the same name", key)
+		}
+		if err := o.member(key, func() error { return marshalValue(w, extensions[key], depth+1) }); err != nil {
+			return fmt.Errorf("extension %s: %w", key, err)
+		}
+	}
+
+	return o.close()
+}
+
+// streamMap writes the entries of a map with string keys, sorted by key.
+func streamMap(w *bufio.Writer, v reflect.Value, depth int) error {
+	keys := v.MapKeys()
+	slices.SortFunc(keys, func(a, b reflect.Value) int { return strings.Compare(a.String(), b.String()) })
+
+	o := objectWriter{w: w, depth: depth}
+	for _, key := range keys {
+		if err := o.member(key.String(), func() error { return streamValue(w, v.MapIndex(key), depth+1) }); err != nil {
+			return fmt.Errorf("%s: %w", key.String(), err)
+		}
+	}
+
+	return o.close()
+}
+
+// objectWriter writes the members of an indented JSON object.
+type objectWriter struct {
+	w       *bufio.Writer
+	depth   int
+	members int
+}
+
+// member writes the name of a member, then its value with write.
+func (o *objectWriter) member(name string, write func() error) error {
+	if o.members == 0 {
+		o.w.WriteByte('{')
+	} else {
+		o.w.WriteByte(',')
+	}
+	o.members++
+	key, err := json.Marshal(name)
+	if err != nil {
+		return err
+	}
+	o.w.WriteByte('\n')
+	o.w.WriteString(indent(o.depth + 1))
+	o.w.Write(key)
+	o.w.WriteString(": ")
+
+	return write()
+}
+
+// close ends the object.
+func (o *objectWriter) close() error {
+	if o.members == 0 {
+		_, err := o.w.WriteString("{}")
+
+		return err
+	}
+	o.w.WriteByte('\n')
+	o.w.WriteString(indent(o.depth))
+
+	return o.w.WriteByte('}')
+}
+
+// indent returns the indentation of a depth.
+func indent(depth int) string {
+	return strings.Repeat("  ", depth)
+}
+
+// hasOption reports whether the options of a json tag hold an option.
+func hasOption(opts, option string) bool {
+	for opts != "" {
+		var opt string
+		opt, opts, _ = strings.Cut(opts, ",")
+		if opt == option {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isEmptyValue reports whether a value is empty as defined by the omitempty
+// option of encoding/json.
+func isEmptyValue(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return v.Len() == 0
+	case reflect.Bool,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64,
+		reflect.Interface, reflect.Pointer:
+		return v.IsZero()
+	default:
+		return false
+	}
+}
@@ -0,0 +1,102 @@
+package util
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type streamDoc struct {
+	Title      string                 `json:"title"`
+	Paths      map[string]*streamItem `json:"paths"`
+	Tags       []string               `json:"tags,omitempty"`
+	Components *streamComponents      `json:"components,omitempty"`
+	Extensions map[string]any         `json:"-"`
+}
+
+func (d *streamDoc) MarshalJSON() ([]byte, error) {
+	type doc streamDoc
+
+	return MarshalWithExtensions(doc(*d), d.Extensions)
+}
+
+type streamComponents struct {
+	Schemas    map[string]any `json:"schemas,omitempty"`
+	Empty      map[string]any `json:"empty"`
+	Extensions map[string]any `json:"-"`
+}
+
+func (c *streamComponents) MarshalJSON() ([]byte, error) {
+	type components streamComponents
+
+	return MarshalWithExtensions(components(*c), c.Extensions)
+}
+
+type streamItem struct {
+	Summary    string         `json:"summary,omitempty"`
+	Get        *streamItem    `json:"get,omitempty"`
+	Post       streamItem2    `json:"post"`
+	Extensions map[string]any `json:"-"`
+}
+
+type streamItem2 struct {
+	Summary    string         `json:"summary,omitempty"`
+	Extensions map[string]any `json:"-"`
+}
+
+func (i *streamItem2) MarshalJSON() ([]byte, error) {
+	type item streamItem2
+
+	return MarshalWithExtensions(item(*i), i.Extensions)
+}
+
+func (i *streamItem) MarshalJSON() ([]byte, error) {
+	type item streamItem
+
+	return MarshalWithExtensions(item(*i), i.Extensions)
+}
+
+func TestStream(t *testing.T) {
+	docs := map[string]*streamDoc{
+		"empty": {},
+		"full": {
+			Title: "<Pets & Owners>",
+			Paths: map[string]*streamItem{
+				"/pets": {
+					Summary: "Pets",
+					Get:     &streamItem{Summary: "List pets", Extensions: map[string]any{"x-internal": true}},
+					Post:    streamItem2{Extensions: map[string]any{"x-internal": false}},
+				},
+				"/owners":    {Extensions: map[string]any{"x-internal": true}},
+				"/pets/{id}": nil,
+			},
+			Components: &streamComponents{
+				Schemas:    map[string]any{"Pet": map[string]any{"type": "object", "properties": map[string]any{}}, "Id": map[string]any{"type": "integer"}},
+				Empty:      map[string]any{},
+				Extensions: map[string]any{"x-b": []any{}, "x-a": nil},
+			},
+			Extensions: map[string]any{"x-logo": map[string]any{"url": "https://example.com/logo.png"}},
+		},
+	}
+	for name, doc := range docs {
+		t.Run(name, func(t *testing.T) {
+			expected, err := json.MarshalIndent(doc, "", "  ")
+			require.NoError(t, err)
+
+			var buf bytes.Buffer
+			require.NoError(t, Stream(&buf, doc))
+			assert.Equal(t, string(expected)+"\n", buf.String())
+		})
+	}
+}
+
+func TestStream_ExtensionConflict(t *testing.T) {
+	doc := &streamDoc{Title: "Pets", Extensions: map[string]any{"title": "Other"}}
+
+	var buf bytes.Buffer
+	err := Stream(&buf, doc)
+	require.ErrorContains(t, err, "extension title: conflicts with the member of the same name")
+}
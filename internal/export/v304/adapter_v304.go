@@ -3,16 +3,24 @@ package v304
 import (
 	_ "embed"
 	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/export/util"
 	"github.com/talav/openapi/internal/model"
 )
 
 //go:embed schema_v304.json
 var schemaV304JSON []byte
 
-type AdapterV304 struct{}
+type AdapterV304 struct {
+	// leaves interns the views of leaf schemas during a View call.
+	leaves map[util.LeafKey]*SchemaV30
+}
 
 func (a *AdapterV304) Version() string {
 	return "3.0.4"
@@ -29,10 +37,16 @@ func (a *AdapterV304) View(spec *model.Spec) (any, debug.Warnings, error) {
 
 	var warnings debug.Warnings
 
+	// Views of leaf schemas are shared within the view.
+	a = &AdapterV304{leaves: make(map[util.LeafKey]*SchemaV30)}
+
 	// Warn about Webhooks (3.1-only)
 	if len(spec.Webhooks) > 0 {
 		warnings = append(warnings, debug.NewWarning(debug.WarnDegradationWebhooks, "#/webhooks", "webhooks are 3.1-only; dropped"))
 	}
+	if spec.JSONSchemaDialect != "" {
+		warnings = append(warnings, debug.NewWarning(debug.WarnDegradationJSONSchemaDialect, "#/jsonSchemaDialect", "jsonSchemaDialect is 3.1-only; dropped"))
+	}
 
 	result := &ViewV304{
 		OpenAPI:      a.Version(),
@@ -210,13 +224,13 @@ func (a *AdapterV304) transformPaths(in map[string]*model.PathItem, warnings *de
 
 	paths := make(PathsV30, len(in))
 	for path, item := range in {
-		paths[path] = a.transformPathItem(item, warnings)
+		paths[path] = a.transformPathItem(item, util.Pointer("#/paths", path), warnings)
 	}
 
 	return paths
 }
 
-func (a *AdapterV304) transformPathItem(in *model.PathItem, warnings *debug.Warnings) *PathItemV30 {
+func (a *AdapterV304) transformPathItem(in *model.PathItem, ptr string, warnings *debug.Warnings) *PathItemV30 {
 	if in == nil {
 		return nil
 	}
@@ -234,33 +248,40 @@ func (a *AdapterV304) transformPathItem(in *model.PathItem, warnings *debug.Warn
 
 	// Transform Parameters
 	if len(in.Parameters) > 0 {
-		item.Parameters = a.transformParameters(in.Parameters, warnings)
+		item.Parameters = a.transformParameters(in.Parameters, ptr, warnings)
 	}
 
 	// Transform Operations
-	item.Get = a.transformOperation(in.Get, warnings)
-	item.Put = a.transformOperation(in.Put, warnings)
-	item.Post = a.transformOperation(in.Post, warnings)
-	item.Delete = a.transformOperation(in.Delete, warnings)
-	item.Options = a.transformOperation(in.Options, warnings)
-	item.Head = a.transformOperation(in.Head, warnings)
-	item.Patch = a.transformOperation(in.Patch, warnings)
-	item.Trace = a.transformOperation(in.Trace, warnings)
+	item.Get = a.transformOperation(in.Get, util.Pointer(ptr, "get"), warnings)
+	item.Put = a.transformOperation(in.Put, util.Pointer(ptr, "put"), warnings)
+	item.Post = a.transformOperation(in.Post, util.Pointer(ptr, "post"), warnings)
+	item.Delete = a.transformOperation(in.Delete, util.Pointer(ptr, "delete"), warnings)
+	item.Options = a.transformOperation(in.Options, util.Pointer(ptr, "options"), warnings)
+	item.Head = a.transformOperation(in.Head, util.Pointer(ptr, "head"), warnings)
+	item.Patch = a.transformOperation(in.Patch, util.Pointer(ptr, "patch"), warnings)
+	item.Trace = a.transformOperation(in.Trace, util.Pointer(ptr, "trace"), warnings)
+
+	if len(in.AdditionalOperations) > 0 {
+		item.Extensions = util.AdditionalOperationExtensions(in.Extensions, in.AdditionalOperations,
+			func(op *model.Operation, location ...string) any {
+				return a.transformOperation(op, util.Pointer(ptr, location...), warnings)
+			})
+	}
 
 	return item
 }
 
-func (a *AdapterV304) transformParameters(in []model.Parameter, warnings *debug.Warnings) []*ParameterV30 {
+func (a *AdapterV304) transformParameters(in []model.Parameter, ptr string, warnings *debug.Warnings) []*ParameterV30 {
 	out := make([]*ParameterV30, 0, len(in))
-	for _, param := range in {
-		p := a.transformParameter(param, warnings)
+	for i, param := range in {
+		p := a.transformParameter(param, util.Pointer(ptr, "parameters", strconv.Itoa(i)), warnings)
 		out = append(out, &p)
 	}
 
 	return out
 }
 
-func (a *AdapterV304) transformParameter(in model.Parameter, warnings *debug.Warnings) ParameterV30 {
+func (a *AdapterV304) transformParameter(in model.Parameter, ptr string, warnings *debug.Warnings) ParameterV30 {
 	// Handle $ref case
 	if in.Ref != "" {
 		return ParameterV30{Ref: in.Ref}
@@ -274,13 +295,13 @@ func (a *AdapterV304) transformParameter(in model.Parameter, warnings *debug.War
 		Deprecated:      in.Deprecated,
 		AllowEmptyValue: in.AllowEmptyValue,
 		Style:           in.Style,
-		Explode:         in.Explode,
+		Explode:         util.Explode(&in),
 		AllowReserved:   in.AllowReserved,
 		Example:         in.Example,
 		Extensions:      in.Extensions,
 	}
 
-	param.Schema = a.transformSchema(in.Schema, warnings)
+	param.Schema = a.transformSchema(in.Schema, util.Pointer(ptr, "schema"), warnings)
 
 	if len(in.Examples) > 0 {
 		param.Examples = make(map[string]*ExampleV30, len(in.Examples))
@@ -325,36 +346,37 @@ func (a *AdapterV304) transformExample(in *model.Example, warnings *debug.Warnin
 	return out
 }
 
-func (a *AdapterV304) transformOperation(in *model.Operation, warnings *debug.Warnings) *OperationV30 {
+func (a *AdapterV304) transformOperation(in *model.Operation, ptr string, warnings *debug.Warnings) *OperationV30 {
 	if in == nil {
 		return nil
 	}
 
 	op := &OperationV30{
-		Tags:        append([]string(nil), in.Tags...),
-		Summary:     in.Summary,
-		Description: in.Description,
-		OperationID: in.OperationID,
-		Deprecated:  in.Deprecated,
-		Extensions:  in.Extensions,
+		Tags:         append([]string(nil), in.Tags...),
+		Summary:      in.Summary,
+		Description:  in.Description,
+		ExternalDocs: a.transformExternalDocs(in.ExternalDocs),
+		OperationID:  in.OperationID,
+		Deprecated:   in.Deprecated,
+		Extensions:   in.Extensions,
 	}
 
 	if len(in.Parameters) > 0 {
-		op.Parameters = a.transformParameters(in.Parameters, warnings)
+		op.Parameters = a.transformParameters(in.Parameters, ptr, warnings)
 	}
 
-	op.RequestBody = a.transformRequestBody(in.RequestBody, warnings)
+	op.RequestBody = a.transformRequestBody(in.RequestBody, util.Pointer(ptr, "requestBody"), warnings)
 	op.Security = a.transformSecurity(in.Security)
 	op.Servers = a.transformServers(in.Servers)
 
 	if len(in.Responses) > 0 {
-		op.Responses = a.transformResponses(in.Responses, warnings)
+		op.Responses = a.transformResponses(in.Responses, util.Pointer(ptr, "responses"), warnings)
 	}
 
 	return op
 }
 
-func (a *AdapterV304) transformRequestBody(in *model.RequestBody, warnings *debug.Warnings) *RequestBodyV30 {
+func (a *AdapterV304) transformRequestBody(in *model.RequestBody, ptr string, warnings *debug.Warnings) *RequestBodyV30 {
 	if in == nil {
 		return nil
 	}
@@ -373,14 +395,14 @@ func (a *AdapterV304) transformRequestBody(in *model.RequestBody, warnings *debu
 	if len(in.Content) > 0 {
 		rb.Content = make(map[string]*MediaTypeV30, len(in.Content))
 		for ct, mt := range in.Content {
-			rb.Content[ct] = a.transformMediaType(mt, warnings)
+			rb.Content[ct] = a.transformMediaType(mt, util.Pointer(ptr, "content", ct), warnings)
 		}
 	}
 
 	return rb
 }
 
-func (a *AdapterV304) transformMediaType(in *model.MediaType, warnings *debug.Warnings) *MediaTypeV30 {
+func (a *AdapterV304) transformMediaType(in *model.MediaType, ptr string, warnings *debug.Warnings) *MediaTypeV30 {
 	if in == nil {
 		return nil
 	}
@@ -390,7 +412,7 @@ func (a *AdapterV304) transformMediaType(in *model.MediaType, warnings *debug.Wa
 		Extensions: in.Extensions,
 	}
 
-	mt.Schema = a.transformSchema(in.Schema, warnings)
+	mt.Schema = a.transformSchema(in.Schema, util.Pointer(ptr, "schema"), warnings)
 
 	if len(in.Examples) > 0 {
 		mt.Examples = make(map[string]*ExampleV30, len(in.Examples))
@@ -415,21 +437,21 @@ func (a *AdapterV304) transformComponents(in *model.Components, warnings *debug.
 	if len(in.Schemas) > 0 {
 		comp.Schemas = make(map[string]*SchemaV30, len(in.Schemas))
 		for name, schema := range in.Schemas {
-			comp.Schemas[name] = a.transformSchema(schema, warnings)
+			comp.Schemas[name] = a.transformSchema(schema, util.Pointer("#/components/schemas", name), warnings)
 		}
 	}
 
 	if len(in.Responses) > 0 {
 		comp.Responses = make(map[string]*ResponseV30, len(in.Responses))
 		for name, r := range in.Responses {
-			comp.Responses[name] = a.transformResponse(r, warnings)
+			comp.Responses[name] = a.transformResponse(r, util.Pointer("#/components/responses", name), warnings)
 		}
 	}
 
 	if len(in.Parameters) > 0 {
 		comp.Parameters = make(map[string]*ParameterV30, len(in.Parameters))
 		for name, param := range in.Parameters {
-			pv := a.transformParameter(*param, warnings)
+			pv := a.transformParameter(*param, util.Pointer("#/components/parameters", name), warnings)
 			comp.Parameters[name] = &pv
 		}
 	}
@@ -444,14 +466,14 @@ func (a *AdapterV304) transformComponents(in *model.Components, warnings *debug.
 	if len(in.RequestBodies) > 0 {
 		comp.RequestBodies = make(map[string]*RequestBodyV30, len(in.RequestBodies))
 		for name, rb := range in.RequestBodies {
-			comp.RequestBodies[name] = a.transformRequestBody(rb, warnings)
+			comp.RequestBodies[name] = a.transformRequestBody(rb, util.Pointer("#/components/requestBodies", name), warnings)
 		}
 	}
 
 	if len(in.Headers) > 0 {
 		comp.Headers = make(map[string]*HeaderV30, len(in.Headers))
 		for name, h := range in.Headers {
-			comp.Headers[name] = a.transformHeader(h, warnings)
+			comp.Headers[name] = a.transformHeader(h, util.Pointer("#/components/headers", name), warnings)
 		}
 	}
 
@@ -472,7 +494,7 @@ func (a *AdapterV304) transformComponents(in *model.Components, warnings *debug.
 	if len(in.Callbacks) > 0 {
 		comp.Callbacks = make(map[string]*CallbackV30, len(in.Callbacks))
 		for name, cb := range in.Callbacks {
-			comp.Callbacks[name] = a.transformCallback(cb, warnings)
+			comp.Callbacks[name] = a.transformCallback(cb, util.Pointer("#/components/callbacks", name), warnings)
 		}
 	}
 
@@ -484,7 +506,7 @@ func (a *AdapterV304) transformComponents(in *model.Components, warnings *debug.
 	return comp
 }
 
-func (a *AdapterV304) transformResponse(in *model.Response, warnings *debug.Warnings) *ResponseV30 {
+func (a *AdapterV304) transformResponse(in *model.Response, ptr string, warnings *debug.Warnings) *ResponseV30 {
 	if in == nil {
 		return nil
 	}
@@ -502,21 +524,28 @@ func (a *AdapterV304) transformResponse(in *model.Response, warnings *debug.Warn
 	if len(in.Content) > 0 {
 		r.Content = make(map[string]*MediaTypeV30, len(in.Content))
 		for ct, mt := range in.Content {
-			r.Content[ct] = a.transformMediaType(mt, warnings)
+			r.Content[ct] = a.transformMediaType(mt, util.Pointer(ptr, "content", ct), warnings)
 		}
 	}
 
 	if len(in.Headers) > 0 {
 		r.Headers = make(map[string]*HeaderV30, len(in.Headers))
 		for name, h := range in.Headers {
-			r.Headers[name] = a.transformHeader(h, warnings)
+			r.Headers[name] = a.transformHeader(h, util.Pointer(ptr, "headers", name), warnings)
+		}
+	}
+
+	if len(in.Links) > 0 {
+		r.Links = make(map[string]*LinkV30, len(in.Links))
+		for name, link := range in.Links {
+			r.Links[name] = a.transformLink(link)
 		}
 	}
 
 	return r
 }
 
-func (a *AdapterV304) transformHeader(in *model.Header, warnings *debug.Warnings) *HeaderV30 {
+func (a *AdapterV304) transformHeader(in *model.Header, ptr string, warnings *debug.Warnings) *HeaderV30 {
 	if in == nil {
 		return nil
 	}
@@ -537,7 +566,7 @@ func (a *AdapterV304) transformHeader(in *model.Header, warnings *debug.Warnings
 		Extensions:      in.Extensions,
 	}
 
-	h.Schema = a.transformSchema(in.Schema, warnings)
+	h.Schema = a.transformSchema(in.Schema, util.Pointer(ptr, "schema"), warnings)
 
 	if len(in.Examples) > 0 {
 		h.Examples = make(map[string]*ExampleV30, len(in.Examples))
@@ -570,9 +599,52 @@ func (a *AdapterV304) transformSecurityScheme(in *model.SecurityScheme) *Securit
 		Extensions:       in.Extensions,
 	}
 
+	if in.Flows != nil {
+		out.Flows = a.transformOAuthFlows(in.Flows)
+	}
+
 	return out
 }
 
+func (a *AdapterV304) transformOAuthFlows(in *model.OAuthFlows) *OAuthFlowsV30 {
+	if in == nil {
+		return nil
+	}
+
+	flows := &OAuthFlowsV30{
+		Extensions: in.Extensions,
+	}
+
+	if in.Implicit != nil {
+		flows.Implicit = a.transformOAuthFlow(in.Implicit)
+	}
+	if in.Password != nil {
+		flows.Password = a.transformOAuthFlow(in.Password)
+	}
+	if in.ClientCredentials != nil {
+		flows.ClientCredentials = a.transformOAuthFlow(in.ClientCredentials)
+	}
+	if in.AuthorizationCode != nil {
+		flows.AuthorizationCode = a.transformOAuthFlow(in.AuthorizationCode)
+	}
+
+	return flows
+}
+
+func (a *AdapterV304) transformOAuthFlow(in *model.OAuthFlow) *OAuthFlowV30 {
+	if in == nil {
+		return nil
+	}
+
+	return &OAuthFlowV30{
+		AuthorizationURL: in.AuthorizationURL,
+		TokenURL:         in.TokenURL,
+		RefreshURL:       in.RefreshURL,
+		Scopes:           in.Scopes,
+		Extensions:       in.Extensions,
+	}
+}
+
 func (a *AdapterV304) transformLink(in *model.Link) *LinkV30 {
 	if in == nil {
 		return nil
@@ -602,7 +674,7 @@ func (a *AdapterV304) transformLink(in *model.Link) *LinkV30 {
 	return link
 }
 
-func (a *AdapterV304) transformCallback(in *model.Callback, warnings *debug.Warnings) *CallbackV30 {
+func (a *AdapterV304) transformCallback(in *model.Callback, ptr string, warnings *debug.Warnings) *CallbackV30 {
 	if in == nil {
 		return nil
 	}
@@ -618,31 +690,53 @@ func (a *AdapterV304) transformCallback(in *model.Callback, warnings *debug.Warn
 	}
 
 	for path, item := range in.PathItems {
-		cb.PathItems[path] = a.transformPathItem(item, warnings)
+		cb.PathItems[path] = a.transformPathItem(item, util.Pointer(ptr, path), warnings)
 	}
 
 	return cb
 }
 
-func (a *AdapterV304) transformResponses(in map[string]*model.Response, warnings *debug.Warnings) ResponsesV30 {
+func (a *AdapterV304) transformResponses(in map[string]*model.Response, ptr string, warnings *debug.Warnings) ResponsesV30 {
 	if len(in) == 0 {
 		return nil
 	}
 
 	responses := make(ResponsesV30, len(in))
 	for code, response := range in {
-		responses[code] = a.transformResponse(response, warnings)
+		responses[code] = a.transformResponse(response, util.Pointer(ptr, code), warnings)
 	}
 
 	return responses
 }
 
-//nolint:cyclop
-func (a *AdapterV304) transformSchema(in *model.Schema, warnings *debug.Warnings) *SchemaV30 {
+// transformSchema projects a schema to 3.0, sharing the views of leaf schemas.
+func (a *AdapterV304) transformSchema(in *model.Schema, ptr string, warnings *debug.Warnings) *SchemaV30 {
 	if in == nil {
 		return nil
 	}
 
+	key, leaf := util.Leaf(in)
+	if out, ok := a.leaves[key]; leaf && ok {
+		return out
+	}
+	out := a.projectSchema(in, ptr, warnings)
+	if leaf && a.leaves != nil {
+		a.leaves[key] = out
+	}
+
+	return out
+}
+
+// projectSchema projects a non-nil schema to 3.0. 3.1-only keywords are translated
+// where 3.0 can express them, and dropped otherwise; each is reported as a
+// degradation warning at ptr, the location of the schema.
+//
+//nolint:cyclop
+func (a *AdapterV304) projectSchema(in *model.Schema, ptr string, warnings *debug.Warnings) *SchemaV30 {
+	if in.ID != "" || in.Dialect != "" {
+		degrade(warnings, debug.WarnDegradationSchemaID, util.Pointer(ptr, "$id"), "$id and $schema are 3.1-only; dropped")
+	}
+
 	// Handle $ref case
 	if in.Ref != "" {
 		return &SchemaV30{Ref: in.Ref}
@@ -666,7 +760,7 @@ func (a *AdapterV304) transformSchema(in *model.Schema, warnings *debug.Warnings
 	} else if len(in.Examples) > 0 {
 		out.Example = in.Examples[0] // Use first example for 3.0
 		if len(in.Examples) > 1 {
-			*warnings = append(*warnings, debug.NewWarning(debug.WarnDegradationMultipleExamples, "#/components/schemas/...", "multiple examples collapsed to first example only"))
+			degrade(warnings, debug.WarnDegradationMultipleExamples, util.Pointer(ptr, "examples"), "multiple examples collapsed to first example only")
 		}
 	}
 
@@ -675,12 +769,17 @@ func (a *AdapterV304) transformSchema(in *model.Schema, warnings *debug.Warnings
 		out.Enum = append([]any(nil), in.Enum...)
 	}
 
-	// Handle const (3.1 feature) - convert to enum
+	// Handle const (3.1 feature) - convert to a single-value enum
 	if in.Const != nil {
+		if len(in.Enum) > 0 && !slices.ContainsFunc(in.Enum, func(v any) bool { return reflect.DeepEqual(v, in.Const) }) {
+			degrade(warnings, debug.WarnDegradationConstToEnumConflict, util.Pointer(ptr, "const"),
+				fmt.Sprintf("const %v is not among the enum values; enum replaced by const", in.Const))
+		} else {
+			degrade(warnings, debug.WarnDegradationConstToEnum, util.Pointer(ptr, "const"), "const converted to enum")
+		}
 		out.Enum = []any{in.Const}
 		// Clear type to avoid conflicts (const value may not match schema type)
 		out.Type = ""
-		*warnings = append(*warnings, debug.NewWarning(debug.WarnDegradationConstToEnum, "#/components/schemas/...", "const converted to enum"))
 	}
 
 	// Handle numeric constraints
@@ -705,13 +804,16 @@ func (a *AdapterV304) transformSchema(in *model.Schema, warnings *debug.Warnings
 	out.MinItems = in.MinItems
 	out.MaxItems = in.MaxItems
 	out.UniqueItems = in.UniqueItems
-	out.Items = a.transformSchema(in.Items, warnings)
+	out.Items = a.transformSchema(in.Items, util.Pointer(ptr, "items"), warnings)
+	if len(in.PrefixItems) > 0 {
+		out.Items = a.transformPrefixItems(in, out.Items, ptr, warnings)
+	}
 
 	// Handle object constraints
 	if len(in.Properties) > 0 {
 		out.Properties = make(map[string]*SchemaV30, len(in.Properties))
 		for name, prop := range in.Properties {
-			out.Properties[name] = a.transformSchema(prop, warnings)
+			out.Properties[name] = a.transformSchema(prop, util.Pointer(ptr, "properties", name), warnings)
 		}
 	}
 	if len(in.Required) > 0 {
@@ -725,44 +827,128 @@ func (a *AdapterV304) transformSchema(in *model.Schema, warnings *debug.Warnings
 		if in.Additional.Allow != nil {
 			out.AdditionalProperties = *in.Additional.Allow
 		} else {
-			out.AdditionalProperties = a.transformSchema(in.Additional.Schema, warnings)
+			out.AdditionalProperties = a.transformSchema(in.Additional.Schema, util.Pointer(ptr, "additionalProperties"), warnings)
 		}
 	}
 
 	// Handle composition
-	if len(in.AllOf) > 0 {
-		out.AllOf = make([]*SchemaV30, 0, len(in.AllOf))
-		for _, schema := range in.AllOf {
-			out.AllOf = append(out.AllOf, a.transformSchema(schema, warnings))
+	out.AllOf = a.transformSchemas(in.AllOf, util.Pointer(ptr, "allOf"), warnings)
+	out.AnyOf = a.transformSchemas(in.AnyOf, util.Pointer(ptr, "anyOf"), warnings)
+	out.OneOf = a.transformSchemas(in.OneOf, util.Pointer(ptr, "oneOf"), warnings)
+	out.Not = a.transformSchema(in.Not, util.Pointer(ptr, "not"), warnings)
+
+	// Handle if/then/else (3.1 feature) - instances match the condition and the
+	// then branch, or do not match it and match the else branch
+	if in.If != nil {
+		cond := a.transformSchema(in.If, util.Pointer(ptr, "if"), warnings)
+		matched, unmatched := cond, &SchemaV30{Not: cond}
+		if then := a.transformSchema(in.Then, util.Pointer(ptr, "then"), warnings); then != nil {
+			matched = &SchemaV30{AllOf: []*SchemaV30{cond, then}}
+		}
+		if els := a.transformSchema(in.Else, util.Pointer(ptr, "else"), warnings); els != nil {
+			unmatched = &SchemaV30{AllOf: []*SchemaV30{unmatched, els}}
 		}
+		out.AllOf = append(out.AllOf, &SchemaV30{AnyOf: []*SchemaV30{matched, unmatched}})
+		degrade(warnings, debug.WarnDegradationConditional, util.Pointer(ptr, "if"), "if/then/else converted to anyOf")
 	}
-	if len(in.AnyOf) > 0 {
-		out.AnyOf = make([]*SchemaV30, 0, len(in.AnyOf))
-		for _, schema := range in.AnyOf {
-			out.AnyOf = append(out.AnyOf, a.transformSchema(schema, warnings))
+
+	// Handle default value
+	out.Default = in.Default
+
+	// Handle discriminator
+	if in.Discriminator != nil {
+		out.Discriminator = &DiscriminatorV30{
+			PropertyName: in.Discriminator.PropertyName,
+			Mapping:      in.Discriminator.Mapping,
 		}
 	}
-	if len(in.OneOf) > 0 {
-		out.OneOf = make([]*SchemaV30, 0, len(in.OneOf))
-		for _, schema := range in.OneOf {
-			out.OneOf = append(out.OneOf, a.transformSchema(schema, warnings))
+
+	// Handle dependentRequired (3.1 feature) - each dependency holds when the
+	// property is absent or the dependent properties are present
+	if len(in.DependentRequired) > 0 {
+		for _, name := range slices.Sorted(maps.Keys(in.DependentRequired)) {
+			out.AllOf = append(out.AllOf, &SchemaV30{
+				AnyOf: []*SchemaV30{
+					{Not: &SchemaV30{Required: []string{name}}},
+					{Required: append([]string(nil), in.DependentRequired[name]...)},
+				},
+			})
 		}
+		degrade(warnings, debug.WarnDegradationDependentRequired, util.Pointer(ptr, "dependentRequired"), "dependentRequired converted to allOf/anyOf")
 	}
-	out.Not = a.transformSchema(in.Not, warnings)
 
-	// Handle default value
-	out.Default = in.Default
+	// Handle binary content (3.1 features) - use the 3.0 string formats
+	switch {
+	case in.ContentEncoding == "base64" && out.Format == "":
+		out.Format = "byte"
+		degrade(warnings, debug.WarnDegradationContentEncoding, util.Pointer(ptr, "contentEncoding"), "contentEncoding base64 converted to format byte")
+	case in.ContentEncoding != "":
+		degrade(warnings, debug.WarnDegradationContentEncoding, util.Pointer(ptr, "contentEncoding"), "contentEncoding dropped (3.1-only)")
+	}
+	switch {
+	case in.ContentMediaType == "application/octet-stream" && in.ContentEncoding == "" && out.Format == "":
+		out.Format = "binary"
+		degrade(warnings, debug.WarnDegradationContentMediaType, util.Pointer(ptr, "contentMediaType"), "contentMediaType application/octet-stream converted to format binary")
+	case in.ContentMediaType != "":
+		degrade(warnings, debug.WarnDegradationContentMediaType, util.Pointer(ptr, "contentMediaType"), "contentMediaType dropped (3.1-only)")
+	}
 
 	// Warn about 3.1-only features that are dropped in 3.0
-	if in.ContentEncoding != "" {
-		*warnings = append(*warnings, debug.NewWarning(debug.WarnDegradationContentEncoding, "#/components/schemas/...", "contentEncoding dropped (3.1-only)"))
+	if len(in.PatternProps) > 0 {
+		degrade(warnings, debug.WarnDegradationPatternProperties, util.Pointer(ptr, "patternProperties"), "patternProperties dropped (3.1-only)")
 	}
-	if in.ContentMediaType != "" {
-		*warnings = append(*warnings, debug.NewWarning(debug.WarnDegradationContentMediaType, "#/components/schemas/...", "contentMediaType dropped (3.1-only)"))
+	if in.PropertyNames != nil {
+		degrade(warnings, debug.WarnDegradationPropertyNames, util.Pointer(ptr, "propertyNames"), "propertyNames dropped (3.1-only)")
 	}
 	if in.Unevaluated != nil {
-		*warnings = append(*warnings, debug.NewWarning(debug.WarnDegradationUnevaluatedProperties, "#/components/schemas/...", "unevaluatedProperties dropped (3.1-only)"))
+		degrade(warnings, debug.WarnDegradationUnevaluatedProperties, util.Pointer(ptr, "unevaluatedProperties"), "unevaluatedProperties dropped (3.1-only)")
 	}
 
 	return out
 }
+
+// transformPrefixItems approximates the tuple schema located at ptr with an anyOf of
+// its distinct item schemas, or the item schema if they are all the same. items is
+// the already projected items schema of the tuple.
+func (a *AdapterV304) transformPrefixItems(in *model.Schema, items *SchemaV30, ptr string, warnings *debug.Warnings) *SchemaV30 {
+	degrade(warnings, debug.WarnDegradationPrefixItems, util.Pointer(ptr, "prefixItems"), "prefixItems converted to items anyOf (3.1-only)")
+
+	var variants []*SchemaV30
+	add := func(item *SchemaV30) {
+		if !slices.ContainsFunc(variants, func(s *SchemaV30) bool { return reflect.DeepEqual(s, item) }) {
+			variants = append(variants, item)
+		}
+	}
+	for i, item := range in.PrefixItems {
+		add(a.transformSchema(item, util.Pointer(ptr, "prefixItems", strconv.Itoa(i)), warnings))
+	}
+	if items != nil && !in.NoAdditionalItems {
+		add(items)
+	}
+	if len(variants) == 1 {
+		return variants[0]
+	}
+
+	return &SchemaV30{AnyOf: variants}
+}
+
+// transformSchemas projects the schemas of a composition keyword located at ptr.
+func (a *AdapterV304) transformSchemas(in []*model.Schema, ptr string, warnings *debug.Warnings) []*SchemaV30 {
+	if len(in) == 0 {
+		return nil
+	}
+
+	out := make([]*SchemaV30, 0, len(in))
+	for i, schema := range in {
+		out = append(out, a.transformSchema(schema, util.Pointer(ptr, strconv.Itoa(i)), warnings))
+	}
+
+	return out
+}
+
+// degrade reports a 3.1-only feature lost or converted by the projection.
+func degrade(warnings *debug.Warnings, code debug.WarningCode, ptr, msg string) {
+	if warnings != nil {
+		warnings.Append(debug.NewWarning(code, ptr, msg))
+	}
+}
@@ -423,12 +423,12 @@ func TestTransformSchema_RefCases(t *testing.T) {
 	adapter := &AdapterV304{}
 
 	// Test nil schema
-	result := adapter.transformSchema(nil, nil)
+	result := adapter.transformSchema(nil, "#/components/schemas/User", nil)
 	assert.Nil(t, result)
 
 	// Test schema with only ref
 	schema := &model.Schema{Ref: "#/components/schemas/User"}
-	result = adapter.transformSchema(schema, nil)
+	result = adapter.transformSchema(schema, "#/components/schemas/User", nil)
 	require.NotNil(t, result)
 	assert.Equal(t, "#/components/schemas/User", result.Ref)
 	// Other fields should not be set
@@ -436,65 +436,301 @@ func TestTransformSchema_RefCases(t *testing.T) {
 	assert.Equal(t, "", result.Title)
 }
 
-func TestTransformSchema_Warnings(t *testing.T) {
+func TestTransformSchema_Downlevel(t *testing.T) {
 	adapter := &AdapterV304{}
+	const ptr = "#/components/schemas/Order"
 
 	tests := []struct {
 		name     string
 		schema   *model.Schema
+		want     *SchemaV30
 		wantCode debug.WarningCode
+		wantPath string
 	}{
 		{
-			name: "multiple examples",
+			name: "multiple examples collapsed to first",
 			schema: &model.Schema{
 				Type:     "string",
 				Examples: []any{"example1", "example2"},
 			},
+			want:     &SchemaV30{Type: "string", Example: "example1"},
 			wantCode: debug.WarnDegradationMultipleExamples,
+			wantPath: ptr + "/examples",
 		},
 		{
-			name: "const value",
+			name: "const to single-value enum",
 			schema: &model.Schema{
 				Type:  "string",
 				Const: "constant-value",
 			},
+			want:     &SchemaV30{Enum: []any{"constant-value"}},
 			wantCode: debug.WarnDegradationConstToEnum,
+			wantPath: ptr + "/const",
 		},
 		{
-			name: "content encoding",
+			name: "const among enum values",
+			schema: &model.Schema{
+				Enum:  []any{"a", "b"},
+				Const: "a",
+			},
+			want:     &SchemaV30{Enum: []any{"a"}},
+			wantCode: debug.WarnDegradationConstToEnum,
+			wantPath: ptr + "/const",
+		},
+		{
+			name: "const conflicting with enum",
+			schema: &model.Schema{
+				Enum:  []any{"a", "b"},
+				Const: "c",
+			},
+			want:     &SchemaV30{Enum: []any{"c"}},
+			wantCode: debug.WarnDegradationConstToEnumConflict,
+			wantPath: ptr + "/const",
+		},
+		{
+			name: "dependentRequired to allOf/anyOf",
+			schema: &model.Schema{
+				Type: "object",
+				DependentRequired: map[string][]string{
+					"card":   {"billingAddress"},
+					"coupon": {"campaign", "channel"},
+				},
+			},
+			want: &SchemaV30{
+				Type: "object",
+				AllOf: []*SchemaV30{
+					{AnyOf: []*SchemaV30{{Not: &SchemaV30{Required: []string{"card"}}}, {Required: []string{"billingAddress"}}}},
+					{AnyOf: []*SchemaV30{{Not: &SchemaV30{Required: []string{"coupon"}}}, {Required: []string{"campaign", "channel"}}}},
+				},
+			},
+			wantCode: debug.WarnDegradationDependentRequired,
+			wantPath: ptr + "/dependentRequired",
+		},
+		{
+			name: "base64 content encoding to format byte",
 			schema: &model.Schema{
 				Type:            "string",
 				ContentEncoding: "base64",
 			},
+			want:     &SchemaV30{Type: "string", Format: "byte"},
 			wantCode: debug.WarnDegradationContentEncoding,
+			wantPath: ptr + "/contentEncoding",
+		},
+		{
+			name: "other content encoding dropped",
+			schema: &model.Schema{
+				Type:            "string",
+				ContentEncoding: "base32",
+			},
+			want:     &SchemaV30{Type: "string"},
+			wantCode: debug.WarnDegradationContentEncoding,
+			wantPath: ptr + "/contentEncoding",
+		},
+		{
+			name: "octet-stream content media type to format binary",
+			schema: &model.Schema{
+				Type:             "string",
+				ContentMediaType: "application/octet-stream",
+			},
+			want:     &SchemaV30{Type: "string", Format: "binary"},
+			wantCode: debug.WarnDegradationContentMediaType,
+			wantPath: ptr + "/contentMediaType",
 		},
 		{
-			name: "content media type",
+			name: "other content media type dropped",
 			schema: &model.Schema{
 				Type:             "string",
 				ContentMediaType: "application/json",
 			},
+			want:     &SchemaV30{Type: "string"},
 			wantCode: debug.WarnDegradationContentMediaType,
+			wantPath: ptr + "/contentMediaType",
 		},
 		{
-			name: "unevaluated properties",
+			name: "pattern properties dropped",
+			schema: &model.Schema{
+				Type:         "object",
+				PatternProps: map[string]*model.Schema{"^x-": {Type: "string"}},
+			},
+			want:     &SchemaV30{Type: "object"},
+			wantCode: debug.WarnDegradationPatternProperties,
+			wantPath: ptr + "/patternProperties",
+		},
+		{
+			name: "property names dropped",
+			schema: &model.Schema{
+				Type:          "object",
+				PropertyNames: &model.Schema{Pattern: "^[a-z]+$"},
+			},
+			want:     &SchemaV30{Type: "object"},
+			wantCode: debug.WarnDegradationPropertyNames,
+			wantPath: ptr + "/propertyNames",
+		},
+		{
+			name: "unevaluated properties dropped",
 			schema: &model.Schema{
 				Type: "object",
 				Unevaluated: &model.Schema{
 					Type: "string",
 				},
 			},
+			want:     &SchemaV30{Type: "object"},
 			wantCode: debug.WarnDegradationUnevaluatedProperties,
+			wantPath: ptr + "/unevaluatedProperties",
+		},
+		{
+			name: "nested schema",
+			schema: &model.Schema{
+				Type: "object",
+				Properties: map[string]*model.Schema{
+					"tags": {Type: "array", Items: &model.Schema{Const: "a/b"}},
+				},
+			},
+			want: &SchemaV30{
+				Type: "object",
+				Properties: map[string]*SchemaV30{
+					"tags": {Type: "array", Items: &SchemaV30{Enum: []any{"a/b"}}},
+				},
+			},
+			wantCode: debug.WarnDegradationConstToEnum,
+			wantPath: ptr + "/properties/tags/items/const",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			var warnings debug.Warnings
-			result := adapter.transformSchema(tt.schema, &warnings)
+			result := adapter.transformSchema(tt.schema, ptr, &warnings)
 
-			require.NotNil(t, result)
-			assert.True(t, warnings.Has(tt.wantCode), "Expected warning %s not found", tt.wantCode)
+			assert.Equal(t, tt.want, result)
+			require.Len(t, warnings, 1)
+			assert.Equal(t, tt.wantCode, warnings[0].Code())
+			assert.Equal(t, tt.wantPath, warnings[0].Path())
+		})
+	}
+}
+
+func TestTransformSchema_Conditional(t *testing.T) {
+	adapter := &AdapterV304{}
+	cond := &model.Schema{Properties: map[string]*model.Schema{"method": {Enum: []any{"card"}}}, Required: []string{"method"}}
+
+	var warnings debug.Warnings
+	result := adapter.transformSchema(&model.Schema{
+		Type: "object",
+		If:   cond,
+		Then: &model.Schema{Required: []string{"card_number"}},
+		Else: &model.Schema{Required: []string{"iban"}},
+	}, "#/components/schemas/Payment", &warnings)
+
+	out, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"allOf": [{"anyOf": [
+			{"allOf": [{"properties": {"method": {"enum": ["card"]}}, "required": ["method"]}, {"required": ["card_number"]}]},
+			{"allOf": [{"not": {"properties": {"method": {"enum": ["card"]}}, "required": ["method"]}}, {"required": ["iban"]}]}
+		]}]
+	}`, string(out))
+	require.Len(t, warnings, 1)
+	assert.Equal(t, debug.WarnDegradationConditional, warnings[0].Code())
+	assert.Equal(t, "#/components/schemas/Payment/if", warnings[0].Path())
+}
+
+func TestTransformSchema_PrefixItems(t *testing.T) {
+	adapter := &AdapterV304{}
+
+	var warnings debug.Warnings
+	result := adapter.transformSchema(&model.Schema{
+		Type:        "array",
+		PrefixItems: []*model.Schema{{Type: "string"}},
+		Items:       &model.Schema{Type: "string", Const: "x"},
+	}, "#/components/schemas/Entry", &warnings)
+
+	assert.Equal(t, &SchemaV30{
+		Type:  "array",
+		Items: &SchemaV30{AnyOf: []*SchemaV30{{Type: "string"}, {Enum: []any{"x"}}}},
+	}, result)
+
+	paths := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		paths = append(paths, w.Path())
+	}
+	assert.ElementsMatch(t, []string{
+		"#/components/schemas/Entry/items/const",
+		"#/components/schemas/Entry/prefixItems",
+	}, paths)
+}
+
+func TestView_DownlevelWarningPaths(t *testing.T) {
+	adapter := &AdapterV304{}
+	spec := &model.Spec{
+		Info: model.Info{Title: "Test", Version: "1.0.0"},
+		Paths: map[string]*model.PathItem{
+			"/files/{id}": {
+				Get: &model.Operation{
+					Parameters: []model.Parameter{
+						{Name: "id", In: "path", Required: true, Schema: &model.Schema{Type: "string", Const: "x"}},
+					},
+					Responses: map[string]*model.Response{
+						"200": {
+							Description: "OK",
+							Content: map[string]*model.MediaType{
+								"application/octet-stream": {Schema: &model.Schema{Type: "string", ContentMediaType: "application/octet-stream"}},
+							},
+							Headers: map[string]*model.Header{
+								"X-Checksum": {Schema: &model.Schema{Type: "string", ContentEncoding: "base64"}},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	_, warnings, err := adapter.View(spec)
+	require.NoError(t, err)
+
+	paths := make([]string, 0, len(warnings))
+	for _, w := range warnings {
+		paths = append(paths, w.Path())
+	}
+	assert.ElementsMatch(t, []string{
+		"#/paths/~1files~1{id}/get/parameters/0/schema/const",
+		"#/paths/~1files~1{id}/get/responses/200/content/application~1octet-stream/schema/contentMediaType",
+		"#/paths/~1files~1{id}/get/responses/200/headers/X-Checksum/schema/contentEncoding",
+	}, paths)
+}
+
+func TestTransformSchema_CombinedBounds(t *testing.T) {
+	adapter := &AdapterV304{}
+
+	tests := []struct {
+		name   string
+		schema *model.Schema
+		want   string
+	}{
+		{
+			name:   "inclusive minimum, exclusive maximum",
+			schema: &model.Schema{Type: "number", Minimum: &model.Bound{Value: 0}, Maximum: &model.Bound{Value: 100, Exclusive: true}},
+			want:   `{"type":"number","minimum":0,"maximum":100,"exclusiveMaximum":true}`,
+		},
+		{
+			name:   "exclusive minimum, inclusive maximum",
+			schema: &model.Schema{Type: "number", Minimum: &model.Bound{Value: 0, Exclusive: true}, Maximum: &model.Bound{Value: 1}},
+			want:   `{"type":"number","minimum":0,"exclusiveMinimum":true,"maximum":1}`,
+		},
+		{
+			name:   "exclusive bounds",
+			schema: &model.Schema{Type: "number", Minimum: &model.Bound{Value: 1, Exclusive: true}, Maximum: &model.Bound{Value: 5, Exclusive: true}},
+			want:   `{"type":"number","minimum":1,"exclusiveMinimum":true,"maximum":5,"exclusiveMaximum":true}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json.Marshal(adapter.transformSchema(tt.schema, "#/components/schemas/Score", nil))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(out))
 		})
 	}
 }
@@ -503,12 +739,12 @@ func TestTransformPathItem_RefCase(t *testing.T) {
 	adapter := &AdapterV304{}
 
 	// Test nil path item
-	result := adapter.transformPathItem(nil, nil)
+	result := adapter.transformPathItem(nil, "#/paths/~1users", nil)
 	assert.Nil(t, result)
 
 	// Test path item with only ref
 	pathItem := &model.PathItem{Ref: "#/paths/users"}
-	result = adapter.transformPathItem(pathItem, nil)
+	result = adapter.transformPathItem(pathItem, "#/paths/~1users", nil)
 	require.NotNil(t, result)
 	assert.Equal(t, "#/paths/users", result.Ref)
 	// Other fields should not be processed
@@ -847,3 +1083,36 @@ func createConstSchema() *model.Schema {
 		Const: "active", // triggers const warning
 	}
 }
+
+func TestView_InternsLeafSchemas(t *testing.T) {
+	spec := &model.Spec{
+		Info:  model.Info{Title: "API", Version: "1.0.0"},
+		Paths: map[string]*model.PathItem{},
+		Components: &model.Components{
+			Schemas: map[string]*model.Schema{
+				"User": {
+					Type: "object",
+					Properties: map[string]*model.Schema{
+						"id":      {Type: "integer", Format: "int64"},
+						"groupId": {Type: "integer", Format: "int64"},
+						"name":    {Type: "string", Description: "Name"},
+						"email":   {Type: "string", Description: "Email"},
+					},
+				},
+				"Group": {
+					Type:       "object",
+					Properties: map[string]*model.Schema{"id": {Type: "integer", Format: "int64"}},
+				},
+			},
+		},
+	}
+
+	result, _, err := (&AdapterV304{}).View(spec)
+	require.NoError(t, err)
+
+	schemas := result.(*ViewV304).Components.Schemas
+	user, group := schemas["User"].Properties, schemas["Group"].Properties
+	assert.Same(t, user["id"], user["groupId"])
+	assert.Same(t, user["id"], group["id"])
+	assert.NotSame(t, user["name"], user["email"])
+}
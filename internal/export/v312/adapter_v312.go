@@ -6,13 +6,24 @@ import (
 	"strings"
 
 	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/export/util"
 	"github.com/talav/openapi/internal/model"
 )
 
 //go:embed schema_v312.json
 var schemaV312JSON []byte
 
-type AdapterV312 struct{}
+// componentSchemasPrefix is the prefix of the references to component schemas.
+const componentSchemasPrefix = "#/components/schemas/"
+
+type AdapterV312 struct {
+	// leaves interns the views of leaf schemas during a View call.
+	leaves map[util.LeafKey]*SchemaV31
+
+	// schemaIDs holds the $id of the identified component schemas by reference,
+	// while projecting component schemas.
+	schemaIDs map[string]string
+}
 
 func (a *AdapterV312) Version() string {
 	return "3.1.2"
@@ -29,17 +40,21 @@ func (a *AdapterV312) View(spec *model.Spec) (any, debug.Warnings, error) {
 
 	var warnings debug.Warnings
 
+	// Views of leaf schemas are shared within the view.
+	a = &AdapterV312{leaves: make(map[util.LeafKey]*SchemaV31)}
+
 	result := &ViewV312{
-		OpenAPI:      a.Version(),
-		Info:         a.transformInfo(spec.Info),
-		Servers:      a.transformServers(spec.Servers),
-		Paths:        a.transformPaths(spec.Paths, &warnings),
-		Components:   a.transformComponents(spec.Components, &warnings),
-		Security:     a.transformSecurity(spec.Security),
-		Tags:         a.transformTags(spec.Tags),
-		ExternalDocs: a.transformExternalDocs(spec.ExternalDocs),
-		Webhooks:     a.transformWebhooks(spec.Webhooks, &warnings),
-		Extensions:   spec.Extensions,
+		OpenAPI:           a.Version(),
+		JSONSchemaDialect: spec.JSONSchemaDialect,
+		Info:              a.transformInfo(spec.Info),
+		Servers:           a.transformServers(spec.Servers),
+		Paths:             a.transformPaths(spec.Paths, &warnings),
+		Components:        a.transformComponents(spec.Components, &warnings),
+		Security:          a.transformSecurity(spec.Security),
+		Tags:              a.transformTags(spec.Tags),
+		ExternalDocs:      a.transformExternalDocs(spec.ExternalDocs),
+		Webhooks:          a.transformWebhooks(spec.Webhooks, &warnings),
+		Extensions:        spec.Extensions,
 	}
 
 	if err := validateViewV312(result); err != nil {
@@ -258,6 +273,11 @@ func (a *AdapterV312) transformPathItem(in *model.PathItem, warnings *debug.Warn
 	item.Patch = a.transformOperation(in.Patch, warnings)
 	item.Trace = a.transformOperation(in.Trace, warnings)
 
+	if len(in.AdditionalOperations) > 0 {
+		item.Extensions = util.AdditionalOperationExtensions(in.Extensions, in.AdditionalOperations,
+			func(op *model.Operation, _ ...string) any { return a.transformOperation(op, warnings) })
+	}
+
 	// Transform Servers
 	if len(in.Servers) > 0 {
 		item.Servers = a.transformServers(in.Servers)
@@ -290,7 +310,7 @@ func (a *AdapterV312) transformParameter(in model.Parameter, warnings *debug.War
 		Deprecated:      in.Deprecated,
 		AllowEmptyValue: in.AllowEmptyValue,
 		Style:           in.Style,
-		Explode:         in.Explode,
+		Explode:         util.Explode(&in),
 		AllowReserved:   in.AllowReserved,
 		Example:         in.Example,
 		Extensions:      in.Extensions,
@@ -477,10 +497,19 @@ func (a *AdapterV312) transformComponents(in *model.Components, warnings *debug.
 	}
 
 	if len(in.Schemas) > 0 {
+		// References within identified schemas resolve against their $id, so that
+		// references to identified schemas use their $id instead of a JSON pointer
+		a.schemaIDs = make(map[string]string)
+		for name, schema := range in.Schemas {
+			if schema != nil && schema.ID != "" {
+				a.schemaIDs[componentSchemasPrefix+name] = schema.ID
+			}
+		}
 		comp.Schemas = make(map[string]*SchemaV31, len(in.Schemas))
 		for name, schema := range in.Schemas {
 			comp.Schemas[name] = a.transformSchema(schema, warnings)
 		}
+		a.schemaIDs = nil
 	}
 
 	if len(in.Responses) > 0 {
@@ -761,18 +790,44 @@ func (a *AdapterV312) transformResponses(in map[string]*model.Response, warnings
 	return responses
 }
 
-//nolint:cyclop,gocognit,gocyclo,unparam
+// transformSchema projects a schema, sharing the views of leaf schemas.
 func (a *AdapterV312) transformSchema(in *model.Schema, warnings *debug.Warnings) *SchemaV31 {
 	if in == nil {
 		return nil
 	}
 
+	key, leaf := util.Leaf(in)
+	if _, ok := a.schemaIDs[in.Ref]; ok {
+		leaf = false
+	}
+	if out, ok := a.leaves[key]; leaf && ok {
+		return out
+	}
+	out := a.projectSchema(in, warnings)
+	if leaf && a.leaves != nil {
+		a.leaves[key] = out
+	}
+
+	return out
+}
+
+// projectSchema projects a non-nil schema.
+//
+//nolint:cyclop,gocognit,gocyclo,unparam
+func (a *AdapterV312) projectSchema(in *model.Schema, warnings *debug.Warnings) *SchemaV31 {
 	// Handle $ref case
 	if in.Ref != "" {
-		return &SchemaV31{Ref: in.Ref}
+		ref := in.Ref
+		if id, ok := a.schemaIDs[ref]; ok {
+			ref = id
+		}
+
+		return &SchemaV31{Schema: in.Dialect, ID: in.ID, Ref: ref}
 	}
 
 	out := &SchemaV31{
+		Schema:           in.Dialect,
+		ID:               in.ID,
 		Title:            in.Title,
 		Description:      in.Description,
 		Format:           in.Format,
@@ -842,7 +897,18 @@ func (a *AdapterV312) transformSchema(in *model.Schema, warnings *debug.Warnings
 	out.MinItems = in.MinItems
 	out.MaxItems = in.MaxItems
 	out.UniqueItems = in.UniqueItems
-	out.Items = a.transformSchema(in.Items, warnings)
+	switch {
+	case in.NoAdditionalItems:
+		out.Items = false
+	case in.Items != nil:
+		out.Items = a.transformSchema(in.Items, warnings)
+	}
+	if len(in.PrefixItems) > 0 {
+		out.PrefixItems = make([]*SchemaV31, 0, len(in.PrefixItems))
+		for _, item := range in.PrefixItems {
+			out.PrefixItems = append(out.PrefixItems, a.transformSchema(item, warnings))
+		}
+	}
 
 	// Handle object constraints
 	if len(in.Properties) > 0 {
@@ -874,6 +940,9 @@ func (a *AdapterV312) transformSchema(in *model.Schema, warnings *debug.Warnings
 		}
 	}
 
+	// Handle property names (3.1.2 feature)
+	out.PropertyNames = a.transformSchema(in.PropertyNames, warnings)
+
 	// Handle unevaluated properties (3.1.2 feature)
 	if in.Unevaluated != nil {
 		out.UnevaluatedProperties = a.transformSchema(in.Unevaluated, warnings)
@@ -899,6 +968,9 @@ func (a *AdapterV312) transformSchema(in *model.Schema, warnings *debug.Warnings
 		}
 	}
 	out.Not = a.transformSchema(in.Not, warnings)
+	out.If = a.transformSchema(in.If, warnings)
+	out.Then = a.transformSchema(in.Then, warnings)
+	out.Else = a.transformSchema(in.Else, warnings)
 
 	// Handle default value
 	out.Default = in.Default
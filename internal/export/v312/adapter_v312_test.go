@@ -537,6 +537,62 @@ func TestTransformSchema_NoWarnings(t *testing.T) {
 	}
 }
 
+func TestTransformSchema_CombinedBounds(t *testing.T) {
+	adapter := &AdapterV312{}
+
+	tests := []struct {
+		name   string
+		schema *model.Schema
+		want   string
+	}{
+		{
+			name:   "inclusive minimum, exclusive maximum",
+			schema: &model.Schema{Type: "number", Minimum: &model.Bound{Value: 0}, Maximum: &model.Bound{Value: 100, Exclusive: true}},
+			want:   `{"type":"number","minimum":0,"exclusiveMaximum":100}`,
+		},
+		{
+			name:   "exclusive minimum, inclusive maximum",
+			schema: &model.Schema{Type: "number", Minimum: &model.Bound{Value: 0, Exclusive: true}, Maximum: &model.Bound{Value: 1}},
+			want:   `{"type":"number","exclusiveMinimum":0,"maximum":1}`,
+		},
+		{
+			name:   "exclusive bounds",
+			schema: &model.Schema{Type: "number", Minimum: &model.Bound{Value: 1, Exclusive: true}, Maximum: &model.Bound{Value: 5, Exclusive: true}},
+			want:   `{"type":"number","exclusiveMinimum":1,"exclusiveMaximum":5}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json.Marshal(adapter.transformSchema(tt.schema, nil))
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(out))
+		})
+	}
+}
+
+func TestTransformSchema_Conditional(t *testing.T) {
+	adapter := &AdapterV312{}
+
+	var warnings debug.Warnings
+	result := adapter.transformSchema(&model.Schema{
+		Type: "object",
+		If:   &model.Schema{Properties: map[string]*model.Schema{"method": {Const: "card"}}, Required: []string{"method"}},
+		Then: &model.Schema{Required: []string{"card_number"}},
+		Else: &model.Schema{Required: []string{"iban"}},
+	}, &warnings)
+
+	out, err := json.Marshal(result)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"type": "object",
+		"if": {"properties": {"method": {"const": "card"}}, "required": ["method"]},
+		"then": {"required": ["card_number"]},
+		"else": {"required": ["iban"]}
+	}`, string(out))
+	assert.Empty(t, warnings)
+}
+
 func TestTransformPathItem_RefCase(t *testing.T) {
 	adapter := &AdapterV312{}
 
@@ -886,3 +942,36 @@ func createConstSchema() *model.Schema {
 		Const: "active",
 	}
 }
+
+func TestView_InternsLeafSchemas(t *testing.T) {
+	spec := &model.Spec{
+		Info:  model.Info{Title: "API", Version: "1.0.0"},
+		Paths: map[string]*model.PathItem{},
+		Components: &model.Components{
+			Schemas: map[string]*model.Schema{
+				"User": {
+					Type: "object",
+					Properties: map[string]*model.Schema{
+						"id":      {Type: "integer", Format: "int64"},
+						"groupId": {Type: "integer", Format: "int64"},
+						"name":    {Type: "string", Description: "Name"},
+						"email":   {Type: "string", Description: "Email"},
+					},
+				},
+				"Group": {
+					Type:       "object",
+					Properties: map[string]*model.Schema{"id": {Type: "integer", Format: "int64"}},
+				},
+			},
+		},
+	}
+
+	result, _, err := (&AdapterV312{}).View(spec)
+	require.NoError(t, err)
+
+	schemas := result.(*ViewV312).Components.Schemas
+	user, group := schemas["User"].Properties, schemas["Group"].Properties
+	assert.Same(t, user["id"], user["groupId"])
+	assert.Same(t, user["id"], group["id"])
+	assert.NotSame(t, user["name"], user["email"])
+}
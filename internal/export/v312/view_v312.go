@@ -313,7 +313,7 @@ type ParameterV31 struct {
 	Style string `json:"style,omitempty"`
 
 	// When this is true, parameter values of type array or object generate separate parameters for each value of the array or key-value pair of the map. For other types of parameters this property has no effect. When style is form, the default value is true. For all other styles, the default value is false.
-	Explode bool `json:"explode,omitempty"`
+	Explode *bool `json:"explode,omitempty"`
 
 	// Determines whether the parameter value SHOULD allow reserved characters, as defined by RFC3986 :/?#[]@!$&'()*+,;= to be included without percent-encoding. This property only applies to parameters with an in value of query. The default value is false.
 	AllowReserved bool `json:"allowReserved,omitempty"`
@@ -454,6 +454,12 @@ func (r *ResponseV31) MarshalJSON() ([]byte, error) {
 // SchemaV31 represents a JSON Schema (Draft 2020-12)
 // https://github.com/OAI/OpenAPI-Specification/blob/main/versions/3.1.2.md#schema-object
 type SchemaV31 struct {
+	// The JSON Schema dialect of the schema resource
+	Schema string `json:"$schema,omitempty"`
+
+	// The URI identifying the schema resource
+	ID string `json:"$id,omitempty"`
+
 	// A reference to a schema defined in components/schemas
 	Ref string `json:"$ref,omitempty"`
 
@@ -517,8 +523,13 @@ type SchemaV31 struct {
 	// Not composition
 	Not *SchemaV31 `json:"not,omitempty"`
 
-	// Items for arrays
-	Items *SchemaV31 `json:"items,omitempty"`
+	// Conditional subschemas
+	If   *SchemaV31 `json:"if,omitempty"`
+	Then *SchemaV31 `json:"then,omitempty"`
+	Else *SchemaV31 `json:"else,omitempty"`
+
+	// Items for arrays: a schema, or false for tuples without additional items
+	Items any `json:"items,omitempty"`
 
 	// Prefix items for tuple schemas
 	PrefixItems []*SchemaV31 `json:"prefixItems,omitempty"`
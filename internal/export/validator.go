@@ -3,9 +3,17 @@ package export
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
+	"strings"
 
 	"github.com/santhosh-tekuri/jsonschema/v6"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/talav/openapi/debug"
 )
 
 // Validator validates OpenAPI specifications against a specific meta-schema.
@@ -18,6 +26,8 @@ type Validator struct {
 //
 // The validator uses santhosh-tekuri/jsonschema which supports both
 // JSON Schema draft-04 (for OpenAPI 3.0) and draft-2020-12 (for OpenAPI 3.1).
+// Validation is offline: the meta-schema must be self-contained, as the
+// resources it references are never loaded (see offlineLoader).
 //
 // Example:
 //
@@ -33,6 +43,7 @@ func NewValidator(schemaJSON []byte) (*Validator, error) {
 	}
 
 	compiler := jsonschema.NewCompiler()
+	compiler.UseLoader(offlineLoader{})
 
 	// Use a simple resource name
 	resourceName := "openapi-schema.json"
@@ -50,6 +61,15 @@ func NewValidator(schemaJSON []byte) (*Validator, error) {
 	}, nil
 }
 
+// offlineLoader refuses to load the resources referenced by schemas, so that
+// validation never reaches the network or the file system. The JSON Schema
+// meta-schemas of the standard drafts are embedded in the compiler.
+type offlineLoader struct{}
+
+func (offlineLoader) Load(url string) (any, error) {
+	return nil, fmt.Errorf("%s is not loaded: validation is offline", url)
+}
+
 // Validate validates an OpenAPI specification JSON against the meta-schema.
 func (v *Validator) Validate(ctx context.Context, specJSON []byte) error {
 	// Unmarshal JSON first, then validate the unmarshaled data
@@ -58,5 +78,120 @@ func (v *Validator) Validate(ctx context.Context, specJSON []byte) error {
 		return fmt.Errorf("failed to unmarshal JSON: %w", err)
 	}
 
-	return v.schema.Validate(data)
+	err := v.schema.Validate(data)
+
+	var ve *jsonschema.ValidationError
+	if errors.As(err, &ve) {
+		return collectViolations(ve, data, true)
+	}
+
+	return err
+}
+
+// messagePrinter renders meta-schema violation messages.
+var messagePrinter = message.NewPrinter(language.English)
+
+// collectViolations flattens a validation error tree into the most specific violations.
+// Leaves are kept, except those located at a parent of another leaf when prune is set:
+// for oneOf/anyOf rules, the deepest failure is usually the one pointing at the actual mistake.
+func collectViolations(root *jsonschema.ValidationError, doc any, prune bool) debug.ValidationErrors {
+	var leaves []*jsonschema.ValidationError
+	var walk func(e *jsonschema.ValidationError)
+	walk = func(e *jsonschema.ValidationError) {
+		if len(e.Causes) == 0 {
+			leaves = append(leaves, e)
+
+			return
+		}
+		for _, c := range e.Causes {
+			walk(c)
+		}
+	}
+	walk(root)
+
+	// Deepest first, then by pointer for deterministic output
+	sort.SliceStable(leaves, func(i, j int) bool {
+		if len(leaves[i].InstanceLocation) != len(leaves[j].InstanceLocation) {
+			return len(leaves[i].InstanceLocation) > len(leaves[j].InstanceLocation)
+		}
+
+		return jsonPointer(leaves[i].InstanceLocation) < jsonPointer(leaves[j].InstanceLocation)
+	})
+
+	var result debug.ValidationErrors
+	seen := make(map[string]bool)
+	for _, leaf := range leaves {
+		pointer := jsonPointer(leaf.InstanceLocation)
+		rule := leaf.SchemaURL + jsonPointer(leaf.ErrorKind.KeywordPath())
+		msg := leaf.ErrorKind.LocalizedString(messagePrinter)
+		if seen[pointer+"\x00"+msg] || prune && coveredByDeeper(result, pointer) {
+			continue
+		}
+		seen[pointer+"\x00"+msg] = true
+
+		result = append(result, debug.ValidationError{
+			Pointer:  pointer,
+			Fragment: fragment(doc, leaf.InstanceLocation),
+			Rule:     rule,
+			Message:  msg,
+		})
+	}
+
+	return result
+}
+
+// coveredByDeeper reports whether a violation was already found below pointer.
+func coveredByDeeper(found debug.ValidationErrors, pointer string) bool {
+	for _, f := range found {
+		if strings.HasPrefix(f.Pointer, pointer+"/") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// jsonPointer encodes reference tokens as a JSON pointer (RFC 6901).
+func jsonPointer(tokens []string) string {
+	var sb strings.Builder
+	for _, tok := range tokens {
+		sb.WriteByte('/')
+		sb.WriteString(strings.NewReplacer("~", "~0", "/", "~1").Replace(tok))
+	}
+
+	return sb.String()
+}
+
+// pointerTokens decodes the reference tokens of a JSON pointer (RFC 6901).
+func pointerTokens(pointer string) []string {
+	if pointer == "" {
+		return nil
+	}
+	tokens := strings.Split(strings.TrimPrefix(pointer, "/"), "/")
+	for i, tok := range tokens {
+		tokens[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(tok)
+	}
+
+	return tokens
+}
+
+// fragment returns the value located by tokens in a decoded JSON document.
+func fragment(doc any, tokens []string) any {
+	current := doc
+	for _, tok := range tokens {
+		switch v := current.(type) {
+		case map[string]any:
+			current = v[tok]
+		case []any:
+			i, err := strconv.Atoi(tok)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil
+			}
+			current = v[i]
+		default:
+			return nil
+		}
+	}
+
+	return current
 }
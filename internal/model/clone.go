@@ -0,0 +1,107 @@
+package model
+
+import "reflect"
+
+// Clone returns a deep copy of the spec, sharing no pointers, maps or slices
+// with it, values of type any included. Objects referenced from several places
+// of the spec are copied once and stay shared in the copy.
+func (s *Spec) Clone() *Spec {
+	if s == nil {
+		return nil
+	}
+	c := cloner{copies: make(map[cloneKey]reflect.Value)}
+	out, _ := c.clone(reflect.ValueOf(s)).Interface().(*Spec)
+
+	return out
+}
+
+// cloneKey identifies a pointer, map or slice already copied.
+type cloneKey struct {
+	t   reflect.Type
+	ptr uintptr
+	len int
+}
+
+// cloner deep copies values, remembering the copies of pointers, maps and
+// slices.
+type cloner struct {
+	copies map[cloneKey]reflect.Value
+}
+
+// clone returns a deep copy of v. Unexported struct fields, functions and
+// channels are copied as is.
+func (c *cloner) clone(v reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Pointer:
+		if v.IsNil() {
+			return v
+		}
+		key := cloneKey{t: v.Type(), ptr: v.Pointer()}
+		if out, ok := c.copies[key]; ok {
+			return out
+		}
+		out := reflect.New(v.Type().Elem())
+		c.copies[key] = out
+		out.Elem().Set(c.clone(v.Elem()))
+
+		return out
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		key := cloneKey{t: v.Type(), ptr: v.Pointer()}
+		if out, ok := c.copies[key]; ok {
+			return out
+		}
+		out := reflect.MakeMapWithSize(v.Type(), v.Len())
+		c.copies[key] = out
+		iter := v.MapRange()
+		for iter.Next() {
+			out.SetMapIndex(iter.Key(), c.clone(iter.Value()))
+		}
+
+		return out
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		key := cloneKey{t: v.Type(), ptr: v.Pointer(), len: v.Len()}
+		if out, ok := c.copies[key]; ok {
+			return out
+		}
+		out := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		c.copies[key] = out
+		for i := range v.Len() {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+
+		return out
+	case reflect.Array:
+		out := reflect.New(v.Type()).Elem()
+		for i := range v.Len() {
+			out.Index(i).Set(c.clone(v.Index(i)))
+		}
+
+		return out
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		out := reflect.New(v.Type()).Elem()
+		out.Set(c.clone(v.Elem()))
+
+		return out
+	case reflect.Struct:
+		out := reflect.New(v.Type()).Elem()
+		out.Set(v)
+		for i := range v.NumField() {
+			if v.Type().Field(i).IsExported() {
+				out.Field(i).Set(c.clone(v.Field(i)))
+			}
+		}
+
+		return out
+	default:
+		return v
+	}
+}
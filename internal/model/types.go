@@ -8,6 +8,10 @@ type Spec struct {
 	// Info contains API metadata (title, version, description, contact, license).
 	Info Info
 
+	// JSONSchemaDialect is the default JSON Schema dialect of the schemas (3.1 feature).
+	// In 3.0, this will be dropped with a warning.
+	JSONSchemaDialect string
+
 	// Servers lists available server URLs for the API.
 	Servers []Server
 
@@ -169,6 +173,10 @@ type PathItem struct {
 	Patch   *Operation
 	Trace   *Operation
 
+	// Operations for other HTTP methods (e.g. QUERY), keyed by upper-case method name.
+	// OpenAPI 3.0 and 3.1 have no field for them: views emit them as extensions.
+	AdditionalOperations map[string]*Operation
+
 	// Alternative server array to service all operations in this path.
 	Servers []Server
 
@@ -589,6 +597,13 @@ type Schema struct {
 	// Ref is a logical reference to a component schema.
 	Ref string
 
+	// ID is the $id of the schema, identifying it as a JSON Schema resource (3.1 feature).
+	ID string
+
+	// Dialect is the $schema of the schema, the JSON Schema dialect of the
+	// resource identified by ID (3.1 feature).
+	Dialect string
+
 	// Type of the schema (string representation).
 	Type string
 
@@ -652,6 +667,14 @@ type Schema struct {
 	// Items defines the item schema for arrays.
 	Items *Schema
 
+	// PrefixItems defines the schemas of the leading items of arrays, for tuples
+	// (3.1 feature). In 3.0, they are converted to an anyOf of items with a warning.
+	PrefixItems []*Schema
+
+	// NoAdditionalItems forbids items beyond PrefixItems (items: false in 3.1).
+	// Items is ignored when set.
+	NoAdditionalItems bool
+
 	// MinItems is the minimum number of items in an array.
 	MinItems *int
 
@@ -681,6 +704,10 @@ type Schema struct {
 	// In 3.0, this will be dropped with a warning.
 	PatternProps map[string]*Schema
 
+	// PropertyNames constrains the property names of objects, such as map keys
+	// (3.1 feature). In 3.0, this will be dropped with a warning.
+	PropertyNames *Schema
+
 	// Unevaluated defines unevaluatedProperties schema (3.1 feature).
 	// In 3.0, this will be dropped with a warning.
 	Unevaluated *Schema
@@ -703,6 +730,12 @@ type Schema struct {
 	// Not represents a not composition.
 	Not *Schema
 
+	// If, Then and Else represent a conditional: instances valid against If
+	// must be valid against Then, the others against Else (3.1 only).
+	If   *Schema
+	Then *Schema
+	Else *Schema
+
 	// Enum lists allowed values for the schema.
 	Enum []any
 
@@ -0,0 +1,838 @@
+package overlay
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// Path is a compiled JSONPath query (RFC 9535). Supported: the root identifier,
+// child and descendant segments, name, wildcard and index selectors, and filter
+// selectors with comparisons, existence tests and logical operators. Array
+// slices and function extensions are not supported.
+//
+// As an extension, member names in dot notation may contain hyphens after their
+// first character, so that extensions can be written @.x-internal rather than
+// @['x-internal'].
+type Path struct {
+	segments []segment
+}
+
+// segment selects nodes from each input node, or from it and all its descendants.
+type segment struct {
+	descendant bool
+	selectors  []selector
+}
+
+// selector selects children of a node.
+type selector interface {
+	selectFrom(root any, n Node, out []Node) []Node
+}
+
+// Node is a value selected by a query, with its location in the document.
+type Node struct {
+	Value any
+
+	parent any // *Object or *Array; nil for the root
+	key    string
+	index  int
+}
+
+// set replaces the value of the node in its parent.
+func (n Node) set(v any) {
+	switch p := n.parent.(type) {
+	case *Object:
+		p.Set(n.key, v)
+	case *Array:
+		p.Items[n.index] = v
+	}
+}
+
+// children returns the members of an object or the items of an array.
+func children(v any) []Node {
+	switch v := v.(type) {
+	case *Object:
+		nodes := make([]Node, 0, len(v.keys))
+		for _, key := range v.keys {
+			nodes = append(nodes, Node{Value: v.values[key], parent: v, key: key})
+		}
+
+		return nodes
+	case *Array:
+		nodes := make([]Node, 0, len(v.Items))
+		for i, item := range v.Items {
+			nodes = append(nodes, Node{Value: item, parent: v, index: i})
+		}
+
+		return nodes
+	default:
+		return nil
+	}
+}
+
+// descendantsOrSelf appends n and all its descendants, in document order, to out.
+func descendantsOrSelf(n Node, out []Node) []Node {
+	out = append(out, n)
+	for _, child := range children(n.Value) {
+		out = descendantsOrSelf(child, out)
+	}
+
+	return out
+}
+
+// Select returns the nodes of root selected by the query.
+func (p *Path) Select(root any) []Node {
+	return p.selectFrom(root, root)
+}
+
+// selectFrom returns the nodes selected by the query from a start node, the root
+// or the current node of a filter.
+func (p *Path) selectFrom(root, start any) []Node {
+	nodes := []Node{{Value: start}}
+	for _, seg := range p.segments {
+		var next []Node
+		for _, n := range nodes {
+			inputs := []Node{n}
+			if seg.descendant {
+				inputs = descendantsOrSelf(n, nil)
+			}
+			for _, in := range inputs {
+				for _, sel := range seg.selectors {
+					next = sel.selectFrom(root, in, next)
+				}
+			}
+		}
+		nodes = next
+	}
+
+	return nodes
+}
+
+type nameSelector string
+
+func (s nameSelector) selectFrom(_ any, n Node, out []Node) []Node {
+	if obj, ok := n.Value.(*Object); ok {
+		if v, ok := obj.Get(string(s)); ok {
+			out = append(out, Node{Value: v, parent: obj, key: string(s)})
+		}
+	}
+
+	return out
+}
+
+type wildcardSelector struct{}
+
+func (wildcardSelector) selectFrom(_ any, n Node, out []Node) []Node {
+	return append(out, children(n.Value)...)
+}
+
+type indexSelector int
+
+func (s indexSelector) selectFrom(_ any, n Node, out []Node) []Node {
+	arr, ok := n.Value.(*Array)
+	if !ok {
+		return out
+	}
+	i := int(s)
+	if i < 0 {
+		i += len(arr.Items)
+	}
+	if i < 0 || i >= len(arr.Items) {
+		return out
+	}
+
+	return append(out, Node{Value: arr.Items[i], parent: arr, index: i})
+}
+
+type filterSelector struct {
+	expr logicalExpr
+}
+
+func (s filterSelector) selectFrom(root any, n Node, out []Node) []Node {
+	for _, child := range children(n.Value) {
+		if s.expr.test(root, child.Value) {
+			out = append(out, child)
+		}
+	}
+
+	return out
+}
+
+// logicalExpr is a filter expression.
+type logicalExpr interface {
+	test(root, current any) bool
+}
+
+type orExpr []logicalExpr
+
+func (e orExpr) test(root, current any) bool {
+	for _, expr := range e {
+		if expr.test(root, current) {
+			return true
+		}
+	}
+
+	return false
+}
+
+type andExpr []logicalExpr
+
+func (e andExpr) test(root, current any) bool {
+	for _, expr := range e {
+		if !expr.test(root, current) {
+			return false
+		}
+	}
+
+	return true
+}
+
+type notExpr struct {
+	expr logicalExpr
+}
+
+func (e notExpr) test(root, current any) bool {
+	return !e.expr.test(root, current)
+}
+
+// existsExpr tests whether a query selects at least one node.
+type existsExpr struct {
+	query *filterQuery
+}
+
+func (e existsExpr) test(root, current any) bool {
+	return len(e.query.nodes(root, current)) > 0
+}
+
+type comparisonExpr struct {
+	left, right operand
+	op          string
+}
+
+func (e comparisonExpr) test(root, current any) bool {
+	left, leftOK := e.left.value(root, current)
+	right, rightOK := e.right.value(root, current)
+
+	switch e.op {
+	case "==":
+		return equal(left, leftOK, right, rightOK)
+	case "!=":
+		return !equal(left, leftOK, right, rightOK)
+	case "<":
+		return leftOK && rightOK && less(left, right)
+	case "<=":
+		return (leftOK && rightOK && less(left, right)) || equal(left, leftOK, right, rightOK)
+	case ">":
+		return leftOK && rightOK && less(right, left)
+	default: // ">="
+		return (leftOK && rightOK && less(right, left)) || equal(left, leftOK, right, rightOK)
+	}
+}
+
+// operand is an operand of a comparison: a literal or a singular query.
+type operand interface {
+	value(root, current any) (any, bool)
+}
+
+type literal struct {
+	v any
+}
+
+func (l literal) value(any, any) (any, bool) {
+	return l.v, true
+}
+
+// filterQuery is a query of a filter, relative to the current node (@) or to
+// the root ($). Only singular queries, made of name and index selectors, can be
+// compared.
+type filterQuery struct {
+	absolute bool
+	path     Path
+}
+
+// nodes returns the nodes selected by the query.
+func (q *filterQuery) nodes(root, current any) []Node {
+	if q.absolute {
+		return q.path.selectFrom(root, root)
+	}
+
+	return q.path.selectFrom(root, current)
+}
+
+// value returns the value of the node selected by a singular query.
+func (q *filterQuery) value(root, current any) (any, bool) {
+	nodes := q.nodes(root, current)
+	if len(nodes) == 0 {
+		return nil, false
+	}
+
+	return nodes[0].Value, true
+}
+
+// singular reports whether the query selects at most one node.
+func (q *filterQuery) singular() bool {
+	for _, seg := range q.path.segments {
+		if seg.descendant || len(seg.selectors) != 1 {
+			return false
+		}
+		switch seg.selectors[0].(type) {
+		case nameSelector, indexSelector:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// equal compares two values following RFC 9535: missing values are equal to
+// each other only, numbers are compared by value, arrays item by item and
+// objects member by member, whatever their order.
+func equal(a any, aOK bool, b any, bOK bool) bool {
+	if !aOK || !bOK {
+		return aOK == bOK
+	}
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+
+		return ok && x == y
+	}
+
+	switch a := a.(type) {
+	case *Object:
+		b, ok := b.(*Object)
+		if !ok || len(a.values) != len(b.values) {
+			return false
+		}
+		for key, v := range a.values {
+			w, ok := b.values[key]
+			if !ok || !equal(v, true, w, true) {
+				return false
+			}
+		}
+
+		return true
+	case *Array:
+		b, ok := b.(*Array)
+		if !ok || len(a.Items) != len(b.Items) {
+			return false
+		}
+		for i := range a.Items {
+			if !equal(a.Items[i], true, b.Items[i], true) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return reflect.DeepEqual(a, b)
+	}
+}
+
+// less reports whether a is less than b, for numbers and for strings.
+func less(a, b any) bool {
+	if x, ok := number(a); ok {
+		y, ok := number(b)
+
+		return ok && x < y
+	}
+	x, ok := a.(string)
+	y, ok2 := b.(string)
+
+	return ok && ok2 && x < y
+}
+
+// number returns the value of a numeric JSON or YAML value.
+func number(v any) (float64, bool) {
+	switch v := v.(type) {
+	case json.Number:
+		f, err := v.Float64()
+
+		return f, err == nil
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
+// Compile parses a JSONPath query.
+func Compile(expr string) (*Path, error) {
+	p := &pathParser{src: expr}
+	path, err := p.parsePath()
+	if err != nil {
+		return nil, fmt.Errorf("invalid JSONPath %q at offset %d: %w", expr, p.pos, err)
+	}
+
+	return path, nil
+}
+
+// pathParser is a recursive descent parser of JSONPath queries.
+type pathParser struct {
+	src string
+	pos int
+}
+
+func (p *pathParser) eof() bool {
+	return p.pos >= len(p.src)
+}
+
+func (p *pathParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.src[p.pos]
+}
+
+func (p *pathParser) consume(s string) bool {
+	if strings.HasPrefix(p.src[p.pos:], s) {
+		p.pos += len(s)
+
+		return true
+	}
+
+	return false
+}
+
+func (p *pathParser) skipSpaces() {
+	for !p.eof() && strings.IndexByte(" \t\n\r", p.peek()) >= 0 {
+		p.pos++
+	}
+}
+
+func (p *pathParser) parsePath() (*Path, error) {
+	if !p.consume("$") {
+		return nil, fmt.Errorf("query must start with $")
+	}
+
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+	if !p.eof() {
+		return nil, fmt.Errorf("unexpected %q", p.peek())
+	}
+
+	return &Path{segments: segments}, nil
+}
+
+// parseSegments parses the segments following an identifier, up to the first
+// character not starting a segment. Segments may be separated by whitespace.
+func (p *pathParser) parseSegments() ([]segment, error) {
+	var segments []segment
+	for {
+		start := p.pos
+		p.skipSpaces()
+
+		var seg segment
+		switch {
+		case p.consume(".."):
+			seg.descendant = true
+			sel, err := p.parseDotOrBracket()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = sel
+		case p.consume("."):
+			sel, err := p.parseDotSelector()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = []selector{sel}
+		case p.peek() == '[':
+			sel, err := p.parseBracket()
+			if err != nil {
+				return nil, err
+			}
+			seg.selectors = sel
+		default:
+			p.pos = start
+
+			return segments, nil
+		}
+		segments = append(segments, seg)
+	}
+}
+
+// parseDotOrBracket parses the selectors following "..".
+func (p *pathParser) parseDotOrBracket() ([]selector, error) {
+	if p.peek() == '[' {
+		return p.parseBracket()
+	}
+	sel, err := p.parseDotSelector()
+	if err != nil {
+		return nil, err
+	}
+
+	return []selector{sel}, nil
+}
+
+// parseDotSelector parses a wildcard or a member name in dot notation. Names
+// start with a letter, an underscore or a non-ASCII character, and may contain
+// hyphens, as in x-internal (an extension of RFC 9535).
+func (p *pathParser) parseDotSelector() (selector, error) {
+	if p.consume("*") {
+		return wildcardSelector{}, nil
+	}
+
+	start := p.pos
+	for !p.eof() {
+		r, size := utf8.DecodeRuneInString(p.src[p.pos:])
+		if r < utf8.RuneSelf && r != '_' && !isAlphaNum(byte(r)) && (r != '-' || p.pos == start) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return nil, fmt.Errorf("expected a member name")
+	}
+	if c := p.src[start]; c >= '0' && c <= '9' {
+		p.pos = start
+
+		return nil, fmt.Errorf("member names cannot start with a digit")
+	}
+
+	return nameSelector(p.src[start:p.pos]), nil
+}
+
+func isAlphaNum(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// parseBracket parses a bracketed selection.
+func (p *pathParser) parseBracket() ([]selector, error) {
+	p.pos++ // [
+	var selectors []selector
+	for {
+		p.skipSpaces()
+		sel, err := p.parseSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, sel)
+		p.skipSpaces()
+		if p.consume("]") {
+			return selectors, nil
+		}
+		if !p.consume(",") {
+			return nil, fmt.Errorf("expected , or ]")
+		}
+	}
+}
+
+func (p *pathParser) parseSelector() (selector, error) {
+	switch c := p.peek(); {
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		return nameSelector(s), nil
+	case c == '*':
+		p.pos++
+
+		return wildcardSelector{}, nil
+	case c == '?':
+		p.pos++
+		p.skipSpaces()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		return filterSelector{expr: expr}, nil
+	case c == '-' || c >= '0' && c <= '9':
+		i, err := p.parseInt()
+		if err != nil {
+			return nil, err
+		}
+		if p.peek() == ':' {
+			return nil, fmt.Errorf("array slices are not supported")
+		}
+
+		return indexSelector(i), nil
+	default:
+		return nil, fmt.Errorf("unexpected %q in selector", c)
+	}
+}
+
+// maxIndex is the largest index magnitude of RFC 9535, that of I-JSON integers.
+const maxIndex = 1<<53 - 1
+
+// parseInt parses an index: 0, or a number without leading zeros.
+func (p *pathParser) parseInt() (int, error) {
+	start := p.pos
+	p.consume("-")
+	digits := p.pos
+	for !p.eof() && p.peek() >= '0' && p.peek() <= '9' {
+		p.pos++
+	}
+	s := p.src[start:p.pos]
+	if p.pos > digits+1 && p.src[digits] == '0' || s == "-0" {
+		return 0, fmt.Errorf("invalid index %s", s)
+	}
+	i, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid index %s", s)
+	}
+	if i > maxIndex || i < -maxIndex {
+		return 0, fmt.Errorf("index %s out of range", s)
+	}
+
+	return i, nil
+}
+
+// parseString parses a single- or double-quoted string literal. Only the quote
+// of the literal, the backslash, the slash and the escapes of JSON can be
+// escaped, and control characters must be.
+func (p *pathParser) parseString() (string, error) {
+	quote := p.peek()
+	p.pos++
+
+	var sb strings.Builder
+	for !p.eof() {
+		c := p.peek()
+		p.pos++
+		switch {
+		case c == quote:
+			return sb.String(), nil
+		case c < 0x20:
+			return "", fmt.Errorf("unescaped control character in string")
+		case c != '\\':
+			sb.WriteByte(c)
+		case p.eof():
+			return "", fmt.Errorf("unterminated string")
+		default:
+			esc := p.peek()
+			p.pos++
+			switch esc {
+			case quote, '\\', '/':
+				sb.WriteByte(esc)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				r, err := p.parseUnicodeEscape()
+				if err != nil {
+					return "", err
+				}
+				sb.WriteRune(r)
+			default:
+				return "", fmt.Errorf("invalid escape \\%c", esc)
+			}
+		}
+	}
+
+	return "", fmt.Errorf("unterminated string")
+}
+
+// parseUnicodeEscape parses the hexadecimal digits of a \u escape, followed by
+// those of the low surrogate of a surrogate pair.
+func (p *pathParser) parseUnicodeEscape() (rune, error) {
+	r, err := p.parseHex4()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case utf16.IsSurrogate(r) && r < 0xDC00:
+		if !p.consume(`\u`) {
+			return 0, fmt.Errorf("missing low surrogate")
+		}
+		low, err := p.parseHex4()
+		if err != nil {
+			return 0, err
+		}
+		if r = utf16.DecodeRune(r, low); r == utf8.RuneError {
+			return 0, fmt.Errorf("invalid low surrogate")
+		}
+	case utf16.IsSurrogate(r):
+		return 0, fmt.Errorf("unpaired low surrogate")
+	}
+
+	return r, nil
+}
+
+func (p *pathParser) parseHex4() (rune, error) {
+	if p.pos+4 > len(p.src) {
+		return 0, fmt.Errorf("invalid unicode escape")
+	}
+	r, err := strconv.ParseUint(p.src[p.pos:p.pos+4], 16, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid unicode escape: %w", err)
+	}
+	p.pos += 4
+
+	return rune(r), nil
+}
+
+func (p *pathParser) parseOr() (logicalExpr, error) {
+	var operands orExpr
+	for {
+		expr, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, expr)
+		p.skipSpaces()
+		if !p.consume("||") {
+			break
+		}
+		p.skipSpaces()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return operands, nil
+}
+
+func (p *pathParser) parseAnd() (logicalExpr, error) {
+	var operands andExpr
+	for {
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, expr)
+		p.skipSpaces()
+		if !p.consume("&&") {
+			break
+		}
+		p.skipSpaces()
+	}
+	if len(operands) == 1 {
+		return operands[0], nil
+	}
+
+	return operands, nil
+}
+
+// parseUnary parses a negation, a parenthesized expression, a comparison or an
+// existence test. Only parenthesized expressions and existence tests can be
+// negated.
+func (p *pathParser) parseUnary() (logicalExpr, error) {
+	switch {
+	case p.consume("!"):
+		p.skipSpaces()
+		paren := p.peek() == '('
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := expr.(existsExpr); !ok && !paren {
+			return nil, fmt.Errorf("only existence tests and parenthesized expressions can be negated")
+		}
+
+		return notExpr{expr: expr}, nil
+	case p.consume("("):
+		p.skipSpaces()
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		p.skipSpaces()
+		if !p.consume(")") {
+			return nil, fmt.Errorf("expected )")
+		}
+
+		return expr, nil
+	}
+
+	left, err := p.parseComparable()
+	if err != nil {
+		return nil, err
+	}
+	p.skipSpaces()
+	for _, op := range []string{"==", "!=", "<=", ">=", "<", ">"} {
+		if p.consume(op) {
+			p.skipSpaces()
+			right, err := p.parseComparable()
+			if err != nil {
+				return nil, err
+			}
+			for _, operand := range []operand{left, right} {
+				if q, ok := operand.(*filterQuery); ok && !q.singular() {
+					return nil, fmt.Errorf("compared queries must select a single node")
+				}
+			}
+
+			return comparisonExpr{left: left, right: right, op: op}, nil
+		}
+	}
+
+	query, ok := left.(*filterQuery)
+	if !ok {
+		return nil, fmt.Errorf("expected a comparison")
+	}
+
+	return existsExpr{query: query}, nil
+}
+
+// numberLiteral matches the number literals of filters, without leading zeros.
+var numberLiteral = regexp.MustCompile(`^-?(0|[1-9][0-9]*)(\.[0-9]+)?([eE][-+]?[0-9]+)?`)
+
+func (p *pathParser) parseComparable() (operand, error) {
+	switch c := p.peek(); {
+	case c == '@' || c == '$':
+		p.pos++
+
+		return p.parseFilterQuery(c == '$')
+	case c == '\'' || c == '"':
+		s, err := p.parseString()
+		if err != nil {
+			return nil, err
+		}
+
+		return literal{v: s}, nil
+	case p.consume("true"):
+		return literal{v: true}, nil
+	case p.consume("false"):
+		return literal{v: false}, nil
+	case p.consume("null"):
+		return literal{v: nil}, nil
+	case c == '-' || c >= '0' && c <= '9':
+		num := numberLiteral.FindString(p.src[p.pos:])
+		if num == "" {
+			return nil, fmt.Errorf("invalid number")
+		}
+		f, err := strconv.ParseFloat(num, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number: %w", err)
+		}
+		p.pos += len(num)
+
+		return literal{v: f}, nil
+	default:
+		return nil, fmt.Errorf("unexpected %q in filter", c)
+	}
+}
+
+// parseFilterQuery parses the segments of a filter query.
+func (p *pathParser) parseFilterQuery(absolute bool) (*filterQuery, error) {
+	segments, err := p.parseSegments()
+	if err != nil {
+		return nil, err
+	}
+
+	return &filterQuery{absolute: absolute, path: Path{segments: segments}}, nil
+}
@@ -0,0 +1,239 @@
+package overlay
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPath_Select(t *testing.T) {
+	root, err := DecodeJSON([]byte(`{
+		"paths": {
+			"/users": {"get": {"operationId": "listUsers", "x-internal": true}, "post": {"operationId": "createUser"}},
+			"/orders": {"get": {"operationId": "listOrders", "x-rank": 2}}
+		},
+		"tags": [{"name": "users"}, {"name": "orders"}, {"name": "admin"}]
+	}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{`$.paths['/users'].get.operationId`, []string{`"listUsers"`}},
+		{`$.paths["/orders"].get.operationId`, []string{`"listOrders"`}},
+		{`$.paths.*.get.operationId`, []string{`"listUsers"`, `"listOrders"`}},
+		{`$.tags[0].name`, []string{`"users"`}},
+		{`$.tags[-1].name`, []string{`"admin"`}},
+		{`$.tags[0,2].name`, []string{`"users"`, `"admin"`}},
+		{`$.tags[?@.name == 'orders']`, []string{`{"name":"orders"}`}},
+		{`$.tags[?@.name != 'orders' && @.name != 'admin'].name`, []string{`"users"`}},
+		{`$.paths.*[?@.x-internal].operationId`, []string{`"listUsers"`}},
+		{`$.paths.*[?!@.x-internal].operationId`, []string{`"createUser"`, `"listOrders"`}},
+		{`$.paths.*[?@.x-rank >= 2 || @.operationId == 'createUser'].operationId`, []string{`"createUser"`, `"listOrders"`}},
+		{`$..operationId`, []string{`"listUsers"`, `"createUser"`, `"listOrders"`}},
+		{`$.paths.missing`, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectJSON(t, root, tt.expr))
+		})
+	}
+}
+
+// TestPath_Conformance runs the examples of RFC 9535 not using slices or
+// functions.
+func TestPath_Conformance(t *testing.T) {
+	root, err := DecodeJSON([]byte(`{
+		"a": [3, 5, 1, 2, 4, 6, {"b": "j"}, {"b": "k"}, {"b": {}}, {"b": "kilo"}],
+		"o": {"p": 1, "q": 2, "r": 3, "s": 5, "t": {"u": 6}},
+		"e": "f",
+		"k'ey": "quote",
+		"\ud834\udd1e": "clef",
+		"x-internal": true
+	}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		// Name selectors
+		{`$.o.p`, []string{`1`}},
+		{`$['o']["p"]`, []string{`1`}},
+		{`$["k'ey"]`, []string{`"quote"`}},
+		{`$['k\'ey']`, []string{`"quote"`}},
+		{`$['\u006b\u0027ey']`, []string{`"quote"`}},
+		{`$['\uD834\uDD1E']`, []string{`"clef"`}},
+		{`$.𝄞`, []string{`"clef"`}},
+		{`$.x-internal`, []string{`true`}},
+		{`$['o']['p','q']`, []string{`1`, `2`}},
+		{`$.o.missing`, nil},
+		{`$.a.b`, nil},
+		// Wildcard selectors
+		{`$.o.*`, []string{`1`, `2`, `3`, `5`, `{"u":6}`}},
+		{`$.o[*]`, []string{`1`, `2`, `3`, `5`, `{"u":6}`}},
+		{`$.o[*, 'p']`, []string{`1`, `2`, `3`, `5`, `{"u":6}`, `1`}},
+		{`$.e.*`, nil},
+		// Index selectors
+		{`$.a[1]`, []string{`5`}},
+		{`$.a[-2]`, []string{`{"b":{}}`}},
+		{`$.a[0, 0]`, []string{`3`, `3`}},
+		{`$.a[10]`, nil},
+		{`$.a[-11]`, nil},
+		{`$.o[0]`, nil},
+		// Descendant segments
+		{`$..u`, []string{`6`}},
+		{`$.o..*`, []string{`1`, `2`, `3`, `5`, `{"u":6}`, `6`}},
+		{`$..[0]`, []string{`3`}},
+		{`$..b`, []string{`"j"`, `"k"`, `{}`, `"kilo"`}},
+		// Whitespace
+		{`$ .o [ 'p' , 'q' ]`, []string{`1`, `2`}},
+		{`$.a[? @.b == 'kilo' ]`, []string{`{"b":"kilo"}`}},
+		// Filter selectors
+		{`$.a[?@.b == 'kilo']`, []string{`{"b":"kilo"}`}},
+		{`$.a[?(@.b == 'kilo')]`, []string{`{"b":"kilo"}`}},
+		{`$.a[?@>3.5]`, []string{`5`, `4`, `6`}},
+		{`$.a[?@.b]`, []string{`{"b":"j"}`, `{"b":"k"}`, `{"b":{}}`, `{"b":"kilo"}`}},
+		{`$[?@.*].u`, nil},
+		{`$[?@.* && @.p]`, []string{`{"p":1,"q":2,"r":3,"s":5,"t":{"u":6}}`}},
+		{`$[?@[?@.b]][0]`, []string{`3`}},
+		{`$.o[?@<3, ?@<3]`, []string{`1`, `2`, `1`, `2`}},
+		{`$.a[?@<2 || @.b == "k"]`, []string{`1`, `{"b":"k"}`}},
+		{`$.o[?@>1 && @<4]`, []string{`2`, `3`}},
+		{`$.o[?@.u || @.x]`, []string{`{"u":6}`}},
+		{`$.a[?@.b == $.x]`, []string{`3`, `5`, `1`, `2`, `4`, `6`}},
+		{`$.a[?@ == @]`, []string{`3`, `5`, `1`, `2`, `4`, `6`, `{"b":"j"}`, `{"b":"k"}`, `{"b":{}}`, `{"b":"kilo"}`}},
+		{`$.a[?!@.b]`, []string{`3`, `5`, `1`, `2`, `4`, `6`}},
+		{`$.a[?!(@ > 2)]`, []string{`1`, `2`, `{"b":"j"}`, `{"b":"k"}`, `{"b":{}}`, `{"b":"kilo"}`}},
+		{`$.a[?@ == 5.0]`, []string{`5`}},
+		{`$.a[?@ == 5e0]`, []string{`5`}},
+		{`$.a[?@ == -1]`, nil},
+		{`$.a[?@.b == $.o.t.missing]`, []string{`3`, `5`, `1`, `2`, `4`, `6`}},
+		{`$.o[?@ == $.o.p]`, []string{`1`}},
+		{`$[?@.u == 6].u`, nil},
+		{`$.o[?@.u == 6].u`, []string{`6`}},
+		{`$..[?@.b == 'j']`, []string{`{"b":"j"}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectJSON(t, root, tt.expr))
+		})
+	}
+}
+
+// TestPath_Comparisons runs the comparison examples of RFC 9535.
+func TestPath_Comparisons(t *testing.T) {
+	root, err := DecodeJSON([]byte(`{"obj": {"x": "y"}, "arr": [2, 3], "same": {"x": "y"}}`))
+	require.NoError(t, err)
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`$.absent1 == $.absent2`, true},
+		{`$.absent1 <= $.absent2`, true},
+		{`$.absent == 'g'`, false},
+		{`$.absent1 != $.absent2`, false},
+		{`$.absent != 'g'`, true},
+		{`1 <= 2`, true},
+		{`1 > 2`, false},
+		{`13 == '13'`, false},
+		{`'a' <= 'b'`, true},
+		{`'a' > 'b'`, false},
+		{`$.obj == $.arr`, false},
+		{`$.obj != $.arr`, true},
+		{`$.obj == $.obj`, true},
+		{`$.obj == $.same`, true},
+		{`$.obj != $.obj`, false},
+		{`$.arr == $.arr`, true},
+		{`$.arr != $.arr`, false},
+		{`$.obj == 17`, false},
+		{`$.obj != 17`, true},
+		{`$.obj <= $.arr`, false},
+		{`$.obj < $.arr`, false},
+		{`$.obj <= $.obj`, true},
+		{`$.arr <= $.arr`, true},
+		{`1 <= $.arr`, false},
+		{`1 >= $.arr`, false},
+		{`1 > $.arr`, false},
+		{`1 < $.arr`, false},
+		{`true <= true`, true},
+		{`true > true`, false},
+		{`null == null`, true},
+		{`$.arr[0] == 2`, true},
+		{`$.arr[-1] >= 3`, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got := selectJSON(t, root, "$[?"+tt.expr+"]")
+			assert.Equal(t, tt.want, len(got) > 0)
+		})
+	}
+}
+
+// selectJSON returns the JSON encodings of the nodes selected by a query.
+func selectJSON(t *testing.T, root any, expr string) []string {
+	t.Helper()
+
+	path, err := Compile(expr)
+	require.NoError(t, err)
+
+	var got []string
+	for _, n := range path.Select(root) {
+		data, err := json.Marshal(n.Value)
+		require.NoError(t, err)
+		got = append(got, string(data))
+	}
+
+	return got
+}
+
+func TestCompile_Invalid(t *testing.T) {
+	for _, expr := range []string{
+		``,
+		`paths`,
+		`$.`,
+		`$[`,
+		`$['unterminated]`,
+		`$.tags[1:2]`,
+		`$.tags[?@.name ==]`,
+		`$.paths trailing`,
+		`$.paths `,
+		`$.1st`,
+		`$.-name`,
+		`$. name`,
+		`$.tags[01]`,
+		`$.tags[-0]`,
+		`$.tags[9007199254740992]`,
+		`$.tags[-]`,
+		`$['\q']`,
+		`$["\'"]`,
+		`$['\uD834']`,
+		`$['\uDD1E']`,
+		`$['\uD834\u0041']`,
+		"$['tab\t']",
+		`$[?@.name == 01]`,
+		`$[?@.name == 1.]`,
+		`$[?@.name == .5]`,
+		`$[?@.name == {}]`,
+		`$[?!@.name == 'users']`,
+		`$[?!!@.name]`,
+		`$[?'users']`,
+		`$[?@.* == 1]`,
+		`$[?@..name == 'users']`,
+		`$[?@['name', 'id'] == 1]`,
+		`$[?@[?@.name] == 1]`,
+		`$[?(@.name == 'users']`,
+	} {
+		t.Run(expr, func(t *testing.T) {
+			_, err := Compile(expr)
+			assert.Error(t, err)
+		})
+	}
+}
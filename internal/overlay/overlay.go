@@ -0,0 +1,206 @@
+// Package overlay applies OpenAPI Overlay documents (https://spec.openapis.org/overlay/v1.0.0),
+// JSON Patches (RFC 6902) and JSON Merge Patches (RFC 7386) to serialized specifications.
+package overlay
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Document is a parsed Overlay document.
+type Document struct {
+	Title   string
+	Version string
+
+	// Extends is the URI of the document the overlay was written for, if any.
+	Extends string
+
+	Actions []Action
+}
+
+// Action is an action of an Overlay document: it updates or removes the nodes
+// selected by its target.
+type Action struct {
+	Target      string
+	Description string
+	Update      any
+	Remove      bool
+
+	path *Path
+}
+
+// Parse parses an Overlay document, in YAML or JSON, and compiles its targets.
+func Parse(data []byte) (*Document, error) {
+	var raw struct {
+		Overlay string `yaml:"overlay"`
+		Info    struct {
+			Title   string `yaml:"title"`
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+		Extends string `yaml:"extends"`
+		Actions []struct {
+			Target      string    `yaml:"target"`
+			Description string    `yaml:"description"`
+			Update      yaml.Node `yaml:"update"`
+			Remove      bool      `yaml:"remove"`
+		} `yaml:"actions"`
+	}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse overlay: %w", err)
+	}
+
+	switch {
+	case !strings.HasPrefix(raw.Overlay, "1."):
+		return nil, fmt.Errorf("unsupported overlay version %q (supported: 1.x)", raw.Overlay)
+	case raw.Info.Title == "" || raw.Info.Version == "":
+		return nil, errors.New("overlay info title and version are required")
+	case len(raw.Actions) == 0:
+		return nil, errors.New("overlay has no actions")
+	}
+
+	doc := &Document{Title: raw.Info.Title, Version: raw.Info.Version, Extends: raw.Extends}
+	for i, a := range raw.Actions {
+		path, err := Compile(a.Target)
+		if err != nil {
+			return nil, fmt.Errorf("action %d: %w", i, err)
+		}
+		action := Action{Target: a.Target, Description: a.Description, Remove: a.Remove, path: path}
+		if a.Update.Kind != 0 {
+			if action.Update, err = fromYAML(&a.Update); err != nil {
+				return nil, fmt.Errorf("action %d: invalid update: %w", i, err)
+			}
+		}
+		doc.Actions = append(doc.Actions, action)
+	}
+
+	return doc, nil
+}
+
+// Apply applies the actions of the document in order to a decoded document
+// (see DecodeJSON). Actions whose target selects nothing are ignored.
+func (d *Document) Apply(root any) error {
+	for i, a := range d.Actions {
+		nodes := a.path.Select(root)
+		var err error
+		if a.Remove {
+			remove(nodes)
+		} else if a.Update != nil {
+			err = update(nodes, a.Update)
+		}
+		if err != nil {
+			return fmt.Errorf("action %d (%s): %w", i, a.Target, err)
+		}
+	}
+
+	return nil
+}
+
+// update merges an update into objects, appends it to arrays and replaces
+// other values with it.
+func update(nodes []Node, value any) error {
+	for _, n := range nodes {
+		switch target := n.Value.(type) {
+		case *Object:
+			obj, ok := value.(*Object)
+			if !ok {
+				return errors.New("update of an object must be an object")
+			}
+			merge(target, obj)
+		case *Array:
+			target.Items = append(target.Items, clone(value))
+		default:
+			if n.parent == nil {
+				return errors.New("cannot replace the root")
+			}
+			n.set(clone(value))
+		}
+	}
+
+	return nil
+}
+
+// merge merges src into dst recursively: objects are merged, arrays concatenated
+// and other values replaced.
+func merge(dst, src *Object) {
+	for _, key := range src.keys {
+		value := src.values[key]
+		existing, _ := dst.Get(key)
+		switch existing := existing.(type) {
+		case *Object:
+			if obj, ok := value.(*Object); ok {
+				merge(existing, obj)
+
+				continue
+			}
+		case *Array:
+			if arr, ok := value.(*Array); ok {
+				existing.Items = append(existing.Items, clone(arr).(*Array).Items...) //nolint:forcetypeassert // clone keeps types
+
+				continue
+			}
+		}
+		dst.Set(key, clone(value))
+	}
+}
+
+// remove removes nodes from their parents. Array items are removed from the
+// last, so that the indices of the others stay valid.
+func remove(nodes []Node) {
+	indices := make(map[*Array][]int)
+	for _, n := range nodes {
+		switch p := n.parent.(type) {
+		case *Object:
+			p.Delete(n.key)
+		case *Array:
+			if !slices.Contains(indices[p], n.index) {
+				indices[p] = append(indices[p], n.index)
+			}
+		}
+	}
+	for arr, idx := range indices {
+		slices.Sort(idx)
+		for _, i := range slices.Backward(idx) {
+			arr.Items = slices.Delete(arr.Items, i, i+1)
+		}
+	}
+}
+
+// Transform is a change of a decoded document: an overlay or a patch.
+type Transform interface {
+	transform(root any) (any, error)
+}
+
+// transform applies the document and returns the result.
+func (d *Document) transform(root any) (any, error) {
+	if err := d.Apply(root); err != nil {
+		return nil, fmt.Errorf("overlay %s %s: %w", d.Title, d.Version, err)
+	}
+
+	return root, nil
+}
+
+// ApplyJSON applies transforms in order to a JSON document, preserving the order
+// of its members, and returns the indented result.
+func ApplyJSON(data []byte, transforms ...Transform) ([]byte, error) {
+	root, err := DecodeJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	for _, t := range transforms {
+		if root, err = t.transform(root); err != nil {
+			return nil, err
+		}
+	}
+
+	out, err := json.MarshalIndent(root, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal spec: %w", err)
+	}
+
+	return out, nil
+}
@@ -0,0 +1,102 @@
+package overlay
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spec = `{
+  "openapi": "3.1.2",
+  "info": {"title": "API", "version": "1.0.0"},
+  "paths": {
+    "/users": {
+      "get": {"operationId": "listUsers", "tags": ["users"], "responses": {"200": {"description": "OK"}}},
+      "delete": {"operationId": "purgeUsers", "x-internal": true, "responses": {"204": {"description": "No Content"}}}
+    }
+  }
+}`
+
+func TestApplyJSON(t *testing.T) {
+	doc, err := Parse([]byte(`
+overlay: 1.0.0
+info:
+  title: Docs
+  version: 1.0.0
+actions:
+  - target: $.info
+    update:
+      description: Public API.
+      x-logo:
+        url: https://example.com/logo.png
+  - target: $.paths.*.get
+    update:
+      summary: List users
+      tags: [public]
+  - target: $.paths.*[?@.x-internal == true]
+    remove: true
+  - target: $.info.title
+    update: Users API
+`))
+	require.NoError(t, err)
+
+	out, err := ApplyJSON([]byte(spec), doc)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"openapi": "3.1.2",
+		"info": {"title": "Users API", "version": "1.0.0", "description": "Public API.", "x-logo": {"url": "https://example.com/logo.png"}},
+		"paths": {
+			"/users": {
+				"get": {"operationId": "listUsers", "tags": ["users", "public"], "responses": {"200": {"description": "OK"}}, "summary": "List users"}
+			}
+		}
+	}`, string(out))
+	assert.Less(t, strings.Index(string(out), `"openapi"`), strings.Index(string(out), `"info"`), "member order must be preserved")
+}
+
+func TestApplyJSON_RemovesArrayItems(t *testing.T) {
+	doc, err := Parse([]byte(`{
+		"overlay": "1.0.0",
+		"info": {"title": "Tags", "version": "1"},
+		"actions": [{"target": "$.tags[?@.name != 'keep']", "remove": true}]
+	}`))
+	require.NoError(t, err)
+
+	out, err := ApplyJSON([]byte(`{"tags": [{"name": "a"}, {"name": "keep"}, {"name": "b"}]}`), doc)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"tags": [{"name": "keep"}]}`, string(out))
+}
+
+func TestApplyJSON_InvalidUpdate(t *testing.T) {
+	doc, err := Parse([]byte(`
+overlay: 1.0.0
+info: {title: Bad, version: "1"}
+actions:
+  - target: $.info
+    update: text
+`))
+	require.NoError(t, err)
+
+	_, err = ApplyJSON([]byte(spec), doc)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "action 0 ($.info)")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"version":    "overlay: 2.0.0\ninfo: {title: T, version: '1'}\nactions: [{target: $, remove: true}]",
+		"info":       "overlay: 1.0.0\ninfo: {title: T}\nactions: [{target: $, remove: true}]",
+		"no actions": "overlay: 1.0.0\ninfo: {title: T, version: '1'}",
+		"target":     "overlay: 1.0.0\ninfo: {title: T, version: '1'}\nactions: [{target: 'paths', remove: true}]",
+		"syntax":     "overlay: [",
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			assert.Error(t, err)
+		})
+	}
+}
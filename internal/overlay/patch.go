@@ -0,0 +1,373 @@
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Patch is a JSON Patch (RFC 6902) or a JSON Merge Patch (RFC 7386) document.
+type Patch struct {
+	ops   []patchOp
+	merge *Object
+}
+
+// patchOp is an operation of a JSON Patch.
+type patchOp struct {
+	op    string
+	path  string
+	from  string
+	value any
+}
+
+// ParsePatch parses a patch: an array of operations is a JSON Patch, an object
+// is a JSON Merge Patch.
+func ParsePatch(data []byte) (*Patch, error) {
+	doc, err := DecodeJSON(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse patch: %w", err)
+	}
+
+	switch doc := doc.(type) {
+	case *Object:
+		return &Patch{merge: doc}, nil
+	case *Array:
+		p := &Patch{}
+		for i, item := range doc.Items {
+			op, err := parsePatchOp(item)
+			if err != nil {
+				return nil, fmt.Errorf("patch operation %d: %w", i, err)
+			}
+			p.ops = append(p.ops, op)
+		}
+
+		return p, nil
+	default:
+		return nil, errors.New("patch must be an array of operations (JSON Patch) or an object (JSON Merge Patch)")
+	}
+}
+
+// parsePatchOp parses and checks an operation of a JSON Patch.
+func parsePatchOp(item any) (patchOp, error) {
+	obj, ok := item.(*Object)
+	if !ok {
+		return patchOp{}, errors.New("operation must be an object")
+	}
+
+	str := func(key string) (string, bool, error) {
+		v, ok := obj.Get(key)
+		if !ok {
+			return "", false, nil
+		}
+		s, isString := v.(string)
+		if !isString {
+			return "", true, fmt.Errorf("%s must be a string", key)
+		}
+
+		return s, true, nil
+	}
+
+	var op patchOp
+	var found bool
+	var err error
+	if op.op, found, err = str("op"); err != nil || !found {
+		return op, errors.Join(err, errors.New("op is required"))
+	}
+	if op.path, found, err = str("path"); err != nil || !found {
+		return op, errors.Join(err, errors.New("path is required"))
+	}
+	if _, err := splitPointer(op.path); err != nil {
+		return op, err
+	}
+
+	switch op.op {
+	case "add", "replace", "test":
+		if op.value, found = obj.Get("value"); !found {
+			return op, fmt.Errorf("%s requires a value", op.op)
+		}
+	case "move", "copy":
+		if op.from, found, err = str("from"); err != nil || !found {
+			return op, errors.Join(err, fmt.Errorf("%s requires from", op.op))
+		}
+		if _, err := splitPointer(op.from); err != nil {
+			return op, err
+		}
+	case "remove":
+	default:
+		return op, fmt.Errorf("unknown op %q (valid: add, remove, replace, move, copy, test)", op.op)
+	}
+
+	return op, nil
+}
+
+// transform applies the patch to a decoded document and returns the result.
+func (p *Patch) transform(root any) (any, error) {
+	if p.merge != nil {
+		return mergePatch(root, p.merge), nil
+	}
+
+	for i, op := range p.ops {
+		var err error
+		if root, err = op.apply(root); err != nil {
+			return nil, fmt.Errorf("patch operation %d (%s %s): %w", i, op.op, op.path, err)
+		}
+	}
+
+	return root, nil
+}
+
+// apply applies the operation to a document and returns the result.
+func (op patchOp) apply(root any) (any, error) {
+	switch op.op {
+	case "add":
+		return add(root, op.path, clone(op.value))
+	case "remove":
+		_, root, err := take(root, op.path)
+
+		return root, err
+	case "replace":
+		if _, err := get(root, op.path); err != nil {
+			return nil, err
+		}
+		_, root, _ = take(root, op.path)
+
+		return add(root, op.path, clone(op.value))
+	case "move":
+		// A location cannot be moved into one of its children (RFC 6902, section 4.4)
+		if isProperPrefix(op.from, op.path) {
+			return nil, fmt.Errorf("cannot move %q into itself", op.from)
+		}
+		value, root, err := take(root, op.from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+
+		return add(root, op.path, value)
+	case "copy":
+		value, err := get(root, op.from)
+		if err != nil {
+			return nil, fmt.Errorf("from: %w", err)
+		}
+
+		return add(root, op.path, clone(value))
+	default: // test
+		value, err := get(root, op.path)
+		if err != nil {
+			return nil, err
+		}
+		if !deepEqual(value, op.value) {
+			return nil, errors.New("test failed: value differs")
+		}
+
+		return root, nil
+	}
+}
+
+// isProperPrefix reports whether the JSON Pointer prefix references an ancestor of
+// the location referenced by pointer.
+func isProperPrefix(prefix, pointer string) bool {
+	ancestor, _ := splitPointer(prefix)
+	tokens, _ := splitPointer(pointer)
+
+	return len(ancestor) < len(tokens) && slices.Equal(ancestor, tokens[:len(ancestor)])
+}
+
+// pointerUnescaper unescapes the reference tokens of JSON Pointers.
+var pointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+
+// splitPointer splits a JSON Pointer (RFC 6901) into unescaped reference tokens.
+func splitPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, fmt.Errorf("invalid JSON pointer %q: must start with /", pointer)
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = pointerUnescaper.Replace(token)
+	}
+
+	return tokens, nil
+}
+
+// get returns the value a pointer refers to.
+func get(root any, pointer string) (any, error) {
+	tokens, _ := splitPointer(pointer)
+	v := root
+	for _, token := range tokens {
+		child, err := childOf(v, token)
+		if err != nil {
+			return nil, err
+		}
+		v = child
+	}
+
+	return v, nil
+}
+
+// parentOf returns the container holding the value a pointer refers to, and the
+// last token of the pointer, which must not be empty.
+func parentOf(root any, pointer string) (any, string, error) {
+	tokens, _ := splitPointer(pointer)
+	last := len(tokens) - 1
+	parent := root
+	for _, token := range tokens[:last] {
+		child, err := childOf(parent, token)
+		if err != nil {
+			return nil, "", err
+		}
+		parent = child
+	}
+
+	return parent, tokens[last], nil
+}
+
+// childOf returns the member or item of a container named by a token.
+func childOf(v any, token string) (any, error) {
+	switch v := v.(type) {
+	case *Object:
+		child, ok := v.Get(token)
+		if !ok {
+			return nil, fmt.Errorf("member %q not found", token)
+		}
+
+		return child, nil
+	case *Array:
+		i, err := arrayIndex(token, len(v.Items)-1)
+		if err != nil {
+			return nil, err
+		}
+
+		return v.Items[i], nil
+	default:
+		return nil, fmt.Errorf("cannot select %q in a value that is not an object or an array", token)
+	}
+}
+
+// arrayIndex parses an array index token, which must be at most maxIndex.
+func arrayIndex(token string, maxIndex int) (int, error) {
+	i, err := strconv.Atoi(token)
+	if err != nil || i < 0 || (len(token) > 1 && token[0] == '0') {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	if i > maxIndex {
+		return 0, fmt.Errorf("array index %d out of range", i)
+	}
+
+	return i, nil
+}
+
+// add adds a value at a pointer: it sets an object member, inserts into an
+// array ("-" appends) or, for the empty pointer, replaces the document.
+func add(root any, pointer string, value any) (any, error) {
+	if pointer == "" {
+		return value, nil
+	}
+	parent, token, err := parentOf(root, pointer)
+	if err != nil {
+		return nil, err
+	}
+
+	switch parent := parent.(type) {
+	case *Object:
+		parent.Set(token, value)
+	case *Array:
+		i := len(parent.Items)
+		if token != "-" {
+			if i, err = arrayIndex(token, len(parent.Items)); err != nil {
+				return nil, err
+			}
+		}
+		parent.Items = append(parent.Items[:i], append([]any{value}, parent.Items[i:]...)...)
+	default:
+		return nil, fmt.Errorf("cannot add %q to a value that is not an object or an array", token)
+	}
+
+	return root, nil
+}
+
+// take removes the value at a pointer and returns it with the resulting document.
+func take(root any, pointer string) (any, any, error) {
+	if pointer == "" {
+		return root, nil, nil
+	}
+	value, err := get(root, pointer)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, token, _ := parentOf(root, pointer)
+
+	switch parent := parent.(type) {
+	case *Object:
+		parent.Delete(token)
+	case *Array:
+		i, _ := arrayIndex(token, len(parent.Items)-1)
+		parent.Items = append(parent.Items[:i], parent.Items[i+1:]...)
+	}
+
+	return value, root, nil
+}
+
+// mergePatch applies a JSON Merge Patch object to a value: null members are
+// removed, object members merged and other members replaced.
+func mergePatch(target any, patch *Object) any {
+	obj, ok := target.(*Object)
+	if !ok {
+		obj = NewObject()
+	}
+	for _, key := range patch.Keys() {
+		value, _ := patch.Get(key)
+		if value == nil {
+			obj.Delete(key)
+
+			continue
+		}
+		if sub, ok := value.(*Object); ok {
+			existing, _ := obj.Get(key)
+			obj.Set(key, mergePatch(existing, sub))
+
+			continue
+		}
+		obj.Set(key, clone(value))
+	}
+
+	return obj
+}
+
+// deepEqual compares two JSON values: object members regardless of their order
+// and numbers by value.
+func deepEqual(a, b any) bool {
+	switch a := a.(type) {
+	case *Object:
+		b, ok := b.(*Object)
+		if !ok || len(a.Keys()) != len(b.Keys()) {
+			return false
+		}
+		for _, key := range a.Keys() {
+			x, _ := a.Get(key)
+			y, found := b.Get(key)
+			if !found || !deepEqual(x, y) {
+				return false
+			}
+		}
+
+		return true
+	case *Array:
+		b, ok := b.(*Array)
+		if !ok || len(a.Items) != len(b.Items) {
+			return false
+		}
+		for i := range a.Items {
+			if !deepEqual(a.Items[i], b.Items[i]) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return equal(a, true, b, true)
+	}
+}
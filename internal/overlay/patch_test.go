@@ -0,0 +1,102 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPatch_JSONPatch(t *testing.T) {
+	tests := []struct {
+		name  string
+		doc   string
+		patch string
+		want  string
+	}{
+		{"add member", `{"foo": "bar"}`, `[{"op": "add", "path": "/baz", "value": "qux"}]`, `{"foo": "bar", "baz": "qux"}`},
+		{"add item", `{"foo": ["bar", "baz"]}`, `[{"op": "add", "path": "/foo/1", "value": "qux"}]`, `{"foo": ["bar", "qux", "baz"]}`},
+		{"append item", `{"foo": ["bar"]}`, `[{"op": "add", "path": "/foo/-", "value": ["abc"]}]`, `{"foo": ["bar", ["abc"]]}`},
+		{"remove member", `{"baz": "qux", "foo": "bar"}`, `[{"op": "remove", "path": "/baz"}]`, `{"foo": "bar"}`},
+		{"remove item", `{"foo": ["bar", "qux", "baz"]}`, `[{"op": "remove", "path": "/foo/1"}]`, `{"foo": ["bar", "baz"]}`},
+		{"replace", `{"baz": "qux", "foo": "bar"}`, `[{"op": "replace", "path": "/baz", "value": "boo"}]`, `{"baz": "boo", "foo": "bar"}`},
+		{"move", `{"foo": {"bar": "baz", "waldo": "fred"}, "qux": {"corge": "grault"}}`, `[{"op": "move", "from": "/foo/waldo", "path": "/qux/thud"}]`,
+			`{"foo": {"bar": "baz"}, "qux": {"corge": "grault", "thud": "fred"}}`},
+		{"move to sibling", `{"a": {"b": 1}}`, `[{"op": "move", "from": "/a", "path": "/ab"}]`, `{"ab": {"b": 1}}`},
+		{"move in place", `{"a": 1}`, `[{"op": "move", "from": "/a", "path": "/a"}]`, `{"a": 1}`},
+		{"copy", `{"a": {"b": 1}}`, `[{"op": "copy", "from": "/a", "path": "/c"}]`, `{"a": {"b": 1}, "c": {"b": 1}}`},
+		{"test", `{"baz": "qux", "foo": ["a", 2, "c"]}`, `[{"op": "test", "path": "/baz", "value": "qux"}, {"op": "test", "path": "/foo/1", "value": 2.0}]`,
+			`{"baz": "qux", "foo": ["a", 2, "c"]}`},
+		{"escaped path", `{"paths": {"/users": {}}}`, `[{"op": "add", "path": "/paths/~1users/x-a~0b", "value": true}]`, `{"paths": {"/users": {"x-a~b": true}}}`},
+		{"replace root", `{"a": 1}`, `[{"op": "replace", "path": "", "value": {"b": 2}}]`, `{"b": 2}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePatch([]byte(tt.patch))
+			require.NoError(t, err)
+
+			out, err := ApplyJSON([]byte(tt.doc), p)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(out))
+		})
+	}
+}
+
+func TestPatch_JSONPatchErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+		want  string
+	}{
+		{"missing member", `[{"op": "replace", "path": "/info/summary", "value": "x"}]`, `patch operation 0 (replace /info/summary): member "summary" not found`},
+		{"missing parent", `[{"op": "add", "path": "/nope/x", "value": 1}]`, `patch operation 0 (add /nope/x): member "nope" not found`},
+		{"index out of range", `[{"op": "add", "path": "/tags/5", "value": "x"}]`, `patch operation 0 (add /tags/5): array index 5 out of range`},
+		{"failed test", `[{"op": "remove", "path": "/tags/0"}, {"op": "test", "path": "/info/title", "value": "Other"}]`,
+			`patch operation 1 (test /info/title): test failed`},
+		{"move into child", `[{"op": "move", "from": "/info", "path": "/info/contact"}]`,
+			`patch operation 0 (move /info/contact): cannot move "/info" into itself`},
+		{"move root", `[{"op": "move", "from": "", "path": "/tags/0"}]`,
+			`patch operation 0 (move /tags/0): cannot move "" into itself`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := ParsePatch([]byte(tt.patch))
+			require.NoError(t, err)
+
+			_, err = ApplyJSON([]byte(`{"info": {"title": "API"}, "tags": ["a"]}`), p)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestPatch_MergePatch(t *testing.T) {
+	p, err := ParsePatch([]byte(`{"a": "z", "c": {"f": null}, "e": [1], "g": {"h": 1}}`))
+	require.NoError(t, err)
+
+	out, err := ApplyJSON([]byte(`{"a": "b", "c": {"d": "e", "f": "g"}, "e": [0, 1]}`), p)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"a": "z", "c": {"d": "e"}, "e": [1], "g": {"h": 1}}`, string(out))
+}
+
+func TestParsePatch_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"scalar":       `"text"`,
+		"syntax":       `[{`,
+		"not object":   `[1]`,
+		"missing op":   `[{"path": "/a"}]`,
+		"unknown op":   `[{"op": "merge", "path": "/a"}]`,
+		"missing path": `[{"op": "remove"}]`,
+		"bad pointer":  `[{"op": "remove", "path": "a"}]`,
+		"no value":     `[{"op": "add", "path": "/a"}]`,
+		"no from":      `[{"op": "copy", "path": "/a"}]`,
+	}
+	for name, patch := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParsePatch([]byte(patch))
+			assert.Error(t, err)
+		})
+	}
+}
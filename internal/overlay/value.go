@@ -0,0 +1,222 @@
+package overlay
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Object is a JSON object preserving the order of its members, so that overlaid
+// documents keep the layout of the generated specification.
+type Object struct {
+	keys   []string
+	values map[string]any
+}
+
+// NewObject returns an empty object.
+func NewObject() *Object {
+	return &Object{values: make(map[string]any)}
+}
+
+// Get returns the value of a member.
+func (o *Object) Get(key string) (any, bool) {
+	v, ok := o.values[key]
+
+	return v, ok
+}
+
+// Set sets the value of a member, appending it if new.
+func (o *Object) Set(key string, v any) {
+	if _, ok := o.values[key]; !ok {
+		o.keys = append(o.keys, key)
+	}
+	o.values[key] = v
+}
+
+// Delete removes a member.
+func (o *Object) Delete(key string) {
+	if _, ok := o.values[key]; !ok {
+		return
+	}
+	delete(o.values, key)
+	o.keys = slices.DeleteFunc(o.keys, func(k string) bool { return k == key })
+}
+
+// Keys returns the member names in order.
+func (o *Object) Keys() []string {
+	return o.keys
+}
+
+// MarshalJSON implements json.Marshaler, writing members in order.
+func (o *Object) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range o.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(o.values[key])
+		if err != nil {
+			return nil, fmt.Errorf("member %s: %w", key, err)
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+
+	return buf.Bytes(), nil
+}
+
+// Array is a JSON array, held by pointer to be modified in place.
+type Array struct {
+	Items []any
+}
+
+// MarshalJSON implements json.Marshaler.
+func (a *Array) MarshalJSON() ([]byte, error) {
+	if a.Items == nil {
+		return []byte("[]"), nil
+	}
+
+	return json.Marshal(a.Items)
+}
+
+// DecodeJSON decodes a JSON document into objects, arrays, strings, json.Number,
+// booleans and nil.
+func DecodeJSON(data []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	v, err := decodeJSONValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the document")
+	}
+
+	return v, nil
+}
+
+// decodeJSONValue decodes the next value of dec.
+func decodeJSONValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch tok {
+	case json.Delim('{'):
+		obj := NewObject()
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(key.(string), v) //nolint:forcetypeassert // object keys are strings
+		}
+		_, err = dec.Token()
+
+		return obj, err
+	case json.Delim('['):
+		arr := &Array{Items: []any{}}
+		for dec.More() {
+			v, err := decodeJSONValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr.Items = append(arr.Items, v)
+		}
+		_, err = dec.Token()
+
+		return arr, err
+	default:
+		return tok, nil
+	}
+}
+
+// fromYAML converts a YAML node to objects, arrays and scalars.
+func fromYAML(n *yaml.Node) (any, error) {
+	switch n.Kind {
+	case yaml.DocumentNode:
+		if len(n.Content) == 0 {
+			return nil, nil //nolint:nilnil // empty documents are null
+		}
+
+		return fromYAML(n.Content[0])
+	case yaml.AliasNode:
+		return fromYAML(n.Alias)
+	case yaml.MappingNode:
+		obj := NewObject()
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			v, err := fromYAML(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			obj.Set(n.Content[i].Value, v)
+		}
+
+		return obj, nil
+	case yaml.SequenceNode:
+		arr := &Array{Items: make([]any, 0, len(n.Content))}
+		for _, item := range n.Content {
+			v, err := fromYAML(item)
+			if err != nil {
+				return nil, err
+			}
+			arr.Items = append(arr.Items, v)
+		}
+
+		return arr, nil
+	case yaml.ScalarNode:
+		if n.Tag == "!!timestamp" {
+			// Dates are kept as written.
+			return n.Value, nil
+		}
+		var v any
+		if err := n.Decode(&v); err != nil {
+			return nil, fmt.Errorf("line %d: %w", n.Line, err)
+		}
+
+		return v, nil
+	default:
+		return nil, fmt.Errorf("line %d: unsupported YAML node", n.Line)
+	}
+}
+
+// clone returns a deep copy of a value, so that an update applied to several
+// targets does not share objects and arrays between them.
+func clone(v any) any {
+	switch v := v.(type) {
+	case *Object:
+		out := &Object{keys: slices.Clone(v.keys), values: maps.Clone(v.values)}
+		for key, value := range out.values {
+			out.values[key] = clone(value)
+		}
+
+		return out
+	case *Array:
+		out := &Array{Items: make([]any, len(v.Items))}
+		for i, item := range v.Items {
+			out.Items[i] = clone(item)
+		}
+
+		return out
+	default:
+		return v
+	}
+}
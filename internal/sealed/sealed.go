@@ -0,0 +1,7 @@
+// Package sealed holds the markers of the option interfaces of the openapi
+// package that types of other packages implement. As the package is internal,
+// users cannot implement the interfaces.
+package sealed
+
+// ResponseOption marks the options of openapi.WithResponse.
+type ResponseOption struct{}
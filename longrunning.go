@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// Headers documented by LongRunning.
+const (
+	headerLocation          = "Location"
+	headerOperationLocation = "Operation-Location"
+)
+
+// operationLink is a link from a response to another operation of the API,
+// identified by its method and router path.
+type operationLink struct {
+	method      string
+	path        string
+	parameters  map[string]any
+	description string
+}
+
+// LongRunning documents the asynchronous request pattern of long-running
+// operations: start answers 202 Accepted with the status of the operation, and
+// the Location and Operation-Location headers pointing to the status operation,
+// a GET operation on statusPath returning the status. A "status" link ties the
+// 202 response to the status operation, passing the path parameters of statusPath
+// from the properties of the same name of the status.
+//
+// opts apply to the status operation. Path parameters of statusPath are documented
+// as strings unless its request struct declares them.
+//
+// Example:
+//
+//	type Job struct {
+//	    ID     string `json:"id"`
+//	    Status string `json:"status" validate:"oneof=running succeeded failed"`
+//	}
+//
+//	ops := openapi.LongRunning(
+//	    openapi.POST("/exports", openapi.WithRequest(CreateExport{})),
+//	    "/jobs/:id", Job{},
+//	    openapi.WithSummary("Get export status"),
+//	)
+func LongRunning(start Operation, statusPath string, status any, opts ...OperationDocOption) []Operation {
+	params := pathTemplateParam.FindAllStringSubmatch(convertPathToOpenAPI(statusPath), -1)
+
+	start = newOperation(start.Method, start.Path, append(slices.Clone(start.opts), func(d *operationDoc) {
+		WithResponse(http.StatusAccepted, status)(d)
+		for _, name := range []string{headerLocation, headerOperationLocation} {
+			d.addResponseHeader(http.StatusAccepted, name, &model.Header{
+				Description: "URL of the status of the operation",
+				Schema:      &model.Schema{Type: "string", Format: "uri-reference"},
+			})
+		}
+
+		link := operationLink{
+			method:      http.MethodGet,
+			path:        statusPath,
+			description: "Status of the operation",
+		}
+		for _, m := range params {
+			if link.parameters == nil {
+				link.parameters = make(map[string]any)
+			}
+			link.parameters[m[1]] = "$response.body#/" + m[1]
+		}
+		if d.ResponseLinks == nil {
+			d.ResponseLinks = make(map[int]map[string]operationLink)
+		}
+		d.ResponseLinks[http.StatusAccepted] = map[string]operationLink{"status": link}
+	})...)
+
+	statusOpts := []OperationDocOption{func(d *operationDoc) {
+		for _, m := range params {
+			d.Parameters = append(d.Parameters, model.Parameter{
+				Name:     m[1],
+				In:       "path",
+				Required: true,
+				Schema:   &model.Schema{Type: "string"},
+			})
+		}
+	}, WithResponse(http.StatusOK, status)}
+
+	return []Operation{start, GET(statusPath, append(statusOpts, opts...)...)}
+}
+
+// addResponseLinks adds the links declared with options to the responses of
+// their status codes, creating responses without body for undeclared ones.
+func (a *API) addResponseLinks(op *model.Operation, links map[int]map[string]operationLink) {
+	for status, byName := range links {
+		code := build.StatusKey(status)
+		resp := op.Responses[code]
+		if resp == nil {
+			resp = &model.Response{Description: build.StatusText(status)}
+			op.Responses[code] = resp
+		}
+		if resp.Links == nil {
+			resp.Links = make(map[string]*model.Link, len(byName))
+		}
+		for name, l := range byName {
+			resp.Links[name] = &model.Link{
+				OperationRef: util.Pointer("#/paths", a.openAPIPath(l.path), strings.ToLower(l.method)),
+				Parameters:   l.parameters,
+				Description:  l.description,
+			}
+		}
+	}
+}
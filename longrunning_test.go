@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type longRunningJob struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
+type longRunningExport struct {
+	Body struct {
+		Format string `json:"format"`
+	} `body:"structured"`
+}
+
+func TestLongRunning(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+
+		result, err := api.Generate(context.Background(), LongRunning(
+			POST("/exports", WithRequest(longRunningExport{})),
+			"/jobs/:id", longRunningJob{},
+			WithSummary("Get export status"),
+		)...)
+		require.NoError(t, err)
+
+		type response struct {
+			Headers map[string]struct {
+				Description string `json:"description"`
+			} `json:"headers"`
+			Content map[string]struct {
+				Schema struct {
+					Ref string `json:"$ref"`
+				} `json:"schema"`
+			} `json:"content"`
+			Links map[string]struct {
+				OperationRef string         `json:"operationRef"`
+				Parameters   map[string]any `json:"parameters"`
+			} `json:"links"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Summary    string `json:"summary"`
+				Parameters []struct {
+					Name     string `json:"name"`
+					In       string `json:"in"`
+					Required bool   `json:"required"`
+				} `json:"parameters"`
+				Responses map[string]response `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		accepted, ok := spec.Paths["/exports"]["post"].Responses["202"]
+		require.True(t, ok, "start operation must answer 202")
+		assert.Equal(t, "#/components/schemas/LongRunningJob", accepted.Content["application/json"].Schema.Ref)
+		assert.Contains(t, accepted.Headers, "Location")
+		assert.Contains(t, accepted.Headers, "Operation-Location")
+		assert.Equal(t, "#/paths/~1jobs~1{id}/get", accepted.Links["status"].OperationRef)
+		assert.Equal(t, map[string]any{"id": "$response.body#/id"}, accepted.Links["status"].Parameters)
+
+		status := spec.Paths["/jobs/{id}"]["get"]
+		assert.Equal(t, "Get export status", status.Summary)
+		require.Len(t, status.Parameters, 1)
+		assert.Equal(t, "id", status.Parameters[0].Name)
+		assert.Equal(t, "path", status.Parameters[0].In)
+		assert.True(t, status.Parameters[0].Required)
+		assert.Equal(t, "#/components/schemas/LongRunningJob", status.Responses["200"].Content["application/json"].Schema.Ref)
+	})
+}
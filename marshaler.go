@@ -0,0 +1,43 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+var (
+	jsonMarshalerType     = reflect.TypeFor[json.Marshaler]()
+	schemaTransformerType = reflect.TypeFor[hook.SchemaTransformer]()
+)
+
+// marshalerWarnings reports the component schemas generated from the fields of
+// json.Marshaler types, whose JSON may have another shape. Types implementing
+// hook.MarshalSchema are documented from the value it returns and are not
+// components; those transforming their schema are assumed to document it.
+func (a *API) marshalerWarnings(spec *model.Spec) debug.Warnings {
+	if spec.Components == nil {
+		return nil
+	}
+
+	var warns debug.Warnings
+	for _, name := range sortedNames(spec.Components.Schemas) {
+		t, ok := a.generator.TypeOf(name)
+		if !ok || !build.Implements(t, jsonMarshalerType) || build.Implements(t, schemaTransformerType) {
+			continue
+		}
+		warns.Append(debug.NewWarning(
+			debug.WarnMarshalerSchema,
+			util.Pointer(componentsPrefix+"schemas", name),
+			fmt.Sprintf("%s implements json.Marshaler but is documented from its fields; implement hook.MarshalSchema to document its JSON", t),
+		))
+	}
+
+	return warns
+}
@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+// marshalerVersion is marshaled to a string like "1.2" but documented from its fields.
+type marshalerVersion struct {
+	Major, Minor int
+}
+
+func (v marshalerVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(fmt.Sprintf("%d.%d", v.Major, v.Minor))
+}
+
+// marshalerDocumentedVersion documents its JSON with MarshalSchema.
+type marshalerDocumentedVersion struct {
+	marshalerVersion
+}
+
+func (marshalerDocumentedVersion) MarshalSchema() any {
+	return ""
+}
+
+type marshalerRelease struct {
+	Version    marshalerVersion           `json:"version"`
+	Compatible marshalerDocumentedVersion `json:"compatible"`
+}
+
+func TestMarshalerWarnings(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(), GET("/release", WithResponse(200, marshalerRelease{})))
+	require.NoError(t, err)
+
+	require.Len(t, result.Warnings, 1)
+	assert.Equal(t, debug.WarnMarshalerSchema, result.Warnings[0].Code())
+	assert.Equal(t, "#/components/schemas/MarshalerVersion", result.Warnings[0].Path())
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Type string `json:"type"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.Equal(t, "string", spec.Components.Schemas["MarshalerRelease"].Properties["compatible"].Type)
+}
@@ -62,22 +62,32 @@
 //	openapi:"deprecated"            // Field is deprecated
 //	openapi:"hidden"                // Field excluded from OpenAPI schema (but in JSON)
 //	openapi:"required"              // Override required status for docs only
+//	openapi:"allowEmptyValue"       // Query parameter may be empty (parameters only)
+//	openapi:"inlineMap"             // Map entries are members of the parent object (map fields only)
 //
 //	// Documentation
 //	openapi:"title=Field Title"
 //	openapi:"description=Detailed description"
 //	openapi:"format=date-time"      // OpenAPI format (date, date-time, email, uri, uuid, etc.)
+//	openapi:"descriptionKey=user.email"   // Description from the description catalog
+//	openapi:"description='Hello, world'"  // Values containing commas are quoted
+//	openapi:"description=It\\'s"          // A backslash escapes commas, quotes, backslashes and pipes
 //
 //	// Examples (pipe-separated for multiple values)
 //	openapi:"examples=value"        // Single example
 //	openapi:"examples=val1|val2"    // Multiple examples
 //
+//	// Reference replacing the schema of the field type
+//	openapi:"ref=#/components/schemas/LegacyThing"
+//
 //	// Extensions (must start with x-, valid at both field and struct level)
 //	openapi:"x-internal=true,x-category=admin"
 //
 //	// Struct-level options (on _ blank identifier field)
 //	openapi:"additionalProperties=false"           // Disallow additional properties
 //	openapi:"nullable=true"                        // Struct can be null
+//	openapi:"title=User,description=A user"        // Title and description of the struct schema
+//	openapi:"required=id|name"                     // Required properties, merged with required fields
 //	openapi:"additionalProperties=false,x-strict=true"  // Can combine with extensions
 //
 // # Validate Tag
@@ -87,6 +97,7 @@
 //	validate:"required"             -> Required=true, used as source of truth
 //	validate:"min=5,max=100"        -> Minimum=5, Maximum=100
 //	validate:"gt=0,lt=100"          -> ExclusiveMinimum=0, ExclusiveMaximum=100
+//	validate:"gte=0,lt=100"         -> Minimum=0, ExclusiveMaximum=100
 //	validate:"email"                -> Format="email" (can be overridden by openapi:"format=...")
 //	validate:"url"                  -> Format="uri"
 //	validate:"oneof=red green blue" -> Enum=["red","green","blue"]
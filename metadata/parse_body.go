@@ -0,0 +1,79 @@
+package metadata
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/talav/schema"
+	"github.com/talav/tagparser"
+)
+
+// Additional body types, documenting response bodies such as reports and exports,
+// declared as body:"text", body:"html" and body:"download", or as the as option of
+// file bodies, as in body:"file,as=download". They only affect documentation.
+const (
+	// BodyTypeText documents the body as plain text (text/plain).
+	BodyTypeText schema.BodyType = "text"
+
+	// BodyTypeHTML documents the body as an HTML document (text/html).
+	BodyTypeHTML schema.BodyType = "html"
+
+	// BodyTypeDownload documents the body as a binary attachment
+	// (application/octet-stream with a Content-Disposition header).
+	BodyTypeDownload schema.BodyType = "download"
+)
+
+// fileBodyTypes are the additional body types, by tag name.
+var fileBodyTypes = map[string]schema.BodyType{
+	string(BodyTypeText):     BodyTypeText,
+	string(BodyTypeHTML):     BodyTypeHTML,
+	string(BodyTypeDownload): BodyTypeDownload,
+}
+
+// ParseBodyTag parses a body tag and returns *schema.BodyMetadata.
+// Tag format: body:"structured|file|multipart|text|html|download", body:"file,as=text|html|download".
+//
+// Besides the body types of schema.ParseBodyTag, text, HTML and download bodies
+// document responses such as reports and exports:
+//   - text -> text/plain, string schema
+//   - html -> text/html, string schema
+//   - download -> application/octet-stream, binary schema and Content-Disposition header
+//
+// schema.ParseBodyTag, used by the default tag parsers of talav/schema, rejects
+// these body types. Structs also bound by talav/schema declare them with the as
+// option of file bodies instead, such as body:"file,as=text": talav/schema ignores
+// the option and binds them as file bodies.
+func ParseBodyTag(field reflect.StructField, index int, tagValue string) (any, error) {
+	tag, err := tagparser.ParseWithName(tagValue)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: failed to parse body tag: %w", field.Name, err)
+	}
+	if bodyType, ok := fileBodyTypes[tag.Name]; ok {
+		if _, ok := tag.Options["as"]; ok {
+			return nil, fmt.Errorf("field %s: the as option requires a file body, not %s", field.Name, bodyType)
+		}
+
+		return &schema.BodyMetadata{MapKey: field.Name, BodyType: bodyType}, nil
+	}
+
+	parsed, err := schema.ParseBodyTag(field, index, tagValue)
+	if err != nil {
+		return nil, err
+	}
+	as, ok := tag.Options["as"]
+	if !ok {
+		return parsed, nil
+	}
+
+	bm, _ := parsed.(*schema.BodyMetadata)
+	if bm.BodyType != schema.BodyTypeFile {
+		return nil, fmt.Errorf("field %s: the as option requires a file body, not %s", field.Name, bm.BodyType)
+	}
+	bodyType, ok := fileBodyTypes[as]
+	if !ok {
+		return nil, fmt.Errorf("field %s: invalid as option %q (must be 'text', 'html' or 'download')", field.Name, as)
+	}
+	bm.BodyType = bodyType
+
+	return bm, nil
+}
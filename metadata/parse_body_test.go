@@ -0,0 +1,63 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/talav/schema"
+)
+
+func TestParseBodyTag(t *testing.T) {
+	tests := []struct {
+		name     string
+		tagValue string
+		want     schema.BodyType
+		wantErr  bool
+
+		// openapiOnly tags are rejected by talav/schema
+		openapiOnly bool
+	}{
+		{name: "empty defaults to structured", tagValue: "", want: schema.BodyTypeStructured},
+		{name: "structured", tagValue: "structured", want: schema.BodyTypeStructured},
+		{name: "file", tagValue: "file", want: schema.BodyTypeFile},
+		{name: "multipart", tagValue: "multipart", want: schema.BodyTypeMultipart},
+		{name: "text", tagValue: "text", want: BodyTypeText, openapiOnly: true},
+		{name: "html", tagValue: "html", want: BodyTypeHTML, openapiOnly: true},
+		{name: "download", tagValue: "download", want: BodyTypeDownload, openapiOnly: true},
+		{name: "text as file", tagValue: "file,as=text", want: BodyTypeText},
+		{name: "html as file", tagValue: "file,as=html", want: BodyTypeHTML},
+		{name: "download as file", tagValue: "file,as=download", want: BodyTypeDownload},
+		{name: "unknown", tagValue: "yaml", wantErr: true},
+		{name: "as on a structured body", tagValue: "structured,as=text", wantErr: true},
+		{name: "as on a text body", tagValue: "text,as=html", wantErr: true},
+		{name: "unknown as", tagValue: "file,as=yaml", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.StructField{Name: "Body"}
+
+			result, err := ParseBodyTag(field, 0, tt.tagValue)
+
+			if tt.wantErr {
+				require.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			_, err = schema.ParseBodyTag(field, 0, tt.tagValue)
+			if tt.openapiOnly {
+				require.Error(t, err, "talav/schema rejects the tag")
+			} else {
+				require.NoError(t, err, "talav/schema must accept the tag")
+			}
+			bm, ok := result.(*schema.BodyMetadata)
+			require.True(t, ok, "result should be *schema.BodyMetadata")
+			assert.Equal(t, tt.want, bm.BodyType)
+			assert.Equal(t, "Body", bm.MapKey)
+		})
+	}
+}
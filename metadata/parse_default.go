@@ -1,8 +1,10 @@
 package metadata
 
 import (
+	"encoding"
 	"encoding/json"
 	"fmt"
+	"math"
 	"reflect"
 )
 
@@ -30,6 +32,9 @@ func ParseDefaultTag(field reflect.StructField, index int, tagValue string) (any
 	}, nil
 }
 
+// textUnmarshalerType is the type of encoding.TextUnmarshaler.
+var textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+
 // parseDefaultValue parses a default value string based on the Go field type.
 func parseDefaultValue(fieldType reflect.Type, value string) (any, error) {
 	// Dereference pointer types
@@ -43,6 +48,15 @@ func parseDefaultValue(fieldType reflect.Type, value string) (any, error) {
 		return value, nil
 	}
 
+	// Types loaded from text, such as time.Time, have string schemas
+	if reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+		if err := validateDefaultText(fieldType, value); err != nil {
+			return nil, err
+		}
+
+		return value, nil
+	}
+
 	// All other types require JSON format
 	var v any
 	if err := json.Unmarshal([]byte(value), &v); err != nil {
@@ -57,10 +71,32 @@ func parseDefaultValue(fieldType reflect.Type, value string) (any, error) {
 	return v, nil
 }
 
-// validateDefaultType validates that the parsed value matches the Go field type.
+// validateDefaultType validates that the parsed value matches the Go field type:
+// numbers must be in the range of the type, integers without fractions, and the
+// items of arrays and the values of maps must match their element type.
 func validateDefaultType(fieldType reflect.Type, value any) error {
+	if fieldType.Kind() == reflect.Ptr {
+		if value == nil {
+			return nil
+		}
+		fieldType = fieldType.Elem()
+	}
+
+	if fieldType.Kind() != reflect.String && reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+		text, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
+
+		return validateDefaultText(fieldType, text)
+	}
+
 	//nolint:exhaustive // Only validating types that can have default values
 	switch fieldType.Kind() {
+	case reflect.String:
+		if _, ok := value.(string); !ok {
+			return fmt.Errorf("expected string, got %T", value)
+		}
 	case reflect.Bool:
 		if _, ok := value.(bool); !ok {
 			return fmt.Errorf("expected bool, got %T", value)
@@ -69,14 +105,36 @@ func validateDefaultType(fieldType reflect.Type, value any) error {
 		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
 		reflect.Float32, reflect.Float64:
 		// JSON unmarshals numbers as float64
-		if _, ok := value.(float64); !ok {
+		f, ok := value.(float64)
+		if !ok {
 			return fmt.Errorf("expected number, got %T", value)
 		}
+
+		return validateDefaultNumber(fieldType, f)
 	case reflect.Slice, reflect.Array:
-		if _, ok := value.([]any); !ok {
+		items, ok := value.([]any)
+		if !ok {
 			return fmt.Errorf("expected array, got %T", value)
 		}
-	case reflect.Map, reflect.Struct:
+		if fieldType.Kind() == reflect.Array && len(items) > fieldType.Len() {
+			return fmt.Errorf("expected at most %d items, got %d", fieldType.Len(), len(items))
+		}
+		for i, item := range items {
+			if err := validateDefaultType(fieldType.Elem(), item); err != nil {
+				return fmt.Errorf("item %d: %w", i, err)
+			}
+		}
+	case reflect.Map:
+		values, ok := value.(map[string]any)
+		if !ok {
+			return fmt.Errorf("expected object, got %T", value)
+		}
+		for key, v := range values {
+			if err := validateDefaultType(fieldType.Elem(), v); err != nil {
+				return fmt.Errorf("key %q: %w", key, err)
+			}
+		}
+	case reflect.Struct:
 		if _, ok := value.(map[string]any); !ok {
 			return fmt.Errorf("expected object, got %T", value)
 		}
@@ -84,3 +142,40 @@ func validateDefaultType(fieldType reflect.Type, value any) error {
 
 	return nil
 }
+
+// validateDefaultText validates that a text is accepted by the UnmarshalText
+// method of a type.
+func validateDefaultText(t reflect.Type, text string) error {
+	//nolint:forcetypeassert // callers check that the type implements it
+	if err := reflect.New(t).Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(text)); err != nil {
+		return fmt.Errorf("invalid text for type %s: %w", t, err)
+	}
+
+	return nil
+}
+
+// validateDefaultNumber validates that a number is in the range of a numeric
+// type, and is an integer for integer types.
+func validateDefaultNumber(t reflect.Type, f float64) error {
+	//nolint:exhaustive // Only numeric kinds are passed
+	switch t.Kind() {
+	case reflect.Float32:
+		if math.Abs(f) > math.MaxFloat32 {
+			return fmt.Errorf("%v overflows %s", f, t)
+		}
+	case reflect.Float64:
+	default:
+		if f != math.Trunc(f) {
+			return fmt.Errorf("expected integer, got %v", f)
+		}
+		if reflect.Zero(t).CanInt() {
+			if reflect.Zero(t).OverflowInt(int64(f)) || f < math.MinInt64 || f >= math.MaxInt64 {
+				return fmt.Errorf("%v overflows %s", f, t)
+			}
+		} else if f < 0 || f >= math.MaxUint64 || reflect.Zero(t).OverflowUint(uint64(f)) {
+			return fmt.Errorf("%v overflows %s", f, t)
+		}
+	}
+
+	return nil
+}
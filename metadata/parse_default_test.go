@@ -0,0 +1,114 @@
+package metadata
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDefaultTag(t *testing.T) {
+	type level string
+	type defaults struct {
+		Name     string
+		Level    level
+		Enabled  bool
+		Limit    int
+		Small    int8
+		Count    uint16
+		Ratio    float32
+		Tags     []string
+		IDs      []int
+		Pair     [2]int
+		Weights  map[string]float64
+		Since    time.Time
+		Optional *int
+		Times    []time.Time
+	}
+
+	tests := []struct {
+		field string
+		tag   string
+		want  any
+	}{
+		{"Name", "Ada Lovelace", "Ada Lovelace"},
+		{"Level", "debug", "debug"},
+		{"Enabled", "true", true},
+		{"Limit", "-20", float64(-20)},
+		{"Small", "127", float64(127)},
+		{"Count", "65535", float64(65535)},
+		{"Ratio", "0.5", 0.5},
+		{"Tags", `["a","b"]`, []any{"a", "b"}},
+		{"IDs", `[1,2]`, []any{float64(1), float64(2)}},
+		{"Pair", `[1]`, []any{float64(1)}},
+		{"Weights", `{"a":1.5}`, map[string]any{"a": 1.5}},
+		{"Since", "2026-01-02T15:04:05Z", "2026-01-02T15:04:05Z"},
+		{"Optional", "5", float64(5)},
+		{"Times", `["2026-01-02T15:04:05Z"]`, []any{"2026-01-02T15:04:05Z"}},
+	}
+
+	typ := reflect.TypeFor[defaults]()
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field, ok := typ.FieldByName(tt.field)
+			require.True(t, ok)
+
+			got, err := ParseDefaultTag(field, 0, tt.tag)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.(*DefaultMetadata).Value)
+		})
+	}
+}
+
+func TestParseDefaultTag_Invalid(t *testing.T) {
+	type defaults struct {
+		Enabled bool
+		Limit   int
+		Small   int8
+		Count   uint16
+		Ratio   float32
+		IDs     []int
+		Pair    [2]int
+		Weights map[string]float64
+		Since   time.Time
+		Times   []time.Time
+		Nested  struct{ A int }
+	}
+
+	tests := []struct {
+		field string
+		tag   string
+		want  string
+	}{
+		{"Enabled", "yes", "invalid JSON for type bool"},
+		{"Enabled", "1", "expected bool, got float64"},
+		{"Limit", "abc", "invalid JSON for type int"},
+		{"Limit", `"10"`, "expected number, got string"},
+		{"Limit", "1.5", "expected integer, got 1.5"},
+		{"Small", "128", "128 overflows int8"},
+		{"Count", "-1", "-1 overflows uint16"},
+		{"Count", "70000", "70000 overflows uint16"},
+		{"Ratio", "1e39", "overflows float32"},
+		{"IDs", `["a"]`, "item 0: expected number, got string"},
+		{"Pair", `[1,2,3]`, "expected at most 2 items, got 3"},
+		{"Weights", `{"a":"heavy"}`, `key "a": expected number, got string`},
+		{"Since", "yesterday", "invalid text for type time.Time"},
+		{"Times", `["yesterday"]`, "item 0: invalid text for type time.Time"},
+		{"Nested", "[]", "expected object, got []interface {}"},
+	}
+
+	typ := reflect.TypeFor[defaults]()
+	for _, tt := range tests {
+		t.Run(tt.field+"="+tt.tag, func(t *testing.T) {
+			field, ok := typ.FieldByName(tt.field)
+			require.True(t, ok)
+
+			_, err := ParseDefaultTag(field, 0, tt.tag)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "field "+tt.field)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -1,8 +1,10 @@
 package metadata
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 
@@ -16,31 +18,49 @@ import (
 //
 // When used on a field (not the _ blank identifier), it represents field-level metadata.
 // When used on the _ blank identifier field, it represents struct-level metadata
-// (additionalProperties, nullable).
+// (additionalProperties, nullable, required properties, the title and description
+// of the struct schema, and the security of request structs).
 type OpenAPIMetadata struct {
 	// Field-level API contract metadata (not validation constraints)
 	// OpenAPI v3.0: readOnly, writeOnly, deprecated are booleans
-	ReadOnly    *bool  // field is read-only
-	WriteOnly   *bool  // field is write-only
-	Deprecated  *bool  // field is deprecated
-	Hidden      *bool  // field is hidden from schema (not included in properties)
-	Required    *bool  // field is required (override for validate:"required")
-	Title       string // title for the schema
-	Description string // description for the schema
-	Format      string // format for the schema (e.g., "date", "date-time", "time", "email", "uri")
-	Examples    []any  // parsed example values
+	ReadOnly        *bool  // field is read-only
+	WriteOnly       *bool  // field is write-only
+	Deprecated      *bool  // field is deprecated
+	Hidden          *bool  // field is hidden from schema (not included in properties)
+	Required        *bool  // field is required (override for validate:"required")
+	AllowEmptyValue *bool  // query parameter may be sent with an empty value (parameters only)
+	InlineMap       *bool  // map field documented as the additionalProperties of the parent object
+	Title           string // title for the schema (field or struct level)
+	Description     string // description for the schema (field or struct level)
+	DescriptionKey  string // key of the description in the description catalog, e.g. "user.email"
+	Format          string // format for the schema (e.g., "date", "date-time", "time", "email", "uri")
+	Examples        []any  // example values, coerced to the field type
+	Sensitivity     string // data classification of the field: pii, secret or public
+	Discriminator   string // property telling the variants of a registered union apart
+	KeyPattern      string // pattern of the keys of map fields
+	Ref             string // reference replacing the schema of the field, e.g. "#/components/schemas/Legacy"
 
 	// Struct-level metadata (only valid when used on _ blank identifier field)
-	AdditionalProperties *bool // allow additional properties (struct-level)
-	Nullable             *bool // struct is nullable (struct-level)
+	AdditionalProperties *bool    // allow additional properties (struct-level)
+	Nullable             *bool    // struct is nullable (struct-level)
+	RequiredProperties   []string // properties required besides the required fields (struct-level)
+	Security             []string // alternative security schemes of request structs (struct-level)
+	Scopes               []string // scopes required from each security scheme (struct-level)
 
 	// Extensions are OpenAPI specification extensions (x-* fields).
 	// Keys must start with "x-" per OpenAPI spec requirement.
 	Extensions map[string]any
 }
 
+// Data classifications of the sensitivity option.
+const (
+	SensitivityPII    = "pii"
+	SensitivitySecret = "secret"
+	SensitivityPublic = "public"
+)
+
 // ParseOpenAPITag parses an openapi tag and returns OpenAPIMetadata.
-// Tag format: openapi:"readOnly,writeOnly,deprecated,hidden,required,title=My Title,description=My description,examples=val1|val2|val3,x-custom=value"
+// Tag format: openapi:"readOnly,writeOnly,deprecated,hidden,required,allowEmptyValue,inlineMap,title=My Title,description=My description,examples=val1|val2|val3,sensitivity=pii,discriminator=type,keyPattern=^[a-z]+$,ref=#/components/schemas/Legacy,x-custom=value"
 //
 // This parser:
 // 1. Parses tag format (comma-separated, key=value pairs or flags)
@@ -48,7 +68,15 @@ type OpenAPIMetadata struct {
 // 3. Converts empty string to true for boolean flags (e.g., "readOnly" -> ReadOnly=true)
 // 4. Routes x-* prefixed keys to Extensions map (OpenAPI spec requirement)
 // 5. Detects struct-level vs field-level based on field name (blank identifier _ = struct-level)
-// 6. Supports pipe-separated examples values: examples=val1|val2|val3
+// 6. Supports pipe-separated examples values: examples=val1|val2|val3, coerced to the field type
+//
+// Values containing commas are quoted with single quotes, as in
+// description='Hello, world'. Within values, quoted or not, a backslash escapes the
+// next character: a comma (\,), a single quote (\'), a backslash (\\) or, in
+// pipe-separated values, a pipe (\|), as in examples=a\|b|c. Equals signs need no
+// escaping after the first one: description=a=b. Backslashes are doubled in Go struct
+// tags, as in openapi:"description=Hello\\, world". Unterminated quotes and escapes
+// are reported as errors.
 //
 // Field-level options (for named fields):
 //   - readOnly -> ReadOnly=true
@@ -56,14 +84,25 @@ type OpenAPIMetadata struct {
 //   - deprecated -> Deprecated=true
 //   - hidden -> Hidden=true (field excluded from schema properties)
 //   - required -> Required=true (overrides validate:"required" for docs only)
+//   - allowEmptyValue -> AllowEmptyValue=true (query parameters only, ignored elsewhere)
+//   - inlineMap -> InlineMap=true (on map fields; the map entries are members of the parent object)
 //   - title=... -> Title="..."
 //   - description=... -> Description="..."
+//   - descriptionKey=... -> DescriptionKey="..." (key in the description catalog, exclusive with description)
 //   - format=... -> Format="..." (e.g., "date", "date-time", "time", "email", "uri")
-//   - examples=val1|val2|val3 -> Examples=[val1, val2, val3] (pipe-separated values)
+//   - examples=val1|val2|val3 -> Examples=[val1, val2, val3] (pipe-separated values, coerced to the field type)
+//   - sensitivity=pii|secret|public -> Sensitivity="..." (data classification)
+//   - discriminator=... -> Discriminator="..." (on fields holding a registered union)
+//   - keyPattern=... -> KeyPattern="..." (on map fields; quote patterns containing commas)
+//   - ref=... -> Ref="..." (replaces the schema generated for the field type)
 //
 // Struct-level options (for _ blank identifier field):
 //   - additionalProperties=true/false -> AdditionalProperties=bool
 //   - nullable=true/false -> Nullable=bool
+//   - required=prop1|prop2 -> RequiredProperties=[prop1, prop2] (merged with the required fields)
+//   - title=..., description=..., descriptionKey=... -> Title, Description, DescriptionKey of the struct schema
+//   - security=scheme1|scheme2 -> Security=[scheme1, scheme2] (alternative schemes of request structs)
+//   - scopes=scope1|scope2 -> Scopes=[scope1, scope2] (required from each security scheme)
 //
 // OpenAPI extensions (valid at both field and struct level):
 //   - x-* -> Extensions["x-*"]="..." (MUST start with x-, minimum length 4)
@@ -71,9 +110,9 @@ func ParseOpenAPITag(field reflect.StructField, index int, tagValue string) (any
 	om := &OpenAPIMetadata{}
 
 	// Parse tag using tagparser (options mode - all items are options)
-	tag, err := tagparser.Parse(tagValue)
+	tag, err := tagparser.Parse(protectEscapedPipes(tagValue))
 	if err != nil {
-		return nil, fmt.Errorf("field %s: failed to parse openapi tag: %w", field.Name, err)
+		return nil, fmt.Errorf("field %s: failed to parse openapi tag: %w (%s)", field.Name, err, quotingHint)
 	}
 
 	// Detect if this is struct-level metadata (blank identifier field)
@@ -81,20 +120,72 @@ func ParseOpenAPITag(field reflect.StructField, index int, tagValue string) (any
 
 	// Process all options
 	for key, value := range tag.Options {
-		if err := applyOpenAPIMapping(om, key, value, isStructLevel); err != nil {
+		if err := applyOpenAPIMapping(om, field.Type, key, value, isStructLevel); err != nil {
 			return nil, fmt.Errorf("field %s: failed to apply openapi mapping: %w", field.Name, err)
 		}
 	}
+	if om.Description != "" && om.DescriptionKey != "" {
+		return nil, fmt.Errorf("field %s: description and descriptionKey are mutually exclusive", field.Name)
+	}
 
 	return om, nil
 }
 
+// quotingHint explains the quoting and escaping of tag values, in parse errors.
+const quotingHint = `quote values containing commas, as in description='Hello, world', and escape quotes and backslashes with a backslash`
+
+// escapedPipe replaces the escaped pipes (\|) of tag values during parsing, so that
+// they are not taken for the separators of pipe-separated values.
+const escapedPipe = "\x00"
+
+// pipeSeparatedOptions lists the options taking pipe-separated values.
+var pipeSeparatedOptions = []string{"examples", "security", "scopes", "required"}
+
+// protectEscapedPipes replaces the escaped pipes of a tag with escapedPipe, leaving
+// other escape sequences, such as an escaped backslash before a pipe, to tagparser.
+func protectEscapedPipes(tag string) string {
+	if !strings.Contains(tag, `\|`) {
+		return tag
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(tag); i++ {
+		switch {
+		case tag[i] == '\\' && i+1 < len(tag) && tag[i+1] == '|':
+			b.WriteString(escapedPipe)
+			i++
+		case tag[i] == '\\' && i+1 < len(tag):
+			b.WriteString(tag[i : i+2])
+			i++
+		default:
+			b.WriteByte(tag[i])
+		}
+	}
+
+	return b.String()
+}
+
+// unknownOptionHint returns a hint for an unknown option without a value, which is
+// most likely the rest of an unquoted value containing a comma.
+func unknownOptionHint(value string) string {
+	if value != "" {
+		return ""
+	}
+
+	return "; if it is part of the previous value, " + quotingHint
+}
+
 // applyOpenAPIMapping maps a single openapi tag option to OpenAPIMetadata field.
 // Extensions (x- prefix, length > 3) are processed first for both struct and field levels.
 // isStructLevel indicates if this is struct-level metadata (on _ blank identifier field).
 // Non-extension keys are routed to struct-level or field-level handlers based on isStructLevel.
-// Supports pipe-separated examples values (e.g., examples=val1|val2|val3).
-func applyOpenAPIMapping(om *OpenAPIMetadata, key, value string, isStructLevel bool) error {
+// Supports pipe-separated examples values (e.g., examples=val1|val2|val3), coerced to fieldType.
+func applyOpenAPIMapping(om *OpenAPIMetadata, fieldType reflect.Type, key, value string, isStructLevel bool) error {
+	// Escaped pipes are literal pipes outside pipe-separated values too
+	if !slices.Contains(pipeSeparatedOptions, key) {
+		value = strings.ReplaceAll(value, escapedPipe, "|")
+	}
+
 	if isExtension(key) {
 		applyExtension(om, key, value)
 
@@ -105,7 +196,7 @@ func applyOpenAPIMapping(om *OpenAPIMetadata, key, value string, isStructLevel b
 		return applyStructLevelOption(om, key, value)
 	}
 
-	return applyFieldLevelOption(om, key, value)
+	return applyFieldLevelOption(om, fieldType, key, value)
 }
 
 // isExtension checks if a key is a valid OpenAPI extension (x- prefix with length > 3).
@@ -138,17 +229,48 @@ func applyStructLevelOption(om *OpenAPIMetadata, key, value string) error {
 		return nil
 	}
 
-	return fmt.Errorf("unknown struct-level option %q (valid: additionalProperties, nullable)", key)
+	stringSetters := map[string]*string{
+		"title":          &om.Title,
+		"description":    &om.Description,
+		"descriptionKey": &om.DescriptionKey,
+	}
+
+	if ptr, ok := stringSetters[key]; ok {
+		*ptr = value
+
+		return nil
+	}
+
+	listSetters := map[string]*[]string{
+		"security": &om.Security,
+		"scopes":   &om.Scopes,
+		"required": &om.RequiredProperties,
+	}
+
+	if ptr, ok := listSetters[key]; ok {
+		values := parseListValues(value)
+		if key == "required" && len(values) == 0 {
+			return errors.New("struct-level required wants property names, e.g. required=id|name")
+		}
+		*ptr = append(*ptr, values...)
+
+		return nil
+	}
+
+	return fmt.Errorf("unknown struct-level option %q (valid: additionalProperties, nullable, required, title, description, descriptionKey, security, scopes)%s", key, unknownOptionHint(value))
 }
 
-// applyFieldLevelOption handles field-level OpenAPI options.
-func applyFieldLevelOption(om *OpenAPIMetadata, key, value string) error {
+// applyFieldLevelOption handles field-level OpenAPI options of a field of type fieldType.
+func applyFieldLevelOption(om *OpenAPIMetadata, fieldType reflect.Type, key, value string) error {
 	boolSetters := map[string]**bool{
 		"readOnly":   &om.ReadOnly,
 		"writeOnly":  &om.WriteOnly,
 		"deprecated": &om.Deprecated,
 		"hidden":     &om.Hidden,
 		"required":   &om.Required,
+
+		"allowEmptyValue": &om.AllowEmptyValue,
+		"inlineMap":       &om.InlineMap,
 	}
 
 	if ptr, ok := boolSetters[key]; ok {
@@ -162,9 +284,13 @@ func applyFieldLevelOption(om *OpenAPIMetadata, key, value string) error {
 	}
 
 	stringSetters := map[string]*string{
-		"title":       &om.Title,
-		"description": &om.Description,
-		"format":      &om.Format,
+		"title":          &om.Title,
+		"description":    &om.Description,
+		"descriptionKey": &om.DescriptionKey,
+		"format":         &om.Format,
+		"discriminator":  &om.Discriminator,
+		"keyPattern":     &om.KeyPattern,
+		"ref":            &om.Ref,
 	}
 
 	if ptr, ok := stringSetters[key]; ok {
@@ -174,30 +300,116 @@ func applyFieldLevelOption(om *OpenAPIMetadata, key, value string) error {
 	}
 
 	if key == "examples" {
-		om.Examples = append(om.Examples, parseExampleValues(value)...)
+		examples, err := parseExampleValues(fieldType, value)
+		if err != nil {
+			return err
+		}
+		om.Examples = append(om.Examples, examples...)
 
 		return nil
 	}
 
-	return fmt.Errorf("unknown field-level option %q (valid: readOnly, writeOnly, deprecated, hidden, required, title, description, format, examples)", key)
+	if key == "sensitivity" {
+		switch value {
+		case SensitivityPII, SensitivitySecret, SensitivityPublic:
+			om.Sensitivity = value
+
+			return nil
+		default:
+			return fmt.Errorf("invalid sensitivity value %q (valid: pii, secret, public)", value)
+		}
+	}
+
+	return fmt.Errorf("unknown field-level option %q (valid: readOnly, writeOnly, deprecated, hidden, required, allowEmptyValue, inlineMap, title, description, descriptionKey, format, examples, sensitivity, discriminator, keyPattern, ref)%s", key, unknownOptionHint(value))
 }
 
-// parseExampleValues parses pipe-separated example values.
-// Numeric values are stored as float64, others as strings.
-func parseExampleValues(value string) []any {
+// parseExampleValues parses pipe-separated example values, in which escaped pipes
+// are literal, coercing them to the field type (see parseExampleValue).
+func parseExampleValues(fieldType reflect.Type, value string) ([]any, error) {
 	var examples []any
 	for part := range strings.SplitSeq(value, "|") {
-		part = strings.TrimSpace(part)
+		part = strings.TrimSpace(strings.ReplaceAll(part, escapedPipe, "|"))
 		if part == "" {
 			continue
 		}
-		// Try to detect if value is numeric
-		if num, err := strconv.ParseFloat(part, 64); err == nil {
-			examples = append(examples, num)
-		} else {
-			examples = append(examples, part)
+		example, err := parseExampleValue(fieldType, part)
+		if err != nil {
+			return nil, fmt.Errorf("invalid example %q for type %s: %w", part, fieldType, err)
+		}
+		examples = append(examples, example)
+	}
+
+	return examples, nil
+}
+
+// parseExampleValue coerces an example value to the field type, so that it
+// validates against the field schema: strings for string and text types such as
+// time.Time, booleans for bool fields, and float64 for numeric fields, in the range
+// of the field type and without fractions for integer types. Values of other or
+// unknown types are stored as float64 when numeric, as strings otherwise.
+func parseExampleValue(fieldType reflect.Type, text string) (any, error) {
+	if fieldType == nil {
+		return parseUntypedExample(text), nil
+	}
+	if fieldType.Kind() == reflect.Ptr {
+		fieldType = fieldType.Elem()
+	}
+	if fieldType.Kind() == reflect.String {
+		return text, nil
+	}
+	if reflect.PointerTo(fieldType).Implements(textUnmarshalerType) {
+		if err := validateDefaultText(fieldType, text); err != nil {
+			return nil, err
+		}
+
+		return text, nil
+	}
+
+	//nolint:exhaustive // Other kinds keep untyped examples
+	switch fieldType.Kind() {
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return nil, errors.New("expected bool")
+		}
+
+		return b, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(text, 64)
+		if err != nil {
+			return nil, errors.New("expected number")
+		}
+		if err := validateDefaultNumber(fieldType, f); err != nil {
+			return nil, err
+		}
+
+		return f, nil
+	default:
+		return parseUntypedExample(text), nil
+	}
+}
+
+// parseUntypedExample returns an example value as float64 when numeric, as is
+// otherwise.
+func parseUntypedExample(text string) any {
+	if num, err := strconv.ParseFloat(text, 64); err == nil {
+		return num
+	}
+
+	return text
+}
+
+// parseListValues parses pipe-separated values, in which escaped pipes are
+// literal, skipping empty ones.
+func parseListValues(value string) []string {
+	var values []string
+	for part := range strings.SplitSeq(value, "|") {
+		if part = strings.TrimSpace(strings.ReplaceAll(part, escapedPipe, "|")); part != "" {
+			values = append(values, part)
 		}
 	}
 
-	return examples
+	return values
 }
@@ -89,6 +89,48 @@ func TestParseOpenAPITag_StructLevel(t *testing.T) {
 				Nullable:             boolPtr(true),
 			},
 		},
+		{
+			name:      "security and scopes",
+			fieldName: "_",
+			tagValue:  "security=oauth2| bearerAuth,scopes=users:read|users:write",
+			want: &OpenAPIMetadata{
+				Security: []string{"oauth2", "bearerAuth"},
+				Scopes:   []string{"users:read", "users:write"},
+			},
+		},
+		{
+			name:      "title and description",
+			fieldName: "_",
+			tagValue:  "title=User,description='A user, as stored',additionalProperties=false",
+			want: &OpenAPIMetadata{
+				AdditionalProperties: boolPtr(false),
+				Title:                "User",
+				Description:          "A user, as stored",
+			},
+		},
+		{
+			name:      "description key",
+			fieldName: "_",
+			tagValue:  "descriptionKey=schemas.user",
+			want: &OpenAPIMetadata{
+				DescriptionKey: "schemas.user",
+			},
+		},
+		{
+			name:      "required properties",
+			fieldName: "_",
+			tagValue:  "required=field_a| field_b",
+			want: &OpenAPIMetadata{
+				RequiredProperties: []string{"field_a", "field_b"},
+			},
+		},
+		{
+			name:        "required without properties",
+			fieldName:   "_",
+			tagValue:    "required",
+			wantErr:     true,
+			errContains: "struct-level required wants property names",
+		},
 		{
 			name:        "unknown option returns error",
 			fieldName:   "_",
@@ -137,6 +179,12 @@ func TestParseOpenAPITag_StructLevel(t *testing.T) {
 
 			assert.Equal(t, tt.want.AdditionalProperties, om.AdditionalProperties, "AdditionalProperties mismatch")
 			assert.Equal(t, tt.want.Nullable, om.Nullable, "Nullable mismatch")
+			assert.Equal(t, tt.want.Security, om.Security, "Security mismatch")
+			assert.Equal(t, tt.want.Scopes, om.Scopes, "Scopes mismatch")
+			assert.Equal(t, tt.want.RequiredProperties, om.RequiredProperties, "RequiredProperties mismatch")
+			assert.Equal(t, tt.want.Title, om.Title, "Title mismatch")
+			assert.Equal(t, tt.want.Description, om.Description, "Description mismatch")
+			assert.Equal(t, tt.want.DescriptionKey, om.DescriptionKey, "DescriptionKey mismatch")
 		})
 	}
 }
@@ -3,6 +3,7 @@ package metadata
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -48,6 +49,22 @@ func TestParseOpenAPI(t *testing.T) {
 				Deprecated: boolPtr(true),
 			},
 		},
+		{
+			name:      "allowEmptyValue flag",
+			fieldName: "Filter",
+			tagValue:  "allowEmptyValue",
+			want: &OpenAPIMetadata{
+				AllowEmptyValue: boolPtr(true),
+			},
+		},
+		{
+			name:      "inlineMap flag",
+			fieldName: "Extra",
+			tagValue:  "inlineMap",
+			want: &OpenAPIMetadata{
+				InlineMap: boolPtr(true),
+			},
+		},
 		{
 			name:      "required flag",
 			fieldName: "Email",
@@ -239,6 +256,60 @@ func TestParseOpenAPI(t *testing.T) {
 			wantErr:     true,
 			errContains: "unknown field-level option",
 		},
+		{
+			name:      "sensitivity",
+			fieldName: "Email",
+			tagValue:  "sensitivity=pii",
+			want: &OpenAPIMetadata{
+				Sensitivity: SensitivityPII,
+			},
+		},
+		{
+			name:        "invalid sensitivity",
+			fieldName:   "Email",
+			tagValue:    "sensitivity=private",
+			wantErr:     true,
+			errContains: `invalid sensitivity value "private"`,
+		},
+		{
+			name:      "discriminator",
+			fieldName: "Shape",
+			tagValue:  "discriminator=kind",
+			want: &OpenAPIMetadata{
+				Discriminator: "kind",
+			},
+		},
+		{
+			name:      "key pattern",
+			fieldName: "Labels",
+			tagValue:  "keyPattern='^[a-z]{1,8}$'",
+			want: &OpenAPIMetadata{
+				KeyPattern: "^[a-z]{1,8}$",
+			},
+		},
+		{
+			name:      "ref",
+			fieldName: "Legacy",
+			tagValue:  "ref=#/components/schemas/LegacyThing",
+			want: &OpenAPIMetadata{
+				Ref: "#/components/schemas/LegacyThing",
+			},
+		},
+		{
+			name:      "description key",
+			fieldName: "Email",
+			tagValue:  "descriptionKey=user.email",
+			want: &OpenAPIMetadata{
+				DescriptionKey: "user.email",
+			},
+		},
+		{
+			name:        "description and description key",
+			fieldName:   "Email",
+			tagValue:    "description=Email,descriptionKey=user.email",
+			wantErr:     true,
+			errContains: "field Email: description and descriptionKey are mutually exclusive",
+		},
 		{
 			name:        "invalid tag parsing",
 			fieldName:   "Field",
@@ -246,6 +317,61 @@ func TestParseOpenAPI(t *testing.T) {
 			wantErr:     true,
 			errContains: "failed to parse openapi tag",
 		},
+		{
+			name:      "quoted description with commas and equals signs",
+			fieldName: "Greeting",
+			tagValue:  "description='Hello, world: a=b',title=Greeting",
+			want: &OpenAPIMetadata{
+				Title:       "Greeting",
+				Description: "Hello, world: a=b",
+			},
+		},
+		{
+			name:      "escaped commas, quotes and backslashes",
+			fieldName: "Greeting",
+			tagValue:  `description=It\'s\, a \\ b=c`,
+			want: &OpenAPIMetadata{
+				Description: `It's, a \ b=c`,
+			},
+		},
+		{
+			name:      "escaped pipes in examples",
+			fieldName: "Expression",
+			tagValue:  `examples='a\|b|c|x\|y, z'`,
+			want: &OpenAPIMetadata{
+				Examples: []any{"a|b", "c", "x|y, z"},
+			},
+		},
+		{
+			name:      "escaped backslash before a pipe",
+			fieldName: "Path",
+			tagValue:  `examples=a\\|b,description=a\|b`,
+			want: &OpenAPIMetadata{
+				Description: "a|b",
+				Examples:    []any{`a\`, "b"},
+			},
+		},
+		{
+			name:        "unterminated quote",
+			fieldName:   "Greeting",
+			tagValue:    "description='Hello, world",
+			wantErr:     true,
+			errContains: "field Greeting: failed to parse openapi tag: unterminated quote (at 13) (quote values containing commas, as in description='Hello, world', and escape quotes and backslashes with a backslash)",
+		},
+		{
+			name:        "unterminated escape",
+			fieldName:   "Greeting",
+			tagValue:    `description=Hello\`,
+			wantErr:     true,
+			errContains: "unterminated escape sequence",
+		},
+		{
+			name:        "unquoted comma",
+			fieldName:   "Greeting",
+			tagValue:    "description=Hello, world",
+			wantErr:     true,
+			errContains: `unknown field-level option "world" (valid: readOnly, writeOnly, deprecated, hidden, required, allowEmptyValue, inlineMap, title, description, descriptionKey, format, examples, sensitivity, discriminator, keyPattern, ref); if it is part of the previous value, quote values containing commas`,
+		},
 		{
 			name:      "extension with special characters",
 			fieldName: "Field",
@@ -431,3 +557,83 @@ func TestParseOpenAPI_ComplexScenarios(t *testing.T) {
 		assert.Equal(t, boolPtr(true), om.Required)
 	})
 }
+
+func TestParseOpenAPI_TypedExamples(t *testing.T) {
+	type examples struct {
+		Name     string
+		Code     string
+		Enabled  bool
+		Age      int
+		Count    uint8
+		Ratio    float32
+		Optional *int
+		Since    time.Time
+		Tags     []string
+	}
+
+	tests := []struct {
+		field string
+		tag   string
+		want  []any
+	}{
+		{"Name", "examples=Ada|Grace", []any{"Ada", "Grace"}},
+		{"Code", "examples=007|42", []any{"007", "42"}},
+		{"Enabled", "examples=true|false", []any{true, false}},
+		{"Age", "examples=25|30", []any{25.0, 30.0}},
+		{"Count", "examples=255", []any{255.0}},
+		{"Ratio", "examples=0.5|1e3", []any{0.5, 1000.0}},
+		{"Optional", "examples=5", []any{5.0}},
+		{"Since", "examples=2026-01-02T15:04:05Z", []any{"2026-01-02T15:04:05Z"}},
+		{"Tags", "examples=a|1", []any{"a", 1.0}},
+	}
+
+	typ := reflect.TypeFor[examples]()
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			field, ok := typ.FieldByName(tt.field)
+			require.True(t, ok)
+
+			got, err := ParseOpenAPITag(field, 0, tt.tag)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.(*OpenAPIMetadata).Examples)
+		})
+	}
+}
+
+func TestParseOpenAPI_TypedExamples_Invalid(t *testing.T) {
+	type examples struct {
+		Enabled bool
+		Age     int
+		Small   int8
+		Count   uint16
+		Ratio   float32
+		Since   time.Time
+	}
+
+	tests := []struct {
+		field string
+		tag   string
+		want  string
+	}{
+		{"Enabled", "examples=yes", `invalid example "yes" for type bool: expected bool`},
+		{"Age", "examples=25|abc", `invalid example "abc" for type int: expected number`},
+		{"Age", "examples=2.5", "expected integer, got 2.5"},
+		{"Small", "examples=128", "128 overflows int8"},
+		{"Count", "examples=-1", "-1 overflows uint16"},
+		{"Ratio", "examples=1e39", "overflows float32"},
+		{"Since", "examples=yesterday", "invalid text for type time.Time"},
+	}
+
+	typ := reflect.TypeFor[examples]()
+	for _, tt := range tests {
+		t.Run(tt.field+"="+tt.tag, func(t *testing.T) {
+			field, ok := typ.FieldByName(tt.field)
+			require.True(t, ok)
+
+			_, err := ParseOpenAPITag(field, 0, tt.tag)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "field "+tt.field)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
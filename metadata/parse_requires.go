@@ -2,26 +2,41 @@ package metadata
 
 import (
 	"fmt"
+	"maps"
 	"reflect"
+	"slices"
+	"strings"
 
 	"github.com/talav/tagparser"
 )
 
-// RequiresMetadata represents fields that become required when this field is present.
-// Extracted from the requires tag for OpenAPI schema generation (JSON Schema dependentRequired keyword).
+// RequiresMetadata represents fields that become required when this field is present,
+// or when it has a given value.
+// Extracted from the requires tag for OpenAPI schema generation (JSON Schema
+// dependentRequired and if/then keywords).
 type RequiresMetadata struct {
-	Fields []string // List of field names that become required when this field is present
+	Fields     []string            // List of field names that become required when this field is present
+	Conditions []RequiresCondition // Fields that become required when this field has a given value
+}
+
+// RequiresCondition lists the fields that become required when a field has Value.
+type RequiresCondition struct {
+	Value  any      // Value of the field, typed after the field (string, float64, bool)
+	Fields []string // Field names that become required
 }
 
 // ParseRequiresTag parses a requires tag and returns RequiresMetadata.
-// Tag format: requires:"field1,field2,field3"
+// Tag format: requires:"field1,field2,value=field3|field4"
 //
 // Parses comma-separated list of field names that become required when this field is present.
+// Options with a value list, pipe-separated, the fields that become required when this field
+// equals the option key. Keys are parsed like default values: as-is for strings, as JSON otherwise.
 // Empty strings and whitespace are filtered out.
 //
 // Example:
 //   - requires:"billing_address,cvv" -> Fields=["billing_address", "cvv"]
 //   - requires:"field1" -> Fields=["field1"]
+//   - requires:"card=card_number|cvv,bank=iban" -> Conditions=[{bank, [iban]}, {card, [card_number, cvv]}]
 //   - requires:"" -> Fields=[] (empty, will be ignored)
 func ParseRequiresTag(field reflect.StructField, index int, tagValue string) (any, error) {
 	tag, err := tagparser.Parse(tagValue)
@@ -30,11 +45,30 @@ func ParseRequiresTag(field reflect.StructField, index int, tagValue string) (an
 	}
 
 	fields := make([]string, 0, len(tag.Options))
-	for key := range tag.Options {
-		fields = append(fields, key)
+	var conditions []RequiresCondition
+	for _, key := range slices.Sorted(maps.Keys(tag.Options)) {
+		value := tag.Options[key]
+		if value == "" {
+			fields = append(fields, key)
+
+			continue
+		}
+
+		equals, err := parseDefaultValue(field.Type, key)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: invalid requires value %q: %w", field.Name, key, err)
+		}
+		var required []string
+		for name := range strings.SplitSeq(value, "|") {
+			if name = strings.TrimSpace(name); name != "" {
+				required = append(required, name)
+			}
+		}
+		conditions = append(conditions, RequiresCondition{Value: equals, Fields: required})
 	}
 
 	return &RequiresMetadata{
-		Fields: fields,
+		Fields:     fields,
+		Conditions: conditions,
 	}, nil
 }
@@ -103,3 +103,66 @@ func TestParseRequiresTag(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRequiresTag_Conditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		fieldType reflect.Type
+		tagValue  string
+		want      *RequiresMetadata
+		wantErr   string
+	}{
+		{
+			name:      "string values",
+			fieldType: reflect.TypeOf(""),
+			tagValue:  "card=card_number|cvv,bank=iban",
+			want: &RequiresMetadata{
+				Fields: []string{},
+				Conditions: []RequiresCondition{
+					{Value: "bank", Fields: []string{"iban"}},
+					{Value: "card", Fields: []string{"card_number", "cvv"}},
+				},
+			},
+		},
+		{
+			name:      "mixed with presence",
+			fieldType: reflect.TypeOf(""),
+			tagValue:  "receipt_email,card=card_number",
+			want: &RequiresMetadata{
+				Fields:     []string{"receipt_email"},
+				Conditions: []RequiresCondition{{Value: "card", Fields: []string{"card_number"}}},
+			},
+		},
+		{
+			name:      "typed values",
+			fieldType: reflect.TypeOf(true),
+			tagValue:  "true=reason",
+			want: &RequiresMetadata{
+				Fields:     []string{},
+				Conditions: []RequiresCondition{{Value: true, Fields: []string{"reason"}}},
+			},
+		},
+		{
+			name:      "value not matching the field type",
+			fieldType: reflect.TypeOf(0),
+			tagValue:  "card=card_number",
+			wantErr:   `field Method: invalid requires value "card"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := reflect.StructField{Name: "Method", Type: tt.fieldType}
+
+			result, err := ParseRequiresTag(field, 0, tt.tagValue)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, result)
+		})
+	}
+}
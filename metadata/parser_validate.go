@@ -44,6 +44,7 @@ type ValidateMetadata struct {
 //   - min=N -> Minimum=N (as float64)
 //   - max=N -> Maximum=N (as float64)
 //   - len=N -> Minimum=N, Maximum=N (as float64, sets both to same value)
+//   - gt=N, lt=N -> ExclusiveMinimum=N, ExclusiveMaximum=N (may be combined with min/max)
 //   - email -> Format="email"
 //   - url -> Format="uri"
 //   - pattern=... -> Pattern="..."
@@ -79,9 +80,64 @@ func ParseValidateTag(field reflect.StructField, index int, tagValue string) (an
 		}
 	}
 
+	if err := vm.checkBounds(); err != nil {
+		return nil, fmt.Errorf("field %s: %w", field.Name, err)
+	}
+
 	return vm, nil
 }
 
+// LowerBound returns the effective lower bound of the value when both inclusive
+// (min, gte) and exclusive (gt) bounds are set: the tighter one, exclusive on a tie.
+// ok is false when there is no lower bound.
+func (vm *ValidateMetadata) LowerBound() (value float64, exclusive, ok bool) {
+	switch {
+	case vm.Minimum == nil && vm.ExclusiveMinimum == nil:
+		return 0, false, false
+	case vm.Minimum == nil || vm.ExclusiveMinimum != nil && *vm.ExclusiveMinimum >= *vm.Minimum:
+		return *vm.ExclusiveMinimum, true, true
+	default:
+		return *vm.Minimum, false, true
+	}
+}
+
+// UpperBound returns the effective upper bound of the value when both inclusive
+// (max, lte) and exclusive (lt) bounds are set: the tighter one, exclusive on a tie.
+// ok is false when there is no upper bound.
+func (vm *ValidateMetadata) UpperBound() (value float64, exclusive, ok bool) {
+	switch {
+	case vm.Maximum == nil && vm.ExclusiveMaximum == nil:
+		return 0, false, false
+	case vm.Maximum == nil || vm.ExclusiveMaximum != nil && *vm.ExclusiveMaximum <= *vm.Maximum:
+		return *vm.ExclusiveMaximum, true, true
+	default:
+		return *vm.Maximum, false, true
+	}
+}
+
+// checkBounds returns an error if the bounds admit no value, e.g. gt=5,lte=5.
+func (vm *ValidateMetadata) checkBounds() error {
+	lo, loExclusive, hasLo := vm.LowerBound()
+	hi, hiExclusive, hasHi := vm.UpperBound()
+	if !hasLo || !hasHi {
+		return nil
+	}
+	if lo > hi || lo == hi && (loExclusive || hiExclusive) {
+		return fmt.Errorf("contradictory bounds: no value is %s and %s", describeBound(">", lo, loExclusive), describeBound("<", hi, hiExclusive))
+	}
+
+	return nil
+}
+
+// describeBound formats a bound for error messages, e.g. "> 5" or ">= 5".
+func describeBound(op string, value float64, exclusive bool) string {
+	if !exclusive {
+		op += "="
+	}
+
+	return fmt.Sprintf("%s %v", op, value)
+}
+
 // applyValidatorMapping maps a single validator tag to OpenAPI constraint.
 // Only includes validators actually supported by go-playground/validator v10.
 // Reference: https://pkg.go.dev/github.com/go-playground/validator/v10
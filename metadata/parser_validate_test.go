@@ -238,6 +238,31 @@ func TestParseValidateTag(t *testing.T) {
 			wantErr:     true,
 			errContains: "oneof requires at least one value",
 		},
+		{
+			name:      "inclusive and exclusive bounds combined",
+			fieldName: "Score",
+			tagValue:  "gte=0,gt=0,lte=100,lt=100",
+			want: &ValidateMetadata{
+				Minimum:          floatPtr(0),
+				ExclusiveMinimum: floatPtr(0),
+				Maximum:          floatPtr(100),
+				ExclusiveMaximum: floatPtr(100),
+			},
+		},
+		{
+			name:        "contradictory bounds",
+			fieldName:   "Score",
+			tagValue:    "gt=5,lte=5",
+			wantErr:     true,
+			errContains: "field Score: contradictory bounds: no value is > 5 and <= 5",
+		},
+		{
+			name:        "inverted bounds",
+			fieldName:   "Score",
+			tagValue:    "min=10,max=1",
+			wantErr:     true,
+			errContains: "contradictory bounds: no value is >= 10 and <= 1",
+		},
 		{
 			name:      "oneof with empty values filtered",
 			fieldName: "Status",
@@ -309,6 +334,42 @@ func TestParseValidateTag(t *testing.T) {
 	}
 }
 
+func TestValidateMetadata_Bounds(t *testing.T) {
+	tests := []struct {
+		name          string
+		vm            ValidateMetadata
+		wantLower     float64
+		wantLowerExcl bool
+		wantUpper     float64
+		wantUpperExcl bool
+	}{
+		{name: "inclusive only", vm: ValidateMetadata{Minimum: floatPtr(1), Maximum: floatPtr(9)}, wantLower: 1, wantUpper: 9},
+		{name: "exclusive only", vm: ValidateMetadata{ExclusiveMinimum: floatPtr(1), ExclusiveMaximum: floatPtr(9)}, wantLower: 1, wantLowerExcl: true, wantUpper: 9, wantUpperExcl: true},
+		{name: "tighter inclusive", vm: ValidateMetadata{Minimum: floatPtr(5), ExclusiveMinimum: floatPtr(1), Maximum: floatPtr(5), ExclusiveMaximum: floatPtr(9)}, wantLower: 5, wantUpper: 5},
+		{name: "tighter exclusive", vm: ValidateMetadata{Minimum: floatPtr(1), ExclusiveMinimum: floatPtr(5), Maximum: floatPtr(9), ExclusiveMaximum: floatPtr(6)}, wantLower: 5, wantLowerExcl: true, wantUpper: 6, wantUpperExcl: true},
+		{name: "tie is exclusive", vm: ValidateMetadata{Minimum: floatPtr(1), ExclusiveMinimum: floatPtr(1), Maximum: floatPtr(9), ExclusiveMaximum: floatPtr(9)}, wantLower: 1, wantLowerExcl: true, wantUpper: 9, wantUpperExcl: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lower, lowerExcl, ok := tt.vm.LowerBound()
+			require.True(t, ok)
+			assert.InDelta(t, tt.wantLower, lower, 0)
+			assert.Equal(t, tt.wantLowerExcl, lowerExcl)
+
+			upper, upperExcl, ok := tt.vm.UpperBound()
+			require.True(t, ok)
+			assert.InDelta(t, tt.wantUpper, upper, 0)
+			assert.Equal(t, tt.wantUpperExcl, upperExcl)
+		})
+	}
+
+	_, _, ok := (&ValidateMetadata{}).LowerBound()
+	assert.False(t, ok)
+	_, _, ok = (&ValidateMetadata{}).UpperBound()
+	assert.False(t, ok)
+}
+
 func TestParseValidateTag_RealWorldScenarios(t *testing.T) {
 	t.Run("user registration email", func(t *testing.T) {
 		field := reflect.StructField{
@@ -0,0 +1,43 @@
+package openapi
+
+import "github.com/talav/openapi/internal/build"
+
+// SchemaNamespacing is a strategy qualifying component schema names with the Go
+// package of their types, selected with WithSchemaNamespacing.
+type SchemaNamespacing string
+
+// Schema namespacing strategies.
+const (
+	// NamespacePackagePrefix prefixes schema names with the last element of the
+	// package path: v1.User becomes V1User.
+	NamespacePackagePrefix SchemaNamespacing = "packagePrefix"
+	// NamespacePackageSuffix suffixes schema names with the last element of the
+	// package path: v1.User becomes UserV1.
+	NamespacePackageSuffix SchemaNamespacing = "packageSuffix"
+)
+
+// schemaNamespacings are the name qualifiers of the namespacing strategies.
+var schemaNamespacings = map[SchemaNamespacing]build.Qualifier{
+	"":                     nil, // unset
+	NamespacePackagePrefix: build.PackagePrefix,
+	NamespacePackageSuffix: build.PackageSuffix,
+}
+
+// WithSchemaNamespacing qualifies component schema names with the Go package of
+// their types, for APIs using types with the same name from different packages,
+// such as v1.User and v2.User. Generic type arguments are qualified too, and
+// unnamed types keep their names.
+//
+// Without namespacing, such types are reported by Generate as a schema name
+// collision listing both types. Generate also fails for unknown strategies.
+//
+// Default: no namespacing
+//
+// Example:
+//
+//	openapi.WithSchemaNamespacing(openapi.NamespacePackagePrefix)
+func WithSchemaNamespacing(strategy SchemaNamespacing) Option {
+	return func(a *API) {
+		a.SchemaNamespacing = strategy
+	}
+}
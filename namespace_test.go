@@ -0,0 +1,61 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"go/token"
+	"testing"
+	"text/scanner"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// namespacePositions references two types named Position, from go/token and text/scanner.
+type namespacePositions struct {
+	Token   token.Position   `json:"token"`
+	Scanner scanner.Position `json:"scanner"`
+}
+
+func TestWithSchemaNamespacing(t *testing.T) {
+	tests := []struct {
+		strategy SchemaNamespacing
+		want     []string
+	}{
+		{strategy: NamespacePackagePrefix, want: []string{"OpenapiNamespacePositions", "TokenPosition", "ScannerPosition"}},
+		{strategy: NamespacePackageSuffix, want: []string{"NamespacePositionsOpenapi", "PositionToken", "PositionScanner"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"), WithSchemaNamespacing(tt.strategy))
+
+			result, err := api.Generate(context.Background(), GET("/positions", WithResponse(200, namespacePositions{})))
+			require.NoError(t, err)
+
+			var spec struct {
+				Components struct {
+					Schemas map[string]any `json:"schemas"`
+				} `json:"components"`
+			}
+			require.NoError(t, json.Unmarshal(result.JSON, &spec))
+			assert.ElementsMatch(t, tt.want, keys(spec.Components.Schemas))
+		})
+	}
+}
+
+func TestSchemaNameCollision(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	_, err := api.Generate(context.Background(), GET("/positions", WithResponse(200, namespacePositions{})))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate schema name Position: go/token.Position and text/scanner.Position")
+}
+
+func TestWithSchemaNamespacing_Unknown(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithSchemaNamespacing("byModule"))
+
+	_, err := api.Generate(context.Background(), GET("/positions", WithResponse(200, namespacePositions{})))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown schema namespacing "byModule"`)
+}
@@ -0,0 +1,82 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// PropertyNaming is a strategy naming the properties of struct fields without
+// json tags, selected with WithPropertyNaming.
+type PropertyNaming string
+
+// Property naming strategies.
+const (
+	// NamingAsIs names properties after the Go field names, like encoding/json.
+	NamingAsIs PropertyNaming = "asIs"
+	// NamingCamelCase names properties in camelCase: UserID becomes userId.
+	NamingCamelCase PropertyNaming = "camelCase"
+	// NamingSnakeCase names properties in snake_case: UserID becomes user_id.
+	NamingSnakeCase PropertyNaming = "snake_case"
+)
+
+// propertyNamings are the field name conversions of the naming strategies.
+var propertyNamings = map[PropertyNaming]func(string) string{
+	"":              nil, // unset
+	NamingAsIs:      nil,
+	NamingCamelCase: build.CamelCase,
+	NamingSnakeCase: build.SnakeCase,
+}
+
+// WithPropertyNaming names the properties of struct fields without json tags with
+// a strategy, for codecs applying a naming convention instead of encoding/json.
+// Explicit json tag names are kept, but those not following the strategy are
+// reported as debug.WarnPropertyNamingConflict warnings, to help migrating a
+// convention. The members of examples built from Go values (see example.New) are
+// named the same way. Generate fails for unknown strategies.
+//
+// Default: NamingAsIs
+//
+// Example:
+//
+//	openapi.WithPropertyNaming(openapi.NamingSnakeCase)
+func WithPropertyNaming(strategy PropertyNaming) Option {
+	return func(a *API) {
+		a.PropertyNaming = strategy
+	}
+}
+
+// namingWarnings reports the properties of component schemas whose json tag name
+// differs from the name given by the property naming strategy.
+func (a *API) namingWarnings(spec *model.Spec) debug.Warnings {
+	naming := propertyNamings[a.PropertyNaming]
+	if naming == nil || spec.Components == nil {
+		return nil
+	}
+
+	var warns debug.Warnings
+	for _, name := range sortedNames(spec.Components.Schemas) {
+		t, ok := a.generator.TypeOf(name)
+		if !ok {
+			continue
+		}
+		for _, prop := range a.generator.Properties(t) {
+			tagName, _ := build.JSONTag(prop.Field)
+			if tagName == "" {
+				continue
+			}
+			if want := naming(prop.Field.Name); tagName != want {
+				warns.Append(debug.NewWarning(
+					debug.WarnPropertyNamingConflict,
+					util.Pointer(componentsPrefix+"schemas", name, "properties", prop.Name),
+					fmt.Sprintf("%s.%s is tagged %q, %s naming gives %q", t, prop.Path, tagName, a.PropertyNaming, want),
+				))
+			}
+		}
+	}
+
+	return warns
+}
@@ -0,0 +1,156 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/example"
+)
+
+type namingAccount struct {
+	AccountID   string
+	DisplayName string `json:",omitempty"`
+	CreatedAt   string `json:"created_at"`
+	UpdatedAt   string `json:"updatedAt"`
+	LastLogin   string `json:",omitzero"`
+	ClosedAt    string `json:"closed_at,omitzero"`
+}
+
+func TestWithPropertyNaming(t *testing.T) {
+	tests := []struct {
+		strategy  PropertyNaming
+		wantProps []string
+		wantWarns []string
+	}{
+		{
+			strategy:  NamingAsIs,
+			wantProps: []string{"AccountID", "DisplayName", "created_at", "updatedAt", "LastLogin", "closed_at"},
+		},
+		{
+			strategy:  NamingCamelCase,
+			wantProps: []string{"accountId", "displayName", "created_at", "updatedAt", "lastLogin", "closed_at"},
+			wantWarns: []string{
+				"#/components/schemas/NamingAccount/properties/created_at",
+				"#/components/schemas/NamingAccount/properties/closed_at",
+			},
+		},
+		{
+			strategy:  NamingSnakeCase,
+			wantProps: []string{"account_id", "display_name", "created_at", "updatedAt", "last_login", "closed_at"},
+			wantWarns: []string{"#/components/schemas/NamingAccount/properties/updatedAt"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.strategy), func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"), WithPropertyNaming(tt.strategy))
+
+			result, err := api.Generate(context.Background(), GET("/account", WithResponse(200, namingAccount{})))
+			require.NoError(t, err)
+
+			var spec struct {
+				Components struct {
+					Schemas map[string]struct {
+						Properties map[string]any `json:"properties"`
+					} `json:"schemas"`
+				} `json:"components"`
+			}
+			require.NoError(t, json.Unmarshal(result.JSON, &spec))
+			assert.ElementsMatch(t, tt.wantProps, keys(spec.Components.Schemas["NamingAccount"].Properties))
+
+			var paths []string
+			for _, w := range result.Warnings {
+				if w.Code() == debug.WarnPropertyNamingConflict {
+					paths = append(paths, w.Path())
+				}
+			}
+			assert.Equal(t, tt.wantWarns, paths)
+		})
+	}
+}
+
+type namingProfile struct {
+	UserName string
+	APIKey   string `openapi:"sensitivity=secret"`
+	Accounts []namingAccount
+}
+
+func TestWithPropertyNaming_Examples(t *testing.T) {
+	profile := namingProfile{
+		UserName: "alice",
+		APIKey:   "sk_live_51H8",
+		Accounts: []namingAccount{{AccountID: "acc_1", CreatedAt: "2024-01-01", UpdatedAt: "2024-02-01"}},
+	}
+
+	tests := []struct {
+		redaction ExampleRedaction
+		want      string
+	}{
+		{
+			redaction: ExampleRedactionOmit,
+			want: `{
+				"user_name": "alice",
+				"accounts": [{"account_id": "acc_1", "created_at": "2024-01-01", "updatedAt": "2024-02-01"}]
+			}`,
+		},
+		{
+			redaction: ExampleRedactionOff,
+			want: `{
+				"user_name": "alice",
+				"api_key": "sk_live_51H8",
+				"accounts": [{"account_id": "acc_1", "created_at": "2024-01-01", "updatedAt": "2024-02-01"}]
+			}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.redaction), func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"), WithPropertyNaming(NamingSnakeCase), WithExampleRedaction(tt.redaction))
+
+			result, err := api.Generate(context.Background(),
+				GET("/profile", WithResponse(200, namingProfile{}, example.New("alice", profile))),
+			)
+			require.NoError(t, err)
+
+			var spec struct {
+				Paths map[string]map[string]struct {
+					Responses map[string]struct {
+						Content map[string]struct {
+							Examples map[string]struct {
+								Value json.RawMessage `json:"value"`
+							} `json:"examples"`
+						} `json:"content"`
+					} `json:"responses"`
+				} `json:"paths"`
+			}
+			require.NoError(t, json.Unmarshal(result.JSON, &spec))
+			value := spec.Paths["/profile"]["get"].Responses["200"].Content["application/json"].Examples["alice"].Value
+			assert.JSONEq(t, tt.want, string(value))
+		})
+	}
+}
+
+func TestWithPropertyNaming_Message(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithPropertyNaming(NamingCamelCase))
+
+	result, err := api.Generate(context.Background(), GET("/account", WithResponse(200, namingAccount{})))
+	require.NoError(t, err)
+	require.Len(t, result.Warnings, 2)
+	assert.Equal(t, `openapi.namingAccount.CreatedAt is tagged "created_at", camelCase naming gives "createdAt"`, result.Warnings[0].Message())
+	assert.Equal(t, `openapi.namingAccount.ClosedAt is tagged "closed_at", camelCase naming gives "closedAt"`, result.Warnings[1].Message())
+	assert.Equal(t, "openapi.namingAccount.AccountID", api.violationSource("/components/schemas/NamingAccount/properties/accountId"))
+	assert.Equal(t, "openapi.namingAccount.LastLogin", api.violationSource("/components/schemas/NamingAccount/properties/lastLogin"))
+	assert.Equal(t, "openapi.namingAccount.ClosedAt", api.violationSource("/components/schemas/NamingAccount/properties/closed_at"))
+}
+
+func TestWithPropertyNaming_Unknown(t *testing.T) {
+	_, err := NewAPI(WithVersion("3.1.2"), WithPropertyNaming("kebab-case")).Generate(context.Background(),
+		GET("/account", WithResponse(200, namingAccount{})),
+	)
+	require.ErrorContains(t, err, `unknown property naming "kebab-case" (valid: asIs, camelCase, snake_case)`)
+}
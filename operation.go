@@ -1,10 +1,18 @@
 package openapi
 
 import (
+	"fmt"
 	"net/http"
 	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/talav/openapi/example"
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/model"
+	"github.com/talav/openapi/internal/sealed"
 )
 
 // Operation represents an OpenAPI operation (HTTP method + path + metadata).
@@ -13,6 +21,13 @@ type Operation struct {
 	Method string       // HTTP method (GET, POST, etc.)
 	Path   string       // URL path with parameters (e.g. "/users/:id")
 	doc    operationDoc // Operation documentation (private)
+
+	// opts are the options doc was built from, rebuilt with defaults first.
+	opts []OperationDocOption
+
+	// callers are the program counters of the declaration of the operation,
+	// resolved to its file:line only when tracing.
+	callers []uintptr
 }
 
 // OperationDocOption configures an OpenAPI operation.
@@ -48,12 +63,21 @@ type operationDoc struct {
 	// Maps to the "tags" field in the Operation Object.
 	Tags []string
 
+	// ExternalDocs provides additional external documentation for this operation.
+	// Maps to the "externalDocs" field in the Operation Object.
+	ExternalDocs *model.ExternalDocs
+
 	// Deprecated declares this operation to be deprecated.
 	// Consumers SHOULD refrain from usage of the declared operation.
 	// Default value is false.
 	// Maps to the "deprecated" field in the Operation Object.
 	Deprecated bool
 
+	// DeprecationNote explains the deprecation and names the successor
+	// operation. Set by WithDeprecation.
+	// Appended to the "description" field in the Operation Object.
+	DeprecationNote string
+
 	// Consumes specifies the MIME types that the operation can consume.
 	// This is used to generate the requestBody content map.
 	// Defaults to ["application/json"].
@@ -87,6 +111,10 @@ type operationDoc struct {
 	// the responses field in the Operation Object.
 	ResponseTypes map[int]reflect.Type
 
+	// ErrorTypes lists the error types the operation can return, declared with
+	// WithError. Their responses are mapped by MapError.
+	ErrorTypes []reflect.Type
+
 	// ResponseNamedExamples maps HTTP status codes to named examples.
 	// These examples are placed in the Media Type Object's "examples" field
 	// within responses[statusCode].content[mediaType].examples.
@@ -94,6 +122,46 @@ type operationDoc struct {
 	// https://spec.openapis.org/oas/v3.1.0#media-type-object
 	ResponseNamedExamples map[int][]example.Example
 
+	// MediaTypeExamples holds the named examples declared for a single media
+	// type with WithRequestExample and WithResponseExample.
+	MediaTypeExamples []mediaTypeExamples
+
+	// ResponseAlternatives maps HTTP status codes to additional representations
+	// of the response, declared with WithAlsoContent.
+	// Maps to extra entries in responses[statusCode].content in the Operation Object.
+	ResponseAlternatives map[int][]responseContent
+
+	// ResponseHeaders maps HTTP status codes to headers documented with
+	// options such as WithResponseHeader, in addition to the headers of the
+	// response struct.
+	// Maps to responses[statusCode].headers in the Operation Object.
+	// https://spec.openapis.org/oas/v3.1.0#response-object
+	ResponseHeaders map[int]map[string]*model.Header
+
+	// CommonResponseHeaders lists headers documented on every response of
+	// the operation, such as rate limit headers.
+	// Maps to responses[*].headers in the Operation Object.
+	CommonResponseHeaders map[string]*model.Header
+
+	// ResponseLinks maps HTTP status codes to links to other operations of
+	// the API, by link name. Targets are resolved when the operation is converted.
+	// Maps to responses[statusCode].links in the Operation Object.
+	ResponseLinks map[int]map[string]operationLink
+
+	// Parameters lists parameters documented with options such as
+	// WithRequestHeader, in addition to the fields of the request struct.
+	// Maps to the "parameters" field in the Operation Object.
+	Parameters []model.Parameter
+
+	// PartialContent documents the 206 response as the 200 response body
+	// served in parts. Set by WithRangeSupport.
+	PartialContent bool
+
+	// WebSocket holds the message types of a WebSocket endpoint, documented
+	// with WithWebSocket.
+	// Maps to the "x-websocket" extension of the Operation Object.
+	WebSocket *webSocketMessages
+
 	// Security is a declaration of which security mechanisms can be used
 	// for this operation. The list of values includes alternative security
 	// requirement objects that can be used. Only one of the security
@@ -101,12 +169,20 @@ type operationDoc struct {
 	// Maps to the "security" field in the Operation Object.
 	Security []SecurityReq
 
+	// inheritedSecurity reports whether Security holds the requirements of
+	// default options only, replaced by the requirements of the operation.
+	inheritedSecurity bool
+
 	// Extensions contains specification extensions (x-* fields).
 	// Extension keys MUST start with "x-". In OpenAPI 3.1.x, keys starting
 	// with "x-oai-" or "x-oas-" are reserved for the OpenAPI Initiative.
 	// Maps to extension fields in the Operation Object.
 	// https://spec.openapis.org/oas/v3.1.0#specification-extensions
 	Extensions map[string]any
+
+	// errs collects option misuse detected while applying options.
+	// Reported by Generate when the operation is converted.
+	errs []error
 }
 
 // SecurityReq represents a security requirement for an operation.
@@ -115,6 +191,13 @@ type SecurityReq struct {
 	Scopes []string
 }
 
+// Headers and extensions documented by WithDeprecation.
+const (
+	headerDeprecation = "Deprecation"
+	headerSunset      = "Sunset"
+	extensionSunset   = "x-sunset"
+)
+
 // newOperation creates an Operation from method, path, and options.
 func newOperation(method, path string, opts ...OperationDocOption) Operation {
 	doc := operationDoc{
@@ -122,18 +205,44 @@ func newOperation(method, path string, opts ...OperationDocOption) Operation {
 		Produces:              []string{"application/json"},
 		ResponseTypes:         make(map[int]reflect.Type),
 		ResponseNamedExamples: make(map[int][]example.Example),
+		ResponseAlternatives:  make(map[int][]responseContent),
+		ResponseHeaders:       make(map[int]map[string]*model.Header),
 	}
 	for _, opt := range opts {
 		opt(&doc)
 	}
 
 	return Operation{
-		Method: method,
-		Path:   path,
-		doc:    doc,
+		Method:  method,
+		Path:    path,
+		doc:     doc,
+		opts:    opts,
+		callers: callers(),
 	}
 }
 
+// withDefaults returns the operation rebuilt with the default options applied
+// before its own, so that its own options take precedence. Security
+// requirements of the operation replace the default ones rather than adding
+// alternatives to them.
+func (o Operation) withDefaults(defaults []OperationDocOption) Operation {
+	if len(defaults) == 0 {
+		return o
+	}
+
+	opts := append(slices.Clone(defaults), inheritDefaults)
+	op := newOperation(o.Method, o.Path, append(opts, o.opts...)...)
+	op.callers = o.callers
+
+	return op
+}
+
+// inheritDefaults marks the settings of the default options as inherited by the
+// options of the operation that follow.
+func inheritDefaults(d *operationDoc) {
+	d.inheritedSecurity = true
+}
+
 // GET creates an Operation for a GET request.
 //
 // Example:
@@ -230,7 +339,39 @@ func TRACE(path string, opts ...OperationDocOption) Operation {
 	return newOperation(http.MethodTrace, path, opts...)
 }
 
-// WithSummary sets the operation summary.
+// MethodQuery is the QUERY method: a safe, idempotent request carrying its query in the body.
+const MethodQuery = "QUERY"
+
+// QUERY creates an Operation for a QUERY request.
+//
+// OpenAPI 3.0 and 3.1 have no field for QUERY: it is documented under the
+// x-query extension of the path item.
+//
+// Example:
+//
+//	openapi.QUERY("/users/search",
+//	    openapi.WithSummary("Search users"),
+//	    openapi.WithRequest(SearchUsersRequest{}),
+//	    openapi.WithResponse(200, []User{}),
+//	)
+func QUERY(path string, opts ...OperationDocOption) Operation {
+	return newOperation(MethodQuery, path, opts...)
+}
+
+// Method creates an Operation for an arbitrary HTTP method, such as a WebDAV
+// method. Methods without a path item field in the target OpenAPI version are
+// documented under the x-additionalOperations extension of the path item.
+//
+// Example:
+//
+//	openapi.Method("PROPFIND", "/files/*path",
+//	    openapi.WithSummary("List file properties"),
+//	)
+func Method(method, path string, opts ...OperationDocOption) Operation {
+	return newOperation(method, path, opts...)
+}
+
+// WithSummary sets the operation summary. Set multiple times, the last value wins.
 //
 // Example:
 //
@@ -241,7 +382,8 @@ func WithSummary(s string) OperationDocOption {
 	return func(d *operationDoc) { d.Summary = s }
 }
 
-// WithDescription sets the operation description.
+// WithDescription sets the operation description. Set multiple times, the last
+// value wins. The note of WithDeprecation is appended to it.
 //
 // Example:
 //
@@ -252,7 +394,7 @@ func WithDescription(s string) OperationDocOption {
 	return func(d *operationDoc) { d.Description = s }
 }
 
-// WithOperationID sets a custom operation ID.
+// WithOperationID sets a custom operation ID. Set multiple times, the last value wins.
 //
 // Example:
 //
@@ -263,6 +405,23 @@ func WithOperationID(id string) OperationDocOption {
 	return func(d *operationDoc) { d.OperationID = id }
 }
 
+// WithOperationExternalDocs sets the external documentation of the operation.
+// Set multiple times, the last value wins.
+//
+// Example:
+//
+//	openapi.POST("/payments",
+//	    openapi.WithOperationExternalDocs("https://docs.example.com/payments", "Payment flows"),
+//	)
+func WithOperationExternalDocs(url, description string) OperationDocOption {
+	return func(d *operationDoc) {
+		d.ExternalDocs = &model.ExternalDocs{
+			URL:         url,
+			Description: description,
+		}
+	}
+}
+
 // WithRequest sets the request type and optionally provides examples.
 //
 // Example:
@@ -279,7 +438,90 @@ func WithRequest(req any, examples ...example.Example) OperationDocOption {
 	}
 }
 
-// WithResponse sets the response schema and examples for a status code.
+// ResponseOption configures a response declared with WithResponse. It is
+// implemented by:
+//   - example.Example: a named example for the primary representation
+//   - WithExamples: named examples for the primary representation
+//   - WithAlsoContent and WithCSVContent: an additional representation of the response
+type ResponseOption interface {
+	ResponseOption(sealed.ResponseOption)
+}
+
+// responseExamples are named examples for the primary representation of a response.
+type responseExamples []example.Example
+
+// ResponseOption makes responseExamples a ResponseOption.
+func (responseExamples) ResponseOption(sealed.ResponseOption) {}
+
+// WithExamples adds named examples to the primary representation of a response,
+// for examples held in a slice. Use it as an option of WithResponse.
+//
+// Example:
+//
+//	openapi.WithResponse(200, User{}, openapi.WithExamples(userExamples...))
+func WithExamples(examples ...example.Example) ResponseOption {
+	return responseExamples(examples)
+}
+
+// parseStatus returns the status code of a response, or the class of a range of
+// status codes such as "3XX", which stands for the range in the responses map.
+func parseStatus[S Status](code S) (int, error) {
+	v := reflect.ValueOf(code)
+	if v.Kind() != reflect.String {
+		// Invalid status codes are reported by spec validation, except for
+		// those standing for ranges
+		status := int(v.Int())
+		if build.StatusKey(status) != strconv.Itoa(status) {
+			return 0, fmt.Errorf("response %d: invalid status code", status)
+		}
+
+		return status, nil
+	}
+
+	s := v.String()
+	if len(s) == 3 && strings.EqualFold(s[1:], "XX") && s[0] >= '1' && s[0] <= '5' {
+		return int(s[0] - '0'), nil
+	}
+	status, err := strconv.Atoi(s)
+	if err != nil || status < 100 || status > 599 {
+		return 0, fmt.Errorf("response %q: invalid status code or range", s)
+	}
+
+	return status, nil
+}
+
+// responseContent is an additional representation of a response.
+type responseContent struct {
+	contentType string
+	bodyType    reflect.Type
+	examples    []example.Example
+
+	// csvRows documents bodyType as the row of a CSV representation.
+	csvRows bool
+}
+
+// ResponseOption makes responseContent a ResponseOption.
+func (responseContent) ResponseOption(sealed.ResponseOption) {}
+
+// Status is an HTTP status code such as 200 or "301", or a range of status
+// codes such as "3XX".
+type Status interface {
+	~int | ~string
+}
+
+// redirectStatuses lists the status codes of redirects sending the Location header.
+var redirectStatuses = []int{
+	http.StatusMovedPermanently,
+	http.StatusFound,
+	http.StatusSeeOther,
+	http.StatusTemporaryRedirect,
+	http.StatusPermanentRedirect,
+}
+
+// WithResponse sets the response schema and examples for a status code, or a
+// range of status codes such as "3XX". A nil response documents a response
+// without body, or with the representations of WithAlsoContent only, and redirects (301, 302, 303, 307 and 308) document the
+// Location header unless the response struct declares it.
 //
 // Supports two patterns:
 //
@@ -306,6 +548,12 @@ func WithRequest(req any, examples ...example.Example) OperationDocOption {
 //	    openapi.WithResponse(200, UserResponse{}),
 //	)
 //
+// Other types, such as slices and maps, are documented as JSON bodies as they are:
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, []User{}),
+//	)
+//
 // With named examples:
 //
 //	openapi.GET("/users/:id",
@@ -317,22 +565,176 @@ func WithRequest(req any, examples ...example.Example) OperationDocOption {
 //	        ),
 //	    ),
 //	)
-func WithResponse(status int, resp any, examples ...example.Example) OperationDocOption {
+//
+// The options are ResponseOptions, not examples: examples held in a slice are
+// passed with WithExamples, as spreading a []example.Example does not compile.
+//
+//	openapi.WithResponse(200, User{}, openapi.WithExamples(userExamples...))
+//
+// With additional negotiable representations:
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, []User{},
+//	        openapi.WithAlsoContent("text/csv", UsersCSV{}),
+//	    ),
+//	)
+//
+// With redirects and ranges:
+//
+//	openapi.GET("/files/:id",
+//	    openapi.WithResponse(200, File{}),
+//	    openapi.WithResponse(http.StatusFound, nil),
+//	    openapi.WithResponse("5XX", ErrorModel{}),
+//	)
+func WithResponse[S Status](code S, resp any, opts ...ResponseOption) OperationDocOption {
 	return func(d *operationDoc) {
-		if resp == nil {
-			d.ResponseTypes[status] = nil
+		status, err := parseStatus(code)
+		if err != nil {
+			d.errs = append(d.errs, err)
 
 			return
 		}
+		if slices.Contains(redirectStatuses, status) {
+			d.addResponseHeader(status, headerLocation, &model.Header{
+				Description: "URL of the redirect target",
+				Required:    true,
+				Schema:      &model.Schema{Type: "string", Format: "uri-reference"},
+			})
+		}
 
+		// A nil response has no primary representation, but may have others
 		d.ResponseTypes[status] = reflect.TypeOf(resp)
-		if len(examples) > 0 {
+
+		var examples []example.Example
+		for _, opt := range opts {
+			switch o := opt.(type) {
+			case example.Example:
+				examples = append(examples, o)
+			case responseExamples:
+				examples = append(examples, o...)
+			case responseContent:
+				d.ResponseAlternatives[status] = append(d.ResponseAlternatives[status], o)
+			}
+		}
+		switch {
+		case len(examples) > 0 && resp == nil:
+			d.errs = append(d.errs, fmt.Errorf("response %s: examples need a body; attach them to the representations of WithAlsoContent", build.StatusKey(status)))
+		case len(examples) > 0:
 			d.ResponseNamedExamples[status] = examples
 		}
 	}
 }
 
-// WithTags adds tags to the operation.
+// WithAlsoContent documents an additional representation of a response.
+// Use it as an option of WithResponse when a status code can be returned in
+// several negotiable content types, each with its own schema.
+//
+// The body follows the same rules as the WithResponse body: either a plain
+// type or a struct with a body-tagged field and header fields.
+// Examples are attached to this representation only.
+//
+// Example:
+//
+//	type UsersCSV struct {
+//	    Body string `body:"text"`
+//	}
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, []User{},
+//	        openapi.WithAlsoContent("text/csv", UsersCSV{}),
+//	    ),
+//	)
+func WithAlsoContent(contentType string, body any, examples ...example.Example) ResponseOption {
+	return responseContent{
+		contentType: contentType,
+		bodyType:    reflect.TypeOf(body),
+		examples:    examples,
+	}
+}
+
+// WithCSVContent documents a text/csv representation of a response, with a row per
+// value of the given struct. Use it as an option of WithResponse.
+//
+// The schema is a string listing the columns in the x-columns extension, and the
+// example is the header row. Columns are named after the fields like JSON properties,
+// in declaration order. Examples replace the header row example.
+//
+// Example:
+//
+//	type UserRow struct {
+//	    ID    int    `json:"id"`
+//	    Email string `json:"email"`
+//	}
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, UsersResponse{},
+//	        openapi.WithCSVContent(UserRow{}),
+//	    ),
+//	)
+func WithCSVContent(row any, examples ...example.Example) ResponseOption {
+	return responseContent{
+		contentType: build.ContentTypeCSV,
+		bodyType:    reflect.TypeOf(row),
+		examples:    examples,
+		csvRows:     true,
+	}
+}
+
+// WithResponseHeader documents a string header sent with the response for a status code.
+// If no response is declared for the status code, a response without body is documented.
+// Headers declared on the response struct take precedence.
+//
+// Example:
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, []User{}),
+//	    openapi.WithResponseHeader(200, "X-Total-Count", "Total number of users"),
+//	)
+func WithResponseHeader(status int, name, description string) OperationDocOption {
+	return func(d *operationDoc) {
+		d.addResponseHeader(status, name, &model.Header{
+			Description: description,
+			Schema:      &model.Schema{Type: "string"},
+		})
+	}
+}
+
+// WithRequestHeader documents an optional string request header.
+// Headers declared on the request struct take precedence.
+//
+// Example:
+//
+//	openapi.GET("/users",
+//	    openapi.WithRequestHeader("X-Request-ID", "Correlation ID of the request"),
+//	)
+func WithRequestHeader(name, description string) OperationDocOption {
+	return func(d *operationDoc) {
+		d.Parameters = append(d.Parameters, model.Parameter{
+			Name:        name,
+			In:          string(InHeader),
+			Description: description,
+			Schema:      &model.Schema{Type: "string"},
+		})
+	}
+}
+
+// addResponseHeader documents a header for the response of a status code.
+func (d *operationDoc) addResponseHeader(status int, name string, header *model.Header) {
+	if d.ResponseHeaders[status] == nil {
+		d.ResponseHeaders[status] = make(map[string]*model.Header)
+	}
+	d.ResponseHeaders[status][name] = header
+}
+
+// addCommonResponseHeader documents a header for every response of the operation.
+func (d *operationDoc) addCommonResponseHeader(name string, header *model.Header) {
+	if d.CommonResponseHeaders == nil {
+		d.CommonResponseHeaders = make(map[string]*model.Header)
+	}
+	d.CommonResponseHeaders[name] = header
+}
+
+// WithTags adds tags to the operation. Set multiple times, the tags accumulate.
 //
 // Example:
 //
@@ -343,7 +745,9 @@ func WithTags(tags ...string) OperationDocOption {
 	return func(d *operationDoc) { d.Tags = append(d.Tags, tags...) }
 }
 
-// WithSecurity adds a security requirement.
+// WithSecurity adds a security requirement. Requirements are alternatives: any
+// of them authorizes a request. Requirements of an operation replace those of
+// WithOperationDefaults and Group.
 //
 // Example:
 //
@@ -360,6 +764,10 @@ func WithSecurity(scheme string, scopes ...string) OperationDocOption {
 		if scopes == nil {
 			scopes = []string{}
 		}
+		if d.inheritedSecurity {
+			d.Security = nil
+			d.inheritedSecurity = false
+		}
 		d.Security = append(d.Security, SecurityReq{
 			Scheme: scheme,
 			Scopes: scopes,
@@ -378,6 +786,54 @@ func WithDeprecated() OperationDocOption {
 	return func(d *operationDoc) { d.Deprecated = true }
 }
 
+// WithDeprecation marks the operation as deprecated and documents the
+// deprecation policy in a machine-readable way:
+//   - the Deprecation and Sunset response headers (RFC 9745, RFC 8594) on every response
+//   - the x-sunset extension with the sunset date (YYYY-MM-DD)
+//   - the reason and the successor operation appended to the description
+//
+// A zero sunset date and empty reason or successor are omitted. The sunset date
+// is the date of the sunset time in UTC, like the Sunset header.
+//
+// Example:
+//
+//	openapi.GET("/v1/users",
+//	    openapi.WithDeprecation("Replaced by the v2 API",
+//	        time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC), "listUsersV2"),
+//	)
+func WithDeprecation(reason string, sunset time.Time, successorOperationID string) OperationDocOption {
+	return func(d *operationDoc) {
+		d.Deprecated = true
+		sunset = sunset.UTC()
+
+		var note []string
+		if reason != "" {
+			note = append(note, "Deprecated: "+reason+".")
+		}
+		if !sunset.IsZero() {
+			note = append(note, "Sunset on "+sunset.Format(time.DateOnly)+".")
+		}
+		if successorOperationID != "" {
+			note = append(note, "Use operation "+successorOperationID+" instead.")
+		}
+		d.DeprecationNote = strings.Join(note, " ")
+
+		d.addCommonResponseHeader(headerDeprecation, &model.Header{
+			Description: "Indicates that the operation is deprecated (RFC 9745)",
+			Schema:      &model.Schema{Type: "string"},
+		})
+
+		if sunset.IsZero() {
+			return
+		}
+		d.addCommonResponseHeader(headerSunset, &model.Header{
+			Description: "Date after which the operation will stop working (RFC 8594), " + sunset.Format(http.TimeFormat),
+			Schema:      &model.Schema{Type: "string"},
+		})
+		WithOperationExtension(extensionSunset, sunset.Format(time.DateOnly))(d)
+	}
+}
+
 // WithConsumes sets the content types that this operation accepts.
 //
 // Example:
@@ -400,7 +856,8 @@ func WithProduces(contentTypes ...string) OperationDocOption {
 	return func(d *operationDoc) { d.Produces = contentTypes }
 }
 
-// WithOperationExtension adds a specification extension to the operation.
+// WithOperationExtension adds a specification extension to the operation. Set
+// multiple times with the same key, the last value wins.
 //
 // Extension keys MUST start with "x-". In OpenAPI 3.1.x, keys starting with
 // "x-oai-" or "x-oas-" are reserved for the OpenAPI Initiative.
@@ -3,7 +3,11 @@ package openapi
 import (
 	"context"
 	"encoding/json"
+	"maps"
+	"net/http"
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -169,6 +173,76 @@ func TestGenerate_OperationMetadata(t *testing.T) {
 	assert.Equal(t, []any{"read", "write"}, secReq["bearerAuth"])
 }
 
+func TestGenerate_OperationExternalDocs(t *testing.T) {
+	type emptyResp struct {
+		Body struct{} `body:"structured"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+
+		result, err := api.Generate(context.Background(),
+			GET("/test",
+				WithOperationExternalDocs("https://docs.example.com/old", ""),
+				WithOperationExternalDocs("https://docs.example.com/test", "Test guide"),
+				WithResponse(200, emptyResp{}),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				ExternalDocs struct {
+					URL         string `json:"url"`
+					Description string `json:"description"`
+				} `json:"externalDocs"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		docs := spec.Paths["/test"]["get"].ExternalDocs
+		assert.Equal(t, "https://docs.example.com/test", docs.URL, "the last external docs should win")
+		assert.Equal(t, "Test guide", docs.Description)
+	})
+}
+
+func TestGenerate_RepeatedOptions(t *testing.T) {
+	type emptyResp struct {
+		Body struct{} `body:"structured"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/test",
+			WithSummary("First"),
+			WithSummary("Second"),
+			WithOperationID("firstOp"),
+			WithOperationID("secondOp"),
+			WithOperationExtension("x-tier", "free"),
+			WithOperationExtension("x-tier", "paid"),
+			WithTags("users"),
+			WithTags("admin"),
+			WithResponse(200, emptyResp{}),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Summary     string   `json:"summary"`
+			OperationID string   `json:"operationId"`
+			Tier        string   `json:"x-tier"`
+			Tags        []string `json:"tags"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	op := spec.Paths["/test"]["get"]
+	assert.Equal(t, "Second", op.Summary)
+	assert.Equal(t, "secondOp", op.OperationID)
+	assert.Equal(t, "paid", op.Tier)
+	assert.Equal(t, []string{"users", "admin"}, op.Tags)
+}
+
 func TestGenerate_WithOptions(t *testing.T) {
 	type emptyResp struct {
 		Body struct{} `body:"structured"`
@@ -305,3 +379,465 @@ func TestGenerate_ResponseExamples(t *testing.T) {
 	assert.Contains(t, examples, "success")
 	assert.Contains(t, examples, "cached")
 }
+
+func TestGenerate_ResponseAlsoContent(t *testing.T) {
+	type User struct {
+		ID int `json:"id"`
+	}
+	type UsersJSON struct {
+		Body []User `body:"structured"`
+	}
+	type UsersCSV struct {
+		Body string `body:"text"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithInfoTitle("Test"),
+			WithInfoVersion("1.0.0"),
+			WithVersion(version),
+			WithValidation(true),
+		)
+
+		result, err := api.Generate(context.Background(),
+			GET("/test",
+				WithResponse(200, UsersJSON{},
+					example.New("json", []User{{ID: 1}}),
+					WithAlsoContent("text/csv", UsersCSV{}, example.New("csv", "id\n1\n")),
+				),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		op := getOperation(t, spec, "get")
+		responses, ok := op["responses"].(map[string]any)
+		require.True(t, ok, "responses must be a map")
+		resp, ok := responses["200"].(map[string]any)
+		require.True(t, ok, "200 response must be a map")
+		content, ok := resp["content"].(map[string]any)
+		require.True(t, ok, "content must be a map")
+		require.Len(t, content, 2)
+
+		jsonContent, ok := content["application/json"].(map[string]any)
+		require.True(t, ok, "application/json content must be a map")
+		assert.Contains(t, jsonContent["examples"], "json")
+		assert.NotContains(t, jsonContent["examples"], "csv")
+
+		csvContent, ok := content["text/csv"].(map[string]any)
+		require.True(t, ok, "text/csv content must be a map")
+		assert.Equal(t, map[string]any{"type": "string"}, csvContent["schema"])
+		assert.Contains(t, csvContent["examples"], "csv")
+		assert.NotContains(t, csvContent["examples"], "json")
+	})
+}
+
+func TestGenerate_ResponseCSVContent(t *testing.T) {
+	type UserRow struct {
+		ID       int    `json:"id"`
+		Name     string `json:"name,omitempty"`
+		Email    string `json:"e-mail"`
+		Password string `json:"password" openapi:"hidden"`
+		Note     string
+	}
+	type UsersJSON struct {
+		Body []UserRow `body:"structured"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+
+		result, err := api.Generate(context.Background(),
+			GET("/users", WithResponse(200, UsersJSON{}, WithCSVContent(UserRow{}))),
+			GET("/admins", WithResponse(200, UsersJSON{}, WithCSVContent(UserRow{}, example.New("one", "id\n1\n")))),
+		)
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		csvContent := func(path string) map[string]any {
+			paths, _ := spec["paths"].(map[string]any)
+			item, _ := paths[path].(map[string]any)
+			op, _ := item["get"].(map[string]any)
+			responses, _ := op["responses"].(map[string]any)
+			resp, _ := responses["200"].(map[string]any)
+			content, _ := resp["content"].(map[string]any)
+			require.Contains(t, content, "application/json")
+			csv, ok := content["text/csv"].(map[string]any)
+			require.True(t, ok, "text/csv content must be a map")
+
+			return csv
+		}
+
+		users := csvContent("/users")
+		assert.Equal(t, map[string]any{
+			"type":      "string",
+			"x-columns": []any{"id", "name", "e-mail", "Note"},
+		}, users["schema"])
+		assert.Equal(t, "id,name,e-mail,Note\n", users["example"])
+
+		admins := csvContent("/admins")
+		assert.NotContains(t, admins, "example")
+		assert.Contains(t, admins["examples"], "one")
+	})
+}
+
+func TestGenerate_ResponseCSVContentNotStruct(t *testing.T) {
+	type Response struct {
+		X string `json:"x"`
+	}
+
+	_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		GET("/test", WithResponse(200, Response{}, WithCSVContent([]string{}))),
+	)
+	require.ErrorContains(t, err, "failed to build text/csv content for response 200: CSV rows must be structs, got []string")
+}
+
+func TestGenerate_ResponseAlsoContentNil(t *testing.T) {
+	type Response struct {
+		X string `json:"x"`
+	}
+
+	_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		GET("/test", WithResponse(200, Response{}, WithAlsoContent("text/plain", nil))),
+	)
+	require.ErrorContains(t, err, "failed to build text/plain content for response 200: the body is nil")
+}
+
+func TestGenerate_ResponseNilWithAlsoContent(t *testing.T) {
+	type CSV struct {
+		Body string `body:"text"`
+	}
+
+	result, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		GET("/report", WithResponse(200, nil, WithAlsoContent("text/csv", CSV{}, example.New("rows", "id,name")))),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Examples map[string]any `json:"examples"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	content := spec.Paths["/report"]["get"].Responses["200"].Content
+	assert.Equal(t, []string{"text/csv"}, slices.Collect(maps.Keys(content)))
+	assert.Equal(t, []string{"rows"}, keys(content["text/csv"].Examples))
+
+	_, err = NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		GET("/report", WithResponse(200, nil, example.New("rows", "id,name"))),
+	)
+	require.ErrorContains(t, err, "response 200: examples need a body")
+}
+
+func TestGenerate_ResponseWithExamples(t *testing.T) {
+	type Response struct {
+		X string `json:"x"`
+	}
+	examples := []example.Example{example.New("a", Response{X: "a"}), example.New("b", Response{X: "b"})}
+
+	result, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		GET("/test", WithResponse(200, Response{}, WithExamples(examples...), example.New("c", Response{X: "c"}))),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Paths map[string]map[string]struct {
+			Responses map[string]struct {
+				Content map[string]struct {
+					Examples map[string]any `json:"examples"`
+				} `json:"content"`
+			} `json:"responses"`
+		} `json:"paths"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, keys(spec.Paths["/test"]["get"].Responses["200"].Content["application/json"].Examples))
+}
+
+func TestGenerate_NonStructResponses(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+		result, err := api.Generate(context.Background(),
+			GET("/users",
+				WithResponse(200, []User{}, example.New("alice", []User{{Name: "Alice"}})),
+				WithResponse(206, &User{}),
+				WithResponse(400, map[string]string{}),
+				WithResponse(500, ""),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Responses map[string]struct {
+					Content map[string]struct {
+						Schema struct {
+							Type                 any            `json:"type"`
+							Ref                  string         `json:"$ref"`
+							Items                map[string]any `json:"items"`
+							AdditionalProperties map[string]any `json:"additionalProperties"`
+						} `json:"schema"`
+						Examples map[string]any `json:"examples"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		responses := spec.Paths["/users"]["get"].Responses
+
+		list := responses["200"].Content["application/json"]
+		assert.Equal(t, "array", list.Schema.Type)
+		assert.Equal(t, "#/components/schemas/User", list.Schema.Items["$ref"])
+		assert.Contains(t, list.Examples, "alice")
+		assert.Equal(t, "#/components/schemas/User", responses["206"].Content["application/json"].Schema.Ref)
+		assert.Equal(t, "object", responses["400"].Content["application/json"].Schema.Type)
+		assert.Equal(t, "string", responses["500"].Content["application/json"].Schema.Type)
+	})
+}
+
+func TestGenerate_HeaderOptions(t *testing.T) {
+	type Request struct {
+		RequestID string `schema:"X-Request-ID,location=header"`
+	}
+	type Response struct {
+		Body  []string `body:"structured"`
+		Total int      `schema:"X-Total-Count,location=header"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/test",
+			WithRequest(Request{}),
+			WithResponse(200, Response{}),
+			WithRequestHeader("X-Request-ID", "ignored, declared on the request struct"),
+			WithRequestHeader("X-Tenant", "Tenant of the request"),
+			WithResponseHeader(200, "X-Total-Count", "ignored, declared on the response struct"),
+			WithResponseHeader(200, "X-Page", "Current page"),
+			WithResponseHeader(503, "Retry-After", "Seconds to wait before retrying"),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	op := getOperation(t, spec, "get")
+
+	params, ok := op["parameters"].([]any)
+	require.True(t, ok, "parameters must be an array")
+	require.Len(t, params, 2)
+	tenant, ok := params[1].(map[string]any)
+	require.True(t, ok, "parameter must be a map")
+	assert.Equal(t, "X-Tenant", tenant["name"])
+	assert.Equal(t, "header", tenant["in"])
+	assert.Equal(t, "Tenant of the request", tenant["description"])
+	assert.Equal(t, map[string]any{"type": "string"}, tenant["schema"])
+
+	responses, ok := op["responses"].(map[string]any)
+	require.True(t, ok, "responses must be a map")
+
+	okResp, ok := responses["200"].(map[string]any)
+	require.True(t, ok, "200 response must be a map")
+	headers, ok := okResp["headers"].(map[string]any)
+	require.True(t, ok, "headers must be a map")
+	assert.Equal(t, "integer", headers["X-Total-Count"].(map[string]any)["schema"].(map[string]any)["type"])
+	assert.Equal(t, "Current page", headers["X-Page"].(map[string]any)["description"])
+
+	unavailable, ok := responses["503"].(map[string]any)
+	require.True(t, ok, "503 response must be a map")
+	assert.Equal(t, "Service Unavailable", unavailable["description"])
+	assert.Contains(t, unavailable["headers"], "Retry-After")
+}
+
+func TestGenerate_ResponseHeaderOnList(t *testing.T) {
+	type User struct {
+		Name string `json:"name"`
+	}
+
+	result, err := NewAPI(WithVersion("3.1.2"), WithValidation(true)).Generate(context.Background(),
+		GET("/test",
+			WithResponse(200, []User{}),
+			WithResponseHeader(200, "X-Total-Count", "Total number of users"),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	okResp := getOperation(t, spec, "get")["responses"].(map[string]any)["200"].(map[string]any)
+	assert.Contains(t, okResp["headers"], "X-Total-Count")
+	assert.Contains(t, okResp["content"], "application/json")
+}
+
+func TestGenerate_ResponseStatusRanges(t *testing.T) {
+	type Redirect struct {
+		Location string `header:"Location" openapi:"description=Canonical URL of the user"`
+	}
+	type LowercaseRedirect struct {
+		Location string `header:"location" openapi:"description=Canonical URL of the user"`
+	}
+	type Problem struct {
+		Title string `json:"title"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+		result, err := api.Generate(context.Background(),
+			GET("/users/:id",
+				WithResponse(http.StatusEarlyHints, nil),
+				WithResponse(http.StatusMovedPermanently, Redirect{}),
+				WithResponse(http.StatusFound, LowercaseRedirect{}),
+				WithResponse("303", nil),
+				WithResponse("3xx", nil),
+				WithResponse("5XX", Problem{}),
+			),
+		)
+		require.NoError(t, err)
+
+		type header struct {
+			Description string `json:"description"`
+			Required    bool   `json:"required"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Responses map[string]struct {
+					Description string            `json:"description"`
+					Headers     map[string]header `json:"headers"`
+					Content     map[string]any    `json:"content"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		responses := spec.Paths["/users/{id}"]["get"].Responses
+		assert.ElementsMatch(t, []string{"103", "301", "302", "303", "3XX", "5XX"}, slices.Collect(maps.Keys(responses)))
+
+		assert.Equal(t, "Early Hints", responses["103"].Description)
+		assert.Empty(t, responses["103"].Content)
+		assert.Equal(t, "Canonical URL of the user", responses["301"].Headers["Location"].Description)
+		assert.Equal(t, map[string]header{"location": {Description: "Canonical URL of the user"}}, responses["302"].Headers,
+			"header names are case-insensitive")
+		assert.Equal(t, header{Description: "URL of the redirect target", Required: true}, responses["303"].Headers["Location"])
+		assert.Equal(t, "Redirection", responses["3XX"].Description)
+		assert.Empty(t, responses["3XX"].Headers)
+		assert.Equal(t, "Server error", responses["5XX"].Description)
+		assert.Contains(t, responses["5XX"].Content, "application/json")
+	})
+}
+
+func TestGenerate_ResponseInvalidStatus(t *testing.T) {
+	for _, tt := range []struct {
+		op   Operation
+		want string
+	}{
+		{GET("/test", WithResponse("6XX", nil)), `response "6XX": invalid status code or range`},
+		{GET("/test", WithResponse("OK", nil)), `response "OK": invalid status code or range`},
+		{GET("/test", WithResponse(3, nil)), "response 3: invalid status code"},
+	} {
+		_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(), tt.op)
+		require.ErrorContains(t, err, tt.want)
+	}
+}
+
+func TestGenerate_WithDeprecation(t *testing.T) {
+	type User struct {
+		ID int `json:"id"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+
+		result, err := api.Generate(context.Background(),
+			GET("/test",
+				WithDescription("Lists users."),
+				WithDeprecation("Replaced by the v2 API",
+					time.Date(2026, time.June, 30, 0, 0, 0, 0, time.UTC), "listUsersV2"),
+				WithResponse(200, User{}),
+				WithProblemResponses(404),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		op := getOperation(t, spec, "get")
+		assert.Equal(t, true, op["deprecated"])
+		assert.Equal(t, "2026-06-30", op["x-sunset"])
+		assert.Equal(t,
+			"Lists users.\n\nDeprecated: Replaced by the v2 API. Sunset on 2026-06-30. Use operation listUsersV2 instead.",
+			op["description"],
+		)
+
+		responses, ok := op["responses"].(map[string]any)
+		require.True(t, ok, "responses must be a map")
+		for status, r := range responses {
+			headers, ok := r.(map[string]any)["headers"].(map[string]any)
+			require.True(t, ok, "%s response must have headers", status)
+			assert.Contains(t, headers, "Deprecation", status)
+			sunset, ok := headers["Sunset"].(map[string]any)
+			require.True(t, ok, "%s response must have a Sunset header", status)
+			assert.Contains(t, sunset["description"], "Tue, 30 Jun 2026 00:00:00 GMT")
+		}
+	})
+}
+
+func TestGenerate_WithDeprecation_SunsetInUTC(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	// 30 June 23:00 in New York is 1 July in UTC
+	newYork := time.FixedZone("EDT", -4*60*60)
+	result, err := api.Generate(context.Background(),
+		GET("/test", WithDeprecation("", time.Date(2026, time.June, 30, 23, 0, 0, 0, newYork), "")),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	op := getOperation(t, spec, "get")
+	assert.Equal(t, "2026-07-01", op["x-sunset"])
+	assert.Equal(t, "Sunset on 2026-07-01.", op["description"])
+	responses, ok := op["responses"].(map[string]any)
+	require.True(t, ok, "responses must be a map")
+	require.NotEmpty(t, responses)
+	for _, r := range responses {
+		headers, _ := r.(map[string]any)["headers"].(map[string]any)
+		sunset, _ := headers["Sunset"].(map[string]any)
+		assert.Contains(t, sunset["description"], "Wed, 01 Jul 2026 03:00:00 GMT")
+	}
+}
+
+func TestGenerate_WithDeprecation_NoSunset(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/test", WithDeprecation("", time.Time{}, "")),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	op := getOperation(t, spec, "get")
+	assert.Equal(t, true, op["deprecated"])
+	assert.NotContains(t, op, "x-sunset")
+	assert.NotContains(t, op, "description")
+
+	headers := op["responses"].(map[string]any)["200"].(map[string]any)["headers"].(map[string]any)
+	assert.Contains(t, headers, "Deprecation")
+	assert.NotContains(t, headers, "Sunset")
+}
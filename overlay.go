@@ -0,0 +1,85 @@
+package openapi
+
+import "github.com/talav/openapi/internal/overlay"
+
+// Overlay is an OpenAPI Overlay document (https://spec.openapis.org/overlay/v1.0.0):
+// a list of actions updating or removing the parts of the specification selected
+// by JSONPath targets. Overlays let documentation teams patch descriptions and
+// examples without touching Go code.
+type Overlay struct {
+	doc *overlay.Document
+}
+
+// ParseOverlay parses an Overlay document, in YAML or JSON.
+//
+// Targets are JSONPath queries (RFC 9535) with name, wildcard and index selectors,
+// descendant segments, and filters with comparisons, existence tests and logical
+// operators; array slices and functions are not supported. As an extension,
+// member names in dot notation may contain hyphens, as in @.x-internal. Updates
+// are merged into the selected objects (nested objects merged, arrays
+// concatenated, other values replaced), appended to the selected arrays, and
+// replace other selected values.
+//
+// Example:
+//
+//	o, err := openapi.ParseOverlay([]byte(`
+//	overlay: 1.0.0
+//	info:
+//	  title: Public descriptions
+//	  version: 1.0.0
+//	actions:
+//	  - target: $.paths['/users'].get
+//	    update:
+//	      description: Lists the users of the organization.
+//	  - target: $.paths.*.*[?@.x-internal == true]
+//	    remove: true
+//	`))
+func ParseOverlay(data []byte) (*Overlay, error) {
+	doc, err := overlay.Parse(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Overlay{doc: doc}, nil
+}
+
+// Title returns the title of the overlay.
+func (o *Overlay) Title() string {
+	return o.doc.Title
+}
+
+// Version returns the version of the overlay.
+func (o *Overlay) Version() string {
+	return o.doc.Version
+}
+
+// WithOverlays applies overlays to the generated specification, in order, before
+// it is validated. Successive calls add overlays.
+//
+// Default: none
+//
+// Example:
+//
+//	o, err := openapi.ParseOverlay(data)
+//	if err != nil {
+//	    log.Fatal(err)
+//	}
+//	api := openapi.NewAPI(openapi.WithOverlays(o))
+func WithOverlays(overlays ...*Overlay) Option {
+	return func(a *API) {
+		a.Overlays = append(a.Overlays, overlays...)
+	}
+}
+
+// transformSpec applies the overlays, then the patches, to a serialized specification.
+func (a *API) transformSpec(specJSON []byte) ([]byte, error) {
+	transforms := make([]overlay.Transform, 0, len(a.Overlays)+len(a.patches))
+	for _, o := range a.Overlays {
+		transforms = append(transforms, o.doc)
+	}
+	for _, p := range a.patches {
+		transforms = append(transforms, p)
+	}
+
+	return overlay.ApplyJSON(specJSON, transforms...)
+}
@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithOverlays(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	o, err := ParseOverlay([]byte(`
+overlay: 1.0.0
+info:
+  title: Public docs
+  version: 1.0.0
+actions:
+  - target: $.paths['/users'].get
+    update:
+      description: Lists the users of the organization.
+  - target: $.paths.*[?@.operationId == 'purgeUsers']
+    remove: true
+`))
+	require.NoError(t, err)
+	assert.Equal(t, "Public docs", o.Title())
+	assert.Equal(t, "1.0.0", o.Version())
+
+	ops := []Operation{
+		GET("/users", WithOperationID("listUsers"), WithResponse(200, user{})),
+		DELETE("/users", WithOperationID("purgeUsers"), WithResponse(200, user{})),
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithInfoTitle("Test"), WithInfoVersion("1.0.0"), WithVersion(version), WithOverlays(o))
+
+		result, err := api.Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				OperationID string `json:"operationId"`
+				Description string `json:"description"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		require.Contains(t, spec.Paths, "/users")
+		assert.Equal(t, "Lists the users of the organization.", spec.Paths["/users"]["get"].Description)
+		assert.NotContains(t, spec.Paths["/users"], "delete")
+
+		var buf bytes.Buffer
+		_, err = api.GenerateTo(context.Background(), &buf, ops...)
+		require.NoError(t, err)
+		assert.JSONEq(t, string(result.JSON), buf.String())
+	})
+}
+
+func TestWithOverlays_Validated(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	o, err := ParseOverlay([]byte(`
+overlay: 1.0.0
+info: {title: Broken, version: 1.0.0}
+actions:
+  - target: $.info
+    remove: true
+`))
+	require.NoError(t, err)
+
+	api := NewAPI(WithInfoTitle("Test"), WithInfoVersion("1.0.0"), WithValidation(true), WithOverlays(o))
+	_, err = api.Generate(context.Background(), GET("/users", WithResponse(200, user{})))
+	require.Error(t, err, "overlaid spec must be validated")
+}
+
+func TestParseOverlay_Invalid(t *testing.T) {
+	_, err := ParseOverlay([]byte("overlay: 1.0.0\ninfo: {title: T, version: '1'}\nactions: [{target: 'users', remove: true}]"))
+	assert.Error(t, err)
+}
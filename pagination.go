@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// PaginationStyle selects the query parameters documented by WithPagination.
+type PaginationStyle string
+
+const (
+	// PaginationOffset documents limit/offset pagination.
+	PaginationOffset PaginationStyle = "offset"
+
+	// PaginationCursor documents cursor-based pagination.
+	PaginationCursor PaginationStyle = "cursor"
+)
+
+// Pagination query parameter and header names.
+const (
+	paramLimit       = "limit"
+	paramOffset      = "offset"
+	paramCursor      = "cursor"
+	headerLink       = "Link"
+	headerTotalCount = "X-Total-Count"
+)
+
+// Default bounds of the limit query parameter.
+const (
+	defaultPageLimit = 20
+	maximumPageLimit = 100
+)
+
+// PaginationOption configures WithPagination.
+type PaginationOption func(*pagination)
+
+// pagination is the configuration of WithPagination.
+type pagination struct {
+	defaultLimit int
+	maximumLimit int
+	totalCount   *bool
+	errs         []error
+}
+
+// WithPageLimit sets the default and maximum of the limit query parameter,
+// 20 and 100 unless set. A maximum of 0 documents no maximum.
+//
+// Example:
+//
+//	openapi.WithPagination(openapi.PaginationOffset,
+//	    openapi.WithPageLimit(50, 500),
+//	)
+func WithPageLimit(defaultLimit, maximum int) PaginationOption {
+	return func(p *pagination) {
+		if defaultLimit < 1 || maximum != 0 && maximum < defaultLimit {
+			p.errs = append(p.errs, fmt.Errorf("invalid page limit: default %d, maximum %d", defaultLimit, maximum))
+
+			return
+		}
+		p.defaultLimit = defaultLimit
+		p.maximumLimit = maximum
+	}
+}
+
+// WithTotalCountHeader sets whether the X-Total-Count header is documented on
+// the 200 response. By default it is for offset pagination only: cursor-based
+// APIs rarely count the items across all pages.
+//
+// Example:
+//
+//	openapi.WithPagination(openapi.PaginationCursor,
+//	    openapi.WithTotalCountHeader(true),
+//	)
+func WithTotalCountHeader(enabled bool) PaginationOption {
+	return func(p *pagination) {
+		p.totalCount = &enabled
+	}
+}
+
+// Paginated is the response envelope for limit/offset pagination.
+// Generic instantiations are named after the item type, e.g. Paginated[User]
+// becomes the PaginatedUser schema.
+//
+// Example:
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, openapi.Paginated[User]{}),
+//	    openapi.WithPagination(openapi.PaginationOffset),
+//	)
+type Paginated[T any] struct {
+	Items  []T `json:"items" openapi:"required,description=Items of the current page"`
+	Total  int `json:"total" validate:"min=0" openapi:"required,description=Total number of items across all pages"`
+	Limit  int `json:"limit" validate:"min=1" openapi:"required,description=Maximum number of items per page"`
+	Offset int `json:"offset" validate:"min=0" openapi:"required,description=Number of items skipped before the current page"`
+}
+
+// CursorPaginated is the response envelope for cursor-based pagination.
+// NextCursor is empty on the last page.
+//
+// Example:
+//
+//	openapi.GET("/events",
+//	    openapi.WithResponse(200, openapi.CursorPaginated[Event]{}),
+//	    openapi.WithPagination(openapi.PaginationCursor),
+//	)
+type CursorPaginated[T any] struct {
+	Items      []T    `json:"items" openapi:"required,description=Items of the current page"`
+	NextCursor string `json:"nextCursor,omitempty" openapi:"description=Cursor of the next page (empty on the last page)"`
+}
+
+// WithPagination documents a paginated list endpoint. It adds the query
+// parameters of the given style (limit and offset, or limit and cursor) and
+// the Link header on the 200 response, with the X-Total-Count header for
+// offset pagination. Options change the bounds of the limit and whether the
+// X-Total-Count header is documented.
+//
+// Combine it with the Paginated or CursorPaginated envelope, or with any
+// response type of your own.
+//
+// Example:
+//
+//	openapi.GET("/users",
+//	    openapi.WithResponse(200, openapi.Paginated[User]{}),
+//	    openapi.WithPagination(openapi.PaginationOffset),
+//	)
+func WithPagination(style PaginationStyle, opts ...PaginationOption) OperationDocOption {
+	return func(d *operationDoc) {
+		p := pagination{defaultLimit: defaultPageLimit, maximumLimit: maximumPageLimit}
+		for _, opt := range opts {
+			opt(&p)
+		}
+		d.errs = append(d.errs, p.errs...)
+
+		limit := &model.Schema{
+			Type:    "integer",
+			Minimum: &model.Bound{Value: 1},
+			Default: p.defaultLimit,
+		}
+		if p.maximumLimit != 0 {
+			limit.Maximum = &model.Bound{Value: float64(p.maximumLimit)}
+		}
+		d.Parameters = append(d.Parameters, model.Parameter{
+			Name:        paramLimit,
+			In:          string(InQuery),
+			Description: "Maximum number of items to return",
+			Schema:      limit,
+		})
+
+		switch style {
+		case PaginationCursor:
+			d.Parameters = append(d.Parameters, model.Parameter{
+				Name:        paramCursor,
+				In:          string(InQuery),
+				Description: "Opaque cursor returned by the previous page",
+				Schema:      &model.Schema{Type: "string"},
+			})
+		case PaginationOffset:
+			d.Parameters = append(d.Parameters, model.Parameter{
+				Name:        paramOffset,
+				In:          string(InQuery),
+				Description: "Number of items to skip",
+				Schema: &model.Schema{
+					Type:    "integer",
+					Minimum: &model.Bound{Value: 0},
+					Default: 0,
+				},
+			})
+		default:
+			d.errs = append(d.errs, fmt.Errorf("unsupported pagination style %q", style))
+		}
+
+		d.addResponseHeader(http.StatusOK, headerLink, &model.Header{
+			Description: "Links to related pages with rel next, prev, first or last (RFC 8288)",
+			Schema:      &model.Schema{Type: "string"},
+		})
+		if p.totalCount == nil && style == PaginationOffset || p.totalCount != nil && *p.totalCount {
+			d.addResponseHeader(http.StatusOK, headerTotalCount, &model.Header{
+				Description: "Total number of items across all pages",
+				Schema:      &model.Schema{Type: "integer", Minimum: &model.Bound{Value: 0}},
+			})
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type paginatedUser struct {
+	ID int `json:"id"`
+}
+
+func TestGenerate_PaginationOffset(t *testing.T) {
+	api := NewAPI(
+		WithInfoTitle("Test"),
+		WithInfoVersion("1.0.0"),
+		WithVersion("3.1.2"),
+		WithValidation(true),
+	)
+
+	result, err := api.Generate(context.Background(),
+		GET("/test",
+			WithResponse(200, Paginated[paginatedUser]{}),
+			WithPagination(PaginationOffset),
+		),
+	)
+	require.NoError(t, err)
+
+	normalized, err := normalizeJSON(result.JSON)
+	require.NoError(t, err)
+
+	expected := `{
+  "components": {
+    "schemas": {
+      "PaginatedPaginatedUser": {
+        "properties": {
+          "items": {
+            "description": "Items of the current page",
+            "items": {
+              "$ref": "#/components/schemas/PaginatedUser"
+            },
+            "type": "array"
+          },
+          "limit": {
+            "description": "Maximum number of items per page",
+            "minimum": 1,
+            "type": "integer"
+          },
+          "offset": {
+            "description": "Number of items skipped before the current page",
+            "minimum": 0,
+            "type": "integer"
+          },
+          "total": {
+            "description": "Total number of items across all pages",
+            "minimum": 0,
+            "type": "integer"
+          }
+        },
+        "required": [
+          "items",
+          "total",
+          "limit",
+          "offset"
+        ],
+        "type": "object"
+      },
+      "PaginatedUser": {
+        "properties": {
+          "id": {
+            "format": "int64",
+            "type": "integer"
+          }
+        },
+        "type": "object"
+      }
+    }
+  },
+  "info": {
+    "title": "Test",
+    "version": "1.0.0"
+  },
+  "openapi": "3.1.2",
+  "paths": {
+    "/test": {
+      "get": {
+        "parameters": [
+          {
+            "description": "Maximum number of items to return",
+            "in": "query",
+            "name": "limit",
+            "schema": {
+              "default": 20,
+              "maximum": 100,
+              "minimum": 1,
+              "type": "integer"
+            }
+          },
+          {
+            "description": "Number of items to skip",
+            "in": "query",
+            "name": "offset",
+            "schema": {
+              "default": 0,
+              "minimum": 0,
+              "type": "integer"
+            }
+          }
+        ],
+        "responses": {
+          "200": {
+            "content": {
+              "application/json": {
+                "schema": {
+                  "$ref": "#/components/schemas/PaginatedPaginatedUser"
+                }
+              }
+            },
+            "description": "OK",
+            "headers": {
+              "Link": {
+                "description": "Links to related pages with rel next, prev, first or last (RFC 8288)",
+                "schema": {
+                  "type": "string"
+                }
+              },
+              "X-Total-Count": {
+                "description": "Total number of items across all pages",
+                "schema": {
+                  "minimum": 0,
+                  "type": "integer"
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+	assert.Equal(t, expected, normalized)
+}
+
+func TestGenerate_PaginationCursor(t *testing.T) {
+	api := NewAPI(WithVersion("3.0.4"), WithValidation(true))
+
+	result, err := api.Generate(context.Background(),
+		GET("/test",
+			WithResponse(200, CursorPaginated[paginatedUser]{}),
+			WithPagination(PaginationCursor),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	op := getOperation(t, spec, "get")
+	params, ok := op["parameters"].([]any)
+	require.True(t, ok, "parameters must be an array")
+	require.Len(t, params, 2)
+	assert.Equal(t, "limit", params[0].(map[string]any)["name"])
+	assert.Equal(t, "cursor", params[1].(map[string]any)["name"])
+
+	headers := op["responses"].(map[string]any)["200"].(map[string]any)["headers"].(map[string]any)
+	assert.Contains(t, headers, "Link")
+	assert.NotContains(t, headers, "X-Total-Count")
+
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	envelope, ok := schemas["CursorPaginatedPaginatedUser"].(map[string]any)
+	require.True(t, ok, "envelope schema must exist")
+	assert.Equal(t, []any{"items"}, envelope["required"])
+	assert.Contains(t, envelope["properties"], "nextCursor")
+}
+
+func TestGenerate_PaginationUnsupportedStyle(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	_, err := api.Generate(context.Background(),
+		GET("/test", WithPagination("page")),
+	)
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported pagination style "page"`)
+}
+
+func TestGenerate_PaginationOptions(t *testing.T) {
+	tests := []struct {
+		name       string
+		style      PaginationStyle
+		opts       []PaginationOption
+		limit      map[string]any
+		totalCount bool
+	}{
+		{
+			name:       "page limit",
+			style:      PaginationOffset,
+			opts:       []PaginationOption{WithPageLimit(50, 500)},
+			limit:      map[string]any{"type": "integer", "minimum": float64(1), "maximum": float64(500), "default": float64(50)},
+			totalCount: true,
+		},
+		{
+			name:  "no maximum",
+			style: PaginationCursor,
+			opts:  []PaginationOption{WithPageLimit(10, 0)},
+			limit: map[string]any{"type": "integer", "minimum": float64(1), "default": float64(10)},
+		},
+		{
+			name:       "cursor with total count",
+			style:      PaginationCursor,
+			opts:       []PaginationOption{WithTotalCountHeader(true)},
+			limit:      map[string]any{"type": "integer", "minimum": float64(1), "maximum": float64(100), "default": float64(20)},
+			totalCount: true,
+		},
+		{
+			name:  "offset without total count",
+			style: PaginationOffset,
+			opts:  []PaginationOption{WithTotalCountHeader(false)},
+			limit: map[string]any{"type": "integer", "minimum": float64(1), "maximum": float64(100), "default": float64(20)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"), WithValidation(true))
+
+			result, err := api.Generate(context.Background(),
+				GET("/test", WithResponse(200, Paginated[paginatedUser]{}), WithPagination(tt.style, tt.opts...)),
+			)
+			require.NoError(t, err)
+
+			var spec map[string]any
+			require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+			op := getOperation(t, spec, "get")
+			assert.Equal(t, tt.limit, op["parameters"].([]any)[0].(map[string]any)["schema"])
+
+			headers := op["responses"].(map[string]any)["200"].(map[string]any)["headers"].(map[string]any)
+			if tt.totalCount {
+				assert.Contains(t, headers, "X-Total-Count")
+			} else {
+				assert.NotContains(t, headers, "X-Total-Count")
+			}
+		})
+	}
+}
+
+func TestGenerate_PaginationInvalidPageLimit(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	for _, opt := range []PaginationOption{WithPageLimit(0, 100), WithPageLimit(50, 10)} {
+		_, err := api.Generate(context.Background(),
+			GET("/test", WithPagination(PaginationOffset, opt)),
+		)
+		assert.ErrorContains(t, err, "invalid page limit")
+	}
+}
@@ -0,0 +1,81 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// parameterKey identifies a parameter by location and name. Header names are
+// case-insensitive, and canonicalized.
+func parameterKey(p *model.Parameter) string {
+	if p.In == string(InHeader) {
+		return p.In + " " + http.CanonicalHeaderKey(p.Name)
+	}
+
+	return p.In + " " + p.Name
+}
+
+// checkParameters checks that the parameters of each operation, and of its path
+// item, are unique by name and location, as the specification requires. Header
+// names differing only in case are duplicates; an operation parameter overriding
+// a path item parameter must spell its name the same.
+func (a *API) checkParameters(spec *model.Spec) error {
+	var errs []error
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		pathParams, err := uniqueParameters(spec, spec.Paths[ref.Path].Parameters)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("path %s: %w", ref.Path, err))
+		}
+		opParams, err := uniqueParameters(spec, ref.Operation.Parameters)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("operation %s %s: %w", ref.Method, ref.Path, err))
+		}
+		for key, name := range opParams {
+			if pathName, ok := pathParams[key]; ok && pathName != name {
+				errs = append(errs, fmt.Errorf("operation %s %s: parameter %s overrides path parameter %s, spelled differently", ref.Method, ref.Path, name, pathName))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// uniqueParameters maps the keys of parameters to their names, and reports the
+// duplicates. References are resolved in the components.
+func uniqueParameters(spec *model.Spec, params []model.Parameter) (map[string]string, error) {
+	names := make(map[string]string, len(params))
+	var errs []error
+	for i := range params {
+		p := &params[i]
+		if p.Ref != "" {
+			if p = spec.Components.Parameters[strings.TrimPrefix(p.Ref, componentsPrefix+"parameters/")]; p == nil {
+				continue
+			}
+		}
+		key := parameterKey(p)
+		if name, ok := names[key]; ok {
+			errs = append(errs, fmt.Errorf("duplicate %s parameter %s (also %s)", p.In, p.Name, name))
+
+			continue
+		}
+		names[key] = p.Name
+	}
+
+	return names, errors.Join(errs...)
+}
+
+// resolveParameter returns p, or the component parameter it references.
+func resolveParameter(spec *model.Spec, p model.Parameter) model.Parameter {
+	if p.Ref == "" {
+		return p
+	}
+	if c := spec.Components.Parameters[strings.TrimPrefix(p.Ref, componentsPrefix+"parameters/")]; c != nil {
+		return *c
+	}
+
+	return p
+}
@@ -0,0 +1,84 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+func TestGenerate_DuplicateParameters(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		op      Operation
+		wantErr string
+	}{
+		{
+			name: "headers differing in case",
+			op: GET("/items", WithRequest(struct {
+				Key    string `schema:"X-API-Key,location=header"`
+				Legacy string `schema:"x-api-key,location=header"`
+			}{})),
+			wantErr: "operation GET /items: duplicate header parameter x-api-key (also X-API-Key)",
+		},
+		{
+			name: "same query parameter",
+			op: GET("/items", WithRequest(struct {
+				Page   int `schema:"page,location=query"`
+				Offset int `schema:"page,location=query"`
+			}{})),
+			wantErr: "operation GET /items: duplicate query parameter page (also page)",
+		},
+		{
+			name: "global parameter",
+			opts: []Option{
+				WithGlobalParameter("Key", model.Parameter{Name: "X-API-Key", In: "header", Schema: &model.Schema{Type: "string"}}, nil),
+				WithGlobalParameter("LegacyKey", model.Parameter{Name: "x-api-key", In: "header", Schema: &model.Schema{Type: "string"}}, nil),
+			},
+			op:      GET("/items"),
+			wantErr: "operation GET /items: duplicate header parameter x-api-key (also X-API-Key)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(append([]Option{WithVersion("3.1.2")}, tt.opts...)...).Generate(context.Background(), tt.op)
+			require.ErrorContains(t, err, "duplicate parameters: ")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerate_ParameterNames(t *testing.T) {
+	// Query names are case-sensitive, and options do not repeat struct headers
+	_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		GET("/items",
+			WithRequest(struct {
+				Sort      string `schema:"sort,location=query"`
+				SortUpper string `schema:"SORT,location=query"`
+				RequestID string `schema:"x-request-id,location=header"`
+			}{}),
+			WithRequestHeader("X-Request-ID", "Correlation ID of the request"),
+		),
+	)
+	require.NoError(t, err)
+}
+
+func TestCheckParameters_PathItem(t *testing.T) {
+	spec := &model.Spec{
+		Components: &model.Components{},
+		Paths: map[string]*model.PathItem{
+			"/items": {
+				Parameters: []model.Parameter{{Name: "X-Tenant", In: "header"}},
+				Get:        &model.Operation{Parameters: []model.Parameter{{Name: "x-tenant", In: "header"}}},
+				Put:        &model.Operation{Parameters: []model.Parameter{{Name: "X-Tenant", In: "header"}}},
+			},
+		},
+	}
+	err := NewAPI().checkParameters(spec)
+	require.EqualError(t, err, "operation GET /items: parameter x-tenant overrides path parameter X-Tenant, spelled differently")
+}
@@ -0,0 +1,35 @@
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/talav/openapi/internal/overlay"
+)
+
+// WithSpecPatch applies a patch to the generated specification before it is
+// validated, after the overlays (see WithOverlays). A JSON array is a JSON Patch
+// (RFC 6902), whose operations are applied in order; a JSON object is a JSON
+// Merge Patch (RFC 7386). Successive calls add patches, applied in order.
+//
+// Generate reports an invalid patch, and the failing operation of a JSON Patch
+// with its path, e.g. a test operation or a replace of a missing value.
+//
+// Default: none
+//
+// Example:
+//
+//	api := openapi.NewAPI(openapi.WithSpecPatch([]byte(`[
+//	  {"op": "replace", "path": "/info/title", "value": "Public API"},
+//	  {"op": "remove", "path": "/paths/~1internal"}
+//	]`)))
+func WithSpecPatch(patch []byte) Option {
+	return func(a *API) {
+		p, err := overlay.ParsePatch(patch)
+		if err != nil {
+			a.patchErrs = append(a.patchErrs, fmt.Errorf("patch %d: %w", len(a.patches)+len(a.patchErrs), err))
+
+			return
+		}
+		a.patches = append(a.patches, p)
+	}
+}
@@ -0,0 +1,67 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSpecPatch(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithInfoTitle("Test"), WithInfoVersion("1.0.0"), WithVersion(version),
+			WithSpecPatch([]byte(`[
+				{"op": "test", "path": "/info/title", "value": "Test"},
+				{"op": "replace", "path": "/info/title", "value": "Public API"},
+				{"op": "remove", "path": "/paths/~1internal"}
+			]`)),
+			WithSpecPatch([]byte(`{"info": {"description": "Patched."}}`)),
+		)
+
+		result, err := api.Generate(context.Background(),
+			GET("/users", WithResponse(200, user{})),
+			GET("/internal", WithResponse(200, user{})),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Info struct {
+				Title       string `json:"title"`
+				Description string `json:"description"`
+			} `json:"info"`
+			Paths map[string]any `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		assert.Equal(t, "Public API", spec.Info.Title)
+		assert.Equal(t, "Patched.", spec.Info.Description)
+		assert.Contains(t, spec.Paths, "/users")
+		assert.NotContains(t, spec.Paths, "/internal")
+	})
+}
+
+func TestWithSpecPatch_Errors(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	t.Run("invalid patch", func(t *testing.T) {
+		api := NewAPI(WithSpecPatch([]byte(`[{"op": "merge", "path": "/info"}]`)))
+		_, err := api.Generate(context.Background(), GET("/users", WithResponse(200, user{})))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid spec patches: patch 0: patch operation 0")
+	})
+
+	t.Run("failed operation", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"), WithSpecPatch([]byte(`[{"op": "replace", "path": "/info/summary", "value": "x"}]`)))
+		_, err := api.Generate(context.Background(), GET("/users", WithResponse(200, user{})))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `patch operation 0 (replace /info/summary): member "summary" not found`)
+	})
+}
@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"strings"
+)
+
+// PathNormalization controls how operation paths are normalized before they
+// are added to the spec. The zero value keeps paths as registered, so /users
+// and /users/ are distinct paths.
+//
+// Normalization steps are applied in a fixed order: duplicate slashes are
+// collapsed, then the trailing slash is trimmed, then static segments are
+// lowercased. Operations whose paths become equal share a path item.
+type PathNormalization struct {
+	// CollapseSlashes replaces runs of slashes with a single slash (//users///me -> /users/me).
+	CollapseSlashes bool
+
+	// TrimTrailingSlash removes the trailing slash (/users/ -> /users).
+	// The root path "/" is kept.
+	TrimTrailingSlash bool
+
+	// Lowercase lowercases static path segments (/Users/{userID} -> /users/{userID}).
+	// Parameter names are kept, as they must match request fields.
+	Lowercase bool
+}
+
+// WithPathNormalization sets how operation paths are normalized.
+//
+// Default: no normalization
+//
+// Example:
+//
+//	openapi.WithPathNormalization(openapi.PathNormalization{
+//	    CollapseSlashes:   true,
+//	    TrimTrailingSlash: true,
+//	})
+func WithPathNormalization(n PathNormalization) Option {
+	return func(a *API) {
+		a.PathNormalization = n
+	}
+}
+
+// normalize applies the normalization to a router path (/users/:id).
+func (n PathNormalization) normalize(path string) string {
+	if n.CollapseSlashes {
+		for strings.Contains(path, "//") {
+			path = strings.ReplaceAll(path, "//", "/")
+		}
+	}
+	if n.TrimTrailingSlash && len(path) > 1 {
+		path = strings.TrimRight(path, "/")
+		if path == "" {
+			path = "/"
+		}
+	}
+	if n.Lowercase {
+		segments := strings.Split(path, "/")
+		for i, segment := range segments {
+			if !strings.HasPrefix(segment, ":") && !strings.HasPrefix(segment, "*") && !strings.Contains(segment, "{") {
+				segments[i] = strings.ToLower(segment)
+			}
+		}
+		path = strings.Join(segments, "/")
+	}
+
+	return path
+}
+
+// openAPIPath returns the normalized OpenAPI path of a router path.
+func (a *API) openAPIPath(path string) string {
+	return convertPathToOpenAPI(a.PathNormalization.normalize(path))
+}
@@ -0,0 +1,74 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathNormalization_Normalize(t *testing.T) {
+	all := PathNormalization{CollapseSlashes: true, TrimTrailingSlash: true, Lowercase: true}
+
+	tests := []struct {
+		name string
+		n    PathNormalization
+		path string
+		want string
+	}{
+		{name: "zero value keeps path", path: "//Users/", want: "//Users/"},
+		{name: "collapse slashes", n: PathNormalization{CollapseSlashes: true}, path: "//users///me/", want: "/users/me/"},
+		{name: "trim trailing slash", n: PathNormalization{TrimTrailingSlash: true}, path: "/users/", want: "/users"},
+		{name: "trim keeps root", n: PathNormalization{TrimTrailingSlash: true}, path: "/", want: "/"},
+		{name: "trim without collapse", n: PathNormalization{TrimTrailingSlash: true}, path: "/users//", want: "/users"},
+		{name: "lowercase keeps parameters", n: PathNormalization{Lowercase: true}, path: "/Users/:userID/{PostID}", want: "/users/:userID/{PostID}"},
+		{name: "all", n: all, path: "//Users//:userID/Posts/", want: "/users/:userID/posts"},
+		{name: "all on root", n: all, path: "//", want: "/"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.n.normalize(tt.path))
+		})
+	}
+}
+
+func TestGenerate_PathNormalization(t *testing.T) {
+	ops := []Operation{
+		GET("/users"),
+		POST("/users/"),
+	}
+
+	t.Run("distinct by default", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"))
+
+		result, err := api.Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		paths := result.Model.Spec().Paths
+		assert.Len(t, paths, 2)
+		assert.NotNil(t, paths["/users"].Get)
+		assert.NotNil(t, paths["/users/"].Post)
+	})
+
+	t.Run("trailing slash trimmed", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"), WithPathNormalization(PathNormalization{TrimTrailingSlash: true}))
+
+		result, err := api.Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		paths := result.Model.Spec().Paths
+		require.Len(t, paths, 1)
+		assert.NotNil(t, paths["/users"].Get)
+		assert.NotNil(t, paths["/users"].Post)
+	})
+
+	t.Run("normalized duplicates conflict", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"), WithPathNormalization(PathNormalization{Lowercase: true}))
+
+		_, err := api.Generate(context.Background(), GET("/Users"), GET("/users"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "operation GET /users is registered more than once")
+	})
+}
@@ -0,0 +1,173 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/model"
+)
+
+// pathTemplateParam matches the parameters of an OpenAPI path template.
+var pathTemplateParam = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// checkPathParameters reports mismatches between the parameters of a path template
+// and the path parameters declared by the operation: every {param} of the path must
+// have a request field with location=path, and every such field must appear in the path.
+//
+// Operations without a request type and catch-all parameters may leave the parameters
+// of the path undeclared: they are documented automatically, as string parameters.
+func (a *API) checkPathParameters(path string, wildcards []string, doc *operationDoc) error {
+	var fields map[string]string
+	if doc.RequestType != nil {
+		var err error
+		if fields, err = a.requestBuilder.PathFields(doc.RequestType); err != nil {
+			return err
+		}
+	}
+	declared := make(map[string]string, len(fields))
+	for name, field := range fields {
+		declared[name] = "request field " + field
+	}
+	for _, p := range doc.Parameters {
+		if p.In == "path" {
+			declared[p.Name] = "parameter " + p.Name
+		}
+	}
+
+	var templated []string
+	for _, m := range pathTemplateParam.FindAllStringSubmatch(path, -1) {
+		templated = append(templated, m[1])
+	}
+
+	var errs []error
+	for _, name := range templated {
+		if _, ok := declared[name]; !ok && !slices.Contains(wildcards, name) && doc.RequestType != nil {
+			errs = append(errs, fmt.Errorf("path parameter {%s} has no request field with location=path%s",
+				name, candidates(declared)))
+		}
+	}
+
+	names := make([]string, 0, len(declared))
+	for name := range declared {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !slices.Contains(templated, name) {
+			errs = append(errs, fmt.Errorf("%s declares path parameter %q, which is not in path %s",
+				declared[name], name, path))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// applyTemplateParameters declares the parameters of the path template that the
+// operation does not declare as required string path parameters, so that operations
+// without a request type document their path.
+func applyTemplateParameters(op *model.Operation, path string) {
+	for _, m := range pathTemplateParam.FindAllStringSubmatch(path, -1) {
+		declared := slices.ContainsFunc(op.Parameters, func(p model.Parameter) bool {
+			return p.In == "path" && p.Name == m[1]
+		})
+		if !declared {
+			op.Parameters = append(op.Parameters, model.Parameter{
+				Name:     m[1],
+				In:       "path",
+				Required: true,
+				Schema:   &model.Schema{Type: "string"},
+			})
+		}
+	}
+}
+
+// candidates lists the declared path parameters for a mismatch error, if any.
+func candidates(declared map[string]string) string {
+	if len(declared) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(declared))
+	for name, source := range declared {
+		names = append(names, fmt.Sprintf("%s as %q", source, name))
+	}
+	sort.Strings(names)
+
+	return fmt.Sprintf(" (declared: %v)", names)
+}
+
+// checkPaths detects conflicting path registrations across operations:
+//   - the same method registered twice on a path is an error
+//   - paths identical apart from parameter names (/users/{id}, /users/{userId})
+//     are an error, as OpenAPI considers them identical
+//   - paths that can match the same request (/users/me, /users/{id}) are reported
+//     as warnings: concrete paths take precedence, which may not be intended
+func (a *API) checkPaths(ops []Operation) (debug.Warnings, error) {
+	var errs []error
+	methods := make(map[string]bool)
+	byShape := make(map[string]string)
+	var paths []string
+	for _, op := range ops {
+		path := a.openAPIPath(op.Path)
+		key := strings.ToUpper(op.Method) + " " + path
+		if methods[key] {
+			errs = append(errs, fmt.Errorf("operation %s is registered more than once", key))
+		}
+		methods[key] = true
+
+		shape := pathTemplateParam.ReplaceAllString(path, "{}")
+		if other, ok := byShape[shape]; ok && other != path {
+			errs = append(errs, fmt.Errorf("paths %s and %s are identical apart from parameter names", other, path))
+			continue
+		} else if !ok {
+			byShape[shape] = path
+			paths = append(paths, path)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	sort.Strings(paths)
+
+	var warns debug.Warnings
+	for i, a := range paths {
+		for _, b := range paths[i+1:] {
+			if overlap(a, b) {
+				warns.Append(debug.NewWarning(
+					debug.WarnAmbiguousPath,
+					"#/paths/"+strings.NewReplacer("~", "~0", "/", "~1").Replace(b),
+					fmt.Sprintf("paths %s and %s can match the same request", a, b),
+				))
+			}
+		}
+	}
+
+	return warns, nil
+}
+
+// overlap reports whether two paths of different shapes can match the same request:
+// every segment is equal, or templated in at least one of them.
+func overlap(a, b string) bool {
+	as, bs := strings.Split(a, "/"), strings.Split(b, "/")
+	if len(as) != len(bs) {
+		return false
+	}
+	for i := range as {
+		if as[i] != bs[i] && !isTemplated(as[i]) && !isTemplated(bs[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isTemplated reports whether a path segment is a single parameter, such as {id}.
+func isTemplated(segment string) bool {
+	return pathTemplateParam.FindString(segment) == segment
+}
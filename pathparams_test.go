@@ -0,0 +1,153 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+func TestGenerate_PathParameters(t *testing.T) {
+	type GetUser struct {
+		ID string `schema:"id,location=path"`
+	}
+	type GetUserMismatch struct {
+		UserID string `schema:"user_id,location=path"`
+	}
+	type ListUsers struct {
+		Limit int `schema:"limit,location=query"`
+	}
+
+	tests := []struct {
+		name    string
+		op      Operation
+		wantErr []string
+	}{
+		{
+			name: "matching",
+			op:   GET("/users/:id", WithRequest(GetUser{})),
+		},
+		{
+			name: "without request type",
+			op:   GET("/users/:id", WithResponse(200, GetUser{})),
+		},
+		{
+			name: "mismatched names",
+			op:   GET("/users/:id", WithRequest(GetUserMismatch{})),
+			wantErr: []string{
+				`path parameter {id} has no request field with location=path (declared: [request field UserID as "user_id"])`,
+				`request field UserID declares path parameter "user_id", which is not in path /users/{id}`,
+			},
+		},
+		{
+			name: "missing field",
+			op:   GET("/users/:id", WithRequest(ListUsers{})),
+			wantErr: []string{
+				"path parameter {id} has no request field with location=path",
+			},
+		},
+		{
+			name: "field not in path",
+			op:   GET("/users", WithRequest(GetUser{})),
+			wantErr: []string{
+				`request field ID declares path parameter "id", which is not in path /users`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"))
+
+			_, err := api.Generate(context.Background(), tt.op)
+			if len(tt.wantErr) == 0 {
+				require.NoError(t, err)
+
+				return
+			}
+			require.Error(t, err)
+			for _, want := range tt.wantErr {
+				assert.Contains(t, err.Error(), want)
+			}
+		})
+	}
+}
+
+func TestGenerate_PathParametersWithoutRequest(t *testing.T) {
+	type User struct {
+		ID string `json:"id"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidation(true))
+
+		result, err := api.Generate(context.Background(),
+			GET("/users/:id/posts/:postId", WithResponse(200, User{})),
+		)
+		require.NoError(t, err)
+
+		params := result.Model.Spec().Paths["/users/{id}/posts/{postId}"].Get.Parameters
+		require.Len(t, params, 2)
+		for i, name := range []string{"id", "postId"} {
+			assert.Equal(t, name, params[i].Name)
+			assert.Equal(t, "path", params[i].In)
+			assert.True(t, params[i].Required)
+			assert.Equal(t, "string", params[i].Schema.Type)
+		}
+	})
+}
+
+func TestGenerate_PathConflicts(t *testing.T) {
+	type GetUser struct {
+		ID string `schema:"id,location=path"`
+	}
+	type GetUserByUserID struct {
+		UserID string `schema:"userId,location=path"`
+	}
+
+	t.Run("identical apart from parameter names", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"))
+
+		_, err := api.Generate(context.Background(),
+			GET("/users/:id", WithRequest(GetUser{})),
+			DELETE("/users/:userId", WithRequest(GetUserByUserID{})),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "paths /users/{id} and /users/{userId} are identical apart from parameter names")
+	})
+
+	t.Run("duplicate operation", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"))
+
+		_, err := api.Generate(context.Background(),
+			GET("/users/:id", WithRequest(GetUser{})),
+			GET("/users/:id", WithRequest(GetUser{})),
+		)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "operation GET /users/{id} is registered more than once")
+	})
+
+	t.Run("overlapping paths", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"))
+
+		result, err := api.Generate(context.Background(),
+			GET("/users/me"),
+			GET("/users/:id", WithRequest(GetUser{})),
+			GET("/users/:id/posts", WithRequest(GetUser{})),
+		)
+		require.NoError(t, err)
+
+		var ambiguous []string
+		for _, w := range result.Warnings {
+			if w.Code() == debug.WarnAmbiguousPath {
+				ambiguous = append(ambiguous, w.Path()+": "+w.Message())
+			}
+		}
+		assert.Equal(t, []string{
+			"#/paths/~1users~1{id}: paths /users/me and /users/{id} can match the same request",
+		}, ambiguous)
+	})
+}
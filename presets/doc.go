@@ -0,0 +1,22 @@
+// Package presets provides ready-made schemas for standardized payload formats,
+// so that their large schema trees need not be written by hand.
+//
+// Each preset is a Go type implementing hook.SchemaProvider: use it as a field or
+// response type and its schema is generated inline.
+//
+//   - GeoJSON (RFC 7946): Geometry, Feature and FeatureCollection
+//   - JSON:API: Document, Collection and Resource
+//   - HAL: Links
+//   - Tuples: Pair and Triple, and Tuple for custom tuple types
+//
+// Example:
+//
+//	type Store struct {
+//	    Name     string           `json:"name"`
+//	    Location presets.Geometry `json:"location"`
+//	}
+//
+//	openapi.GET("/stores/:id",
+//	    openapi.WithResponse(200, presets.Document[Store]{}),
+//	)
+package presets
@@ -0,0 +1,128 @@
+package presets
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+// GeoJSONContentType is the media type of GeoJSON documents (RFC 7946).
+const GeoJSONContentType = "application/geo+json"
+
+// Geometry is a GeoJSON geometry (RFC 7946, section 3.1) other than a
+// GeometryCollection: a Point, MultiPoint, LineString, MultiLineString, Polygon
+// or MultiPolygon.
+type Geometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+	BBox        []float64       `json:"bbox,omitempty"`
+}
+
+// Schema implements hook.SchemaProvider.
+func (Geometry) Schema(hook.SchemaRegistry) *model.Schema {
+	return geometrySchema()
+}
+
+// Feature is a GeoJSON feature (RFC 7946, section 3.2) with properties of type P.
+// Unlocated features, with a null geometry, are not documented.
+type Feature[P any] struct {
+	Type       string    `json:"type"`
+	ID         any       `json:"id,omitempty"`
+	Geometry   *Geometry `json:"geometry"`
+	Properties P         `json:"properties"`
+	BBox       []float64 `json:"bbox,omitempty"`
+}
+
+// ContentType implements ContentTypeProvider.
+func (Feature[P]) ContentType(string) string {
+	return GeoJSONContentType
+}
+
+// Schema implements hook.SchemaProvider.
+func (Feature[P]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return featureSchema(r.Schema(reflect.TypeFor[P]()))
+}
+
+// FeatureCollection is a GeoJSON feature collection (RFC 7946, section 3.3) of
+// features with properties of type P.
+type FeatureCollection[P any] struct {
+	Type     string       `json:"type"`
+	Features []Feature[P] `json:"features"`
+	BBox     []float64    `json:"bbox,omitempty"`
+}
+
+// ContentType implements ContentTypeProvider.
+func (FeatureCollection[P]) ContentType(string) string {
+	return GeoJSONContentType
+}
+
+// Schema implements hook.SchemaProvider.
+func (FeatureCollection[P]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return geoJSONObject("FeatureCollection", map[string]*model.Schema{
+		"features": {Type: "array", Items: featureSchema(r.Schema(reflect.TypeFor[P]()))},
+	}, "features")
+}
+
+// featureSchema returns the schema of a feature with the given properties schema.
+func featureSchema(properties *model.Schema) *model.Schema {
+	return geoJSONObject("Feature", map[string]*model.Schema{
+		"id":         {AnyOf: []*model.Schema{{Type: "string"}, {Type: "number"}}},
+		"geometry":   geometrySchema(),
+		"properties": properties,
+	}, "geometry", "properties")
+}
+
+// geometrySchema returns the schema of a geometry other than a GeometryCollection.
+func geometrySchema() *model.Schema {
+	position := func() *model.Schema {
+		return array(&model.Schema{Type: "number"}, 2)
+	}
+	lineString := func() *model.Schema {
+		return array(position(), 2)
+	}
+	// A linear ring is a closed line string with four or more positions
+	polygon := func() *model.Schema {
+		return array(array(position(), 4), 0)
+	}
+
+	return &model.Schema{
+		OneOf: []*model.Schema{
+			geometryObject("Point", position()),
+			geometryObject("MultiPoint", array(position(), 0)),
+			geometryObject("LineString", lineString()),
+			geometryObject("MultiLineString", array(lineString(), 0)),
+			geometryObject("Polygon", polygon()),
+			geometryObject("MultiPolygon", array(polygon(), 0)),
+		},
+	}
+}
+
+// geometryObject returns the schema of a geometry of the given type.
+func geometryObject(typ string, coordinates *model.Schema) *model.Schema {
+	return geoJSONObject(typ, map[string]*model.Schema{"coordinates": coordinates}, "coordinates")
+}
+
+// geoJSONObject returns the schema of a GeoJSON object of the given type, with an
+// optional bounding box.
+func geoJSONObject(typ string, properties map[string]*model.Schema, required ...string) *model.Schema {
+	properties["type"] = &model.Schema{Type: "string", Enum: []any{typ}}
+	properties["bbox"] = array(&model.Schema{Type: "number"}, 4)
+
+	return &model.Schema{
+		Type:       "object",
+		Properties: properties,
+		Required:   append([]string{"type"}, required...),
+	}
+}
+
+// array returns the schema of an array of items with at least minItems items.
+func array(items *model.Schema, minItems int) *model.Schema {
+	s := &model.Schema{Type: "array", Items: items}
+	if minItems > 0 {
+		s.MinItems = &minItems
+	}
+
+	return s
+}
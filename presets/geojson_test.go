@@ -0,0 +1,120 @@
+package presets
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi"
+)
+
+// jsonSchema holds the schema keywords checked by the tests.
+type jsonSchema struct {
+	Ref        string                 `json:"$ref"`
+	Type       any                    `json:"type"`
+	Required   []string               `json:"required"`
+	Enum       []any                  `json:"enum"`
+	MinItems   *int                   `json:"minItems"`
+	Items      *jsonSchema            `json:"items"`
+	Properties map[string]*jsonSchema `json:"properties"`
+	OneOf      []*jsonSchema          `json:"oneOf"`
+	Additional *jsonSchema            `json:"additionalProperties"`
+}
+
+// generate documents a GET operation responding with resp, validating the spec,
+// and returns the schemas of the 200 response contents and the component schemas.
+func generate(t *testing.T, version string, resp any) (content, schemas map[string]*jsonSchema) {
+	t.Helper()
+
+	api := openapi.NewAPI(openapi.WithVersion(version), openapi.WithValidation(true))
+	result, err := api.Generate(context.Background(), openapi.GET("/test", openapi.WithResponse(200, resp)))
+	require.NoError(t, err)
+	assert.Empty(t, result.Warnings)
+
+	var spec struct {
+		Paths map[string]struct {
+			Get struct {
+				Responses map[string]struct {
+					Content map[string]struct {
+						Schema *jsonSchema `json:"schema"`
+					} `json:"content"`
+				} `json:"responses"`
+			} `json:"get"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]*jsonSchema `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	content = make(map[string]*jsonSchema)
+	for contentType, mediaType := range spec.Paths["/test"].Get.Responses["200"].Content {
+		content[contentType] = mediaType.Schema
+	}
+
+	return content, spec.Components.Schemas
+}
+
+// forEachVersion runs test as a subtest for each supported OpenAPI version.
+func forEachVersion(t *testing.T, test func(t *testing.T, version string)) {
+	t.Helper()
+	for _, version := range []string{"3.0.4", "3.1.2"} {
+		t.Run(version, func(t *testing.T) {
+			test(t, version)
+		})
+	}
+}
+
+// arrayOf returns the expected schema of an array.
+func arrayOf(items *jsonSchema, minItems int) *jsonSchema {
+	s := &jsonSchema{Type: "array", Items: items}
+	if minItems > 0 {
+		s.MinItems = &minItems
+	}
+
+	return s
+}
+
+type store struct {
+	Name     string   `json:"name"`
+	Location Geometry `json:"location"`
+}
+
+func TestGeometry(t *testing.T) {
+	position := arrayOf(&jsonSchema{Type: "number"}, 2)
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		_, schemas := generate(t, version, store{})
+
+		require.Contains(t, schemas, "Store")
+		variants := schemas["Store"].Properties["location"].OneOf
+		require.Len(t, variants, 6)
+
+		point := variants[0]
+		assert.Equal(t, []string{"type", "coordinates"}, point.Required)
+		assert.Equal(t, []any{"Point"}, point.Properties["type"].Enum)
+		assert.Equal(t, position, point.Properties["coordinates"])
+
+		polygon := variants[4]
+		assert.Equal(t, []any{"Polygon"}, polygon.Properties["type"].Enum)
+		assert.Equal(t, arrayOf(arrayOf(position, 4), 0), polygon.Properties["coordinates"])
+	})
+}
+
+func TestFeatureCollection(t *testing.T) {
+	content, schemas := generate(t, "3.1.2", FeatureCollection[store]{})
+
+	require.Contains(t, content, GeoJSONContentType)
+	collection := content[GeoJSONContentType]
+	assert.Equal(t, []string{"type", "features"}, collection.Required)
+
+	feature := collection.Properties["features"].Items
+	assert.Equal(t, []string{"type", "geometry", "properties"}, feature.Required)
+	assert.Equal(t, []any{"Feature"}, feature.Properties["type"].Enum)
+	assert.Len(t, feature.Properties["geometry"].OneOf, 6)
+	assert.Equal(t, &jsonSchema{Ref: "#/components/schemas/Store"}, feature.Properties["properties"])
+	assert.Contains(t, schemas, "Store")
+}
@@ -0,0 +1,51 @@
+package presets
+
+import (
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/envelope"
+	"github.com/talav/openapi/internal/model"
+)
+
+// HALContentType is the media type of HAL documents.
+const HALContentType = envelope.HALContentType
+
+// Link is a HAL link object.
+type Link struct {
+	Href        string `json:"href"`
+	Templated   bool   `json:"templated,omitempty"`
+	Type        string `json:"type,omitempty"`
+	Deprecation string `json:"deprecation,omitempty"`
+	Name        string `json:"name,omitempty"`
+	Profile     string `json:"profile,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Hreflang    string `json:"hreflang,omitempty"`
+}
+
+// Schema implements hook.SchemaProvider.
+func (Link) Schema(hook.SchemaRegistry) *model.Schema {
+	return envelope.Link()
+}
+
+// Links is the HAL _links member of a resource, mapping relation types to a link
+// or an array of links. The self relation is a single link.
+//
+// Example:
+//
+//	type Order struct {
+//	    Links presets.Links `json:"_links"`
+//	    Total float64       `json:"total"`
+//	}
+type Links map[string]any
+
+// Schema implements hook.SchemaProvider.
+func (Links) Schema(hook.SchemaRegistry) *model.Schema {
+	return envelope.Links()
+}
+
+// HALEnvelope returns the schema of a HAL resource wrapping a body schema: the body
+// with _links and _embedded members, or for array schemas a resource embedding the
+// items under "items".
+// Use it in SchemaProvider implementations to document HAL payloads.
+func HALEnvelope(body *model.Schema) *model.Schema {
+	return envelope.HAL(body, body)
+}
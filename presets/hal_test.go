@@ -0,0 +1,29 @@
+package presets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type order struct {
+	Links Links   `json:"_links"`
+	Total float64 `json:"total"`
+}
+
+func TestLinks(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		_, schemas := generate(t, version, order{})
+
+		require.Contains(t, schemas, "Order")
+		links := schemas["Order"].Properties["_links"]
+		self := links.Properties["self"]
+		assert.Equal(t, []string{"href"}, self.Required)
+
+		relations := links.Additional.OneOf
+		require.Len(t, relations, 2)
+		assert.Equal(t, self, relations[0])
+		assert.Equal(t, arrayOf(self, 0), relations[1])
+	})
+}
@@ -0,0 +1,93 @@
+package presets
+
+import (
+	"reflect"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/envelope"
+	"github.com/talav/openapi/internal/model"
+)
+
+// JSONAPIContentType is the media type of JSON:API documents.
+const JSONAPIContentType = envelope.JSONAPIContentType
+
+// Resource is a JSON:API resource object with attributes of type A.
+type Resource[A any] struct {
+	Type          string                  `json:"type"`
+	ID            string                  `json:"id,omitempty"`
+	Attributes    A                       `json:"attributes"`
+	Relationships map[string]Relationship `json:"relationships,omitempty"`
+	Links         map[string]any          `json:"links,omitempty"`
+	Meta          map[string]any          `json:"meta,omitempty"`
+}
+
+// Schema implements hook.SchemaProvider.
+func (Resource[A]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return envelope.Resource(r.Schema(reflect.TypeFor[A]()))
+}
+
+// Relationship is a JSON:API relationship object. Data holds a ResourceIdentifier
+// for to-one relationships and a slice of them for to-many relationships.
+type Relationship struct {
+	Data  any            `json:"data,omitempty"`
+	Links map[string]any `json:"links,omitempty"`
+	Meta  map[string]any `json:"meta,omitempty"`
+}
+
+// Schema implements hook.SchemaProvider.
+func (Relationship) Schema(hook.SchemaRegistry) *model.Schema {
+	return envelope.Relationship()
+}
+
+// ResourceIdentifier identifies a JSON:API resource.
+type ResourceIdentifier struct {
+	Type string `json:"type" validate:"required"`
+	ID   string `json:"id" validate:"required"`
+}
+
+// Document is a JSON:API top-level document holding a single resource with
+// attributes of type A.
+type Document[A any] struct {
+	Data     Resource[A]     `json:"data"`
+	Included []Resource[any] `json:"included,omitempty"`
+	Meta     map[string]any  `json:"meta,omitempty"`
+	Links    map[string]any  `json:"links,omitempty"`
+	JSONAPI  map[string]any  `json:"jsonapi,omitempty"`
+}
+
+// ContentType implements ContentTypeProvider.
+func (Document[A]) ContentType(string) string {
+	return JSONAPIContentType
+}
+
+// Schema implements hook.SchemaProvider.
+func (Document[A]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return envelope.Document(envelope.Resource(r.Schema(reflect.TypeFor[A]())))
+}
+
+// Collection is a JSON:API top-level document holding resources with attributes
+// of type A.
+type Collection[A any] struct {
+	Data     []Resource[A]   `json:"data"`
+	Included []Resource[any] `json:"included,omitempty"`
+	Meta     map[string]any  `json:"meta,omitempty"`
+	Links    map[string]any  `json:"links,omitempty"`
+	JSONAPI  map[string]any  `json:"jsonapi,omitempty"`
+}
+
+// ContentType implements ContentTypeProvider.
+func (Collection[A]) ContentType(string) string {
+	return JSONAPIContentType
+}
+
+// Schema implements hook.SchemaProvider.
+func (Collection[A]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return envelope.Document(&model.Schema{Type: "array", Items: envelope.Resource(r.Schema(reflect.TypeFor[A]()))})
+}
+
+// JSONAPIEnvelope returns the schema of a JSON:API document wrapping a body schema:
+// the attributes of a single resource, or of a resource per item for array schemas.
+// Use it in SchemaProvider implementations to document JSON:API payloads.
+func JSONAPIEnvelope(body *model.Schema) *model.Schema {
+	return envelope.JSONAPI(body, body)
+}
@@ -0,0 +1,37 @@
+package presets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type article struct {
+	Title string `json:"title"`
+}
+
+func TestDocument(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		content, schemas := generate(t, version, Document[article]{})
+
+		require.Contains(t, content, JSONAPIContentType)
+		document := content[JSONAPIContentType]
+		assert.Equal(t, []string{"data"}, document.Required)
+
+		data := document.Properties["data"]
+		assert.Equal(t, []string{"type"}, data.Required)
+		assert.Equal(t, &jsonSchema{Ref: "#/components/schemas/Article"}, data.Properties["attributes"])
+		assert.Len(t, data.Properties["relationships"].Additional.Properties["data"].OneOf, 2)
+		assert.Contains(t, schemas, "Article")
+	})
+}
+
+func TestCollection(t *testing.T) {
+	content, _ := generate(t, "3.1.2", Collection[article]{})
+
+	require.Contains(t, content, JSONAPIContentType)
+	data := content[JSONAPIContentType].Properties["data"]
+	assert.Equal(t, "array", data.Type)
+	assert.Equal(t, &jsonSchema{Ref: "#/components/schemas/Article"}, data.Items.Properties["attributes"])
+}
@@ -0,0 +1,98 @@
+package presets
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+// Pair is a tuple of two values, encoded as the JSON array [First, Second], such
+// as a coordinate pair or a [name, score] entry.
+//
+// Example:
+//
+//	type Ranking struct {
+//	    Entries []presets.Pair[string, float64] `json:"entries"`
+//	}
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// MarshalJSON encodes the pair as a JSON array.
+func (p Pair[A, B]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{p.First, p.Second})
+}
+
+// UnmarshalJSON decodes the pair from a JSON array of two items.
+func (p *Pair[A, B]) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &p.First, &p.Second)
+}
+
+// Schema implements hook.SchemaProvider.
+func (Pair[A, B]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return Tuple(r, reflect.TypeFor[A](), reflect.TypeFor[B]())
+}
+
+// Triple is a tuple of three values, encoded as the JSON array [First, Second, Third].
+type Triple[A, B, C any] struct {
+	First  A
+	Second B
+	Third  C
+}
+
+// MarshalJSON encodes the triple as a JSON array.
+func (t Triple[A, B, C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]any{t.First, t.Second, t.Third})
+}
+
+// UnmarshalJSON decodes the triple from a JSON array of three items.
+func (t *Triple[A, B, C]) UnmarshalJSON(data []byte) error {
+	return unmarshalTuple(data, &t.First, &t.Second, &t.Third)
+}
+
+// Schema implements hook.SchemaProvider.
+func (Triple[A, B, C]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return Tuple(r, reflect.TypeFor[A](), reflect.TypeFor[B](), reflect.TypeFor[C]())
+}
+
+// Tuple returns the schema of a JSON array holding one value of each type, in order,
+// for hook.SchemaProvider implementations of other tuple types. It is emitted as
+// prefixItems without additional items in 3.1, and approximated in 3.0 with items
+// matching any of the types and a fixed length.
+func Tuple(r hook.SchemaRegistry, types ...reflect.Type) *model.Schema {
+	items := make([]*model.Schema, 0, len(types))
+	for _, t := range types {
+		items = append(items, r.Schema(t))
+	}
+	n := len(types)
+
+	return &model.Schema{
+		Type:              "array",
+		PrefixItems:       items,
+		NoAdditionalItems: true,
+		MinItems:          &n,
+		MaxItems:          &n,
+	}
+}
+
+// unmarshalTuple decodes a JSON array into values, one item each.
+func unmarshalTuple(data []byte, values ...any) error {
+	var items []json.RawMessage
+	if err := json.Unmarshal(data, &items); err != nil {
+		return err
+	}
+	if len(items) != len(values) {
+		return fmt.Errorf("presets: tuple of %d items, got %d", len(values), len(items))
+	}
+	for i, item := range items {
+		if err := json.Unmarshal(item, values[i]); err != nil {
+			return fmt.Errorf("presets: tuple item %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,93 @@
+package presets
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi"
+	"github.com/talav/openapi/debug"
+)
+
+type tupleRanking struct {
+	Entries []Pair[string, float64] `json:"entries"`
+}
+
+// tupleSchema holds the tuple keywords of the entries property.
+type tupleSchema struct {
+	Type        any           `json:"type"`
+	Items       any           `json:"items"`
+	PrefixItems []*jsonSchema `json:"prefixItems"`
+	MinItems    *int          `json:"minItems"`
+	MaxItems    *int          `json:"maxItems"`
+}
+
+// generateTuple returns the schema of the entries of tupleRanking, and the warnings.
+func generateTuple(t *testing.T, version string) (tupleSchema, debug.Warnings) {
+	t.Helper()
+
+	api := openapi.NewAPI(openapi.WithVersion(version), openapi.WithValidation(true))
+	result, err := api.Generate(context.Background(), openapi.GET("/ranking", openapi.WithResponse(200, tupleRanking{})))
+	require.NoError(t, err)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Properties map[string]struct {
+					Items tupleSchema `json:"items"`
+				} `json:"properties"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	return spec.Components.Schemas["TupleRanking"].Properties["entries"].Items, result.Warnings
+}
+
+func TestPair_Schema(t *testing.T) {
+	two := 2
+
+	t.Run("3.1", func(t *testing.T) {
+		entry, warns := generateTuple(t, "3.1.2")
+		assert.Empty(t, warns)
+		assert.Equal(t, "array", entry.Type)
+		assert.Equal(t, false, entry.Items)
+		require.Len(t, entry.PrefixItems, 2)
+		assert.Equal(t, "string", entry.PrefixItems[0].Type)
+		assert.Equal(t, "number", entry.PrefixItems[1].Type)
+		assert.Equal(t, &two, entry.MinItems)
+		assert.Equal(t, &two, entry.MaxItems)
+	})
+
+	t.Run("3.0", func(t *testing.T) {
+		entry, warns := generateTuple(t, "3.0.4")
+		assert.True(t, warns.Has(debug.WarnDegradationPrefixItems))
+		assert.Empty(t, entry.PrefixItems)
+		assert.Equal(t, map[string]any{
+			"anyOf": []any{map[string]any{"type": "string"}, map[string]any{"type": "number", "format": "double"}},
+		}, entry.Items)
+		assert.Equal(t, &two, entry.MinItems)
+		assert.Equal(t, &two, entry.MaxItems)
+	})
+}
+
+func TestTuple_JSON(t *testing.T) {
+	data, err := json.Marshal(Triple[string, int, bool]{First: "a", Second: 1, Third: true})
+	require.NoError(t, err)
+	assert.JSONEq(t, `["a", 1, true]`, string(data))
+
+	var pair Pair[float64, float64]
+	require.NoError(t, json.Unmarshal([]byte(`[13.4, 52.5]`), &pair))
+	assert.Equal(t, Pair[float64, float64]{First: 13.4, Second: 52.5}, pair)
+
+	err = json.Unmarshal([]byte(`[13.4]`), &pair)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tuple of 2 items, got 1")
+
+	err = json.Unmarshal([]byte(`["north", 52.5]`), &pair)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tuple item 0")
+}
@@ -0,0 +1,148 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+// ProblemContentType is the media type of Problem Details documents (RFC 9457).
+const ProblemContentType = "application/problem+json"
+
+// ProblemDetails is the Problem Details error model defined by RFC 9457.
+// It is documented as application/problem+json and allows extension members.
+//
+// Example:
+//
+//	openapi.GET("/users/:id",
+//	    openapi.WithResponse(200, User{}),
+//	    openapi.WithProblemResponses(400, 404, 500),
+//	)
+type ProblemDetails struct {
+	Type     string `json:"type,omitempty" openapi:"format=uri-reference,description=URI reference identifying the problem type"`
+	Title    string `json:"title,omitempty" openapi:"description=Short human-readable summary of the problem type"`
+	Status   int    `json:"status,omitempty" validate:"min=100,max=599" openapi:"description=HTTP status code generated by the origin server"`
+	Detail   string `json:"detail,omitempty" openapi:"description=Human-readable explanation specific to this occurrence of the problem"`
+	Instance string `json:"instance,omitempty" openapi:"format=uri-reference,description=URI reference identifying this occurrence of the problem"`
+}
+
+// ContentType implements ContentTypeProvider.
+func (ProblemDetails) ContentType(string) string {
+	return ProblemContentType
+}
+
+// TransformSchema implements hook.SchemaTransformer. Problem Details documents
+// may carry extension members, so additional properties are allowed.
+func (ProblemDetails) TransformSchema(_ hook.SchemaRegistry, s *model.Schema) *model.Schema {
+	allow := true
+	s.Additional = &model.Additional{Allow: &allow}
+
+	return s
+}
+
+// Problem is a Problem Details document with typed extension members.
+// It is documented as the allOf of ProblemDetails and E, and serialized as a
+// single JSON object containing the members of both.
+//
+// Example:
+//
+//	type ValidationErrors struct {
+//	    Errors []FieldError `json:"errors" validate:"required"`
+//	}
+//
+//	openapi.POST("/users",
+//	    openapi.WithResponse(422, openapi.Problem[ValidationErrors]{}),
+//	)
+type Problem[E any] struct {
+	ProblemDetails
+
+	// Extensions holds the extension members of the problem.
+	Extensions E
+}
+
+// ContentType implements ContentTypeProvider.
+func (Problem[E]) ContentType(string) string {
+	return ProblemContentType
+}
+
+// Schema implements hook.SchemaProvider.
+func (Problem[E]) Schema(r hook.SchemaRegistry) *model.Schema {
+	return &model.Schema{
+		AllOf: []*model.Schema{
+			r.Schema(reflect.TypeFor[ProblemDetails]()),
+			r.Schema(reflect.TypeFor[E]()),
+		},
+	}
+}
+
+// MarshalJSON serializes the standard and extension members as a single object.
+func (p Problem[E]) MarshalJSON() ([]byte, error) {
+	members := make(map[string]json.RawMessage)
+
+	ext, err := json.Marshal(p.Extensions)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal problem extensions: %w", err)
+	}
+	if err := json.Unmarshal(ext, &members); err != nil {
+		return nil, fmt.Errorf("problem extensions must be a JSON object: %w", err)
+	}
+
+	std, err := json.Marshal(p.ProblemDetails)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal problem details: %w", err)
+	}
+	// Standard members take precedence over extension members with the same name
+	if err := json.Unmarshal(std, &members); err != nil {
+		return nil, fmt.Errorf("failed to merge problem details: %w", err)
+	}
+
+	return json.Marshal(members)
+}
+
+// UnmarshalJSON deserializes a single object into the standard members and the
+// extension members, which receive the members other than the standard ones.
+func (p *Problem[E]) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return fmt.Errorf("problem must be a JSON object: %w", err)
+	}
+	if err := json.Unmarshal(data, &p.ProblemDetails); err != nil {
+		return fmt.Errorf("failed to unmarshal problem details: %w", err)
+	}
+
+	for _, name := range problemMembers {
+		delete(members, name)
+	}
+	ext, err := json.Marshal(members)
+	if err != nil {
+		return fmt.Errorf("failed to split problem extensions: %w", err)
+	}
+	if err := json.Unmarshal(ext, &p.Extensions); err != nil {
+		return fmt.Errorf("failed to unmarshal problem extensions: %w", err)
+	}
+
+	return nil
+}
+
+// problemMembers are the names of the standard members of ProblemDetails.
+var problemMembers = []string{"type", "title", "status", "detail", "instance"}
+
+// WithProblemResponses documents ProblemDetails error responses for the given
+// status codes, served as application/problem+json.
+//
+// Example:
+//
+//	openapi.GET("/users/:id",
+//	    openapi.WithResponse(200, User{}),
+//	    openapi.WithProblemResponses(400, 404, 500),
+//	)
+func WithProblemResponses(statuses ...int) OperationDocOption {
+	return func(d *operationDoc) {
+		for _, status := range statuses {
+			WithResponse(status, ProblemDetails{})(d)
+		}
+	}
+}
@@ -0,0 +1,152 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type problemFieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+type problemValidationErrors struct {
+	Errors []problemFieldError `json:"errors" validate:"required"`
+}
+
+func TestGenerate_ProblemResponses(t *testing.T) {
+	type User struct {
+		ID int `json:"id"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithInfoTitle("Test"),
+			WithInfoVersion("1.0.0"),
+			WithVersion(version),
+			WithValidation(true),
+		)
+
+		result, err := api.Generate(context.Background(),
+			GET("/test",
+				WithResponse(200, User{}),
+				WithResponse(422, Problem[problemValidationErrors]{}),
+				WithProblemResponses(404, 500),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		op := getOperation(t, spec, "get")
+		responses, ok := op["responses"].(map[string]any)
+		require.True(t, ok, "responses must be a map")
+
+		for _, status := range []string{"404", "500"} {
+			resp, ok := responses[status].(map[string]any)
+			require.True(t, ok, "%s response must be a map", status)
+			content, ok := resp["content"].(map[string]any)
+			require.True(t, ok, "content must be a map")
+			require.Contains(t, content, ProblemContentType)
+			assert.Equal(t,
+				map[string]any{"$ref": "#/components/schemas/ProblemDetails"},
+				content[ProblemContentType].(map[string]any)["schema"],
+			)
+		}
+
+		unprocessable := responses["422"].(map[string]any)["content"].(map[string]any)
+		require.Contains(t, unprocessable, ProblemContentType)
+		assert.Equal(t,
+			map[string]any{"allOf": []any{
+				map[string]any{"$ref": "#/components/schemas/ProblemDetails"},
+				map[string]any{"$ref": "#/components/schemas/ProblemValidationErrors"},
+			}},
+			unprocessable[ProblemContentType].(map[string]any)["schema"],
+		)
+
+		schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+		problem, ok := schemas["ProblemDetails"].(map[string]any)
+		require.True(t, ok, "ProblemDetails schema must exist")
+		assert.Equal(t, true, problem["additionalProperties"])
+		assert.ElementsMatch(t,
+			[]string{"type", "title", "status", "detail", "instance"},
+			keys(problem["properties"].(map[string]any)),
+		)
+	})
+}
+
+func TestProblem_MarshalJSON(t *testing.T) {
+	p := Problem[problemValidationErrors]{
+		ProblemDetails: ProblemDetails{
+			Type:   "https://example.com/probs/validation",
+			Title:  "Validation failed",
+			Status: 422,
+		},
+		Extensions: problemValidationErrors{
+			Errors: []problemFieldError{{Field: "name", Message: "is required"}},
+		},
+	}
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+
+	assert.JSONEq(t, `{
+		"type": "https://example.com/probs/validation",
+		"title": "Validation failed",
+		"status": 422,
+		"errors": [{"field": "name", "message": "is required"}]
+	}`, string(data))
+}
+
+func TestProblem_MarshalJSON_NonObjectExtensions(t *testing.T) {
+	_, err := json.Marshal(Problem[[]string]{Extensions: []string{"a"}})
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "problem extensions must be a JSON object")
+}
+
+func TestProblem_UnmarshalJSON(t *testing.T) {
+	want := Problem[problemValidationErrors]{
+		ProblemDetails: ProblemDetails{
+			Type:   "https://example.com/probs/validation",
+			Title:  "Validation failed",
+			Status: 422,
+		},
+		Extensions: problemValidationErrors{
+			Errors: []problemFieldError{{Field: "name", Message: "is required"}},
+		},
+	}
+	data, err := json.Marshal(want)
+	require.NoError(t, err)
+
+	var got Problem[problemValidationErrors]
+	require.NoError(t, json.Unmarshal(data, &got))
+	assert.Equal(t, want, got)
+
+	// Extension maps receive the members other than the standard ones
+	var generic Problem[map[string]any]
+	require.NoError(t, json.Unmarshal([]byte(`{"title": "Out of credit", "balance": 30}`), &generic))
+	assert.Equal(t, "Out of credit", generic.Title)
+	assert.Equal(t, map[string]any{"balance": float64(30)}, generic.Extensions)
+}
+
+func TestProblem_UnmarshalJSON_Errors(t *testing.T) {
+	var p Problem[problemValidationErrors]
+	require.ErrorContains(t, json.Unmarshal([]byte(`["a"]`), &p), "problem must be a JSON object")
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"status": "bad"}`), &p), "failed to unmarshal problem details")
+	require.ErrorContains(t, json.Unmarshal([]byte(`{"errors": "bad"}`), &p), "failed to unmarshal problem extensions")
+}
+
+func keys(m map[string]any) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+
+	return result
+}
@@ -0,0 +1,42 @@
+package openapi
+
+import "net/http"
+
+// Header names used by range requests (RFC 9110, section 14).
+const (
+	headerRange        = "Range"
+	headerIfRange      = "If-Range"
+	headerAcceptRanges = "Accept-Ranges"
+	headerContentRange = "Content-Range"
+)
+
+// WithRangeSupport documents an endpoint that serves byte ranges, such as
+// resumable or seekable downloads. It bundles:
+//   - the optional Range and If-Range request headers
+//   - the Accept-Ranges header on the 200 response
+//   - a 206 Partial Content response with the 200 response body and a Content-Range header
+//   - a 416 Range Not Satisfiable response with a Content-Range header
+//
+// Declare the 200 response with WithResponse; an explicit 206 response takes precedence.
+//
+// Example:
+//
+//	type FileDownload struct {
+//	    Body []byte `body:"download"`
+//	}
+//
+//	openapi.GET("/files/:id",
+//	    openapi.WithResponse(200, FileDownload{}),
+//	    openapi.WithRangeSupport(),
+//	)
+func WithRangeSupport() OperationDocOption {
+	return WithOptions(
+		WithRequestHeader(headerRange, "Requested byte ranges, e.g. bytes=0-1023"),
+		WithRequestHeader(headerIfRange, "Only serve the requested ranges if the representation matches this ETag or date"),
+		WithResponseHeader(http.StatusOK, headerAcceptRanges, "Range units supported by the server, e.g. bytes"),
+		WithResponseHeader(http.StatusPartialContent, headerAcceptRanges, "Range units supported by the server, e.g. bytes"),
+		WithResponseHeader(http.StatusPartialContent, headerContentRange, "Position of the partial body in the full representation, e.g. bytes 0-1023/4096"),
+		WithResponseHeader(http.StatusRequestedRangeNotSatisfiable, headerContentRange, "Size of the full representation, e.g. bytes */4096"),
+		func(d *operationDoc) { d.PartialContent = true },
+	)
+}
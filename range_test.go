@@ -0,0 +1,101 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate_RangeSupport(t *testing.T) {
+	type FileDownload struct {
+		Body []byte `body:"download"`
+	}
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithInfoTitle("Test"),
+			WithInfoVersion("1.0.0"),
+			WithVersion(version),
+			WithValidation(true),
+		)
+
+		result, err := api.Generate(context.Background(),
+			GET("/test",
+				WithResponse(200, FileDownload{}),
+				WithRangeSupport(),
+			),
+		)
+		require.NoError(t, err)
+
+		var spec map[string]any
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		op := getOperation(t, spec, "get")
+
+		params, ok := op["parameters"].([]any)
+		require.True(t, ok, "parameters must be an array")
+		names := make([]string, 0, len(params))
+		for _, p := range params {
+			param, ok := p.(map[string]any)
+			require.True(t, ok, "parameter must be a map")
+			assert.Equal(t, "header", param["in"])
+			names = append(names, param["name"].(string))
+		}
+		assert.Equal(t, []string{"Range", "If-Range"}, names)
+
+		responses, ok := op["responses"].(map[string]any)
+		require.True(t, ok, "responses must be a map")
+		require.Contains(t, responses, "200")
+		require.Contains(t, responses, "206")
+		require.Contains(t, responses, "416")
+
+		ok200 := responses["200"].(map[string]any)
+		partial := responses["206"].(map[string]any)
+		notSatisfiable := responses["416"].(map[string]any)
+
+		assert.Equal(t, "Partial Content", partial["description"])
+		assert.Equal(t, ok200["content"], partial["content"])
+		assert.Contains(t, ok200["headers"], "Accept-Ranges")
+		assert.Contains(t, partial["headers"], "Accept-Ranges")
+		assert.Contains(t, partial["headers"], "Content-Range")
+		assert.Contains(t, partial["headers"], "Content-Disposition")
+
+		assert.Equal(t, "Requested Range Not Satisfiable", notSatisfiable["description"])
+		assert.NotContains(t, notSatisfiable, "content")
+		assert.Contains(t, notSatisfiable["headers"], "Content-Range")
+	})
+}
+
+func TestGenerate_RangeSupport_ExplicitPartialResponse(t *testing.T) {
+	type FileDownload struct {
+		Body []byte `body:"download"`
+	}
+	type Chunk struct {
+		Body string `body:"text"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/test",
+			WithResponse(200, FileDownload{}),
+			WithResponse(206, Chunk{}),
+			WithRangeSupport(),
+		),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	op := getOperation(t, spec, "get")
+	responses, ok := op["responses"].(map[string]any)
+	require.True(t, ok, "responses must be a map")
+	partial, ok := responses["206"].(map[string]any)
+	require.True(t, ok, "206 response must be a map")
+	assert.Contains(t, partial["content"], "text/plain")
+	assert.Contains(t, partial["headers"], "Content-Range")
+}
@@ -0,0 +1,103 @@
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// Header fields of the IETF RateLimit header fields draft, and the extension
+// documenting rate limit policies.
+const (
+	headerRateLimitFieldPolicy    = "RateLimit-Policy"
+	headerRateLimitFieldLimit     = "RateLimit-Limit"
+	headerRateLimitFieldRemaining = "RateLimit-Remaining"
+	headerRateLimitFieldReset     = "RateLimit-Reset"
+
+	extensionRateLimit = "x-ratelimit"
+)
+
+// RateLimitPolicy describes the request quota of rate-limited operations.
+type RateLimitPolicy struct {
+	// Name identifies the policy in the RateLimit-Policy header, e.g. "default".
+	Name string
+
+	// Limit is the number of requests allowed per window.
+	Limit int
+
+	// Window is the duration of the quota window, in whole seconds.
+	Window time.Duration
+}
+
+// rateLimitExtension is the x-ratelimit extension documenting a rate limit policy.
+type rateLimitExtension struct {
+	Policy string `json:"policy,omitempty"`
+	Limit  int    `json:"limit"`
+	Window int    `json:"window"`
+}
+
+// WithRateLimit documents the rate limit of the operation in one declaration:
+//   - the RateLimit-Policy, RateLimit-Limit, RateLimit-Remaining and RateLimit-Reset
+//     headers (IETF RateLimit header fields draft) on every response
+//   - a 429 Too Many Requests response with a Retry-After header
+//   - the x-ratelimit extension with the policy, for gateways and tooling
+//
+// Use WithOperationDefaults or Group to apply a policy to all operations or to a
+// group of them; the policy of the operation takes precedence. See RateLimitHeaders
+// for the X-RateLimit-* headers.
+//
+// Example:
+//
+//	openapi.WithOperationDefaults(
+//	    openapi.WithRateLimit(openapi.RateLimitPolicy{Name: "default", Limit: 100, Window: time.Minute}),
+//	)
+//
+//	openapi.POST("/exports",
+//	    openapi.WithRateLimit(openapi.RateLimitPolicy{Name: "exports", Limit: 5, Window: time.Hour}),
+//	)
+func WithRateLimit(policy RateLimitPolicy) OperationDocOption {
+	return func(d *operationDoc) {
+		window := int(policy.Window / time.Second)
+		quota := fmt.Sprintf("%d requests per %d seconds", policy.Limit, window)
+		nonNegative := func() *model.Schema {
+			return &model.Schema{Type: "integer", Minimum: &model.Bound{Value: 0}}
+		}
+
+		d.addCommonResponseHeader(headerRateLimitFieldPolicy, &model.Header{
+			Description: "Quota policy of the operation: " + rateLimitPolicyField(policy.Name, policy.Limit, window),
+			Schema:      &model.Schema{Type: "string"},
+		})
+		d.addCommonResponseHeader(headerRateLimitFieldLimit, &model.Header{
+			Description: "Request quota of the current window (" + quota + ")",
+			Schema:      nonNegative(),
+		})
+		d.addCommonResponseHeader(headerRateLimitFieldRemaining, &model.Header{
+			Description: "Number of requests remaining in the current window",
+			Schema:      nonNegative(),
+		})
+		d.addCommonResponseHeader(headerRateLimitFieldReset, &model.Header{
+			Description: "Number of seconds until the quota resets",
+			Schema:      nonNegative(),
+		})
+		d.addResponseHeader(http.StatusTooManyRequests, headerRetryAfter, &model.Header{
+			Description: "Number of seconds to wait before retrying",
+			Schema:      nonNegative(),
+		})
+		WithOperationExtension(extensionRateLimit, rateLimitExtension{
+			Policy: policy.Name,
+			Limit:  policy.Limit,
+			Window: window,
+		})(d)
+	}
+}
+
+// rateLimitPolicyField formats a RateLimit-Policy field value, e.g. `"default";q=100;w=60`.
+func rateLimitPolicyField(name string, limit, window int) string {
+	if name == "" {
+		return fmt.Sprintf("%d;w=%d", limit, window)
+	}
+
+	return fmt.Sprintf("%q;q=%d;w=%d", name, limit, window)
+}
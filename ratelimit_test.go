@@ -0,0 +1,72 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type rateLimitExport struct {
+	ID string `json:"id"`
+}
+
+func TestWithRateLimit(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithVersion(version),
+			WithOperationDefaults(
+				WithRateLimit(RateLimitPolicy{Name: "default", Limit: 100, Window: time.Minute}),
+			),
+		)
+
+		result, err := api.Generate(context.Background(),
+			GET("/exports", WithResponse(200, rateLimitExport{})),
+			POST("/exports",
+				WithRateLimit(RateLimitPolicy{Name: "exports", Limit: 5, Window: time.Hour}),
+				WithResponse(202, rateLimitExport{}),
+			),
+		)
+		require.NoError(t, err)
+
+		type header struct {
+			Description string `json:"description"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				RateLimit struct {
+					Policy string `json:"policy"`
+					Limit  int    `json:"limit"`
+					Window int    `json:"window"`
+				} `json:"x-ratelimit"`
+				Responses map[string]struct {
+					Description string            `json:"description"`
+					Headers     map[string]header `json:"headers"`
+				} `json:"responses"`
+			} `json:"paths"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		list := spec.Paths["/exports"]["get"]
+		assert.Equal(t, "default", list.RateLimit.Policy)
+		assert.Equal(t, 100, list.RateLimit.Limit)
+		assert.Equal(t, 60, list.RateLimit.Window)
+		require.Contains(t, list.Responses, "429")
+		assert.Equal(t, "Too Many Requests", list.Responses["429"].Description)
+		assert.Contains(t, list.Responses["429"].Headers, "Retry-After")
+		for status, resp := range list.Responses {
+			for _, name := range []string{"RateLimit-Policy", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Reset"} {
+				assert.Contains(t, resp.Headers, name, status)
+			}
+		}
+		assert.Equal(t, `Quota policy of the operation: "default";q=100;w=60`, list.Responses["200"].Headers["RateLimit-Policy"].Description)
+
+		create := spec.Paths["/exports"]["post"]
+		assert.Equal(t, "exports", create.RateLimit.Policy, "the policy of the operation takes precedence")
+		assert.Equal(t, 3600, create.RateLimit.Window)
+		assert.Equal(t, "Request quota of the current window (5 requests per 3600 seconds)", create.Responses["202"].Headers["RateLimit-Limit"].Description)
+	})
+}
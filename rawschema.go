@@ -0,0 +1,85 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/model"
+)
+
+// AddRawSchema adds a hand-written JSON Schema, such as one maintained by another
+// system, to the component schemas under name. Go-typed fields reference it with
+// the ref option of the openapi tag, in place of the schema of their type:
+//
+//	type Order struct {
+//	    Legacy LegacyThing `json:"legacy" openapi:"ref=#/components/schemas/LegacyThing"`
+//	}
+//
+// The schema is decoded when the specification is generated: Generate fails for
+// invalid JSON, keywords the generator cannot represent (such as $defs), and names
+// of generated schemas. Adding a name again replaces the schema.
+//
+// Example:
+//
+//	legacy, _ := os.ReadFile("schemas/legacy-thing.json")
+//	api.AddRawSchema("LegacyThing", legacy)
+func (a *API) AddRawSchema(name string, jsonSchema []byte) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// The schemas are shared with the models of previous Generate calls: copy on write
+	schemas := maps.Clone(a.rawSchemas)
+	if schemas == nil {
+		schemas = make(map[string][]byte)
+	}
+	schemas[name] = slices.Clone(jsonSchema)
+	a.rawSchemas = schemas
+}
+
+// addRawSchemas decodes the schemas of AddRawSchema into the component schemas.
+func (a *API) addRawSchemas(schemas map[string]*model.Schema) error {
+	var errs []error
+	for _, name := range slices.Sorted(maps.Keys(a.rawSchemas)) {
+		if t, ok := a.generator.TypeOf(name); ok {
+			errs = append(errs, fmt.Errorf("schema %s: conflicts with the schema generated for %s", name, t))
+
+			continue
+		}
+		s, err := build.ParseSchema(a.rawSchemas[name])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("schema %s: %w", name, err))
+
+			continue
+		}
+		schemas[name] = s
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkSchemaRefs reports the references to undefined component schemas, such as
+// those of the ref option of the openapi tag to schemas never added with
+// AddRawSchema, with UNDEFINED_REFERENCE warnings. References outside the
+// components, such as external URIs, are not checked.
+func (a *API) checkSchemaRefs(spec *model.Spec) debug.Warnings {
+	u := newComponentUsage(spec, a.SchemaPrefix)
+
+	var warns debug.Warnings
+	for _, location := range slices.Sorted(maps.Keys(u.used)) {
+		kind, name, _ := strings.Cut(location, "/")
+		if _, ok := spec.Components.Schemas[name]; kind == "schemas" && !ok {
+			warns.Append(debug.NewWarning(
+				debug.WarnUndefinedReference,
+				a.SchemaPrefix+name,
+				fmt.Sprintf("schema %s is referenced but not defined; add it with AddRawSchema", name),
+			))
+		}
+	}
+
+	return warns
+}
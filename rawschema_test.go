@@ -0,0 +1,130 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+type rawLegacyThing struct {
+	Code string
+}
+
+type rawOrder struct {
+	ID     int              `json:"id"`
+	Legacy rawLegacyThing   `json:"legacy" openapi:"ref=#/components/schemas/LegacyThing,description=Thing of the legacy system"`
+	Items  []rawLegacyThing `json:"items,omitempty"`
+}
+
+const rawLegacySchema = `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "required": ["code"],
+  "properties": {
+    "code": {"type": "string", "pattern": "^[A-Z]{3}$"},
+    "note": {"type": ["string", "null"], "maxLength": 200}
+  },
+  "additionalProperties": false,
+  "x-owner": "billing"
+}`
+
+func TestAddRawSchema(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+		api.AddRawSchema("LegacyThing", []byte(rawLegacySchema))
+
+		result, err := api.Generate(context.Background(), GET("/orders", WithResponse(200, rawOrder{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Components struct {
+				Schemas map[string]struct {
+					Type       any                       `json:"type"`
+					Required   []string                  `json:"required"`
+					Properties map[string]map[string]any `json:"properties"`
+					Owner      string                    `json:"x-owner"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		legacy := spec.Components.Schemas["LegacyThing"]
+		assert.Equal(t, "object", legacy.Type)
+		assert.Equal(t, []string{"code"}, legacy.Required)
+		assert.Equal(t, "^[A-Z]{3}$", legacy.Properties["code"]["pattern"])
+		assert.Equal(t, "billing", legacy.Owner)
+
+		order := spec.Components.Schemas["RawOrder"]
+		assert.Equal(t, "#/components/schemas/LegacyThing", order.Properties["legacy"]["$ref"])
+		// Fields without the ref option keep their generated schema
+		assert.Contains(t, spec.Components.Schemas, "RawLegacyThing")
+	})
+}
+
+func TestAddRawSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		schema string
+		want   string
+	}{
+		{"invalid JSON", `{"type":`, "schema Legacy: invalid JSON"},
+		{"unsupported keyword", `{"$defs": {"a": {}}}`, "schema Legacy: #/$defs: unsupported keyword"},
+		{"invalid value", `{"properties": {"a": {"minLength": "3"}}}`, "schema Legacy: #/properties/a/minLength: want a non-negative integer, got 3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"))
+			api.AddRawSchema("Legacy", []byte(tt.schema))
+			_, err := api.Generate(context.Background(), GET("/orders"))
+			require.ErrorContains(t, err, "invalid raw schemas: "+tt.want)
+		})
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+	api.AddRawSchema("RawOrder", []byte(`{"type": "object"}`))
+	_, err := api.Generate(context.Background(), GET("/orders", WithResponse(200, rawOrder{})))
+	require.ErrorContains(t, err, "schema RawOrder: conflicts with the schema generated for openapi.rawOrder")
+}
+
+type rawExternalOrder struct {
+	ID       int            `json:"id"`
+	Customer rawLegacyThing `json:"customer" openapi:"ref=https://schemas.example.com/legacy.json#/Customer"`
+}
+
+func TestAddRawSchema_UndefinedReferences(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		result, err := NewAPI(WithVersion(version)).Generate(context.Background(),
+			GET("/orders", WithResponse(200, rawOrder{})),
+			GET("/customers", WithResponse(200, rawExternalOrder{})),
+		)
+		require.NoError(t, err)
+
+		var spec struct {
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]map[string]any `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		assert.Equal(t, "#/components/schemas/LegacyThing", spec.Components.Schemas["RawOrder"].Properties["legacy"]["$ref"])
+		assert.Equal(t, "https://schemas.example.com/legacy.json#/Customer", spec.Components.Schemas["RawExternalOrder"].Properties["customer"]["$ref"])
+
+		// Only the internal reference is checked
+		var undefined []string
+		for _, w := range result.Warnings {
+			if w.Code() == debug.WarnUndefinedReference {
+				undefined = append(undefined, w.Path()+": "+w.Message())
+			}
+		}
+		assert.Equal(t, []string{
+			"#/components/schemas/LegacyThing: schema LegacyThing is referenced but not defined; add it with AddRawSchema",
+		}, undefined)
+	})
+}
@@ -0,0 +1,398 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/model"
+	"github.com/talav/openapi/metadata"
+)
+
+// RedactionProfile selects the classified properties removed from generated
+// specs, so that specs for different audiences can be produced from the same types.
+// Properties are classified with the sensitivity option of the openapi tag, or
+// an x-data-classification extension attached with ExtendSchema.
+type RedactionProfile struct {
+	// Threshold is the highest classification left as is: properties classified
+	// above it are redacted. Classifications rank public < pii < secret.
+	Threshold string
+
+	// Mask keeps redacted properties in schemas, stripped of everything but their
+	// type, instead of removing them.
+	Mask bool
+}
+
+// Built-in redaction profiles.
+const (
+	// RedactionInternal keeps every property.
+	RedactionInternal = "internal"
+	// RedactionExternal removes pii and secret properties.
+	RedactionExternal = "external"
+)
+
+// redactionProfiles are the built-in redaction profiles.
+var redactionProfiles = map[string]RedactionProfile{
+	RedactionInternal: {Threshold: metadata.SensitivitySecret},
+	RedactionExternal: {Threshold: metadata.SensitivityPublic},
+}
+
+// sensitivityRank orders the classifications of the sensitivity option.
+var sensitivityRank = map[string]int{
+	metadata.SensitivityPublic: 0,
+	metadata.SensitivityPII:    1,
+	metadata.SensitivitySecret: 2,
+}
+
+// WithRedactionProfile redacts the generated spec with the named profile: one of
+// the built-in profiles ("internal", "external") or a profile registered with
+// WithRedactionProfiles. Generate fails for unknown profiles.
+//
+// Examples of the redacted schemas, request and response bodies, parameters and
+// headers are redacted along with the schemas.
+// Component schemas only reachable through redacted properties are removed.
+//
+// Default: no redaction
+//
+// Example:
+//
+//	openapi.WithRedactionProfile("external")
+func WithRedactionProfile(name string) Option {
+	return func(a *API) {
+		a.RedactionProfile = name
+	}
+}
+
+// WithRedactionProfiles registers custom redaction profiles by name.
+// Profiles with the name of a built-in profile replace it.
+//
+// Example:
+//
+//	openapi.WithRedactionProfiles(map[string]openapi.RedactionProfile{
+//	    "partner": {Threshold: "pii", Mask: true},
+//	})
+func WithRedactionProfiles(profiles map[string]RedactionProfile) Option {
+	return func(a *API) {
+		if a.RedactionProfiles == nil {
+			a.RedactionProfiles = make(map[string]RedactionProfile)
+		}
+		maps.Copy(a.RedactionProfiles, profiles)
+	}
+}
+
+// redactionProfile resolves the configured redaction profile.
+func (a *API) redactionProfile() (RedactionProfile, bool, error) {
+	if a.RedactionProfile == "" {
+		return RedactionProfile{}, false, nil
+	}
+	p, ok := a.RedactionProfiles[a.RedactionProfile]
+	if !ok {
+		p, ok = redactionProfiles[a.RedactionProfile]
+	}
+	if !ok {
+		return RedactionProfile{}, false, fmt.Errorf("unknown redaction profile %q", a.RedactionProfile)
+	}
+	if _, ok := sensitivityRank[p.Threshold]; !ok {
+		return RedactionProfile{}, false, fmt.Errorf("redaction profile %q: invalid threshold %q (valid: public, pii, secret)", a.RedactionProfile, p.Threshold)
+	}
+
+	return p, true, nil
+}
+
+// redactSpec redacts the schemas of the spec with the configured profile, along
+// with the examples of the redacted schemas, media types, parameters and headers,
+// and removes the component schemas only reachable through redacted properties.
+// Redacted schemas are copied, as component schemas are shared with the schema generator.
+func (a *API) redactSpec(spec *model.Spec) error {
+	p, ok, err := a.redactionProfile()
+	if err != nil || !ok {
+		return err
+	}
+
+	unused := make(map[string]bool)
+	for _, location := range unusedComponents(spec, a.SchemaPrefix) {
+		unused[location] = true
+	}
+
+	schemas := spec.Components.Schemas
+	r := &redactor{profile: p, schemas: maps.Clone(schemas), schemaPrefix: a.SchemaPrefix}
+	for name, s := range schemas {
+		schemas[name] = r.schema(s)
+	}
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		r.operation(ref.Operation)
+	}
+
+	for _, location := range unusedComponents(spec, a.SchemaPrefix) {
+		if name, ok := strings.CutPrefix(location, "schemas/"); ok && !unused[location] {
+			delete(schemas, name)
+		}
+	}
+
+	return nil
+}
+
+// redactor redacts schemas and example values with a redaction profile.
+type redactor struct {
+	profile RedactionProfile
+	// schemas are the component schemas before redaction, against which
+	// example values are redacted.
+	schemas      map[string]*model.Schema
+	schemaPrefix string
+}
+
+// operation redacts the parameters, bodies and response headers of an operation
+// in place. Shared parameters and media types are copied.
+func (r *redactor) operation(op *model.Operation) {
+	if params := r.parameters(op.Parameters); params != nil {
+		op.Parameters = params
+	}
+	if op.RequestBody != nil {
+		r.content(op.RequestBody.Content)
+	}
+	for _, resp := range op.Responses {
+		if resp == nil {
+			continue
+		}
+		r.content(resp.Content)
+		for name, h := range resp.Headers {
+			if h == nil {
+				continue
+			}
+			c := *h
+			c.Schema = r.schema(h.Schema)
+			c.Example, c.Examples = r.examples(h.Schema, h.Example, h.Examples)
+			c.Content = maps.Clone(h.Content)
+			r.content(c.Content)
+			if !reflect.DeepEqual(&c, h) {
+				resp.Headers[name] = &c
+			}
+		}
+	}
+}
+
+// parameters returns a redacted copy of parameters, or nil when none is redacted.
+func (r *redactor) parameters(params []model.Parameter) []model.Parameter {
+	var out []model.Parameter
+	for i, param := range params {
+		c := param
+		c.Schema = r.schema(param.Schema)
+		c.Example, c.Examples = r.examples(param.Schema, param.Example, param.Examples)
+		c.Content = maps.Clone(param.Content)
+		r.content(c.Content)
+		if !reflect.DeepEqual(c, param) {
+			if out == nil {
+				out = slices.Clone(params)
+			}
+			out[i] = c
+		}
+	}
+
+	return out
+}
+
+// content redacts the schemas and examples of request or response content in place.
+func (r *redactor) content(content map[string]*model.MediaType) {
+	for ct, mt := range content {
+		if mt == nil || mt.Schema == nil {
+			continue
+		}
+		c := *mt
+		c.Schema = r.schema(mt.Schema)
+		c.Example, c.Examples = r.examples(mt.Schema, mt.Example, mt.Examples)
+		if c.Schema != mt.Schema || !reflect.DeepEqual(c.Example, mt.Example) || !maps.Equal(c.Examples, mt.Examples) {
+			content[ct] = &c
+		}
+	}
+}
+
+// examples returns the example and examples of a value of the unredacted schema s
+// redacted. Examples are copied, unless nothing is redacted.
+func (r *redactor) examples(s *model.Schema, example any, examples map[string]*model.Example) (any, map[string]*model.Example) {
+	if s == nil {
+		return example, examples
+	}
+	example = r.value(s, example)
+	var out map[string]*model.Example
+	for name, ex := range examples {
+		if ex == nil || ex.Value == nil {
+			continue
+		}
+		if v := r.value(s, ex.Value); !reflect.DeepEqual(v, ex.Value) {
+			if out == nil {
+				out = maps.Clone(examples)
+			}
+			c := *ex
+			c.Value = v
+			out[name] = &c
+		}
+	}
+	if out == nil {
+		return example, examples
+	}
+
+	return example, out
+}
+
+// redacts reports whether a property schema is classified above the threshold.
+func (r *redactor) redacts(s *model.Schema) bool {
+	classification, _ := s.Extensions[build.ExtensionDataClassification].(string)
+	rank, ok := sensitivityRank[classification]
+
+	return ok && rank > sensitivityRank[r.profile.Threshold]
+}
+
+// schema returns s with its classified properties redacted, or s itself when
+// nothing is redacted.
+func (r *redactor) schema(s *model.Schema) *model.Schema {
+	if s == nil || s.Ref != "" {
+		return s
+	}
+
+	redacted := rewriteSubschemas(s, r.schema)
+	c := *redacted
+	changed := redacted != s
+	if len(redacted.Properties) > 0 {
+		c.Properties = make(map[string]*model.Schema, len(redacted.Properties))
+		for name, ps := range redacted.Properties {
+			switch {
+			case ps != nil && r.redacts(ps) && r.profile.Mask:
+				c.Properties[name] = maskSchema(ps)
+				changed = true
+			case ps != nil && r.redacts(ps):
+				c.Required = slices.DeleteFunc(slices.Clone(c.Required), func(req string) bool { return req == name })
+				c.DependentRequired = withoutDependency(c.DependentRequired, name)
+				changed = true
+			default:
+				c.Properties[name] = ps
+			}
+		}
+	}
+	if !changed {
+		return s
+	}
+
+	c.Example = r.value(s, s.Example)
+	if len(s.Examples) > 0 {
+		c.Examples = make([]any, len(s.Examples))
+		for i, ex := range s.Examples {
+			c.Examples[i] = r.value(s, ex)
+		}
+	}
+
+	return &c
+}
+
+// value returns an example value of the unredacted schema s with its classified
+// properties redacted, or the value itself when nothing is redacted.
+func (r *redactor) value(s *model.Schema, v any) any {
+	if v == nil {
+		return nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return v
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var decoded any
+	if err := dec.Decode(&decoded); err != nil {
+		return v
+	}
+	if !r.redactValue(s, decoded, make(map[*model.Schema]bool)) {
+		return v
+	}
+
+	return decoded
+}
+
+// redactValue redacts the JSON decoding of a value of s in place, and reports
+// whether anything was redacted. Subschemas of s are applied to the value they
+// describe; the branches of anyOf and oneOf all are, as the matching one is unknown.
+func (r *redactor) redactValue(s *model.Schema, v any, seen map[*model.Schema]bool) bool {
+	if s == nil || v == nil {
+		return false
+	}
+	if name, ok := strings.CutPrefix(s.Ref, r.schemaPrefix); ok {
+		s = r.schemas[name]
+		if s == nil || seen[s] {
+			return false
+		}
+		// A value is only redacted once per schema: references back to s describe nested values
+		seen[s] = true
+		defer delete(seen, s)
+	}
+
+	changed := false
+	for _, group := range [][]*model.Schema{s.AllOf, s.AnyOf, s.OneOf} {
+		for _, sub := range group {
+			changed = r.redactValue(sub, v, seen) || changed
+		}
+	}
+
+	switch v := v.(type) {
+	case map[string]any:
+		for name, member := range v {
+			ps, declared := s.Properties[name]
+			switch {
+			case declared && ps != nil && r.redacts(ps) && r.profile.Mask:
+				v[name] = maskJSON(member)
+				changed = true
+			case declared && ps != nil && r.redacts(ps):
+				delete(v, name)
+				changed = true
+			case declared:
+				changed = r.redactValue(ps, member, make(map[*model.Schema]bool)) || changed
+			case s.Additional != nil:
+				changed = r.redactValue(s.Additional.Schema, member, make(map[*model.Schema]bool)) || changed
+			}
+		}
+	case []any:
+		for i, item := range v {
+			if i < len(s.PrefixItems) {
+				changed = r.redactValue(s.PrefixItems[i], item, make(map[*model.Schema]bool)) || changed
+			} else {
+				changed = r.redactValue(s.Items, item, make(map[*model.Schema]bool)) || changed
+			}
+		}
+	}
+
+	return changed
+}
+
+// maskSchema returns a masked copy of a redacted property: its type and
+// classification are kept, its examples, constraints and description are dropped.
+func maskSchema(s *model.Schema) *model.Schema {
+	return &model.Schema{
+		Type:        s.Type,
+		Nullable:    s.Nullable,
+		ReadOnly:    s.ReadOnly,
+		WriteOnly:   s.WriteOnly,
+		Description: "Redacted",
+		Extensions: map[string]any{
+			build.ExtensionDataClassification: s.Extensions[build.ExtensionDataClassification],
+		},
+	}
+}
+
+// withoutDependency removes a property from dependent required constraints.
+func withoutDependency(deps map[string][]string, name string) map[string][]string {
+	if len(deps) == 0 {
+		return deps
+	}
+
+	out := make(map[string][]string, len(deps))
+	for key, required := range deps {
+		if key == name {
+			continue
+		}
+		out[key] = slices.DeleteFunc(slices.Clone(required), func(r string) bool { return r == name })
+	}
+
+	return out
+}
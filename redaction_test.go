@@ -0,0 +1,185 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/example"
+	"github.com/talav/openapi/internal/model"
+)
+
+type redactedCard struct {
+	Number string `json:"number"`
+}
+
+type redactedAccount struct {
+	ID       string       `json:"id" validate:"required" openapi:"sensitivity=public"`
+	Email    string       `json:"email" validate:"required" openapi:"sensitivity=pii,examples=jane@example.com"`
+	Token    string       `json:"token" openapi:"sensitivity=secret"`
+	Card     redactedCard `json:"card" openapi:"sensitivity=secret"`
+	Nickname string       `json:"nickname"`
+}
+
+func redactedSchemas(t *testing.T, opts ...Option) map[string]any {
+	t.Helper()
+
+	api := NewAPI(append([]Option{WithVersion("3.1.2")}, opts...)...)
+	result, err := api.Generate(context.Background(), GET("/accounts/:id", WithResponse(200, redactedAccount{})))
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	return spec["components"].(map[string]any)["schemas"].(map[string]any)
+}
+
+func TestWithRedactionProfile(t *testing.T) {
+	t.Run("internal", func(t *testing.T) {
+		schemas := redactedSchemas(t, WithRedactionProfile(RedactionInternal))
+		props := schemas["RedactedAccount"].(map[string]any)["properties"].(map[string]any)
+
+		assert.ElementsMatch(t, []string{"id", "email", "token", "card", "nickname"}, keys(props))
+		assert.Contains(t, schemas, "RedactedCard")
+	})
+
+	t.Run("external", func(t *testing.T) {
+		schemas := redactedSchemas(t, WithRedactionProfile(RedactionExternal))
+		account := schemas["RedactedAccount"].(map[string]any)
+
+		assert.ElementsMatch(t, []string{"id", "nickname"}, keys(account["properties"].(map[string]any)))
+		assert.Equal(t, []any{"id"}, account["required"])
+		assert.NotContains(t, schemas, "RedactedCard", "schemas only reachable through redacted properties are removed")
+	})
+
+	t.Run("custom masking profile", func(t *testing.T) {
+		schemas := redactedSchemas(t,
+			WithRedactionProfiles(map[string]RedactionProfile{"partner": {Threshold: "pii", Mask: true}}),
+			WithRedactionProfile("partner"),
+		)
+		props := schemas["RedactedAccount"].(map[string]any)["properties"].(map[string]any)
+
+		assert.Equal(t, map[string]any{"type": "string", "examples": []any{"jane@example.com"}, "x-data-classification": "pii"}, props["email"])
+		assert.Equal(t, map[string]any{"type": "string", "description": "Redacted", "x-data-classification": "secret"}, props["token"])
+	})
+
+	t.Run("generated schemas are not modified", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"))
+		op := GET("/accounts/:id", WithResponse(200, redactedAccount{}))
+
+		full, err := api.Generate(context.Background(), op)
+		require.NoError(t, err)
+		api.RedactionProfile = RedactionExternal
+		_, err = api.Generate(context.Background(), op)
+		require.NoError(t, err)
+		api.RedactionProfile = ""
+		again, err := api.Generate(context.Background(), op)
+		require.NoError(t, err)
+
+		assert.Equal(t, string(full.JSON), string(again.JSON))
+	})
+}
+
+func TestWithRedactionProfile_Errors(t *testing.T) {
+	op := GET("/accounts/:id", WithResponse(200, redactedAccount{}))
+
+	api := NewAPI(WithVersion("3.1.2"), WithRedactionProfile("partner"))
+	_, err := api.Generate(context.Background(), op)
+	require.ErrorContains(t, err, `unknown redaction profile "partner"`)
+
+	api = NewAPI(WithVersion("3.1.2"),
+		WithRedactionProfiles(map[string]RedactionProfile{"partner": {Threshold: "confidential"}}),
+		WithRedactionProfile("partner"),
+	)
+	_, err = api.Generate(context.Background(), op)
+	require.ErrorContains(t, err, `redaction profile "partner": invalid threshold "confidential" (valid: public, pii, secret)`)
+}
+
+func TestWithRedactionProfile_Examples(t *testing.T) {
+	type accountRequest struct {
+		Body redactedAccount `body:"structured"`
+	}
+	type accountList struct {
+		Items []redactedAccount `json:"items"`
+	}
+	account := map[string]any{"id": "acc_1", "email": "jane@example.com", "token": "tok_1", "card": map[string]any{"number": "4242"}, "nickname": "jane"}
+
+	examples := func(t *testing.T, opts ...Option) (request, response any) {
+		t.Helper()
+
+		api := NewAPI(append([]Option{WithVersion("3.1.2")}, opts...)...)
+		result, err := api.Generate(context.Background(), POST("/accounts",
+			WithRequest(accountRequest{}, example.New("account", account)),
+			WithResponse(200, accountList{}, WithExamples(example.New("list", map[string]any{"items": []any{account}}))),
+		))
+		require.NoError(t, err)
+
+		var spec struct {
+			Paths map[string]map[string]struct {
+				RequestBody struct {
+					Content map[string]struct {
+						Examples map[string]struct{ Value any }
+					}
+				}
+				Responses map[string]struct {
+					Content map[string]struct {
+						Examples map[string]struct{ Value any }
+					}
+				}
+			}
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		op := spec.Paths["/accounts"]["post"]
+
+		return op.RequestBody.Content["application/json"].Examples["account"].Value,
+			op.Responses["200"].Content["application/json"].Examples["list"].Value
+	}
+
+	t.Run("external", func(t *testing.T) {
+		request, response := examples(t, WithRedactionProfile(RedactionExternal))
+
+		redacted := map[string]any{"id": "acc_1", "nickname": "jane"}
+		assert.Equal(t, redacted, request)
+		assert.Equal(t, map[string]any{"items": []any{redacted}}, response, "examples are redacted through references")
+	})
+
+	t.Run("masking profile", func(t *testing.T) {
+		request, _ := examples(t,
+			WithRedactionProfiles(map[string]RedactionProfile{"partner": {Threshold: "pii", Mask: true}}),
+			WithRedactionProfile("partner"),
+		)
+
+		assert.Equal(t, map[string]any{"id": "acc_1", "email": "jane@example.com", "token": "********", "card": map[string]any{}, "nickname": "jane"}, request)
+	})
+
+	t.Run("no profile", func(t *testing.T) {
+		request, _ := examples(t)
+
+		assert.Equal(t, account, request)
+	})
+}
+
+func TestRedactor_Subschemas(t *testing.T) {
+	secret := &model.Schema{Type: "string", Extensions: map[string]any{"x-data-classification": "secret"}}
+	object := func() *model.Schema {
+		return &model.Schema{Type: "object", Properties: map[string]*model.Schema{"token": secret, "name": {Type: "string"}}}
+	}
+	s := &model.Schema{
+		PatternProps:  map[string]*model.Schema{"^x-": object()},
+		If:            object(),
+		Then:          object(),
+		Else:          object(),
+		PropertyNames: object(),
+	}
+
+	r := &redactor{profile: redactionProfiles[RedactionExternal], schemaPrefix: "#/components/schemas/"}
+	redacted := r.schema(s)
+
+	for _, sub := range []*model.Schema{redacted.PatternProps["^x-"], redacted.If, redacted.Then, redacted.Else, redacted.PropertyNames} {
+		assert.Equal(t, []string{"name"}, keysOf(sub.Properties))
+	}
+	assert.Len(t, s.If.Properties, 2, "the redacted schema is a copy")
+}
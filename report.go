@@ -0,0 +1,155 @@
+package openapi
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+)
+
+// Report is a machine-readable summary of a generation, for CI systems to store
+// and trend over time: the statistics of the specification, its warnings and
+// lint findings, and validation findings. Marshal it with encoding/json.
+type Report struct {
+	// Version is the target OpenAPI version.
+	Version string `json:"version"`
+
+	// Fingerprint is Result.Fingerprint; empty for GenerateTo, Check and failed generations.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// Stats summarizes the size and documentation coverage of the specification.
+	Stats ReportStats `json:"stats"`
+
+	// Warnings lists the downlevel degradations and spec violations.
+	Warnings []ReportFinding `json:"warnings"`
+
+	// Lint lists the lint warnings: documentation that is valid but likely incorrect.
+	Lint []ReportFinding `json:"lint"`
+
+	// Validation lists the violations that failed the generation (see ErrorReport).
+	Validation []ReportFinding `json:"validation"`
+
+	// Error is the error of a failed generation (see ErrorReport).
+	Error string `json:"error,omitempty"`
+}
+
+// ReportStats is the serializable form of Stats. Operations are listed as
+// "METHOD /path".
+type ReportStats struct {
+	Operations            int            `json:"operations"`
+	Schemas               int            `json:"schemas"`
+	OperationsByTag       map[string]int `json:"operationsByTag"`
+	MissingDescriptions   []string       `json:"missingDescriptions"`
+	MissingExamples       []string       `json:"missingExamples"`
+	MissingErrorResponses []string       `json:"missingErrorResponses"`
+	Deprecations          int            `json:"deprecations"`
+	Coverage              float64        `json:"coverage"`
+}
+
+// ReportFinding is a warning or validation finding of a Report.
+type ReportFinding struct {
+	// Code is the warning code; empty for validation findings.
+	Code string `json:"code,omitempty"`
+
+	// Rule is the violated rule of validation findings.
+	Rule string `json:"rule,omitempty"`
+
+	// Pointer is the JSON pointer to the affected element.
+	Pointer string `json:"pointer"`
+
+	// Message is a human-readable description.
+	Message string `json:"message"`
+
+	// Source identifies what produced the failing value of validation findings, when known.
+	Source string `json:"source,omitempty"`
+}
+
+// ErrorReport returns the report of a generation that failed with err, listing
+// its validation findings when err holds debug.ValidationErrors.
+//
+// Example:
+//
+//	result, err := api.Generate(ctx, ops...)
+//	report := api.ErrorReport(err)
+//	if err == nil {
+//	    report = result.Report
+//	}
+func (a *API) ErrorReport(err error) Report {
+	report := newReport(a.Version)
+	if err == nil {
+		return report
+	}
+	report.Error = err.Error()
+
+	var violations debug.ValidationErrors
+	if errors.As(err, &violations) {
+		for _, v := range violations {
+			report.Validation = append(report.Validation, ReportFinding{
+				Rule:    v.Rule,
+				Pointer: v.Pointer,
+				Message: v.Message,
+				Source:  v.Source,
+			})
+		}
+	}
+
+	return report
+}
+
+// report returns the report of a generated specification.
+func (a *API) report(r *Result) Report {
+	report := newReport(a.Version)
+	report.Fingerprint = r.Fingerprint
+	report.Stats = ReportStats{
+		Operations:            r.Stats.Operations,
+		Schemas:               r.Stats.Schemas,
+		OperationsByTag:       r.Stats.OperationsByTag,
+		MissingDescriptions:   reportOperations(r.Stats.MissingDescriptions),
+		MissingExamples:       reportOperations(r.Stats.MissingExamples),
+		MissingErrorResponses: reportOperations(r.Stats.MissingErrorResponses),
+		Deprecations:          len(r.Deprecations),
+		Coverage:              r.Stats.Coverage,
+	}
+
+	for _, w := range r.Warnings {
+		f := ReportFinding{Code: string(w.Code()), Pointer: w.Path(), Message: w.Message()}
+		if isLintWarning(w.Code()) {
+			report.Lint = append(report.Lint, f)
+		} else {
+			report.Warnings = append(report.Warnings, f)
+		}
+	}
+
+	return report
+}
+
+// newReport returns an empty report, with empty lists rather than nulls.
+func newReport(version string) Report {
+	return Report{
+		Version: version,
+		Stats: ReportStats{
+			OperationsByTag:       map[string]int{},
+			MissingDescriptions:   []string{},
+			MissingExamples:       []string{},
+			MissingErrorResponses: []string{},
+		},
+		Warnings:   []ReportFinding{},
+		Lint:       []ReportFinding{},
+		Validation: []ReportFinding{},
+	}
+}
+
+// isLintWarning reports whether a warning is a lint warning, rather than a
+// degradation or a spec violation.
+func isLintWarning(code debug.WarningCode) bool {
+	return !strings.HasPrefix(string(code), "DEGRADATION_") && !strings.HasPrefix(string(code), "INVALID_")
+}
+
+func reportOperations(refs []OperationRef) []string {
+	ops := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		ops = append(ops, ref.Method+" "+ref.Path)
+	}
+
+	return ops
+}
@@ -0,0 +1,71 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reportUser struct {
+	ID int `json:"id"`
+}
+
+func TestResult_Report(t *testing.T) {
+	api := NewAPI(WithVersion("3.0.4"), WithInfoSummary("Users"))
+	result, err := api.Generate(context.Background(),
+		GET("/users/{id}", WithSummary("Get user"), WithTags("users"), WithResponse(200, reportUser{}), WithResponse(404, nil)),
+		GET("/users/me", WithTags("users"), WithResponse(200, reportUser{})),
+	)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(result.Report)
+	require.NoError(t, err)
+
+	var report map[string]any
+	require.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, "3.0.4", report["version"])
+	assert.Equal(t, result.Fingerprint, report["fingerprint"])
+	assert.Equal(t, map[string]any{
+		"operations":            float64(2),
+		"schemas":               float64(1),
+		"operationsByTag":       map[string]any{"users": float64(2)},
+		"missingDescriptions":   []any{"GET /users/me"},
+		"missingExamples":       []any{"GET /users/me", "GET /users/{id}"},
+		"missingErrorResponses": []any{"GET /users/me"},
+		"deprecations":          float64(0),
+		"coverage":              result.Stats.Coverage,
+	}, report["stats"])
+	assert.Equal(t, []any{map[string]any{
+		"code":    "DEGRADATION_INFO_SUMMARY",
+		"pointer": "#/info/summary",
+		"message": "info.summary is 3.1-only; dropped",
+	}}, report["warnings"])
+	require.Len(t, report["lint"], 1)
+	assert.Equal(t, "AMBIGUOUS_PATH", report["lint"].([]any)[0].(map[string]any)["code"])
+	assert.Equal(t, []any{}, report["validation"])
+	assert.NotContains(t, report, "error")
+}
+
+func TestAPI_ErrorReport(t *testing.T) {
+	type BadName struct {
+		Name string `json:"name" validate:"max=-1"`
+	}
+
+	api := NewAPI(WithVersion("3.0.4"), WithValidation(true))
+	_, err := api.Generate(context.Background(), GET("/users", WithResponse(200, BadName{})))
+	require.Error(t, err)
+
+	report := api.ErrorReport(err)
+	assert.Equal(t, "3.0.4", report.Version)
+	assert.Equal(t, err.Error(), report.Error)
+	require.Len(t, report.Validation, 1)
+	assert.Equal(t, "/components/schemas/BadName/properties/name/maxLength", report.Validation[0].Pointer)
+	assert.Equal(t, "openapi.BadName.Name", report.Validation[0].Source)
+	assert.Contains(t, report.Validation[0].Rule, "/minimum")
+	assert.Empty(t, report.Lint)
+
+	assert.Empty(t, api.ErrorReport(nil).Error)
+}
@@ -1,6 +1,16 @@
 package openapi
 
-import "github.com/talav/openapi/debug"
+import (
+	"maps"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/model"
+)
 
 type Result struct {
 	JSON []byte
@@ -8,4 +18,261 @@ type Result struct {
 	// Warnings contains informational, non-fatal issues.
 	// These are advisory only and do not indicate failure.
 	Warnings debug.Warnings
+
+	// Model is a read-only view of the generated specification, for tools that
+	// consume it programmatically instead of re-parsing JSON.
+	Model *Model
+
+	// Fingerprint is the SHA-256 content hash (hex) of the specification
+	// canonicalized with JCS (RFC 8785), whatever the output format. Suitable for
+	// cache invalidation, ETag values and change detection in CI.
+	Fingerprint string
+
+	// SchemaFingerprints maps component schema names to the content hash of
+	// their canonicalized definition.
+	SchemaFingerprints map[string]string
+
+	// Stats summarizes the size and documentation coverage of the specification.
+	Stats Stats
+
+	// Deprecations lists the deprecated operations, parameters and properties,
+	// with their sunset dates, to track deprecation debt across services.
+	Deprecations []Deprecation
+
+	// Trace records what produced the specification, with WithDebugTrace.
+	// Nil otherwise.
+	Trace *debug.Trace
+	// Report aggregates the statistics, warnings and lint findings into a
+	// machine-readable artifact for CI systems.
+	Report Report
+}
+
+// Model is a read-only view of a generated specification.
+// It reflects the version-agnostic model, before projection to the target
+// OpenAPI version. The model holds a copy of the specification: it is not shared
+// with the generator nor with other results, and Spec returns a copy of it.
+// Operations and schemas returned by the lookup helpers belong to the model:
+// modifying them changes this model only.
+type Model struct {
+	spec         *model.Spec
+	schemaPrefix string
+	scopes       map[string]string
+}
+
+// OperationRef locates an operation in the specification.
+type OperationRef struct {
+	Method    string // HTTP method in upper case (GET, POST, etc.)
+	Path      string // OpenAPI path template (e.g. "/users/{id}")
+	Operation *model.Operation
+}
+
+// Spec is the version-agnostic model of a specification returned by Model.Spec.
+type Spec = model.Spec
+
+// Spec returns a copy of the specification model, which callers may modify
+// without changing the Model.
+func (m *Model) Spec() *Spec {
+	return m.spec.Clone()
+}
+
+// Operations returns all operations sorted by path, then by method: standard
+// methods first, then other methods (such as QUERY) by name.
+func (m *Model) Operations() []OperationRef {
+	paths := make([]string, 0, len(m.spec.Paths))
+	for path := range m.spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var ops []OperationRef
+	for _, path := range paths {
+		item := m.spec.Paths[path]
+		for _, entry := range []struct {
+			method string
+			op     *model.Operation
+		}{
+			{http.MethodGet, item.Get},
+			{http.MethodPut, item.Put},
+			{http.MethodPost, item.Post},
+			{http.MethodDelete, item.Delete},
+			{http.MethodOptions, item.Options},
+			{http.MethodHead, item.Head},
+			{http.MethodPatch, item.Patch},
+			{http.MethodTrace, item.Trace},
+		} {
+			if entry.op != nil {
+				ops = append(ops, OperationRef{Method: entry.method, Path: path, Operation: entry.op})
+			}
+		}
+		for _, method := range slices.Sorted(maps.Keys(item.AdditionalOperations)) {
+			ops = append(ops, OperationRef{Method: method, Path: path, Operation: item.AdditionalOperations[method]})
+		}
+	}
+
+	return ops
+}
+
+// OperationByID returns the operation with the given operationId.
+func (m *Model) OperationByID(operationID string) (OperationRef, bool) {
+	if operationID == "" {
+		return OperationRef{}, false
+	}
+	for _, ref := range m.Operations() {
+		if ref.Operation.OperationID == operationID {
+			return ref, true
+		}
+	}
+
+	return OperationRef{}, false
+}
+
+// SchemaByName returns the component schema with the given name.
+func (m *Model) SchemaByName(name string) (*model.Schema, bool) {
+	if m.spec.Components == nil {
+		return nil, false
+	}
+	s, ok := m.spec.Components.Schemas[name]
+
+	return s, ok
+}
+
+// DataExposure is an operation handling fields of a data classification,
+// in its request or responses.
+type DataExposure struct {
+	OperationRef
+
+	// Fields lists the classified fields as "Schema.property", sorted. Fields of
+	// inline schemas are named after the path to them, e.g. "request.password".
+	Fields []string
+}
+
+// DataExposures lists the operations whose request or responses contain fields of
+// the given classification (see the sensitivity option of the openapi tag), sorted
+// by path, then by method. Use it to review the endpoints handling PII or secrets.
+//
+// Example:
+//
+//	for _, e := range result.Model.DataExposures("pii") {
+//	    fmt.Println(e.Method, e.Path, e.Fields)
+//	}
+func (m *Model) DataExposures(classification string) []DataExposure {
+	var exposures []DataExposure
+	for _, ref := range m.Operations() {
+		found := make(map[string]bool)
+		visited := make(map[string]bool)
+		op := ref.Operation
+		if op.RequestBody != nil {
+			for _, mt := range op.RequestBody.Content {
+				m.classifiedFields(mt.Schema, "request", classification, found, visited)
+			}
+		}
+		for status, resp := range op.Responses {
+			if resp == nil {
+				continue
+			}
+			for _, mt := range resp.Content {
+				m.classifiedFields(mt.Schema, "response"+status, classification, found, visited)
+			}
+		}
+		if len(found) > 0 {
+			exposures = append(exposures, DataExposure{
+				OperationRef: ref,
+				Fields:       slices.Sorted(maps.Keys(found)),
+			})
+		}
+	}
+
+	return exposures
+}
+
+// classifiedFields collects the fields of a classification in a schema and the
+// schemas it references. owner names the schema in field names.
+func (m *Model) classifiedFields(s *model.Schema, owner, classification string, found, visited map[string]bool) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		name := strings.TrimPrefix(s.Ref, m.schemaPrefix)
+		if visited[name] {
+			return
+		}
+		visited[name] = true
+		if target, ok := m.SchemaByName(name); ok {
+			m.classifiedFields(target, name, classification, found, visited)
+		}
+
+		return
+	}
+
+	forEachSubschemaAt(s, func(tokens []string, sub *model.Schema) {
+		if tokens[0] != "properties" {
+			m.classifiedFields(sub, owner, classification, found, visited)
+
+			return
+		}
+		field := owner + "." + tokens[1]
+		if sub.Extensions[build.ExtensionDataClassification] == classification {
+			found[field] = true
+		}
+		m.classifiedFields(sub, field, classification, found, visited)
+	})
+}
+
+// ScopeCoverage is a scope with the operations requiring it.
+type ScopeCoverage struct {
+	// Scope is the scope name.
+	Scope string
+
+	// Description is the description of the scope in the scope catalog, if any.
+	Description string
+
+	// Operations lists the operations requiring the scope, sorted by path, then
+	// by method. Operations without security requirements of their own require
+	// the scopes of the default security requirements.
+	Operations []OperationRef
+}
+
+// ScopeCoverage lists the scopes of the scope catalog (see API.DefineScopes) and
+// those requested by the operations, sorted by name, with the operations requiring
+// them. Use it to review which operations a scope grants access to; scopes of the
+// catalog without operations are unused.
+//
+// Example:
+//
+//	for _, c := range result.Model.ScopeCoverage() {
+//	    fmt.Println(c.Scope, len(c.Operations))
+//	}
+func (m *Model) ScopeCoverage() []ScopeCoverage {
+	byScope := make(map[string][]OperationRef)
+	for scope := range m.scopes {
+		byScope[scope] = nil
+	}
+	for _, ref := range m.Operations() {
+		security := ref.Operation.Security
+		if len(security) == 0 {
+			security = m.spec.Security
+		}
+		required := make(map[string]bool)
+		for _, req := range security {
+			for _, scopes := range req {
+				for _, scope := range scopes {
+					required[scope] = true
+				}
+			}
+		}
+		for scope := range required {
+			byScope[scope] = append(byScope[scope], ref)
+		}
+	}
+
+	coverage := make([]ScopeCoverage, 0, len(byScope))
+	for _, scope := range slices.Sorted(maps.Keys(byScope)) {
+		coverage = append(coverage, ScopeCoverage{
+			Scope:       scope,
+			Description: m.scopes[scope],
+			Operations:  byScope[scope],
+		})
+	}
+
+	return coverage
 }
@@ -0,0 +1,180 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResult_Model(t *testing.T) {
+	type User struct {
+		ID   int    `json:"id"`
+		Name string `json:"name"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		POST("/users", WithOperationID("createUser"), WithResponse(201, User{})),
+		GET("/users/:id", WithOperationID("getUser"), WithResponse(200, User{})),
+		DELETE("/users/:id", WithOperationID("deleteUser")),
+		GET("/health"),
+	)
+	require.NoError(t, err)
+	require.NotNil(t, result.Model)
+
+	t.Run("Operations", func(t *testing.T) {
+		ops := result.Model.Operations()
+		require.Len(t, ops, 4)
+
+		var got []string
+		for _, ref := range ops {
+			got = append(got, ref.Method+" "+ref.Path)
+		}
+		assert.Equal(t, []string{
+			"GET /health",
+			"POST /users",
+			"GET /users/{id}",
+			"DELETE /users/{id}",
+		}, got)
+	})
+
+	t.Run("OperationByID", func(t *testing.T) {
+		ref, ok := result.Model.OperationByID("getUser")
+		require.True(t, ok)
+		assert.Equal(t, http.MethodGet, ref.Method)
+		assert.Equal(t, "/users/{id}", ref.Path)
+		assert.Contains(t, ref.Operation.Responses, "200")
+
+		_, ok = result.Model.OperationByID("missing")
+		assert.False(t, ok)
+
+		_, ok = result.Model.OperationByID("")
+		assert.False(t, ok, "operations without ID must not match the empty ID")
+	})
+
+	t.Run("SchemaByName", func(t *testing.T) {
+		s, ok := result.Model.SchemaByName("User")
+		require.True(t, ok)
+		assert.Contains(t, s.Properties, "name")
+
+		_, ok = result.Model.SchemaByName("Missing")
+		assert.False(t, ok)
+	})
+
+	t.Run("Spec", func(t *testing.T) {
+		var spec *Spec = result.Model.Spec()
+		assert.Len(t, spec.Paths, 3)
+
+		// The returned spec is a copy
+		delete(spec.Paths, "/health")
+		spec.Components.Schemas["User"].Properties["name"].Type = "integer"
+		assert.Len(t, result.Model.Spec().Paths, 3)
+		user, _ := result.Model.SchemaByName("User")
+		assert.Equal(t, "string", user.Properties["name"].Type)
+	})
+
+	t.Run("detached from the generator", func(t *testing.T) {
+		user, _ := result.Model.SchemaByName("User")
+		user.Properties["name"].Type = "integer"
+
+		again, err := api.Generate(context.Background(), GET("/users/:id", WithResponse(200, User{})))
+		require.NoError(t, err)
+		user, _ = again.Model.SchemaByName("User")
+		assert.Equal(t, "string", user.Properties["name"].Type)
+	})
+}
+
+func TestModel_DataExposures(t *testing.T) {
+	type Address struct {
+		Street string `json:"street" openapi:"sensitivity=pii"`
+	}
+	type Customer struct {
+		ID      string  `json:"id" openapi:"sensitivity=public"`
+		Email   string  `json:"email" openapi:"sensitivity=pii"`
+		Address Address `json:"address"`
+	}
+	type ListCustomers struct {
+		Body []Customer `body:"structured"`
+	}
+	type Credentials struct {
+		Body struct {
+			Login    string `json:"login" openapi:"sensitivity=pii"`
+			Password string `json:"password" openapi:"sensitivity=secret"`
+		} `body:"structured"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/customers", WithResponse(200, ListCustomers{})),
+		POST("/login", WithRequest(Credentials{})),
+		GET("/health"),
+	)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	email := spec["components"].(map[string]any)["schemas"].(map[string]any)["Customer"].(map[string]any)["properties"].(map[string]any)["email"]
+	assert.Equal(t, map[string]any{"type": "string", "x-data-classification": "pii"}, email)
+
+	summarize := func(exposures []DataExposure) map[string][]string {
+		out := make(map[string][]string)
+		for _, e := range exposures {
+			out[e.Method+" "+e.Path] = e.Fields
+		}
+
+		return out
+	}
+
+	assert.Equal(t, map[string][]string{
+		"GET /customers": {"Address.street", "Customer.email"},
+		"POST /login":    {"CredentialsBody.login"},
+	}, summarize(result.Model.DataExposures("pii")))
+
+	assert.Equal(t, map[string][]string{
+		"POST /login": {"CredentialsBody.password"},
+	}, summarize(result.Model.DataExposures("secret")))
+}
+
+func TestModel_ScopeCoverage(t *testing.T) {
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithBearerAuth("bearerAuth", "JWT"),
+		WithDefaultSecurity("bearerAuth", "users:read"),
+	)
+	api.DefineScopes(map[string]string{
+		"users:read":  "Read users",
+		"users:write": "Write users",
+		"admin":       "Administer the API",
+	})
+
+	result, err := api.Generate(context.Background(),
+		GET("/users"),
+		POST("/users", WithSecurity("bearerAuth", "users:read", "users:write")),
+	)
+	require.NoError(t, err)
+
+	summarize := func(coverage []ScopeCoverage) map[string][]string {
+		out := make(map[string][]string)
+		for _, c := range coverage {
+			ops := []string{}
+			for _, ref := range c.Operations {
+				ops = append(ops, ref.Method+" "+ref.Path)
+			}
+			out[c.Scope+" ("+c.Description+")"] = ops
+		}
+
+		return out
+	}
+
+	assert.Equal(t, map[string][]string{
+		"admin (Administer the API)": {},
+		"users:read (Read users)":    {"GET /users", "POST /users"},
+		"users:write (Write users)":  {"POST /users"},
+	}, summarize(result.Model.ScopeCoverage()))
+}
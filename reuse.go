@@ -0,0 +1,303 @@
+package openapi
+
+import (
+	"maps"
+	"reflect"
+	"strings"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// WithSchemaReuseThreshold inlines the component schemas referenced fewer than
+// threshold times at their references, and keeps the others as components, for
+// smaller specs with components only for reused types. With a threshold of 2,
+// schemas used once are inlined.
+//
+// Schemas referencing themselves, directly or through other schemas, targets of
+// discriminator mappings and WebSocket message schemas are always kept as
+// components.
+//
+// Default: 0 (structs are always referenced)
+//
+// Example:
+//
+//	openapi.WithSchemaReuseThreshold(2)
+func WithSchemaReuseThreshold(threshold int) Option {
+	return func(a *API) {
+		a.SchemaReuseThreshold = threshold
+	}
+}
+
+// schemaInliner replaces references to component schemas with the schemas.
+// Schemas are copied on write, as component schemas are shared with the
+// schema generator.
+type schemaInliner struct {
+	schemaPrefix string
+
+	// inline holds the component schemas to inline by name, and inlined the
+	// schemas with their own references inlined.
+	inline  map[string]*model.Schema
+	inlined map[string]*model.Schema
+}
+
+// applySchemaReuseThreshold inlines the component schemas of the spec referenced
+// fewer times than the reuse threshold, and removes them from the components.
+func (a *API) applySchemaReuseThreshold(spec *model.Spec) {
+	if a.SchemaReuseThreshold < 2 || spec.Components == nil {
+		return
+	}
+
+	usage := newComponentUsage(spec, a.SchemaPrefix)
+	in := &schemaInliner{
+		schemaPrefix: a.SchemaPrefix,
+		inline:       make(map[string]*model.Schema),
+		inlined:      make(map[string]*model.Schema),
+	}
+	for name, s := range spec.Components.Schemas {
+		location := "schemas/" + name
+		refs := usage.refs[location]
+		if refs == 0 || refs >= a.SchemaReuseThreshold || usage.mapped[location] || a.isRecursiveSchema(spec, name) {
+			continue
+		}
+		in.inline[name] = s
+	}
+	if len(in.inline) == 0 {
+		return
+	}
+
+	for name := range in.inline {
+		delete(spec.Components.Schemas, name)
+	}
+	for name, s := range spec.Components.Schemas {
+		spec.Components.Schemas[name] = in.schema(s)
+	}
+	for _, item := range spec.Paths {
+		in.pathItem(item)
+	}
+	for _, item := range spec.Webhooks {
+		in.pathItem(item)
+	}
+	in.components(spec.Components)
+}
+
+// isRecursiveSchema reports whether the component schema name references itself,
+// directly or through other component schemas.
+func (a *API) isRecursiveSchema(spec *model.Spec, name string) bool {
+	visited := make(map[*model.Schema]bool)
+	var reaches func(s *model.Schema) bool
+	reaches = func(s *model.Schema) bool {
+		if s == nil || visited[s] {
+			return false
+		}
+		visited[s] = true
+		if target, ok := strings.CutPrefix(s.Ref, a.SchemaPrefix); ok {
+			if target == name {
+				return true
+			}
+
+			return reaches(spec.Components.Schemas[target])
+		}
+		found := false
+		forEachSubschema(s, func(sub *model.Schema) {
+			found = found || reaches(sub)
+		})
+
+		return found
+	}
+
+	return reaches(spec.Components.Schemas[name])
+}
+
+// components inlines the schemas referenced by the responses, parameters, request
+// bodies and headers of the components. They are copied, as they may be shared
+// with the options they were registered with.
+func (in *schemaInliner) components(c *model.Components) {
+	for name, resp := range c.Responses {
+		if resp == nil {
+			continue
+		}
+		r := *resp
+		r.Content = cloneContent(resp.Content)
+		r.Headers = maps.Clone(resp.Headers)
+		for key, h := range r.Headers {
+			r.Headers[key] = in.componentHeader(h)
+		}
+		in.content(r.Content)
+		c.Responses[name] = &r
+	}
+	for name, param := range c.Parameters {
+		if param == nil {
+			continue
+		}
+		p := *param
+		p.Content = cloneContent(param.Content)
+		in.parameter(&p)
+		c.Parameters[name] = &p
+	}
+	for name, body := range c.RequestBodies {
+		if body == nil {
+			continue
+		}
+		rb := *body
+		rb.Content = cloneContent(body.Content)
+		in.content(rb.Content)
+		c.RequestBodies[name] = &rb
+	}
+	for name, h := range c.Headers {
+		c.Headers[name] = in.componentHeader(h)
+	}
+}
+
+// componentHeader returns a copy of a header of the components with the schemas
+// inlined.
+func (in *schemaInliner) componentHeader(h *model.Header) *model.Header {
+	if h == nil {
+		return nil
+	}
+	c := *h
+	c.Content = cloneContent(h.Content)
+	in.header(&c)
+
+	return &c
+}
+
+// cloneContent returns a copy of content with copies of the media types.
+func cloneContent(content map[string]*model.MediaType) map[string]*model.MediaType {
+	if content == nil {
+		return nil
+	}
+	c := make(map[string]*model.MediaType, len(content))
+	for name, mt := range content {
+		if mt != nil {
+			cp := *mt
+			mt = &cp
+		}
+		c[name] = mt
+	}
+
+	return c
+}
+
+func (in *schemaInliner) pathItem(item *model.PathItem) {
+	if item == nil {
+		return
+	}
+	for i := range item.Parameters {
+		in.parameter(&item.Parameters[i])
+	}
+	for _, op := range []*model.Operation{
+		item.Get, item.Put, item.Post, item.Delete,
+		item.Options, item.Head, item.Patch, item.Trace,
+	} {
+		in.operation(op)
+	}
+	for _, op := range item.AdditionalOperations {
+		in.operation(op)
+	}
+}
+
+func (in *schemaInliner) operation(op *model.Operation) {
+	if op == nil {
+		return
+	}
+	for i := range op.Parameters {
+		in.parameter(&op.Parameters[i])
+	}
+	if op.RequestBody != nil {
+		in.content(op.RequestBody.Content)
+	}
+	for _, resp := range op.Responses {
+		if resp == nil {
+			continue
+		}
+		in.content(resp.Content)
+		for _, h := range resp.Headers {
+			in.header(h)
+		}
+	}
+	for _, cb := range op.Callbacks {
+		if cb == nil {
+			continue
+		}
+		for _, item := range cb.PathItems {
+			in.pathItem(item)
+		}
+	}
+}
+
+func (in *schemaInliner) parameter(p *model.Parameter) {
+	p.Schema = in.schema(p.Schema)
+	in.content(p.Content)
+}
+
+func (in *schemaInliner) header(h *model.Header) {
+	if h == nil {
+		return
+	}
+	h.Schema = in.schema(h.Schema)
+	in.content(h.Content)
+}
+
+func (in *schemaInliner) content(content map[string]*model.MediaType) {
+	for _, mt := range content {
+		if mt == nil {
+			continue
+		}
+		// Media types are built per operation: no copy needed
+		mt.Schema = in.schema(mt.Schema)
+		for _, enc := range mt.Encoding {
+			if enc == nil {
+				continue
+			}
+			for _, h := range enc.Headers {
+				in.header(h)
+			}
+		}
+	}
+}
+
+// schema returns s with the references to inlined schemas replaced, or s itself
+// when it has none. References with sibling keywords become an allOf of the
+// inlined schema, keeping the siblings.
+func (in *schemaInliner) schema(s *model.Schema) *model.Schema {
+	if s == nil {
+		return nil
+	}
+	if name, ok := strings.CutPrefix(s.Ref, in.schemaPrefix); ok {
+		target, ok := in.target(name)
+		if !ok {
+			return s
+		}
+		if isPlainRef(s) {
+			return target
+		}
+		c := *s
+		c.Ref = ""
+		c.AllOf = append([]*model.Schema{target}, s.AllOf...)
+
+		return &c
+	}
+
+	return rewriteSubschemas(s, in.schema)
+}
+
+// target returns the inlined schema name, if it is inlined.
+func (in *schemaInliner) target(name string) (*model.Schema, bool) {
+	if s, ok := in.inlined[name]; ok {
+		return s, true
+	}
+	s, ok := in.inline[name]
+	if !ok {
+		return nil, false
+	}
+	// Inlined schemas are not recursive: their references can be inlined first
+	s = in.schema(s)
+	in.inlined[name] = s
+
+	return s, true
+}
+
+// isPlainRef reports whether s is a reference without sibling keywords.
+func isPlainRef(s *model.Schema) bool {
+	return s.Ref != "" && reflect.DeepEqual(s, &model.Schema{Ref: s.Ref})
+}
@@ -0,0 +1,128 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type reuseAddress struct {
+	City string `json:"city"`
+}
+
+type reuseCustomer struct {
+	Address reuseAddress `json:"address"`
+}
+
+type reuseOrder struct {
+	Customer reuseCustomer `json:"customer"`
+}
+
+type reuseCategory struct {
+	Parent *reuseCategory `json:"parent"`
+}
+
+// reuseSpec holds the parts of generated specs checked by the reuse tests.
+type reuseSpec struct {
+	Paths map[string]map[string]struct {
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema reuseSchema `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+	Components struct {
+		Schemas map[string]reuseSchema `json:"schemas"`
+	} `json:"components"`
+}
+
+type reuseSchema struct {
+	Ref        string                 `json:"$ref"`
+	Properties map[string]reuseSchema `json:"properties"`
+}
+
+func TestWithSchemaReuseThreshold(t *testing.T) {
+	ops := []Operation{
+		GET("/orders", WithResponse(200, reuseOrder{})),
+		GET("/customer", WithResponse(200, reuseCustomer{})),
+		GET("/categories", WithResponse(200, reuseCategory{})),
+	}
+
+	generate := func(t *testing.T, threshold int) reuseSpec {
+		t.Helper()
+		api := NewAPI(WithVersion("3.1.2"), WithSchemaReuseThreshold(threshold))
+		result, err := api.Generate(context.Background(), ops...)
+		require.NoError(t, err)
+
+		var spec reuseSpec
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		return spec
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		spec := generate(t, 0)
+		assert.ElementsMatch(t, []string{"ReuseAddress", "ReuseCustomer", "ReuseOrder", "ReuseCategory"}, slices.Collect(maps.Keys(spec.Components.Schemas)))
+	})
+
+	t.Run("inline schemas used once", func(t *testing.T) {
+		spec := generate(t, 2)
+		// The customer is used twice, the recursive category is always referenced
+		assert.ElementsMatch(t, []string{"ReuseCustomer", "ReuseCategory"}, slices.Collect(maps.Keys(spec.Components.Schemas)))
+		assert.Contains(t, spec.Components.Schemas["ReuseCustomer"].Properties["address"].Properties, "city")
+
+		order := spec.Paths["/orders"]["get"].Responses["200"].Content["application/json"].Schema
+		assert.Empty(t, order.Ref)
+		assert.Equal(t, "#/components/schemas/ReuseCustomer", order.Properties["customer"].Ref)
+	})
+
+	t.Run("inline schemas used twice", func(t *testing.T) {
+		spec := generate(t, 3)
+		assert.ElementsMatch(t, []string{"ReuseCategory"}, slices.Collect(maps.Keys(spec.Components.Schemas)))
+
+		customer := spec.Paths["/customer"]["get"].Responses["200"].Content["application/json"].Schema
+		assert.Contains(t, customer.Properties["address"].Properties, "city")
+	})
+
+	t.Run("components are kept across calls", func(t *testing.T) {
+		api := NewAPI(WithVersion("3.1.2"), WithSchemaReuseThreshold(2))
+		for range 2 {
+			result, err := api.Generate(context.Background(), ops...)
+			require.NoError(t, err)
+			assert.Empty(t, result.Warnings)
+		}
+	})
+}
+
+type reuseCursor struct {
+	Offset int `json:"offset"`
+}
+
+func TestWithSchemaReuseThreshold_Components(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithSchemaReuseThreshold(2), WithValidation(true))
+	api.RegisterHeader("X-Cursor", Header{Type: reuseCursor{}})
+
+	result, err := api.Generate(context.Background(),
+		GET("/orders", WithResponse(200, reuseAddress{}), WithResponseHeaderRef(200, "X-Cursor")))
+	require.NoError(t, err)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+			Headers map[string]struct {
+				Schema reuseSchema `json:"schema"`
+			} `json:"headers"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.NotContains(t, spec.Components.Schemas, "ReuseCursor")
+	// The header component refers to the inlined schema
+	cursor := spec.Components.Headers["X-Cursor"].Schema
+	assert.Empty(t, cursor.Ref)
+	assert.Contains(t, cursor.Properties, "offset")
+}
@@ -0,0 +1,136 @@
+package openapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/talav/openapi/internal/export/util"
+	"github.com/talav/openapi/internal/model"
+)
+
+// checkSchemas rejects component schemas with contradictory keywords, which no
+// value can satisfy or no client can honor:
+//   - readOnly and writeOnly on the same property
+//   - required writeOnly properties in schemas only used by responses
+//   - minimum above maximum, and the same for lengths, items and properties
+//
+// Errors name the Go field the property was generated from. Checks stop when the
+// context is done, reporting the number of checked schemas.
+func (a *API) checkSchemas(ctx context.Context, spec *model.Spec) error {
+	if spec.Components == nil {
+		return nil
+	}
+
+	responseOnly := a.responseOnlySchemas(spec)
+
+	var errs []error
+	names := sortedNames(spec.Components.Schemas)
+	for i, name := range names {
+		if err := ctx.Err(); err != nil {
+			return fmt.Errorf("canceled after checking %d of %d component schemas: %w", i, len(names), err)
+		}
+		s := spec.Components.Schemas[name]
+		ptr := util.Pointer("#/components/schemas", name)
+		errs = append(errs, a.checkSchema(s, ptr)...)
+
+		if !responseOnly[name] || s == nil {
+			continue
+		}
+		for _, prop := range s.Required {
+			if ps := s.Properties[prop]; ps != nil && ps.WriteOnly {
+				errs = append(errs, a.schemaError(util.Pointer(ptr, "properties", prop),
+					"required writeOnly property in a schema only used by responses can never be present; make it optional or drop writeOnly"))
+			}
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// checkSchema checks a schema and its inline subschemas located at ptr.
+func (a *API) checkSchema(s *model.Schema, ptr string) []error {
+	if s == nil || s.Ref != "" {
+		return nil
+	}
+
+	var errs []error
+	if s.ReadOnly && s.WriteOnly {
+		errs = append(errs, a.schemaError(ptr, "readOnly and writeOnly are mutually exclusive; remove one of them"))
+	}
+	if s.Minimum != nil && s.Maximum != nil {
+		lo, hi := s.Minimum, s.Maximum
+		if lo.Value > hi.Value || lo.Value == hi.Value && (lo.Exclusive || hi.Exclusive) {
+			errs = append(errs, a.schemaError(ptr, fmt.Sprintf("minimum %v and maximum %v admit no value", lo.Value, hi.Value)))
+		}
+	}
+	for _, r := range []struct {
+		keyword  string
+		min, max *int
+	}{
+		{"Length", s.MinLength, s.MaxLength},
+		{"Items", s.MinItems, s.MaxItems},
+		{"Properties", s.MinProperties, s.MaxProperties},
+	} {
+		if r.min != nil && r.max != nil && *r.min > *r.max {
+			errs = append(errs, a.schemaError(ptr, fmt.Sprintf("min%s %d is greater than max%s %d", r.keyword, *r.min, r.keyword, *r.max)))
+		}
+	}
+
+	forEachSubschemaAt(s, func(tokens []string, sub *model.Schema) {
+		errs = append(errs, a.checkSchema(sub, util.Pointer(ptr, tokens...))...)
+	})
+
+	return errs
+}
+
+// schemaError returns an error for the schema at ptr, naming the Go type or field it
+// was generated from when known.
+func (a *API) schemaError(ptr, msg string) error {
+	if source := a.violationSource(ptr[1:]); source != "" {
+		return fmt.Errorf("%s (%s): %s", source, ptr, msg)
+	}
+
+	return fmt.Errorf("%s: %s", ptr, msg)
+}
+
+// responseOnlySchemas returns the names of the component schemas reachable from
+// responses but not from parameters or request bodies.
+func (a *API) responseOnlySchemas(spec *model.Spec) map[string]bool {
+	newUsage := func() *componentUsage {
+		return &componentUsage{
+			spec:         spec,
+			schemaPrefix: a.SchemaPrefix,
+			used:         make(map[string]bool),
+			refs:         make(map[string]int),
+			mapped:       make(map[string]bool),
+			visited:      make(map[*model.Schema]bool),
+		}
+	}
+	requests, responses := newUsage(), newUsage()
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		op := ref.Operation
+		for i := range op.Parameters {
+			requests.parameter(&op.Parameters[i])
+		}
+		requests.requestBody(op.RequestBody)
+		for _, resp := range op.Responses {
+			responses.response(resp)
+		}
+	}
+	for _, item := range spec.Paths {
+		for i := range item.Parameters {
+			requests.parameter(&item.Parameters[i])
+		}
+	}
+
+	only := make(map[string]bool)
+	for location := range responses.used {
+		if name, ok := strings.CutPrefix(location, "schemas/"); ok && !requests.used[location] {
+			only[name] = true
+		}
+	}
+
+	return only
+}
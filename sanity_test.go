@@ -0,0 +1,142 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+type sanityAccount struct {
+	Password string `json:"password" openapi:"readOnly,writeOnly"`
+}
+
+type sanityCredentials struct {
+	Login    string `json:"login" validate:"required"`
+	Password string `json:"password" validate:"required" openapi:"writeOnly"`
+}
+
+type sanityLogin struct {
+	Body sanityCredentials `body:"structured"`
+}
+
+type sanityRange struct {
+	Tags []string `json:"tags" validate:"min=1"`
+}
+
+// TransformSchema introduces bounds that no value can satisfy.
+func (sanityRange) TransformSchema(_ hook.SchemaRegistry, s *model.Schema) *model.Schema {
+	one, five := 1, 5
+	s.MinProperties, s.MaxProperties = &five, &one
+
+	return s
+}
+
+type sanityExtensions struct {
+	Name string `json:"name"`
+}
+
+// TransformSchema introduces contradictions in keywords other than properties.
+func (sanityExtensions) TransformSchema(_ hook.SchemaRegistry, s *model.Schema) *model.Schema {
+	one, five := 1, 5
+	s.PatternProps = map[string]*model.Schema{"^x-": {Type: "string", MinLength: &five, MaxLength: &one}}
+	s.Not = &model.Schema{ReadOnly: true, WriteOnly: true}
+
+	return s
+}
+
+func TestGenerate_SchemaSanity(t *testing.T) {
+	tests := []struct {
+		name    string
+		op      Operation
+		wantErr string
+	}{
+		{
+			name:    "readOnly and writeOnly",
+			op:      GET("/accounts", WithResponse(200, sanityAccount{})),
+			wantErr: "openapi.sanityAccount.Password (#/components/schemas/SanityAccount/properties/password): readOnly and writeOnly are mutually exclusive",
+		},
+		{
+			name:    "required writeOnly in response-only schema",
+			op:      GET("/credentials", WithResponse(200, sanityCredentials{})),
+			wantErr: "openapi.sanityCredentials.Password (#/components/schemas/SanityCredentials/properties/password): required writeOnly property in a schema only used by responses",
+		},
+		{
+			name:    "inverted counts",
+			op:      GET("/ranges", WithResponse(200, sanityRange{})),
+			wantErr: "openapi.sanityRange (#/components/schemas/SanityRange): minProperties 5 is greater than maxProperties 1",
+		},
+		{
+			name:    "pattern properties",
+			op:      GET("/extensions", WithResponse(200, sanityExtensions{})),
+			wantErr: "openapi.sanityExtensions (#/components/schemas/SanityExtensions/patternProperties/^x-): minLength 5 is greater than maxLength 1",
+		},
+		{
+			name:    "not",
+			op:      GET("/extensions", WithResponse(200, sanityExtensions{})),
+			wantErr: "openapi.sanityExtensions (#/components/schemas/SanityExtensions/not): readOnly and writeOnly are mutually exclusive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(), tt.op)
+			require.ErrorContains(t, err, "invalid schemas: ")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerate_SchemaSanity_WriteOnlyInRequests(t *testing.T) {
+	// The schema is used by a request too, where the password is sent
+	_, err := NewAPI(WithVersion("3.1.2")).Generate(context.Background(),
+		POST("/login", WithRequest(sanityLogin{}), WithResponse(200, sanityCredentials{})),
+	)
+	require.NoError(t, err)
+}
+
+type sanityPage struct {
+	Limit int8 `schema:"limit,location=query" default:"500"`
+}
+
+func TestGenerate_InvalidDefault(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+	_, err := api.Generate(context.Background(), GET("/items", WithRequest(sanityPage{}), WithResponse(200, sanityRange{})))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "openapi.sanityPage")
+	assert.Contains(t, err.Error(), `field Limit: failed to parse default value "500": value 500 does not match type int8: 500 overflows int8`)
+}
+
+func TestGenerate_SchemaSanity_InlinedSchemas(t *testing.T) {
+	type accountRequest struct {
+		Body sanityAccount `body:"structured"`
+	}
+
+	tests := []struct {
+		name string
+		opt  Option
+		op   Operation
+	}{
+		{
+			name: "reuse threshold",
+			opt:  WithSchemaReuseThreshold(2),
+			op:   GET("/accounts", WithResponse(200, sanityAccount{})),
+		},
+		{
+			name: "inline request bodies",
+			opt:  WithInlineRequestBodies(true),
+			op:   POST("/accounts", WithRequest(accountRequest{})),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2"), tt.opt).Generate(context.Background(), tt.op)
+			require.ErrorContains(t, err, "readOnly and writeOnly are mutually exclusive")
+		})
+	}
+}
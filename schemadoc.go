@@ -0,0 +1,115 @@
+package openapi
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// SchemaTemplates derive the title and description of component schemas from
+// their Go types, for documentation UIs rendering untitled schemas poorly.
+// Templates are text/template templates executed with SchemaTemplateData, and
+// apply to schemas without a title or description. Empty templates are skipped.
+type SchemaTemplates struct {
+	// Title is the template of schema titles, e.g. "{{.Type}} object".
+	Title string
+
+	// Description is the template of schema descriptions, e.g.
+	// "Schema generated from {{.Package}}.{{.Type}}".
+	Description string
+}
+
+// SchemaTemplateData is the data SchemaTemplates are executed with.
+type SchemaTemplateData struct {
+	// Name is the component schema name, e.g. "PaginatedUser".
+	Name string
+
+	// Type is the Go type name, e.g. "Paginated[github.com/acme/api.User]".
+	Type string
+
+	// Package is the import path of the Go type, e.g. "github.com/acme/api".
+	Package string
+}
+
+// WithSchemaTemplates derives the title and description of component schemas
+// without them from their Go types. Generate fails for invalid templates.
+//
+// Example:
+//
+//	openapi.WithSchemaTemplates(openapi.SchemaTemplates{
+//	    Title:       "{{.Name}} object",
+//	    Description: "Schema generated from {{.Package}}.{{.Type}}",
+//	})
+func WithSchemaTemplates(templates SchemaTemplates) Option {
+	return func(a *API) {
+		a.SchemaTemplates = templates
+	}
+}
+
+// applySchemaTemplates sets the titles and descriptions of the component schemas
+// from the schema templates. Documented schemas are copied, as component schemas
+// are shared with the schema generator.
+func (a *API) applySchemaTemplates(schemas map[string]*model.Schema) error {
+	title, err := parseSchemaTemplate("title", a.SchemaTemplates.Title)
+	if err != nil {
+		return err
+	}
+	description, err := parseSchemaTemplate("description", a.SchemaTemplates.Description)
+	if err != nil {
+		return err
+	}
+	if title == nil && description == nil {
+		return nil
+	}
+
+	for _, name := range sortedNames(schemas) {
+		s := schemas[name]
+		t, ok := a.generator.TypeOf(name)
+		if s == nil || !ok {
+			continue
+		}
+		data := SchemaTemplateData{Name: name, Type: t.Name(), Package: t.PkgPath()}
+
+		c := *s
+		if c.Title, err = executeSchemaTemplate(title, s.Title, data); err != nil {
+			return err
+		}
+		if c.Description, err = executeSchemaTemplate(description, s.Description, data); err != nil {
+			return err
+		}
+		if c.Title != s.Title || c.Description != s.Description {
+			schemas[name] = &c
+		}
+	}
+
+	return nil
+}
+
+// parseSchemaTemplate parses a schema template, or returns nil for an empty one.
+func parseSchemaTemplate(name, text string) (*template.Template, error) {
+	if text == "" {
+		return nil, nil //nolint:nilnil // No template to apply
+	}
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s template: %w", name, err)
+	}
+
+	return tmpl, nil
+}
+
+// executeSchemaTemplate returns the value of a schema keyword: the current value if
+// set or there is no template, the executed template otherwise.
+func executeSchemaTemplate(tmpl *template.Template, current string, data SchemaTemplateData) (string, error) {
+	if tmpl == nil || current != "" {
+		return current, nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("failed to execute schema %s template for %s: %w", tmpl.Name(), data.Name, err)
+	}
+
+	return b.String(), nil
+}
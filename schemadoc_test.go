@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/hook"
+	"github.com/talav/openapi/internal/model"
+)
+
+type templatedUser struct {
+	ID string `json:"id"`
+}
+
+type templatedOrder struct {
+	ID string `json:"id"`
+}
+
+// TransformSchema documents the schema explicitly.
+func (templatedOrder) TransformSchema(_ hook.SchemaRegistry, s *model.Schema) *model.Schema {
+	s.Title = "Order"
+
+	return s
+}
+
+func TestWithSchemaTemplates(t *testing.T) {
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithSchemaTemplates(SchemaTemplates{
+			Title:       "{{.Name}} object",
+			Description: "Schema generated from {{.Package}}.{{.Type}}",
+		}),
+	)
+
+	result, err := api.Generate(context.Background(),
+		GET("/user", WithResponse(200, templatedUser{})),
+		GET("/order", WithResponse(200, templatedOrder{})),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]struct {
+				Title       string `json:"title"`
+				Description string `json:"description"`
+			} `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	user := spec.Components.Schemas["TemplatedUser"]
+	assert.Equal(t, "TemplatedUser object", user.Title)
+	assert.Equal(t, "Schema generated from github.com/talav/openapi.templatedUser", user.Description)
+
+	order := spec.Components.Schemas["TemplatedOrder"]
+	assert.Equal(t, "Order", order.Title, "explicit titles are kept")
+	assert.Equal(t, "Schema generated from github.com/talav/openapi.templatedOrder", order.Description)
+
+	// Component schemas are shared with the generator: templates must not leak into it
+	s, ok := api.generator.Schemas()["TemplatedUser"]
+	require.True(t, ok)
+	assert.Empty(t, s.Title)
+}
+
+func TestWithSchemaTemplates_Invalid(t *testing.T) {
+	tests := []struct {
+		name      string
+		templates SchemaTemplates
+		wantErr   string
+	}{
+		{
+			name:      "parse error",
+			templates: SchemaTemplates{Title: "{{.Name"},
+			wantErr:   "invalid schema title template",
+		},
+		{
+			name:      "unknown field",
+			templates: SchemaTemplates{Description: "{{.Doc}}"},
+			wantErr:   "failed to execute schema description template for TemplatedUser",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2"), WithSchemaTemplates(tt.templates)).Generate(context.Background(),
+				GET("/user", WithResponse(200, templatedUser{})),
+			)
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// schemaExtension is an extension attached to a component schema or one of its properties.
+type schemaExtension struct {
+	schema   string
+	property string
+	key      string
+	value    any
+}
+
+// ExtendSchema attaches a specification extension to a generated component schema,
+// or to one of its properties with "Schema.property" (the JSON property name).
+// Use it for extensions governed outside the type definitions, such as database
+// hints or data classification, which should not live in struct tags.
+//
+// Extensions are applied by Generate, which fails if the key does not start with
+// "x-" or the schema or property does not exist.
+//
+// Example:
+//
+//	api.ExtendSchema("User", "x-table", "users")
+//	api.ExtendSchema("User.email", "x-pii", "contact")
+func (a *API) ExtendSchema(target, key string, value any) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	name, property, _ := strings.Cut(target, ".")
+	a.schemaExtensions = append(a.schemaExtensions, schemaExtension{
+		schema:   name,
+		property: property,
+		key:      key,
+		value:    value,
+	})
+}
+
+// applySchemaExtensions applies the extensions of ExtendSchema to the component
+// schemas of the spec. Extended schemas are copied, as component schemas are shared
+// with the schema generator.
+func (a *API) applySchemaExtensions(schemas map[string]*model.Schema) error {
+	var errs []error
+	copied := make(map[*model.Schema]bool)
+	own := func(s *model.Schema) *model.Schema {
+		if copied[s] {
+			return s
+		}
+		c := *s
+		c.Extensions = maps.Clone(s.Extensions)
+		if c.Extensions == nil {
+			c.Extensions = make(map[string]any)
+		}
+		copied[&c] = true
+
+		return &c
+	}
+
+	for _, ext := range a.schemaExtensions {
+		target := ext.schema
+		if ext.property != "" {
+			target += "." + ext.property
+		}
+		if !strings.HasPrefix(ext.key, "x-") {
+			errs = append(errs, fmt.Errorf("schema %s: extension key must start with x-: %s", target, ext.key))
+
+			continue
+		}
+		s, ok := schemas[ext.schema]
+		if !ok {
+			errs = append(errs, fmt.Errorf("schema %s: unknown schema %q", target, ext.schema))
+
+			continue
+		}
+		s = own(s)
+		schemas[ext.schema] = s
+
+		if ext.property == "" {
+			s.Extensions[ext.key] = ext.value
+
+			continue
+		}
+		prop, ok := s.Properties[ext.property]
+		if !ok {
+			errs = append(errs, fmt.Errorf("schema %s: unknown property %q (properties: %s)",
+				target, ext.property, strings.Join(sortedNames(s.Properties), ", ")))
+
+			continue
+		}
+		if !copied[prop] {
+			s.Properties = maps.Clone(s.Properties)
+		}
+		prop = own(prop)
+		s.Properties[ext.property] = prop
+		prop.Extensions[ext.key] = ext.value
+	}
+
+	return errors.Join(errs...)
+}
+
+// sortedNames returns the keys of a schema map in order.
+func sortedNames(m map[string]*model.Schema) []string {
+	names := keysOf(m)
+	sort.Strings(names)
+
+	return names
+}
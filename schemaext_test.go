@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type extendedUser struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+func TestAPI_ExtendSchema(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+	api.ExtendSchema("ExtendedUser", "x-table", "users")
+	api.ExtendSchema("ExtendedUser.email", "x-pii", "contact")
+	api.ExtendSchema("ExtendedUser.email", "x-mask", true)
+
+	ops := []Operation{GET("/users", WithResponse(200, extendedUser{}))}
+
+	result, err := api.Generate(context.Background(), ops...)
+	require.NoError(t, err)
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	user := spec["components"].(map[string]any)["schemas"].(map[string]any)["ExtendedUser"].(map[string]any)
+
+	assert.Equal(t, "users", user["x-table"])
+	props := user["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"type": "string", "x-pii": "contact", "x-mask": true}, props["email"])
+	assert.Equal(t, map[string]any{"type": "string"}, props["name"])
+
+	// Generated schemas are not modified: a second generation is identical
+	again, err := api.Generate(context.Background(), ops...)
+	require.NoError(t, err)
+	assert.Equal(t, string(result.JSON), string(again.JSON))
+}
+
+func TestAPI_ExtendSchema_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  string
+		key     string
+		wantErr string
+	}{
+		{name: "unknown schema", target: "Missing", key: "x-table", wantErr: `schema Missing: unknown schema "Missing"`},
+		{name: "unknown property", target: "ExtendedUser.phone", key: "x-pii", wantErr: `schema ExtendedUser.phone: unknown property "phone" (properties: email, name)`},
+		{name: "invalid key", target: "ExtendedUser", key: "table", wantErr: "schema ExtendedUser: extension key must start with x-: table"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := NewAPI(WithVersion("3.1.2"))
+			api.ExtendSchema(tt.target, tt.key, "value")
+
+			_, err := api.Generate(context.Background(), GET("/users", WithResponse(200, extendedUser{})))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
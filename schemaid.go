@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// WithJSONSchemaDialect sets the jsonSchemaDialect of the specification, the
+// default JSON Schema dialect of its schemas. With WithSchemaIDs, it is also the
+// $schema of the component schemas. jsonSchemaDialect is 3.1-only, and dropped
+// with a warning in 3.0.
+//
+// Default: "" (JSON Schema 2020-12)
+//
+// Example:
+//
+//	openapi.WithJSONSchemaDialect("https://spec.openapis.org/oas/3.1/dialect/base")
+func WithJSONSchemaDialect(dialect string) Option {
+	return func(a *API) {
+		a.JSONSchemaDialect = dialect
+	}
+}
+
+// WithSchemaIDs emits the $id of each component schema, its name resolved
+// against the base URI of WithSchemaBaseURI, and its $schema when a dialect is set
+// with WithJSONSchemaDialect. Component schemas are then JSON Schema resources that
+// can be referenced from other documents and validated with standard JSON Schema
+// tooling. References between component schemas use their $id, against which they
+// resolve. $id and $schema are 3.1-only, and dropped with a warning in 3.0.
+//
+// Default: false
+//
+// Example:
+//
+//	openapi.WithSchemaIDs(true)
+func WithSchemaIDs(enabled bool) Option {
+	return func(a *API) {
+		a.SchemaIDs = enabled
+	}
+}
+
+// WithSchemaBaseURI sets the base URI of the $id of the component schemas
+// emitted with WithSchemaIDs: the $id of a schema is its name resolved against
+// the base URI. Without a base URI, the $id is the name, resolved against the
+// URI of the specification.
+//
+// Default: ""
+//
+// Example:
+//
+//	openapi.WithSchemaBaseURI("https://api.example.com/schemas/")
+func WithSchemaBaseURI(uri string) Option {
+	return func(a *API) {
+		a.SchemaBaseURI = uri
+	}
+}
+
+// checkSchemaIdentifiers checks the JSON Schema dialect, an absolute URI, and
+// the schema base URI, a URI without fragment.
+func (a *API) checkSchemaIdentifiers() error {
+	if a.JSONSchemaDialect != "" {
+		if u, err := url.Parse(a.JSONSchemaDialect); err != nil || !u.IsAbs() {
+			return fmt.Errorf("invalid JSON Schema dialect %q: want an absolute URI", a.JSONSchemaDialect)
+		}
+	}
+	if a.SchemaBaseURI != "" {
+		if u, err := url.Parse(a.SchemaBaseURI); err != nil || u.Fragment != "" {
+			return fmt.Errorf("invalid schema base URI %q: want a URI without fragment", a.SchemaBaseURI)
+		}
+	}
+
+	return nil
+}
+
+// applySchemaIDs sets the $id and $schema of the component schemas, when
+// enabled. Schemas are copied, the generator keeping them across calls.
+func (a *API) applySchemaIDs(spec *model.Spec) {
+	if !a.SchemaIDs {
+		return
+	}
+
+	// The base URI was checked by checkSchemaIdentifiers
+	base, _ := url.Parse(a.SchemaBaseURI)
+	for name, s := range spec.Components.Schemas {
+		c := *s
+		c.ID = name
+		if a.SchemaBaseURI != "" {
+			c.ID = base.ResolveReference(&url.URL{Path: name}).String()
+		}
+		c.Dialect = a.JSONSchemaDialect
+		spec.Components.Schemas[name] = &c
+	}
+}
@@ -0,0 +1,134 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/example"
+)
+
+type schemaIDAddress struct {
+	City string `json:"city"`
+}
+
+type schemaIDCustomer struct {
+	Name    string          `json:"name"`
+	Address schemaIDAddress `json:"address"`
+}
+
+type schemaIDView struct {
+	Dialect    string `json:"jsonSchemaDialect"`
+	Components struct {
+		Schemas map[string]struct {
+			Schema     string `json:"$schema"`
+			ID         string `json:"$id"`
+			Properties map[string]struct {
+				Ref string `json:"$ref"`
+			} `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+	Paths map[string]map[string]struct {
+		Responses map[string]struct {
+			Content map[string]struct {
+				Schema struct {
+					Ref string `json:"$ref"`
+				} `json:"schema"`
+			} `json:"content"`
+		} `json:"responses"`
+	} `json:"paths"`
+}
+
+func TestWithSchemaIDs(t *testing.T) {
+	const dialect = "https://spec.openapis.org/oas/3.1/dialect/base"
+
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithValidation(true),
+		WithJSONSchemaDialect(dialect),
+		WithSchemaIDs(true),
+		WithSchemaBaseURI("https://api.example.com/schemas/"),
+		WithExampleCheck(true),
+	)
+	invalid := map[string]any{"name": "Ada", "address": map[string]any{"city": 3}}
+	result, err := api.Generate(context.Background(), GET("/customers/{id}",
+		WithResponse(200, schemaIDCustomer{}),
+		WithResponseExample(200, "application/json", example.New("invalid", invalid)),
+	))
+	require.NoError(t, err)
+	// Examples are checked against the identified schemas
+	assert.True(t, result.Warnings.Has(debug.WarnExampleSchemaMismatch))
+
+	var spec schemaIDView
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.Equal(t, dialect, spec.Dialect)
+
+	customer := spec.Components.Schemas["SchemaIDCustomer"]
+	assert.Equal(t, "https://api.example.com/schemas/SchemaIDCustomer", customer.ID)
+	assert.Equal(t, dialect, customer.Schema)
+	// References between component schemas resolve against their $id
+	assert.Equal(t, "https://api.example.com/schemas/SchemaIDAddress", customer.Properties["address"].Ref)
+	assert.Equal(t, "https://api.example.com/schemas/SchemaIDAddress", spec.Components.Schemas["SchemaIDAddress"].ID)
+
+	// References of the operations are unchanged
+	body := spec.Paths["/customers/{id}"]["get"].Responses["200"].Content["application/json"].Schema
+	assert.Equal(t, "#/components/schemas/SchemaIDCustomer", body.Ref)
+}
+
+func TestWithSchemaIDs_RelativeIDs(t *testing.T) {
+	result, err := NewAPI(WithVersion("3.1.2"), WithSchemaIDs(true)).
+		Generate(context.Background(), GET("/customers/{id}", WithResponse(200, schemaIDCustomer{})))
+	require.NoError(t, err)
+
+	var spec schemaIDView
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	customer := spec.Components.Schemas["SchemaIDCustomer"]
+	assert.Equal(t, "SchemaIDCustomer", customer.ID)
+	assert.Empty(t, customer.Schema)
+	assert.Equal(t, "SchemaIDAddress", customer.Properties["address"].Ref)
+	assert.Empty(t, spec.Dialect)
+}
+
+func TestWithSchemaIDs_Downlevel(t *testing.T) {
+	opts := []Option{
+		WithVersion("3.0.4"),
+		WithJSONSchemaDialect("https://json-schema.org/draft/2020-12/schema"),
+		WithSchemaIDs(true),
+	}
+	result, err := NewAPI(opts...).Generate(context.Background(), GET("/customers/{id}", WithResponse(200, schemaIDCustomer{})))
+	require.NoError(t, err)
+
+	var spec schemaIDView
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.Empty(t, spec.Dialect)
+	customer := spec.Components.Schemas["SchemaIDCustomer"]
+	assert.Empty(t, customer.ID)
+	assert.Equal(t, "#/components/schemas/SchemaIDAddress", customer.Properties["address"].Ref)
+	assert.True(t, result.Warnings.Has(debug.WarnDegradationJSONSchemaDialect))
+	assert.True(t, result.Warnings.Has(debug.WarnDegradationSchemaID))
+
+	_, err = NewAPI(append(opts, WithStrictDownlevel(true))...).Generate(context.Background(), GET("/customers/{id}", WithResponse(200, schemaIDCustomer{})))
+	require.Error(t, err)
+}
+
+func TestWithSchemaIDs_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     Option
+		wantErr string
+	}{
+		{"relative dialect", WithJSONSchemaDialect("dialect/base"), `invalid JSON Schema dialect "dialect/base": want an absolute URI`},
+		{"base URI with fragment", WithSchemaBaseURI("https://api.example.com/schemas#defs"), `invalid schema base URI "https://api.example.com/schemas#defs": want a URI without fragment`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2"), tt.opt).Generate(context.Background(), GET("/health"))
+			require.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
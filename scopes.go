@@ -0,0 +1,59 @@
+package openapi
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// DefineScopes adds scopes to the scope catalog, mapping scope names to their
+// descriptions. With a catalog, Generate fails for operations, or default security
+// requirements, requesting scopes it does not define. Model.ScopeCoverage reports
+// the operations requiring each scope.
+//
+// Example:
+//
+//	api.DefineScopes(map[string]string{
+//	    "users:read":  "Read user profiles",
+//	    "users:write": "Create and update users",
+//	})
+func (a *API) DefineScopes(scopes map[string]string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// The catalog is shared with the models of previous Generate calls: copy on write
+	catalog := make(map[string]string, len(a.scopes)+len(scopes))
+	maps.Copy(catalog, a.scopes)
+	maps.Copy(catalog, scopes)
+	a.scopes = catalog
+}
+
+// checkScopes reports the scopes requested by the security requirements of the
+// spec and missing from the scope catalog, if any.
+func (a *API) checkScopes(spec *model.Spec) error {
+	if len(a.scopes) == 0 {
+		return nil
+	}
+
+	var errs []error
+	check := func(where string, reqs []model.SecurityRequirement) {
+		for _, req := range reqs {
+			for _, scheme := range slices.Sorted(maps.Keys(req)) {
+				for _, scope := range req[scheme] {
+					if _, ok := a.scopes[scope]; !ok {
+						errs = append(errs, fmt.Errorf("%s: scope %q of security scheme %s is not defined", where, scope, scheme))
+					}
+				}
+			}
+		}
+	}
+	check("default security", spec.Security)
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		check("operation "+ref.Method+" "+ref.Path, ref.Operation.Security)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,38 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDefineScopes_Undefined(t *testing.T) {
+	api := NewAPI(
+		WithVersion("3.1.2"),
+		WithBearerAuth("bearerAuth", "JWT"),
+		WithDefaultSecurity("bearerAuth", "users:list"),
+	)
+	api.DefineScopes(map[string]string{"users:read": "Read users"})
+	api.DefineScopes(map[string]string{"users:write": "Write users"})
+
+	_, err := api.Generate(context.Background(),
+		GET("/users", WithSecurity("bearerAuth", "users:read")),
+		POST("/users", WithSecurity("bearerAuth", "users:write", "users:delete")),
+	)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `default security: scope "users:list" of security scheme bearerAuth is not defined`)
+	assert.Contains(t, err.Error(), `operation POST /users: scope "users:delete" of security scheme bearerAuth is not defined`)
+	assert.NotContains(t, err.Error(), `"users:read"`)
+	assert.NotContains(t, err.Error(), `"users:write"`)
+}
+
+func TestDefineScopes_NoCatalog(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithBearerAuth("bearerAuth", "JWT"))
+
+	_, err := api.Generate(context.Background(),
+		GET("/users", WithSecurity("bearerAuth", "users:read")),
+	)
+	require.NoError(t, err)
+}
@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+// requestKey is the context key of the request of ContextWithRequest.
+type requestKey struct{}
+
+// ContextWithRequest returns a context carrying the request the specification is
+// generated for, from which WithServerFromRequest derives the server URL.
+//
+// Example:
+//
+//	func serveSpec(w http.ResponseWriter, r *http.Request) {
+//	    result, err := api.Generate(openapi.ContextWithRequest(r.Context(), r), ops...)
+//	    ...
+//	}
+func ContextWithRequest(ctx context.Context, r *http.Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, r)
+}
+
+// WithDynamicServer computes the servers of the specification on each generation,
+// from the context given to Generate, for deployments whose URL is not known at
+// build time, such as multi-tenant ones. The servers replace those of WithServer,
+// unless the function returns none.
+//
+// Default: nil (the servers of WithServer)
+//
+// Example:
+//
+//	openapi.WithDynamicServer(func(ctx context.Context) []openapi.Server {
+//	    return []openapi.Server{openapi.NewServer("https://" + tenantFrom(ctx) + ".example.com")}
+//	})
+func WithDynamicServer(fn func(ctx context.Context) []Server) Option {
+	return func(a *API) {
+		a.DynamicServers = fn
+	}
+}
+
+// WithServerFromRequest derives the server of the specification from the request
+// attached to the context with ContextWithRequest (see ServerFromRequest), so that
+// a spec served behind proxies points at the URL clients used. The servers of
+// WithServer are used when the context carries no request.
+//
+// Forwarding headers can be set by clients: enable it only behind a proxy that
+// overwrites them.
+//
+// Example:
+//
+//	openapi.WithServerFromRequest("/api")
+func WithServerFromRequest(basePath string) Option {
+	return WithDynamicServer(func(ctx context.Context) []Server {
+		r, ok := ctx.Value(requestKey{}).(*http.Request)
+		if !ok || r == nil {
+			return nil
+		}
+
+		return []Server{ServerFromRequest(r, basePath)}
+	})
+}
+
+// ServerFromRequest returns the server a request was sent to, followed by
+// basePath. The scheme, host and path prefix are taken from the Forwarded header
+// (RFC 7239), then from the X-Forwarded-Proto, X-Forwarded-Host and
+// X-Forwarded-Prefix headers, then from the request itself.
+func ServerFromRequest(r *http.Request, basePath string) Server {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	host := r.Host
+
+	if proto := firstHeaderValue(r, "X-Forwarded-Proto"); proto != "" {
+		scheme = proto
+	}
+	if h := firstHeaderValue(r, "X-Forwarded-Host"); h != "" {
+		host = h
+	}
+	if forwarded := firstHeaderValue(r, "Forwarded"); forwarded != "" {
+		for pair := range strings.SplitSeq(forwarded, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(pair), "=")
+			value = strings.Trim(value, `"`)
+			switch strings.ToLower(key) {
+			case "proto":
+				scheme = value
+			case "host":
+				host = value
+			}
+		}
+	}
+
+	prefix := strings.TrimSuffix(firstHeaderValue(r, "X-Forwarded-Prefix"), "/")
+
+	return Server{URL: strings.ToLower(scheme) + "://" + host + prefix + basePath}
+}
+
+// firstHeaderValue returns the first of the comma-separated values of a header,
+// the one set by the proxy closest to the client.
+func firstHeaderValue(r *http.Request, name string) string {
+	first, _, _ := strings.Cut(r.Header.Get(name), ",")
+
+	return strings.TrimSpace(first)
+}
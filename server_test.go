@@ -0,0 +1,106 @@
+package openapi
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestServerFromRequest(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers map[string]string
+		tls     bool
+		want    string
+	}{
+		{"request", nil, false, "http://api.internal:8080/v1"},
+		{"tls", nil, true, "https://api.internal:8080/v1"},
+		{"x-forwarded", map[string]string{
+			"X-Forwarded-Proto":  "https",
+			"X-Forwarded-Host":   "api.example.com, proxy.internal",
+			"X-Forwarded-Prefix": "/gateway/",
+		}, false, "https://api.example.com/gateway/v1"},
+		{"forwarded", map[string]string{
+			"Forwarded":        `for=192.0.2.60;proto=HTTPS;host="tenant.example.com", for=198.51.100.17`,
+			"X-Forwarded-Host": "ignored.example.com",
+		}, false, "https://tenant.example.com/v1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "http://api.internal:8080/openapi.json", nil)
+			for name, value := range tt.headers {
+				r.Header.Set(name, value)
+			}
+			if tt.tls {
+				r.TLS = &tls.ConnectionState{}
+			}
+
+			assert.Equal(t, tt.want, ServerFromRequest(r, "/v1").URL)
+		})
+	}
+}
+
+func TestWithServerFromRequest(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+
+	api := NewAPI(WithVersion("3.1.2"), WithServer("https://static.example.com"), WithServerFromRequest("/api"))
+	servers := func(t *testing.T, ctx context.Context) []string {
+		t.Helper()
+		result, err := api.Generate(ctx, GET("/users", WithResponse(200, user{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Servers []struct {
+				URL string `json:"url"`
+			} `json:"servers"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		var urls []string
+		for _, s := range spec.Servers {
+			urls = append(urls, s.URL)
+		}
+
+		return urls
+	}
+
+	r := httptest.NewRequest("GET", "https://tenant.example.com/openapi.json", nil)
+	assert.Equal(t, []string{"https://tenant.example.com/api"}, servers(t, ContextWithRequest(context.Background(), r)))
+	assert.Equal(t, []string{"https://static.example.com"}, servers(t, context.Background()))
+}
+
+func TestWithDynamicServer(t *testing.T) {
+	type user struct {
+		ID int `json:"id"`
+	}
+	type tenantKey struct{}
+
+	api := NewAPI(WithVersion("3.0.4"), WithDynamicServer(func(ctx context.Context) []Server {
+		tenant, _ := ctx.Value(tenantKey{}).(string)
+
+		return []Server{NewServer("https://"+tenant+".example.com", WithServerDescription("Tenant "+tenant))}
+	}))
+
+	ctx := context.WithValue(context.Background(), tenantKey{}, "acme")
+	result, err := api.Generate(ctx, GET("/users", WithResponse(200, user{})))
+	require.NoError(t, err)
+
+	var spec struct {
+		Servers []struct {
+			URL         string `json:"url"`
+			Description string `json:"description"`
+		} `json:"servers"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	require.Len(t, spec.Servers, 1)
+	assert.Equal(t, "https://acme.example.com", spec.Servers[0].URL)
+	assert.Equal(t, "Tenant acme", spec.Servers[0].Description)
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/enumsrc"
+)
+
+// WithSourceEnums documents enum types from their Go source, instead of repeating
+// their values in oneof tags. The values of a type are the constants declared with
+// it in the package directory dir, in source order; their doc comments (or line
+// comments) describe them. The directory is relative to the working directory of
+// the program at run time, not to the source of the caller, so this suits
+// generation at build time rather than servers deployed without their source.
+//
+// Schemas of the types get an enum of the values, with the constant names and
+// descriptions in the x-enum-varnames and x-enum-descriptions extensions. A oneof
+// tag on a field still overrides the enum. Types are passed as values and must be
+// named string, integer, float or boolean types. Constants computed from other
+// packages are not supported.
+//
+// Generate fails if the directory cannot be parsed, holds a package of another
+// name than the package of a type, or a type has no constants.
+//
+// Example:
+//
+//	// type Status string
+//	//
+//	// const (
+//	//     StatusActive Status = "active" // Active accounts can sign in.
+//	//     StatusLocked Status = "locked" // Locked accounts cannot.
+//	// )
+//	openapi.WithSourceEnums("./internal/account", account.Status(""))
+func WithSourceEnums(dir string, types ...any) Option {
+	return func(a *API) {
+		pkg, err := enumsrc.Load(dir)
+		if err != nil {
+			a.sourceEnumErrs = append(a.sourceEnumErrs, fmt.Errorf("%s: %w", dir, err))
+
+			return
+		}
+		for _, v := range types {
+			t := reflect.TypeOf(v)
+			if t == nil || t.PkgPath() == "" || t.Kind() > reflect.Float64 && t.Kind() != reflect.String {
+				a.sourceEnumErrs = append(a.sourceEnumErrs, fmt.Errorf("enum %T: want a value of a named string, integer, float or boolean type", v))
+
+				continue
+			}
+			// Reflection does not tell the directory of a package: the package
+			// names at least must match
+			if name := strings.TrimSuffix(t.String(), "."+t.Name()); name != pkg.Name() {
+				a.sourceEnumErrs = append(a.sourceEnumErrs, fmt.Errorf("enum %s: declared in package %s (%s), but %s holds package %s", t, name, t.PkgPath(), dir, pkg.Name()))
+
+				continue
+			}
+			values, err := pkg.Values(t.Name())
+			if err != nil {
+				a.sourceEnumErrs = append(a.sourceEnumErrs, fmt.Errorf("enum %s: %w", t, err))
+
+				continue
+			}
+			if a.sourceEnums == nil {
+				a.sourceEnums = make(map[reflect.Type][]build.EnumValue)
+			}
+			enum := make([]build.EnumValue, 0, len(values))
+			for _, value := range values {
+				enum = append(enum, build.EnumValue{Name: value.Name, Value: value.Value, Description: value.Description})
+			}
+			a.sourceEnums[t] = enum
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type sourceStatus string
+
+type sourcePriority int
+
+type sourceTicket struct {
+	Status   sourceStatus   `json:"status"`
+	Priority sourcePriority `json:"priority"`
+	Legacy   sourceStatus   `json:"legacy" validate:"oneof=open"`
+}
+
+const sourceEnumPackage = `package openapi
+
+type sourceStatus string
+
+const (
+	// Open tickets await an answer.
+	sourceStatusOpen sourceStatus = "open"
+	sourceStatusClosed sourceStatus = "closed" // Closed tickets are archived.
+)
+
+type sourcePriority int
+
+const (
+	sourcePriorityLow sourcePriority = iota
+	sourcePriorityHigh
+)
+`
+
+func TestWithSourceEnums(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tickets.go"), []byte(sourceEnumPackage), 0o600))
+
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithSourceEnums(dir, sourceStatus(""), sourcePriority(0)))
+		result, err := api.Generate(context.Background(), GET("/tickets", WithResponse(200, sourceTicket{})))
+		require.NoError(t, err)
+
+		var spec struct {
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]struct {
+						Enum         []any    `json:"enum"`
+						Const        any      `json:"const"`
+						VarNames     []string `json:"x-enum-varnames"`
+						Descriptions []string `json:"x-enum-descriptions"`
+					} `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+		props := spec.Components.Schemas["SourceTicket"].Properties
+
+		assert.Equal(t, []any{"open", "closed"}, props["status"].Enum)
+		assert.Equal(t, []string{"sourceStatusOpen", "sourceStatusClosed"}, props["status"].VarNames)
+		assert.Equal(t, []string{"Open tickets await an answer.", "Closed tickets are archived."}, props["status"].Descriptions)
+
+		assert.Equal(t, []any{float64(0), float64(1)}, props["priority"].Enum)
+		assert.Equal(t, []string{"sourcePriorityLow", "sourcePriorityHigh"}, props["priority"].VarNames)
+		assert.Nil(t, props["priority"].Descriptions)
+
+		// The oneof tag overrides the enum
+		legacy := props["legacy"]
+		if version == "3.0.4" {
+			assert.Equal(t, []any{"open"}, legacy.Enum)
+		} else {
+			assert.Equal(t, "open", legacy.Const)
+		}
+	})
+}
+
+func TestWithSourceEnums_Errors(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tickets.go"), []byte(sourceEnumPackage), 0o600))
+
+	_, err := NewAPI(WithVersion("3.1.2"), WithSourceEnums(dir, sourceTicket{}, 0, ExampleRedactionMask)).Generate(context.Background())
+	require.ErrorContains(t, err, "invalid source enums: ")
+	assert.ErrorContains(t, err, "enum openapi.sourceTicket: want a value of a named string, integer, float or boolean type")
+	assert.ErrorContains(t, err, "enum int: want a value of a named string, integer, float or boolean type")
+	assert.ErrorContains(t, err, "enum openapi.ExampleRedaction: type ExampleRedaction not found in package openapi")
+
+	other := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(other, "tickets.go"), []byte(strings.Replace(sourceEnumPackage, "package openapi", "package tickets", 1)), 0o600))
+	_, err = NewAPI(WithVersion("3.1.2"), WithSourceEnums(other, sourceStatus(""))).Generate(context.Background())
+	assert.ErrorContains(t, err, "enum openapi.sourceStatus: declared in package openapi (github.com/talav/openapi), but "+other+" holds package tickets")
+
+	_, err = NewAPI(WithVersion("3.1.2"), WithSourceEnums(filepath.Join(dir, "missing"), sourceStatus(""))).Generate(context.Background())
+	assert.ErrorContains(t, err, "failed to read package")
+}
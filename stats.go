@@ -0,0 +1,121 @@
+package openapi
+
+import (
+	"strings"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// Stats summarizes the size and documentation quality of a specification.
+type Stats struct {
+	// Operations is the number of operations.
+	Operations int
+
+	// Schemas is the number of component schemas.
+	Schemas int
+
+	// OperationsByTag maps tags to the number of operations tagged with them.
+	// Operations with several tags count for each tag.
+	OperationsByTag map[string]int
+
+	// MissingDescriptions lists the operations with neither a summary nor a description.
+	MissingDescriptions []OperationRef
+
+	// MissingExamples lists the operations with request or response bodies of
+	// which no media type has examples.
+	MissingExamples []OperationRef
+
+	// MissingErrorResponses lists the operations documenting neither 4XX or 5XX
+	// responses nor a default response.
+	MissingErrorResponses []OperationRef
+
+	// Coverage is the documentation coverage, in percent: the share of the checks
+	// above passed by the operations. Examples are only checked for operations
+	// with bodies. Coverage is 100 for specifications without operations.
+	Coverage float64
+}
+
+// stats computes the statistics of the specification. Operations are listed
+// sorted by path, then by method.
+func (m *Model) stats() Stats {
+	stats := Stats{OperationsByTag: make(map[string]int)}
+	if m.spec.Components != nil {
+		stats.Schemas = len(m.spec.Components.Schemas)
+	}
+
+	var checks, passed int
+	for _, ref := range m.Operations() {
+		op := ref.Operation
+		stats.Operations++
+		for _, tag := range op.Tags {
+			stats.OperationsByTag[tag]++
+		}
+
+		checks += 2
+		if op.Summary == "" && op.Description == "" {
+			stats.MissingDescriptions = append(stats.MissingDescriptions, ref)
+		} else {
+			passed++
+		}
+		if !hasErrorResponse(op.Responses) {
+			stats.MissingErrorResponses = append(stats.MissingErrorResponses, ref)
+		} else {
+			passed++
+		}
+
+		hasBody, hasExample := bodyExamples(op)
+		if !hasBody {
+			continue
+		}
+		checks++
+		if !hasExample {
+			stats.MissingExamples = append(stats.MissingExamples, ref)
+		} else {
+			passed++
+		}
+	}
+
+	stats.Coverage = 100
+	if checks > 0 {
+		stats.Coverage = float64(passed) * 100 / float64(checks)
+	}
+
+	return stats
+}
+
+// hasErrorResponse reports whether responses document 4XX or 5XX status codes
+// or a default response.
+func hasErrorResponse(responses map[string]*model.Response) bool {
+	for status := range responses {
+		if status == "default" || strings.HasPrefix(status, "4") || strings.HasPrefix(status, "5") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// bodyExamples reports whether the operation has request or response bodies, and
+// whether one of their media types has examples.
+func bodyExamples(op *model.Operation) (hasBody, hasExample bool) {
+	contents := make([]map[string]*model.MediaType, 0, len(op.Responses)+1)
+	if op.RequestBody != nil {
+		contents = append(contents, op.RequestBody.Content)
+	}
+	for _, resp := range op.Responses {
+		if resp != nil {
+			contents = append(contents, resp.Content)
+		}
+	}
+
+	for _, content := range contents {
+		for _, mt := range content {
+			hasBody = true
+			if mt != nil && (mt.Example != nil || len(mt.Examples) > 0) {
+				return true, true
+			}
+		}
+	}
+
+	return hasBody, false
+}
@@ -0,0 +1,65 @@
+package openapi
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/example"
+)
+
+type statsUser struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type statsError struct {
+	Message string `json:"message"`
+}
+
+func TestResult_Stats(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/users/:id",
+			WithSummary("Get user"),
+			WithTags("users"),
+			WithResponse(200, statsUser{}, example.New("alice", statsUser{ID: 1, Name: "Alice"})),
+			WithResponse(404, statsError{}),
+		),
+		GET("/users", WithTags("users", "admin"), WithResponse(200, statsUser{})),
+		DELETE("/users/:id", WithDescription("Delete a user"), WithTags("admin"), WithResponse(500, statsError{})),
+	)
+	require.NoError(t, err)
+
+	stats := result.Stats
+	assert.Equal(t, 3, stats.Operations)
+	assert.Equal(t, 2, stats.Schemas)
+	assert.Equal(t, map[string]int{"users": 2, "admin": 2}, stats.OperationsByTag)
+
+	refs := func(ops []OperationRef) []string {
+		names := make([]string, 0, len(ops))
+		for _, op := range ops {
+			names = append(names, op.Method+" "+op.Path)
+		}
+
+		return names
+	}
+	assert.Equal(t, []string{"GET /users"}, refs(stats.MissingDescriptions))
+	assert.Equal(t, []string{"GET /users"}, refs(stats.MissingErrorResponses))
+	assert.Equal(t, []string{"GET /users", "DELETE /users/{id}"}, refs(stats.MissingExamples))
+
+	// 3 description checks, 3 error response checks and 3 example checks; 5 pass.
+	assert.InDelta(t, 500.0/9, stats.Coverage, 1e-9)
+}
+
+func TestResult_Stats_NoOperations(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, result.Stats.Operations)
+	assert.InDelta(t, 100.0, result.Stats.Coverage, 0)
+}
@@ -0,0 +1,164 @@
+package openapi
+
+import (
+	"maps"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/build"
+	"github.com/talav/openapi/internal/model"
+)
+
+// packageDir is the directory of the sources of this package, whose frames are
+// skipped when locating the declaration of operations.
+var packageDir = func() string {
+	_, file, _, _ := runtime.Caller(0)
+
+	return filepath.Dir(file)
+}()
+
+// callers returns the program counters of the calling goroutine from the caller
+// of callers. They are resolved by callerSource when tracing only, as resolving
+// them for every operation would slow down the declaration of operations.
+func callers() []uintptr {
+	pcs := make([]uintptr, 16)
+
+	return pcs[:runtime.Callers(2, pcs)]
+}
+
+// callerSource returns the file:line of the first caller outside this package,
+// tests excluded, so that operations declared through helpers such as GraphQL are
+// located at the helper call.
+func callerSource(pcs []uintptr) string {
+	frames := runtime.CallersFrames(pcs)
+	for {
+		frame, more := frames.Next()
+		if filepath.Dir(frame.File) != packageDir || strings.HasSuffix(frame.File, "_test.go") {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// WithDebugTrace records what produced the generated specification in
+// Result.Trace: for each operation, the file:line of its declaration and the Go
+// types of its request and responses, and for each component schema, its Go type
+// and the struct fields and tags of its properties. Save the trace as a JSON
+// sidecar to find where a schema or property came from.
+//
+// Default: false
+//
+// Example:
+//
+//	openapi.WithDebugTrace(true)
+func WithDebugTrace(enabled bool) Option {
+	return func(a *API) {
+		a.DebugTrace = enabled
+	}
+}
+
+// traceOperation records the declaration of an operation, when tracing.
+func (a *API) traceOperation(path string, op Operation, modelOp *model.Operation) {
+	if !a.DebugTrace {
+		return
+	}
+
+	trace := debug.OperationTrace{
+		Method:      strings.ToUpper(op.Method),
+		Path:        path,
+		OperationID: modelOp.OperationID,
+		Source:      callerSource(op.callers),
+	}
+	if op.doc.RequestType != nil {
+		trace.Request = traceTypeName(op.doc.RequestType)
+	}
+	for status, t := range op.doc.ResponseTypes {
+		if t == nil {
+			continue
+		}
+		if trace.Responses == nil {
+			trace.Responses = make(map[string]string)
+		}
+		trace.Responses[build.StatusKey(status)] = traceTypeName(t)
+	}
+	a.operationTraces[trace.Method+" "+path] = trace
+}
+
+// trace returns the trace of the specification, or nil when not tracing.
+func (a *API) trace(spec *model.Spec) *debug.Trace {
+	if !a.DebugTrace {
+		return nil
+	}
+
+	trace := &debug.Trace{
+		Operations: []debug.OperationTrace{},
+		Schemas:    []debug.SchemaTrace{},
+	}
+	for _, ref := range (&Model{spec: spec}).Operations() {
+		if op, ok := a.operationTraces[ref.Method+" "+ref.Path]; ok {
+			trace.Operations = append(trace.Operations, op)
+		}
+	}
+
+	schemas := spec.Components.Schemas
+	for _, name := range slices.Sorted(maps.Keys(schemas)) {
+		t, ok := a.generator.TypeOf(name)
+		if !ok {
+			continue
+		}
+		st := debug.SchemaTrace{Name: name, Type: traceTypeName(t)}
+		fields := make(map[string]build.Property)
+		for _, prop := range a.generator.Properties(t) {
+			fields[prop.Name] = prop
+		}
+		for _, prop := range slices.Sorted(maps.Keys(schemas[name].Properties)) {
+			field, ok := fields[prop]
+			if !ok {
+				continue
+			}
+			st.Properties = append(st.Properties, debug.PropertyTrace{
+				Name:  prop,
+				Field: field.Path,
+				Tags:  a.fieldTags(field.Field),
+			})
+		}
+		trace.Schemas = append(trace.Schemas, st)
+	}
+
+	return trace
+}
+
+// fieldTags returns the struct tags of a field read by the generator.
+func (a *API) fieldTags(field reflect.StructField) map[string]string {
+	tc := a.TagConfig
+	var tags map[string]string
+	for _, name := range []string{"json", tc.Schema, tc.Body, tc.OpenAPI, tc.Validate, tc.Default, tc.Requires, tc.Header} {
+		if value, ok := field.Tag.Lookup(name); ok && name != "" {
+			if tags == nil {
+				tags = make(map[string]string)
+			}
+			tags[name] = value
+		}
+	}
+
+	return tags
+}
+
+// traceTypeName returns the name of a type with the import path of its package.
+func traceTypeName(t reflect.Type) string {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.PkgPath() == "" {
+		return t.String()
+	}
+
+	return t.PkgPath() + "." + t.Name()
+}
@@ -0,0 +1,95 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"runtime"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+type traceUser struct {
+	ID    int    `json:"id" openapi:"readOnly"`
+	Name  string `json:"name" validate:"required"`
+	Email string `json:"email" openapi:"format=email"`
+}
+
+type traceCreateUser struct {
+	Body traceUser `body:"structured"`
+}
+
+func TestWithDebugTrace(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithDebugTrace(true))
+
+	_, file, line, _ := runtime.Caller(0)
+	create := POST("/users", WithOperationID("createUser"), WithRequest(traceCreateUser{}), WithResponse(201, traceUser{}))
+	health := HealthCheck("/healthz")
+
+	result, err := api.Generate(context.Background(), health, create)
+	require.NoError(t, err)
+	require.NotNil(t, result.Trace)
+
+	require.Len(t, result.Trace.Operations, 2)
+	assert.Equal(t, debug.OperationTrace{
+		Method:      "GET",
+		Path:        "/healthz",
+		OperationID: "healthCheck",
+		Source:      file + ":" + strconv.Itoa(line+2),
+		Responses: map[string]string{
+			"200": "github.com/talav/openapi.HealthStatus",
+			"503": "github.com/talav/openapi.HealthStatus",
+		},
+	}, result.Trace.Operations[0])
+	assert.Equal(t, debug.OperationTrace{
+		Method:      "POST",
+		Path:        "/users",
+		OperationID: "createUser",
+		Source:      file + ":" + strconv.Itoa(line+1),
+		Request:     "github.com/talav/openapi.traceCreateUser",
+		Responses:   map[string]string{"201": "github.com/talav/openapi.traceUser"},
+	}, result.Trace.Operations[1])
+
+	schemas := make(map[string]debug.SchemaTrace)
+	for _, s := range result.Trace.Schemas {
+		schemas[s.Name] = s
+	}
+	require.Contains(t, schemas, "TraceUser")
+	assert.Equal(t, debug.SchemaTrace{
+		Name: "TraceUser",
+		Type: "github.com/talav/openapi.traceUser",
+		Properties: []debug.PropertyTrace{
+			{Name: "email", Field: "Email", Tags: map[string]string{"json": "email", "openapi": "format=email"}},
+			{Name: "id", Field: "ID", Tags: map[string]string{"json": "id", "openapi": "readOnly"}},
+			{Name: "name", Field: "Name", Tags: map[string]string{"json": "name", "validate": "required"}},
+		},
+	}, schemas["TraceUser"])
+
+	sidecar, err := json.Marshal(result.Trace)
+	require.NoError(t, err)
+	assert.Contains(t, string(sidecar), `"source":"`+file)
+}
+
+func TestWithDebugTrace_Disabled(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(), GET("/users", WithResponse(200, traceUser{})))
+	require.NoError(t, err)
+	assert.Nil(t, result.Trace)
+}
+
+func TestWithDebugTrace_OperationDefaults(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithDebugTrace(true), WithOperationDefaults(WithTags("users")))
+
+	_, file, line, _ := runtime.Caller(0)
+	op := GET("/users", WithResponse(200, traceUser{}))
+
+	result, err := api.Generate(context.Background(), op)
+	require.NoError(t, err)
+	require.Len(t, result.Trace.Operations, 1)
+	assert.Equal(t, file+":"+strconv.Itoa(line+1), result.Trace.Operations[0].Source)
+}
@@ -0,0 +1,75 @@
+package openapi
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/talav/openapi/internal/build"
+)
+
+// UnionVariant is an implementation of an interface registered with WithUnion.
+type UnionVariant struct {
+	name  string
+	value any
+}
+
+// Variant returns a union implementation named by its discriminator value.
+//
+// Example:
+//
+//	openapi.Variant("circle", Circle{})
+func Variant(name string, value any) UnionVariant {
+	return UnionVariant{name: name, value: value}
+}
+
+// union is an interface type registered with WithUnion.
+type union struct {
+	iface    reflect.Type
+	variants []build.UnionVariant
+}
+
+// WithUnion registers the implementations of an interface type, passed as a nil
+// pointer to the interface. Fields of the interface type are documented as a oneOf
+// of the implementation schemas instead of any value.
+//
+// Tag such a field with openapi:"discriminator=<property>" to add a discriminator
+// mapping the variant names to their schemas.
+//
+// Generate fails if the interface or a variant is invalid.
+//
+// Example:
+//
+//	openapi.WithUnion((*Shape)(nil),
+//	    openapi.Variant("circle", Circle{}),
+//	    openapi.Variant("square", Square{}),
+//	)
+func WithUnion(iface any, variants ...UnionVariant) Option {
+	return func(a *API) {
+		t := reflect.TypeOf(iface)
+		if t == nil || t.Kind() != reflect.Pointer || t.Elem().Kind() != reflect.Interface {
+			a.unionErrs = append(a.unionErrs, fmt.Errorf("union %T: want a nil pointer to an interface, such as (*Shape)(nil)", iface))
+
+			return
+		}
+		t = t.Elem()
+
+		u := union{iface: t}
+		names := make(map[string]bool, len(variants))
+		for _, v := range variants {
+			vt := reflect.TypeOf(v.value)
+			switch {
+			case vt == nil || !vt.Implements(t) && !reflect.PointerTo(vt).Implements(t):
+				a.unionErrs = append(a.unionErrs, fmt.Errorf("union %s: variant %q (%T) does not implement it", t, v.name, v.value))
+
+				return
+			case v.name == "" || names[v.name]:
+				a.unionErrs = append(a.unionErrs, fmt.Errorf("union %s: variant names must be unique and non-empty, got %q", t, v.name))
+
+				return
+			}
+			names[v.name] = true
+			u.variants = append(u.variants, build.UnionVariant{Name: v.name, Type: vt})
+		}
+		a.unions = append(a.unions, u)
+	}
+}
@@ -0,0 +1,116 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type unionShape interface {
+	Area() float64
+}
+
+type unionCircle struct {
+	Kind   string  `json:"kind" validate:"required"`
+	Radius float64 `json:"radius"`
+}
+
+func (c unionCircle) Area() float64 { return 3 * c.Radius * c.Radius }
+
+type unionSquare struct {
+	Kind string  `json:"kind" validate:"required"`
+	Side float64 `json:"side"`
+}
+
+func (s *unionSquare) Area() float64 { return s.Side * s.Side }
+
+type unionDrawing struct {
+	Main   unionShape   `json:"main" openapi:"discriminator=kind"`
+	Layers []unionShape `json:"layers" openapi:"discriminator=kind"`
+	Any    unionShape   `json:"any"`
+}
+
+type unionNotShape struct {
+	Side float64 `json:"side"`
+}
+
+func TestWithUnion(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(
+			WithVersion(version),
+			WithUnion((*unionShape)(nil), Variant("circle", unionCircle{}), Variant("square", unionSquare{})),
+		)
+
+		result, err := api.Generate(context.Background(), GET("/drawing", WithResponse(200, unionDrawing{})))
+		require.NoError(t, err)
+
+		var doc struct {
+			Components struct {
+				Schemas map[string]struct {
+					Properties map[string]json.RawMessage `json:"properties"`
+				} `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &doc))
+		props := doc.Components.Schemas["UnionDrawing"].Properties
+
+		union := `{
+			"oneOf": [
+				{"$ref": "#/components/schemas/UnionCircle"},
+				{"$ref": "#/components/schemas/UnionSquare"}
+			],
+			"discriminator": {
+				"propertyName": "kind",
+				"mapping": {
+					"circle": "#/components/schemas/UnionCircle",
+					"square": "#/components/schemas/UnionSquare"
+				}
+			}
+		}`
+		assert.JSONEq(t, union, string(props["main"]))
+		assert.JSONEq(t, `{"type": "array", "items": `+union+`}`, string(props["layers"]))
+		assert.JSONEq(t, `{
+			"oneOf": [
+				{"$ref": "#/components/schemas/UnionCircle"},
+				{"$ref": "#/components/schemas/UnionSquare"}
+			]
+		}`, string(props["any"]))
+	})
+}
+
+func TestWithUnion_InvalidRegistration(t *testing.T) {
+	tests := []struct {
+		name    string
+		opt     Option
+		wantErr string
+	}{
+		{
+			name:    "not an interface pointer",
+			opt:     WithUnion(unionCircle{}),
+			wantErr: "union openapi.unionCircle: want a nil pointer to an interface",
+		},
+		{
+			name:    "variant not implementing the interface",
+			opt:     WithUnion((*unionShape)(nil), Variant("other", unionNotShape{})),
+			wantErr: `variant "other" (openapi.unionNotShape) does not implement it`,
+		},
+		{
+			name:    "duplicate variant name",
+			opt:     WithUnion((*unionShape)(nil), Variant("shape", unionCircle{}), Variant("shape", unionSquare{})),
+			wantErr: `variant names must be unique and non-empty, got "shape"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewAPI(WithVersion("3.1.2"), tt.opt).Generate(context.Background(),
+				GET("/drawing", WithResponse(200, unionCircle{})),
+			)
+			require.ErrorContains(t, err, "invalid unions: ")
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package openapi
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/talav/openapi/debug"
+	"github.com/talav/openapi/internal/model"
+)
+
+// componentsPrefix is the JSON pointer prefix of component references.
+const componentsPrefix = "#/components/"
+
+// componentUsage collects the components reachable from the paths and webhooks of a spec.
+type componentUsage struct {
+	spec         *model.Spec
+	schemaPrefix string
+
+	// used holds the locations of used components, e.g. "schemas/User", refs the
+	// number of references to them, and mapped those targeted by discriminator
+	// mappings or extensions, which must remain components.
+	used    map[string]bool
+	refs    map[string]int
+	mapped  map[string]bool
+	visited map[*model.Schema]bool
+}
+
+// newComponentUsage collects the components used by the paths and webhooks of a spec.
+func newComponentUsage(spec *model.Spec, schemaPrefix string) *componentUsage {
+	u := &componentUsage{
+		spec:         spec,
+		schemaPrefix: schemaPrefix,
+		used:         make(map[string]bool),
+		refs:         make(map[string]int),
+		mapped:       make(map[string]bool),
+		visited:      make(map[*model.Schema]bool),
+	}
+	for _, item := range spec.Paths {
+		u.pathItem(item)
+	}
+	for _, item := range spec.Webhooks {
+		u.pathItem(item)
+	}
+
+	return u
+}
+
+// componentReferences returns the locations of the components referenced by the
+// responses, parameters, request bodies and headers of the components, whether
+// those are used or not.
+func componentReferences(spec *model.Spec, schemaPrefix string) map[string]bool {
+	u := &componentUsage{
+		spec:         spec,
+		schemaPrefix: schemaPrefix,
+		used:         make(map[string]bool),
+		refs:         make(map[string]int),
+		mapped:       make(map[string]bool),
+		visited:      make(map[*model.Schema]bool),
+	}
+	c := spec.Components
+	for _, resp := range c.Responses {
+		u.response(resp)
+	}
+	for _, p := range c.Parameters {
+		u.parameter(p)
+	}
+	for _, rb := range c.RequestBodies {
+		u.requestBody(rb)
+	}
+	for _, h := range c.Headers {
+		u.header(h)
+	}
+
+	return u.used
+}
+
+// unusedComponents returns the locations of the schemas, responses, parameters,
+// request bodies, headers and examples of the spec that are never referenced,
+// e.g. "schemas/User", sorted.
+func unusedComponents(spec *model.Spec, schemaPrefix string) []string {
+	if spec.Components == nil {
+		return nil
+	}
+
+	u := newComponentUsage(spec, schemaPrefix)
+
+	var unused []string
+	c := spec.Components
+	unused = u.appendUnused(unused, "schemas", keysOf(c.Schemas))
+	unused = u.appendUnused(unused, "responses", keysOf(c.Responses))
+	unused = u.appendUnused(unused, "parameters", keysOf(c.Parameters))
+	unused = u.appendUnused(unused, "requestBodies", keysOf(c.RequestBodies))
+	unused = u.appendUnused(unused, "headers", keysOf(c.Headers))
+	unused = u.appendUnused(unused, "examples", keysOf(c.Examples))
+	sort.Strings(unused)
+
+	return unused
+}
+
+// pruneUnused removes the unused components of the spec and returns their locations.
+func pruneUnused(spec *model.Spec, schemaPrefix string) []string {
+	unused := unusedComponents(spec, schemaPrefix)
+	for _, location := range unused {
+		kind, name, _ := strings.Cut(location, "/")
+		switch kind {
+		case "schemas":
+			delete(spec.Components.Schemas, name)
+		case "responses":
+			delete(spec.Components.Responses, name)
+		case "parameters":
+			delete(spec.Components.Parameters, name)
+		case "requestBodies":
+			delete(spec.Components.RequestBodies, name)
+		case "headers":
+			delete(spec.Components.Headers, name)
+		case "examples":
+			delete(spec.Components.Examples, name)
+		}
+	}
+
+	return unused
+}
+
+// dropStaleSchemas removes the generated component schemas of previous generations
+// from schemas: those neither generated nor referenced by this one, directly or
+// through other schemas. The schema generator keeps them across calls.
+func (a *API) dropStaleSchemas(schemas map[string]*model.Schema) {
+	current := make(map[string]bool)
+	var visit func(s *model.Schema)
+	visit = func(s *model.Schema) {
+		if name, ok := strings.CutPrefix(s.Ref, a.SchemaPrefix); ok {
+			if target := schemas[name]; target != nil && !current[name] {
+				current[name] = true
+				visit(target)
+			}
+
+			return
+		}
+		forEachSubschema(s, visit)
+	}
+	for name, s := range schemas {
+		if a.generator.Used(name) && !current[name] {
+			current[name] = true
+			visit(s)
+		}
+	}
+
+	for name := range schemas {
+		if !current[name] {
+			delete(schemas, name)
+		}
+	}
+}
+
+// unusedWarnings reports unused components as warnings.
+func unusedWarnings(unused []string) debug.Warnings {
+	var warns debug.Warnings
+	for _, location := range unused {
+		warns.Append(debug.NewWarning(
+			debug.WarnUnusedComponent,
+			componentsPrefix+location,
+			fmt.Sprintf("component %s is never referenced", location),
+		))
+	}
+
+	return warns
+}
+
+func (u *componentUsage) appendUnused(unused []string, kind string, names []string) []string {
+	for _, name := range names {
+		if !u.used[kind+"/"+name] {
+			unused = append(unused, kind+"/"+name)
+		}
+	}
+
+	return unused
+}
+
+// location returns the location of the component referenced by ref, e.g.
+// "schemas/User", or "" for references outside the components.
+func (u *componentUsage) location(ref string) string {
+	switch {
+	case ref == "":
+		return ""
+	case strings.HasPrefix(ref, u.schemaPrefix):
+		return "schemas/" + strings.TrimPrefix(ref, u.schemaPrefix)
+	case strings.HasPrefix(ref, componentsPrefix):
+		return strings.TrimPrefix(ref, componentsPrefix)
+	default:
+		return ""
+	}
+}
+
+// ref marks the component referenced by ref as used, and visits it the first time.
+func (u *componentUsage) ref(ref string) {
+	location := u.location(ref)
+	if location == "" {
+		return
+	}
+	u.refs[location]++
+	if u.used[location] {
+		return
+	}
+	u.used[location] = true
+
+	kind, name, _ := strings.Cut(location, "/")
+	c := u.spec.Components
+	switch kind {
+	case "schemas":
+		u.schema(c.Schemas[name])
+	case "responses":
+		u.response(c.Responses[name])
+	case "parameters":
+		u.parameter(c.Parameters[name])
+	case "requestBodies":
+		u.requestBody(c.RequestBodies[name])
+	case "headers":
+		u.header(c.Headers[name])
+	}
+}
+
+func (u *componentUsage) pathItem(item *model.PathItem) {
+	if item == nil {
+		return
+	}
+	for i := range item.Parameters {
+		u.parameter(&item.Parameters[i])
+	}
+	for _, op := range []*model.Operation{
+		item.Get, item.Put, item.Post, item.Delete,
+		item.Options, item.Head, item.Patch, item.Trace,
+	} {
+		u.operation(op)
+	}
+	for _, op := range item.AdditionalOperations {
+		u.operation(op)
+	}
+}
+
+func (u *componentUsage) operation(op *model.Operation) {
+	if op == nil {
+		return
+	}
+	for i := range op.Parameters {
+		u.parameter(&op.Parameters[i])
+	}
+	u.requestBody(op.RequestBody)
+	for _, resp := range op.Responses {
+		u.response(resp)
+	}
+	if ws, ok := op.Extensions[extensionWebSocket].(webSocketExtension); ok {
+		for _, ref := range []*schemaRef{ws.ClientMessages, ws.ServerMessages} {
+			if ref != nil {
+				u.ref(ref.Ref)
+				u.mapped[u.location(ref.Ref)] = true
+			}
+		}
+	}
+	for _, cb := range op.Callbacks {
+		if cb == nil {
+			continue
+		}
+		for _, item := range cb.PathItems {
+			u.pathItem(item)
+		}
+	}
+}
+
+func (u *componentUsage) parameter(p *model.Parameter) {
+	if p == nil {
+		return
+	}
+	u.ref(p.Ref)
+	u.schema(p.Schema)
+	u.examples(p.Examples)
+	u.content(p.Content)
+}
+
+func (u *componentUsage) requestBody(rb *model.RequestBody) {
+	if rb == nil {
+		return
+	}
+	u.ref(rb.Ref)
+	u.content(rb.Content)
+}
+
+func (u *componentUsage) response(resp *model.Response) {
+	if resp == nil {
+		return
+	}
+	u.ref(resp.Ref)
+	u.content(resp.Content)
+	for _, h := range resp.Headers {
+		u.header(h)
+	}
+}
+
+func (u *componentUsage) header(h *model.Header) {
+	if h == nil {
+		return
+	}
+	u.ref(h.Ref)
+	u.schema(h.Schema)
+	u.examples(h.Examples)
+	u.content(h.Content)
+}
+
+func (u *componentUsage) content(content map[string]*model.MediaType) {
+	for _, mt := range content {
+		if mt == nil {
+			continue
+		}
+		u.schema(mt.Schema)
+		u.examples(mt.Examples)
+		for _, enc := range mt.Encoding {
+			if enc == nil {
+				continue
+			}
+			for _, h := range enc.Headers {
+				u.header(h)
+			}
+		}
+	}
+}
+
+func (u *componentUsage) examples(examples map[string]*model.Example) {
+	for _, ex := range examples {
+		if ex != nil {
+			u.ref(ex.Ref)
+		}
+	}
+}
+
+func (u *componentUsage) schema(s *model.Schema) {
+	if s == nil || u.visited[s] {
+		return
+	}
+	u.visited[s] = true
+
+	u.ref(s.Ref)
+	forEachSubschema(s, u.schema)
+	if s.Discriminator != nil {
+		for _, target := range s.Discriminator.Mapping {
+			u.ref(target)
+			u.mapped[u.location(target)] = true
+		}
+	}
+}
+
+// forEachSubschema calls fn with the non-nil subschemas of s.
+func forEachSubschema(s *model.Schema, fn func(*model.Schema)) {
+	forEachSubschemaAt(s, func(_ []string, sub *model.Schema) {
+		fn(sub)
+	})
+}
+
+// forEachSubschemaAt calls fn with the non-nil subschemas of s and their JSON
+// pointer tokens relative to s, e.g. ["properties", "id"]. Properties are
+// visited in name order.
+func forEachSubschemaAt(s *model.Schema, fn func(tokens []string, sub *model.Schema)) {
+	rewriteSubschemasAt(s, func(tokens []string, sub *model.Schema) *model.Schema {
+		fn(tokens, sub)
+
+		return sub
+	})
+}
+
+// rewriteSubschemas returns s with its non-nil subschemas replaced by fn, copying
+// s, and the maps and slices holding them, only if fn changed one of them.
+func rewriteSubschemas(s *model.Schema, fn func(*model.Schema) *model.Schema) *model.Schema {
+	return rewriteSubschemasAt(s, func(_ []string, sub *model.Schema) *model.Schema {
+		return fn(sub)
+	})
+}
+
+// rewriteSubschemasAt is rewriteSubschemas, with the JSON pointer tokens of the
+// subschemas passed to fn. It is the only walker of the subschema keywords.
+func rewriteSubschemasAt(s *model.Schema, fn func(tokens []string, sub *model.Schema) *model.Schema) *model.Schema {
+	c := *s
+	changed := false
+	replace := func(sub *model.Schema, tokens ...string) *model.Schema {
+		if sub == nil {
+			return nil
+		}
+		n := fn(tokens, sub)
+		changed = changed || n != sub
+
+		return n
+	}
+
+	for _, sub := range []struct {
+		keyword string
+		schema  **model.Schema
+	}{
+		{"items", &c.Items},
+		{"not", &c.Not},
+		{"if", &c.If},
+		{"then", &c.Then},
+		{"else", &c.Else},
+		{"propertyNames", &c.PropertyNames},
+		{"unevaluatedProperties", &c.Unevaluated},
+	} {
+		*sub.schema = replace(*sub.schema, sub.keyword)
+	}
+	if s.Additional != nil && s.Additional.Schema != nil {
+		if n := replace(s.Additional.Schema, "additionalProperties"); n != s.Additional.Schema {
+			additional := *s.Additional
+			additional.Schema = n
+			c.Additional = &additional
+		}
+	}
+	for _, props := range []struct {
+		keyword string
+		schemas *map[string]*model.Schema
+	}{
+		{"properties", &c.Properties},
+		{"patternProperties", &c.PatternProps},
+	} {
+		original, cloned := *props.schemas, false
+		for _, name := range sortedNames(original) {
+			if n := replace(original[name], props.keyword, name); n != original[name] {
+				if !cloned {
+					*props.schemas, cloned = maps.Clone(original), true
+				}
+				(*props.schemas)[name] = n
+			}
+		}
+	}
+	for _, group := range []struct {
+		keyword string
+		schemas *[]*model.Schema
+	}{
+		{"prefixItems", &c.PrefixItems},
+		{"allOf", &c.AllOf},
+		{"anyOf", &c.AnyOf},
+		{"oneOf", &c.OneOf},
+	} {
+		original, cloned := *group.schemas, false
+		for i, sub := range original {
+			if n := replace(sub, group.keyword, strconv.Itoa(i)); n != sub {
+				if !cloned {
+					*group.schemas, cloned = slices.Clone(original), true
+				}
+				(*group.schemas)[i] = n
+			}
+		}
+	}
+	if !changed {
+		return s
+	}
+
+	return &c
+}
+
+// keysOf returns the keys of a component map.
+func keysOf[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	return keys
+}
@@ -0,0 +1,78 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+type unusedAddress struct {
+	City string `json:"city"`
+}
+
+type unusedUser struct {
+	Name    string        `json:"name"`
+	Address unusedAddress `json:"address"`
+}
+
+type unusedOrder struct {
+	ID string `json:"id"`
+}
+
+func TestGenerate_UnusedComponents(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"))
+
+	result, err := api.Generate(context.Background(),
+		GET("/users", WithResponse(200, unusedUser{})),
+		GET("/orders", WithResponse(200, unusedOrder{})),
+	)
+	require.NoError(t, err)
+	assert.False(t, result.Warnings.Has(debug.WarnUnusedComponent))
+
+	// The orders operation is gone: its schema, still known to the schema
+	// generator, is not part of the spec
+	api.RegisterHeader("X-Cursor", Header{Type: ""})
+	result, err = api.Generate(context.Background(),
+		GET("/users", WithResponse(200, unusedUser{})),
+	)
+	require.NoError(t, err)
+
+	var unused []string
+	for _, w := range result.Warnings {
+		if w.Code() == debug.WarnUnusedComponent {
+			unused = append(unused, w.Path())
+		}
+	}
+	assert.Equal(t, []string{"#/components/headers/X-Cursor"}, unused, "registered components are reported")
+
+	_, ok := result.Model.SchemaByName("UnusedOrder")
+	assert.False(t, ok)
+	_, ok = result.Model.SchemaByName("UnusedAddress")
+	assert.True(t, ok, "schemas referenced by the schemas of the operations are kept")
+}
+
+func TestGenerate_PruneUnused(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithPruneUnused(true))
+
+	_, err := api.Generate(context.Background(),
+		GET("/orders", WithResponse(200, unusedOrder{})),
+	)
+	require.NoError(t, err)
+
+	result, err := api.Generate(context.Background(),
+		GET("/users", WithResponse(200, unusedUser{})),
+	)
+	require.NoError(t, err)
+	assert.False(t, result.Warnings.Has(debug.WarnUnusedComponent))
+
+	var spec map[string]any
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+	schemas := spec["components"].(map[string]any)["schemas"].(map[string]any)
+	assert.ElementsMatch(t, []string{"UnusedAddress", "UnusedUser"}, keys(schemas), "transitively referenced schemas are kept")
+}
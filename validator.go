@@ -0,0 +1,66 @@
+package openapi
+
+import "github.com/talav/openapi/debug"
+
+// Validator checks generated specifications, e.g. with another JSON Schema
+// library or against organization-specific rules. It receives the marshaled
+// specification, as written to Result.JSON, and returns its findings.
+type Validator interface {
+	Validate(doc []byte) []Finding
+}
+
+// ValidatorFunc adapts a function to a [Validator].
+type ValidatorFunc func(doc []byte) []Finding
+
+// Validate calls f(doc).
+func (f ValidatorFunc) Validate(doc []byte) []Finding {
+	return f(doc)
+}
+
+// Finding is a violation found by a [Validator].
+type Finding struct {
+	// Pointer is the JSON pointer to the failing value in the specification.
+	// Example: "/paths/~1users/get/operationId"
+	Pointer string
+
+	// Rule identifies the violated rule.
+	Rule string
+
+	// Message is a human-readable description of the violation.
+	Message string
+}
+
+// WithValidator adds a validator run on every generated specification. Its
+// findings fail Generate, returned with the meta-schema violations of
+// WithValidation as debug.ValidationErrors. Validators run whether or not
+// WithValidation is enabled: disable it to replace the built-in validation.
+//
+// Example:
+//
+//	openapi.WithValidator(openapi.ValidatorFunc(func(doc []byte) []openapi.Finding {
+//	    if !bytes.Contains(doc, []byte(`"x-owner"`)) {
+//	        return []openapi.Finding{{Rule: "owner", Message: "x-owner is required"}}
+//	    }
+//	    return nil
+//	}))
+func WithValidator(v Validator) Option {
+	return func(a *API) {
+		a.Validators = append(a.Validators, v)
+	}
+}
+
+// runValidators runs the validators of WithValidator on the marshaled spec.
+func (a *API) runValidators(specJSON []byte) debug.ValidationErrors {
+	var violations debug.ValidationErrors
+	for _, v := range a.Validators {
+		for _, f := range v.Validate(specJSON) {
+			violations = append(violations, debug.ValidationError{
+				Pointer: f.Pointer,
+				Rule:    f.Rule,
+				Message: f.Message,
+			})
+		}
+	}
+
+	return violations
+}
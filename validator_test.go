@@ -0,0 +1,80 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/talav/openapi/debug"
+)
+
+// operationIDValidator requires an operationId on every operation.
+func operationIDValidator(doc []byte) []Finding {
+	var spec struct {
+		Paths map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(doc, &spec); err != nil {
+		return []Finding{{Rule: "json", Message: err.Error()}}
+	}
+
+	var findings []Finding
+	for path, item := range spec.Paths {
+		for method, op := range item {
+			if op.OperationID == "" {
+				findings = append(findings, Finding{
+					Pointer: "/paths/" + strings.ReplaceAll(path, "/", "~1") + "/" + method,
+					Rule:    "operation-id",
+					Message: "operationId is required",
+				})
+			}
+		}
+	}
+
+	return findings
+}
+
+func TestWithValidator(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version), WithValidator(ValidatorFunc(operationIDValidator)))
+
+		_, err := api.Generate(context.Background(), GET("/users", WithOperationID("listUsers")))
+		require.NoError(t, err)
+
+		_, err = api.Generate(context.Background(), GET("/users"))
+		var violations debug.ValidationErrors
+		require.ErrorAs(t, err, &violations)
+		require.Len(t, violations, 1)
+		assert.Equal(t, "/paths/~1users/get", violations[0].Pointer)
+		assert.Equal(t, "operation-id", violations[0].Rule)
+		assert.Equal(t, "operationId is required", violations[0].Message)
+		assert.Equal(t, "GET /users", violations[0].Source)
+		assert.NotNil(t, violations[0].Fragment)
+	})
+}
+
+func TestWithValidator_WithMetaSchemaValidation(t *testing.T) {
+	type BadName struct {
+		Name string `json:"name" validate:"max=-1"`
+	}
+
+	api := NewAPI(
+		WithVersion("3.0.4"),
+		WithValidation(true),
+		WithValidator(ValidatorFunc(operationIDValidator)),
+	)
+	_, err := api.Generate(context.Background(), GET("/users", WithResponse(200, BadName{})))
+
+	var violations debug.ValidationErrors
+	require.ErrorAs(t, err, &violations)
+	var pointers []string
+	for _, v := range violations {
+		pointers = append(pointers, v.Pointer)
+	}
+	assert.Equal(t, []string{"/components/schemas/BadName/properties/name/maxLength", "/paths/~1users/get"}, pointers)
+}
@@ -0,0 +1,110 @@
+package openapi
+
+import (
+	"net/http"
+	"reflect"
+
+	"github.com/talav/openapi/internal/model"
+)
+
+// Headers and extension documented by WithWebSocket.
+const (
+	headerUpgrade             = "Upgrade"
+	headerConnection          = "Connection"
+	headerSecWebSocketKey     = "Sec-WebSocket-Key"
+	headerSecWebSocketVersion = "Sec-WebSocket-Version"
+	headerSecWebSocketAccept  = "Sec-WebSocket-Accept"
+
+	extensionWebSocket = "x-websocket"
+)
+
+// webSocketMessages holds the message types of a WebSocket endpoint.
+type webSocketMessages struct {
+	client reflect.Type
+	server reflect.Type
+}
+
+// webSocketExtension is the x-websocket extension documenting the messages of a
+// WebSocket endpoint, as references to their component schemas.
+type webSocketExtension struct {
+	ClientMessages *schemaRef `json:"clientMessages,omitempty"`
+	ServerMessages *schemaRef `json:"serverMessages,omitempty"`
+}
+
+// schemaRef is a reference to a component schema in an extension.
+type schemaRef struct {
+	Ref string `json:"$ref"`
+}
+
+// WithWebSocket documents a WebSocket endpoint (RFC 6455), which OpenAPI cannot
+// describe: the upgrade request headers, the 101 Switching Protocols response,
+// and the x-websocket extension referencing the schemas of the messages sent by
+// the client and by the server, generated from their Go types as components.
+// Either message type may be nil for one-way endpoints; register a union with
+// WithUnion for several kinds of messages.
+//
+// Example:
+//
+//	openapi.GET("/chat",
+//	    openapi.WithSummary("Chat stream"),
+//	    openapi.WithWebSocket(ChatCommand{}, ChatEvent{}),
+//	)
+func WithWebSocket(clientMessage, serverMessage any) OperationDocOption {
+	return func(d *operationDoc) {
+		d.WebSocket = &webSocketMessages{
+			client: reflect.TypeOf(clientMessage),
+			server: reflect.TypeOf(serverMessage),
+		}
+
+		for _, h := range []struct {
+			name, description string
+			enum              []any
+		}{
+			{headerUpgrade, "Protocol to switch to", []any{"websocket"}},
+			{headerConnection, "Connection option requesting the protocol switch", []any{"Upgrade"}},
+			{headerSecWebSocketKey, "Base64-encoded random nonce of the handshake", nil},
+			{headerSecWebSocketVersion, "WebSocket protocol version", []any{"13"}},
+		} {
+			d.Parameters = append(d.Parameters, model.Parameter{
+				Name:        h.name,
+				In:          string(InHeader),
+				Description: h.description,
+				Required:    true,
+				Schema:      &model.Schema{Type: "string", Enum: h.enum},
+			})
+		}
+
+		d.addResponseHeader(http.StatusSwitchingProtocols, headerUpgrade, &model.Header{
+			Description: "Protocol switched to",
+			Schema:      &model.Schema{Type: "string", Enum: []any{"websocket"}},
+		})
+		d.addResponseHeader(http.StatusSwitchingProtocols, headerConnection, &model.Header{
+			Description: "Connection option confirming the protocol switch",
+			Schema:      &model.Schema{Type: "string", Enum: []any{"Upgrade"}},
+		})
+		d.addResponseHeader(http.StatusSwitchingProtocols, headerSecWebSocketAccept, &model.Header{
+			Description: "Proof of receipt of the handshake, derived from Sec-WebSocket-Key",
+			Schema:      &model.Schema{Type: "string"},
+		})
+	}
+}
+
+// addWebSocketMessages documents the message types of a WebSocket endpoint in the
+// x-websocket extension of the operation.
+func (a *API) addWebSocketMessages(op *model.Operation, ws *webSocketMessages) {
+	if ws == nil {
+		return
+	}
+
+	var ext webSocketExtension
+	if ws.client != nil {
+		ext.ClientMessages = &schemaRef{Ref: a.generator.RefFor(ws.client, op.OperationID+"ClientMessage").Ref}
+	}
+	if ws.server != nil {
+		ext.ServerMessages = &schemaRef{Ref: a.generator.RefFor(ws.server, op.OperationID+"ServerMessage").Ref}
+	}
+	if op.Extensions == nil {
+		op.Extensions = make(map[string]any)
+	}
+	op.Extensions[extensionWebSocket] = ext
+}
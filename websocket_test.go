@@ -0,0 +1,107 @@
+package openapi
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type webSocketCommand struct {
+	Text string `json:"text"`
+}
+
+type webSocketEvent struct {
+	From string `json:"from"`
+	Text string `json:"text"`
+}
+
+func TestWithWebSocket(t *testing.T) {
+	forEachVersion(t, func(t *testing.T, version string) {
+		api := NewAPI(WithVersion(version))
+
+		result, err := api.Generate(context.Background(),
+			GET("/chat", WithOperationID("chat"), WithWebSocket(webSocketCommand{}, webSocketEvent{})),
+			GET("/feed", WithOperationID("feed"), WithWebSocket(nil, []webSocketEvent{})),
+		)
+		require.NoError(t, err)
+
+		type schemaRef struct {
+			Ref string `json:"$ref"`
+		}
+		var spec struct {
+			Paths map[string]map[string]struct {
+				Parameters []struct {
+					Name     string `json:"name"`
+					In       string `json:"in"`
+					Required bool   `json:"required"`
+					Schema   struct {
+						Enum []string `json:"enum"`
+					} `json:"schema"`
+				} `json:"parameters"`
+				Responses map[string]struct {
+					Headers map[string]any `json:"headers"`
+				} `json:"responses"`
+				WebSocket struct {
+					ClientMessages *schemaRef `json:"clientMessages"`
+					ServerMessages *schemaRef `json:"serverMessages"`
+				} `json:"x-websocket"`
+			} `json:"paths"`
+			Components struct {
+				Schemas map[string]any `json:"schemas"`
+			} `json:"components"`
+		}
+		require.NoError(t, json.Unmarshal(result.JSON, &spec))
+
+		chat := spec.Paths["/chat"]["get"]
+		enums := map[string][]string{}
+		for _, p := range chat.Parameters {
+			assert.Equal(t, "header", p.In)
+			assert.True(t, p.Required)
+			enums[p.Name] = p.Schema.Enum
+		}
+		assert.Equal(t, map[string][]string{
+			"Upgrade":               {"websocket"},
+			"Connection":            {"Upgrade"},
+			"Sec-WebSocket-Key":     nil,
+			"Sec-WebSocket-Version": {"13"},
+		}, enums)
+
+		require.Contains(t, chat.Responses, "101")
+		assert.Len(t, chat.Responses["101"].Headers, 3)
+		assert.Contains(t, chat.Responses["101"].Headers, "Sec-WebSocket-Accept")
+
+		require.NotNil(t, chat.WebSocket.ClientMessages)
+		require.NotNil(t, chat.WebSocket.ServerMessages)
+		assert.Equal(t, "#/components/schemas/WebSocketCommand", chat.WebSocket.ClientMessages.Ref)
+		assert.Equal(t, "#/components/schemas/WebSocketEvent", chat.WebSocket.ServerMessages.Ref)
+
+		feed := spec.Paths["/feed"]["get"]
+		assert.Nil(t, feed.WebSocket.ClientMessages)
+		require.NotNil(t, feed.WebSocket.ServerMessages)
+		assert.Equal(t, "#/components/schemas/FeedServerMessage", feed.WebSocket.ServerMessages.Ref)
+
+		assert.Contains(t, spec.Components.Schemas, "WebSocketCommand")
+		assert.Contains(t, spec.Components.Schemas, "WebSocketEvent")
+		assert.Contains(t, spec.Components.Schemas, "FeedServerMessage")
+	})
+}
+
+func TestWithWebSocket_KeepsMessageComponents(t *testing.T) {
+	api := NewAPI(WithVersion("3.1.2"), WithPruneUnused(true), WithSchemaReuseThreshold(2))
+
+	result, err := api.Generate(context.Background(),
+		GET("/chat", WithOperationID("chat"), WithWebSocket(webSocketCommand{}, nil)),
+	)
+	require.NoError(t, err)
+
+	var spec struct {
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	require.NoError(t, json.Unmarshal(result.JSON, &spec))
+	assert.Contains(t, spec.Components.Schemas, "WebSocketCommand")
+}